@@ -127,6 +127,7 @@ type InstanceServer interface {
 
 	GetInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) (content io.ReadCloser, err error)
 	DeleteInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) (err error)
+	GetInstanceConsoleScreenshot(instanceName string) (content io.ReadCloser, err error)
 
 	GetInstanceFile(instanceName string, path string) (content io.ReadCloser, resp *InstanceFileResponse, err error)
 	CreateInstanceFile(instanceName string, path string, args InstanceFileArgs) (err error)
@@ -194,7 +195,10 @@ type InstanceServer interface {
 	GetNetworksAllProjects() (networks []api.Network, err error)
 	GetNetwork(name string) (network *api.Network, ETag string, err error)
 	GetNetworkLeases(name string) (leases []api.NetworkLease, err error)
+	CreateNetworkLease(name string, lease api.NetworkLeasesPost) (err error)
+	DeleteNetworkLease(name string, address string) (err error)
 	GetNetworkState(name string) (state *api.NetworkState, err error)
+	GetNetworkBGP(name string) (bgp *api.NetworkBGP, err error)
 	CreateNetwork(network api.NetworksPost) (op Operation, err error)
 	UpdateNetwork(name string, network api.NetworkPut, ETag string) (op Operation, err error)
 	RenameNetwork(name string, network api.NetworkPost) (op Operation, err error)
@@ -212,6 +216,7 @@ type InstanceServer interface {
 	GetNetworkLoadBalancerAddresses(networkName string) ([]string, error)
 	GetNetworkLoadBalancers(networkName string) ([]api.NetworkLoadBalancer, error)
 	GetNetworkLoadBalancer(networkName string, listenAddress string) (forward *api.NetworkLoadBalancer, ETag string, err error)
+	GetNetworkLoadBalancerState(networkName string, listenAddress string) (state *api.NetworkLoadBalancerState, err error)
 	CreateNetworkLoadBalancer(networkName string, forward api.NetworkLoadBalancersPost) (op Operation, err error)
 	UpdateNetworkLoadBalancer(networkName string, listenAddress string, forward api.NetworkLoadBalancerPut, ETag string) (op Operation, err error)
 	DeleteNetworkLoadBalancer(networkName string, listenAddress string) (op Operation, err error)
@@ -230,11 +235,21 @@ type InstanceServer interface {
 	GetNetworkACLsAllProjects() (acls []api.NetworkACL, err error)
 	GetNetworkACL(name string) (acl *api.NetworkACL, ETag string, err error)
 	GetNetworkACLLogfile(name string) (log io.ReadCloser, err error)
+	GetNetworkACLState(name string) (state *api.NetworkACLState, err error)
 	CreateNetworkACL(acl api.NetworkACLsPost) (op Operation, err error)
 	UpdateNetworkACL(name string, acl api.NetworkACLPut, ETag string) (op Operation, err error)
 	RenameNetworkACL(name string, acl api.NetworkACLPost) (op Operation, err error)
 	DeleteNetworkACL(name string) (op Operation, err error)
 
+	// Network address set functions ("network_address_sets" API extension)
+	GetNetworkAddressSetNames() (names []string, err error)
+	GetNetworkAddressSets() (sets []api.NetworkAddressSet, err error)
+	GetNetworkAddressSet(name string) (set *api.NetworkAddressSet, ETag string, err error)
+	CreateNetworkAddressSet(set api.NetworkAddressSetsPost) (op Operation, err error)
+	UpdateNetworkAddressSet(name string, set api.NetworkAddressSetPut, ETag string) (op Operation, err error)
+	RenameNetworkAddressSet(name string, set api.NetworkAddressSetPost) (op Operation, err error)
+	DeleteNetworkAddressSet(name string) (op Operation, err error)
+
 	// Network allocations functions ("network_allocations" API extension)
 	GetNetworkAllocations(allProjects bool) (allocations []api.NetworkAllocations, err error)
 
@@ -388,6 +403,7 @@ type InstanceServer interface {
 	GetReplicatorNames() (replicatorNames []string, err error)
 	GetReplicator(project string, name string) (replicator *api.Replicator, ETag string, err error)
 	GetReplicatorState(project string, name string) (replicatorState *api.ReplicatorState, err error)
+	GetReplicatorRuns(project string, name string) (runs []api.ReplicatorRun, err error)
 	CreateReplicator(project string, replicator api.ReplicatorsPost) (err error)
 	UpdateReplicator(project string, name string, replicator api.ReplicatorPut, ETag string) (err error)
 	DeleteReplicator(project string, name string) (err error)
@@ -448,6 +464,7 @@ type InstanceServer interface {
 	UpdatePlacementGroup(placementGroupName string, placementGroupPut api.PlacementGroupPut, ETag string) error
 	DeletePlacementGroup(placementGroupName string) error
 	RenamePlacementGroup(placementGroupName string, placementGroupPost api.PlacementGroupPost) error
+	GetPlacementGroupCompliance(placementGroupName string) (compliance *api.PlacementGroupCompliance, err error)
 
 	// Internal functions (for internal use)
 	RawQuery(method string, path string, data any, queryETag string) (resp *api.Response, ETag string, err error)
@@ -672,6 +689,12 @@ type InstanceBackupArgs struct {
 
 	// If set, it would override devices
 	Devices map[string]map[string]string
+
+	// If set, the backup is fetched by the server from this backup target rather than read from BackupFile
+	BackupTarget string
+
+	// Location of the backup in the backup target
+	BackupLocation string
 }
 
 // The InstanceCopyArgs struct is used to pass additional options during instance copy.
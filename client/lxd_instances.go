@@ -477,7 +477,7 @@ func (r *ProtocolLXD) CreateInstanceFromBackup(args InstanceBackupArgs) (Operati
 		return nil, err
 	}
 
-	if args.PoolName == "" && args.Name == "" && len(args.Devices) == 0 {
+	if args.PoolName == "" && args.Name == "" && len(args.Devices) == 0 && args.BackupTarget == "" {
 		// Send the request
 		op, _, err := r.queryOperation(http.MethodPost, path, args.BackupFile, "", true)
 		if err != nil {
@@ -508,6 +508,13 @@ func (r *ProtocolLXD) CreateInstanceFromBackup(args InstanceBackupArgs) (Operati
 		}
 	}
 
+	if args.BackupTarget != "" {
+		err = r.CheckExtension("backup_target_s3")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Prepare the HTTP request
 	reqURL, err := r.setQueryAttributes(r.httpBaseURL.String() + "/1.0" + path)
 
@@ -530,6 +537,11 @@ func (r *ProtocolLXD) CreateInstanceFromBackup(args InstanceBackupArgs) (Operati
 		req.Header.Set("X-LXD-name", args.Name)
 	}
 
+	if args.BackupTarget != "" {
+		req.Header.Set("X-LXD-backup-target", args.BackupTarget)
+		req.Header.Set("X-LXD-backup-location", args.BackupLocation)
+	}
+
 	if len(args.Devices) > 0 {
 		devProps := url.Values{}
 
@@ -2537,6 +2549,13 @@ func (r *ProtocolLXD) ConsoleInstance(instanceName string, console api.InstanceC
 		}
 	}
 
+	if console.Type == "vnc" {
+		err = r.CheckExtension("instance_console_vnc")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	useEventListener := r.CheckExtension("operation_wait") != nil
 	op, _, err := r.queryOperation(http.MethodPost, path+"/"+url.PathEscape(instanceName)+"/console", console, "", useEventListener)
@@ -2640,6 +2659,13 @@ func (r *ProtocolLXD) ConsoleInstanceDynamic(instanceName string, console api.In
 		}
 	}
 
+	if console.Type == "vnc" {
+		err = r.CheckExtension("instance_console_vnc")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Send the request.
 	op, _, err := r.queryOperation(http.MethodPost, path+"/"+url.PathEscape(instanceName)+"/console", console, "", true)
 	if err != nil {
@@ -2761,6 +2787,50 @@ func (r *ProtocolLXD) GetInstanceConsoleLog(instanceName string, args *InstanceC
 	return resp.Body, err
 }
 
+// GetInstanceConsoleScreenshot returns a PNG image of the VGA display of a running virtual machine.
+//
+// Note that it's the caller's responsibility to close the returned ReadCloser.
+func (r *ProtocolLXD) GetInstanceConsoleScreenshot(instanceName string) (io.ReadCloser, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.CheckExtension("instance_console_vnc")
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	url := r.httpBaseURL.String() + "/1.0" + path + "/" + url.PathEscape(instanceName) + "/console?type=screenshot"
+
+	url, err = r.setQueryAttributes(url)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := lxdParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, err
+}
+
 // DeleteInstanceConsoleLog deletes the requested instance's console log.
 func (r *ProtocolLXD) DeleteInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) error {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2863,6 +2933,20 @@ func (r *ProtocolLXD) CreateInstanceBackup(instanceName string, backup api.Insta
 		return nil, err
 	}
 
+	if backup.IncrementalFrom != "" {
+		err = r.CheckExtension("backup_incremental")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if backup.Target != "" {
+		err = r.CheckExtension("backup_target_s3")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation(http.MethodPost, path+"/"+url.PathEscape(instanceName)+"/backups", backup, "", true)
 	if err != nil {
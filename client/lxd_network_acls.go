@@ -80,6 +80,24 @@ func (r *ProtocolLXD) GetNetworkACL(name string) (*api.NetworkACL, string, error
 	return &acl, etag, nil
 }
 
+// GetNetworkACLState returns the hit counters of the rules of a network ACL.
+func (r *ProtocolLXD) GetNetworkACLState(name string) (*api.NetworkACLState, error) {
+	err := r.CheckExtension("network_acl_state")
+	if err != nil {
+		return nil, err
+	}
+
+	aclState := api.NetworkACLState{}
+
+	// Fetch the raw value.
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("network-acls", name, "state").String(), nil, "", &aclState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aclState, nil
+}
+
 // GetNetworkACLLogfile returns a reader for the ACL log file.
 //
 // Note that it's the caller's responsibility to close the returned ReadCloser.
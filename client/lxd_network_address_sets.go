@@ -0,0 +1,122 @@
+package lxd
+
+import (
+	"net/http"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// GetNetworkAddressSetNames returns a list of network address set names.
+func (r *ProtocolLXD) GetNetworkAddressSetNames() ([]string, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/network-address-sets"
+	_, err = r.queryStruct(http.MethodGet, baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetNetworkAddressSets returns a list of network address set structs.
+func (r *ProtocolLXD) GetNetworkAddressSets() ([]api.NetworkAddressSet, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	sets := []api.NetworkAddressSet{}
+
+	// Fetch the raw value.
+	_, err = r.queryStruct(http.MethodGet, "/network-address-sets?recursion=1", nil, "", &sets)
+	if err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+// GetNetworkAddressSet returns a network address set entry for the provided name.
+func (r *ProtocolLXD) GetNetworkAddressSet(name string) (*api.NetworkAddressSet, string, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, "", err
+	}
+
+	set := api.NetworkAddressSet{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct(http.MethodGet, api.NewURL().Path("network-address-sets", name).String(), nil, "", &set)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &set, etag, nil
+}
+
+// CreateNetworkAddressSet defines a new network address set using the provided struct.
+func (r *ProtocolLXD) CreateNetworkAddressSet(set api.NetworkAddressSetsPost) (Operation, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryNetworkAddressSetOperation(http.MethodPost, "/network-address-sets", set, "")
+}
+
+// UpdateNetworkAddressSet updates the network address set to match the provided struct.
+func (r *ProtocolLXD) UpdateNetworkAddressSet(name string, set api.NetworkAddressSetPut, ETag string) (Operation, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryNetworkAddressSetOperation(http.MethodPut, api.NewURL().Path("network-address-sets", name).String(), set, ETag)
+}
+
+// RenameNetworkAddressSet renames an existing network address set entry.
+func (r *ProtocolLXD) RenameNetworkAddressSet(name string, set api.NetworkAddressSetPost) (Operation, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryNetworkAddressSetOperation(http.MethodPost, api.NewURL().Path("network-address-sets", name).String(), set, "")
+}
+
+// DeleteNetworkAddressSet deletes an existing network address set.
+func (r *ProtocolLXD) DeleteNetworkAddressSet(name string) (Operation, error) {
+	err := r.CheckExtension("network_address_sets")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryNetworkAddressSetOperation(http.MethodDelete, api.NewURL().Path("network-address-sets", name).String(), nil, "")
+}
+
+// queryNetworkAddressSetOperation sends a network address set modification request.
+// Cluster operation notifications are handled synchronously by the server.
+func (r *ProtocolLXD) queryNetworkAddressSetOperation(method string, path string, data any, ETag string) (Operation, error) {
+	if r.isClusterOperationNotification() {
+		_, _, err := r.query(method, path, data, ETag)
+		if err != nil {
+			return nil, err
+		}
+
+		return noopOperation{}, nil
+	}
+
+	op, _, err := r.queryOperation(method, path, data, ETag, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
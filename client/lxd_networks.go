@@ -99,6 +99,56 @@ func (r *ProtocolLXD) GetNetworkLeases(name string) ([]api.NetworkLease, error)
 	return leases, nil
 }
 
+// CreateNetworkLease reserves an address of the network for a MAC address.
+func (r *ProtocolLXD) CreateNetworkLease(name string, lease api.NetworkLeasesPost) error {
+	err := r.CheckExtension("network_leases_reservations")
+	if err != nil {
+		return err
+	}
+
+	// Send the request
+	_, _, err = r.query(http.MethodPost, api.NewURL().Path("networks", name, "leases").String(), lease, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteNetworkLease removes the DHCP reservation of an address or releases its dynamic lease.
+func (r *ProtocolLXD) DeleteNetworkLease(name string, address string) error {
+	err := r.CheckExtension("network_leases_reservations")
+	if err != nil {
+		return err
+	}
+
+	// Send the request
+	_, _, err = r.query(http.MethodDelete, api.NewURL().Path("networks", name, "leases", address).String(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNetworkBGP returns the state of the BGP sessions with the peers of the network.
+func (r *ProtocolLXD) GetNetworkBGP(name string) (*api.NetworkBGP, error) {
+	err := r.CheckExtension("network_bgp_import")
+	if err != nil {
+		return nil, err
+	}
+
+	bgp := api.NetworkBGP{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct(http.MethodGet, fmt.Sprintf("/networks/%s/bgp", url.PathEscape(name)), nil, "", &bgp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bgp, nil
+}
+
 // GetNetworkState returns metrics and information on the running network.
 func (r *ProtocolLXD) GetNetworkState(name string) (*api.NetworkState, error) {
 	err := r.CheckExtension("network_state")
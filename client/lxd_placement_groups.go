@@ -147,3 +147,19 @@ func (r *ProtocolLXD) RenamePlacementGroup(placementGroupName string, placementG
 
 	return nil
 }
+
+// GetPlacementGroupCompliance returns the compliance of the instances in the placement group with its policy.
+func (r *ProtocolLXD) GetPlacementGroupCompliance(placementGroupName string) (*api.PlacementGroupCompliance, error) {
+	err := r.CheckExtension("placement_group_rebalance")
+	if err != nil {
+		return nil, err
+	}
+
+	var compliance api.PlacementGroupCompliance
+	_, err = r.queryStruct(http.MethodGet, api.NewURL().Path("placement-groups", placementGroupName, "compliance").String(), nil, "", &compliance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compliance, nil
+}
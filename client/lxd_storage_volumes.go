@@ -963,6 +963,20 @@ func (r *ProtocolLXD) CreateStoragePoolVolumeBackup(pool string, volName string,
 		return nil, err
 	}
 
+	if backup.IncrementalFrom != "" {
+		err = r.CheckExtension("backup_incremental")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if backup.Target != "" {
+		err = r.CheckExtension("backup_target_s3")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation(http.MethodPost, "/storage-pools/"+url.PathEscape(pool)+"/volumes/custom/"+url.PathEscape(volName)+"/backups", backup, "", true)
 	if err != nil {
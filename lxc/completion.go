@@ -76,6 +76,9 @@ var topLevelInstanceServerResourceNameFuncs = map[string]func(server lxd.Instanc
 	"network_acl": func(server lxd.InstanceServer) ([]string, error) {
 		return server.GetNetworkACLNames()
 	},
+	"network_address_set": func(server lxd.InstanceServer) ([]string, error) {
+		return server.GetNetworkAddressSetNames()
+	},
 	"network_zone": func(server lxd.InstanceServer) ([]string, error) {
 		return server.GetNetworkZoneNames()
 	},
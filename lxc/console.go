@@ -28,8 +28,9 @@ import (
 type cmdConsole struct {
 	global *cmdGlobal
 
-	flagShowLog bool
-	flagType    string
+	flagShowLog    bool
+	flagScreenshot string
+	flagType       string
 }
 
 func (c *cmdConsole) command() *cobra.Command {
@@ -39,11 +40,20 @@ func (c *cmdConsole) command() *cobra.Command {
 	cmd.Long = cli.FormatSection("Description", cmd.Short+`
 
 This command allows you to interact with the boot console of an instance
-as well as retrieve past log entries from it.`)
+as well as retrieve past log entries from it.
+
+For virtual machines, the graphical output can be accessed over SPICE ('vga' type)
+or VNC ('vnc' type), and a screenshot of it can be saved as a PNG image.`)
+	cmd.Example = cli.FormatSection("", `lxc console v1 --type=vnc
+    Connect to the graphical output of virtual machine "v1" with a VNC viewer
+
+lxc console v1 --screenshot=v1.png
+    Save a screenshot of the graphical output of virtual machine "v1" to v1.png`)
 
 	cmd.RunE = c.run
 	cmd.Flags().BoolVar(&c.flagShowLog, "show-log", false, "Retrieve the container's console log")
-	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", cli.FormatStringFlagLabel("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output"))
+	cmd.Flags().StringVar(&c.flagScreenshot, "screenshot", "", cli.FormatStringFlagLabel("Save a PNG screenshot of the VM's graphical output to the given file"))
+	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", cli.FormatStringFlagLabel("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output, 'vnc' for VNC graphical output"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpTopLevelResource("instance", toComplete)
@@ -110,7 +120,7 @@ func (c *cmdConsole) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate flags.
-	if !slices.Contains([]string{"console", "vga"}, c.flagType) {
+	if !slices.Contains([]string{"console", "vga", "vnc"}, c.flagType) {
 		return fmt.Errorf("Unknown output type %q", c.flagType)
 	}
 
@@ -151,9 +161,36 @@ func (c *cmdConsole) run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Save a screenshot if requested.
+	if c.flagScreenshot != "" {
+		return c.screenshot(d, name)
+	}
+
 	return c.runConsole(d, name)
 }
 
+func (c *cmdConsole) screenshot(d lxd.InstanceServer, name string) error {
+	screenshot, err := d.GetInstanceConsoleScreenshot(name)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = screenshot.Close() }()
+
+	f, err := os.Create(c.flagScreenshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, screenshot)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
 func (c *cmdConsole) runConsole(d lxd.InstanceServer, name string) error {
 	if c.flagType == "" {
 		c.flagType = "console"
@@ -162,7 +199,7 @@ func (c *cmdConsole) runConsole(d lxd.InstanceServer, name string) error {
 	switch c.flagType {
 	case "console":
 		return c.console(d, name)
-	case "vga":
+	case "vga", "vnc":
 		return c.vga(d, name)
 	}
 
@@ -254,7 +291,13 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 
 	// Prepare the remote console.
 	req := api.InstanceConsolePost{
-		Type: "vga",
+		Type: c.flagType,
+	}
+
+	// The VGA console uses SPICE.
+	scheme := "spice"
+	if c.flagType == "vnc" {
+		scheme = "vnc"
 	}
 
 	chDisconnect := make(chan bool)
@@ -269,14 +312,14 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 	var socket string
 	var listener net.Listener
 	if runtime.GOOS != "windows" {
-		// Create a temporary unix socket mirroring the instance's spice socket.
+		// Create a temporary unix socket mirroring the instance's spice or vnc socket.
 		err := os.MkdirAll(conf.ConfigPath("sockets"), 0700)
 		if err != nil {
 			return err
 		}
 
 		// Generate a random file name.
-		path, err := os.CreateTemp(conf.ConfigPath("sockets"), "*.spice")
+		path, err := os.CreateTemp(conf.ConfigPath("sockets"), "*."+scheme)
 		if err != nil {
 			return err
 		}
@@ -297,7 +340,11 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 
 		defer func() { _ = os.Remove(path.Name()) }()
 
-		socket = "spice+unix://" + path.Name()
+		// VNC viewers take the path of unix sockets directly.
+		socket = scheme + "+unix://" + path.Name()
+		if scheme == "vnc" {
+			socket = path.Name()
+		}
 	} else {
 		listener, err = net.Listen("tcp", "127.0.0.1:0")
 		if err != nil {
@@ -309,7 +356,7 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 			return errors.New("Failed getting TCP listen address")
 		}
 
-		socket = "spice://127.0.0.1:" + strconv.Itoa(addr.Port)
+		socket = scheme + "://127.0.0.1:" + strconv.Itoa(addr.Port)
 	}
 
 	// Clean everything up when the viewer is done.
@@ -356,18 +403,9 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 		}
 	}()
 
-	// Use either spicy or remote-viewer if available.
-	remoteViewer := c.findCommand("remote-viewer")
-	spicy := c.findCommand("spicy")
-
-	if remoteViewer != "" || spicy != "" {
-		var cmd *exec.Cmd
-		if remoteViewer != "" {
-			cmd = exec.Command(remoteViewer, socket)
-		} else {
-			cmd = exec.Command(spicy, "--uri="+socket)
-		}
-
+	// Use a viewer if available.
+	cmd := c.viewerCommand(scheme, socket)
+	if cmd != nil {
 		// Start the command.
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -394,8 +432,14 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 			_ = cmd.Process.Kill()
 		}()
 	} else {
-		fmt.Println("LXD automatically uses either spicy or remote-viewer when present.")
-		fmt.Println("As neither could be found, the raw SPICE socket can be found at:")
+		if scheme == "vnc" {
+			fmt.Println("LXD automatically uses vncviewer (or remote-viewer on Windows) when present.")
+			fmt.Println("As it couldn't be found, the raw VNC socket can be found at:")
+		} else {
+			fmt.Println("LXD automatically uses either spicy or remote-viewer when present.")
+			fmt.Println("As neither could be found, the raw SPICE socket can be found at:")
+		}
+
 		fmt.Printf("  %s\n", socket)
 
 		// Wait for all connections to complete.
@@ -412,3 +456,38 @@ func (c *cmdConsole) vga(d lxd.InstanceServer, name string) error {
 
 	return nil
 }
+
+// viewerCommand returns the command running a viewer connected to the given socket, or nil if no viewer is available.
+func (c *cmdConsole) viewerCommand(scheme string, socket string) *exec.Cmd {
+	remoteViewer := c.findCommand("remote-viewer")
+
+	if scheme == "vnc" {
+		// Only remote-viewer handles VNC URIs, while vncviewer handles unix sockets.
+		if runtime.GOOS == "windows" {
+			if remoteViewer != "" {
+				return exec.Command(remoteViewer, socket)
+			}
+
+			return nil
+		}
+
+		vncViewer := c.findCommand("vncviewer")
+		if vncViewer != "" {
+			return exec.Command(vncViewer, socket)
+		}
+
+		return nil
+	}
+
+	// Use either spicy or remote-viewer for SPICE.
+	if remoteViewer != "" {
+		return exec.Command(remoteViewer, socket)
+	}
+
+	spicy := c.findCommand("spicy")
+	if spicy != "" {
+		return exec.Command(spicy, "--uri="+socket)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -22,6 +23,8 @@ type cmdExport struct {
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
 	flagExportVersion        string
+	flagIncrementalFrom      string
+	flagBackupTarget         string
 }
 
 func (c *cmdExport) command() *cobra.Command {
@@ -30,7 +33,13 @@ func (c *cmdExport) command() *cobra.Command {
 	cmd.Short = "Export instance backups"
 	cmd.Long = cli.FormatSection("Description", `Export instances as backup tarballs.`)
 	cmd.Example = cli.FormatSection("", `lxc export u1 backup0.tar.gz
-    Download a backup tarball of the u1 instance.`)
+    Download a backup tarball of the u1 instance.
+
+lxc export u1 backup1.tar.gz --optimized-storage --incremental-from snap0
+    Download a backup tarball of the u1 instance only containing the changes made since its snap0 snapshot.
+
+lxc export u1 --backup-target s3
+    Store a backup of the u1 instance in the S3 backup target of the server.`)
 
 	cmd.RunE = c.run
 	cmd.Flags().BoolVar(&c.flagInstanceOnly, "instance-only", false,
@@ -39,6 +48,8 @@ func (c *cmdExport) command() *cobra.Command {
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", cli.FormatStringFlagLabel(`Compression algorithm to use (none for uncompressed)`))
 	cmd.Flags().StringVar(&c.flagExportVersion, "export-version", "",
 		cli.FormatStringFlagLabel("Use a different metadata format version than the latest one supported by the server (to support imports on older LXD versions)"))
+	cmd.Flags().StringVar(&c.flagIncrementalFrom, "incremental-from", "", cli.FormatStringFlagLabel("Only include the changes made since the given snapshot or backup (requires --optimized-storage)"))
+	cmd.Flags().StringVar(&c.flagBackupTarget, "backup-target", "", cli.FormatStringFlagLabel("Store the backup in the given backup target of the server rather than downloading it"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
 		if len(args) > 0 {
@@ -80,6 +91,7 @@ func (c *cmdExport) run(cmd *cobra.Command, args []string) error {
 		InstanceOnly:         instanceOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		IncrementalFrom:      c.flagIncrementalFrom,
 	}
 
 	req.Version, err = getExportVersion(d, c.flagExportVersion)
@@ -87,6 +99,18 @@ func (c *cmdExport) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagBackupTarget != "" {
+		if len(args) > 1 {
+			return errors.New("A target file can't be used together with --backup-target")
+		}
+
+		// Backups stored in a backup target are kept until deleted.
+		req.ExpiresAt = time.Time{}
+		req.Target = c.flagBackupTarget
+
+		return c.exportToTarget(d, name, req)
+	}
+
 	op, err := d.CreateInstanceBackup(name, req)
 	if err != nil {
 		return fmt.Errorf("Create instance backup: %w", err)
@@ -212,3 +236,46 @@ func (c *cmdExport) run(cmd *cobra.Command, args []string) error {
 	exportProgress.Done("Backup exported successfully!")
 	return nil
 }
+
+// exportToTarget creates a backup stored in a backup target of the server and prints its location.
+func (c *cmdExport) exportToTarget(d lxd.InstanceServer, name string, req api.InstanceBackupsPost) error {
+	op, err := d.CreateInstanceBackup(name, req)
+	if err != nil {
+		return fmt.Errorf("Create instance backup: %w", err)
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: "Backing up instance: %s",
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = cli.CancelableWait(op, &progress)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done("")
+
+	backupName, _, err := getEntityFromOperationMetadata(op.Get().Metadata)
+	if err != nil {
+		return fmt.Errorf("Failed getting instance backup name from operation: %w", err)
+	}
+
+	backup, _, err := d.GetInstanceBackup(name, backupName)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Backup %s stored at: %s\n", backup.Name, backup.TargetLocation)
+	}
+
+	return nil
+}
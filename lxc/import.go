@@ -15,8 +15,10 @@ import (
 type cmdImport struct {
 	global *cmdGlobal
 
-	flagStorage string
-	flagDevice  []string
+	flagStorage      string
+	flagDevice       []string
+	flagIncremental  []string
+	flagBackupTarget string
 }
 
 func (c *cmdImport) command() *cobra.Command {
@@ -25,11 +27,19 @@ func (c *cmdImport) command() *cobra.Command {
 	cmd.Short = "Import instance backups"
 	cmd.Long = cli.FormatSection("Description", `Import backups of instances including their snapshots.`)
 	cmd.Example = cli.FormatSection("", `lxc import backup0.tar.gz
-    Create a new instance using backup0.tar.gz as the source.`)
+    Create a new instance using backup0.tar.gz as the source.
+
+lxc import backup0.tar.gz --incremental backup1.tar.gz --incremental backup2.tar.gz
+    Create a new instance using backup0.tar.gz as the source and apply the incremental backups on top of it.
+
+lxc import default/instances/c1/backup0-20260101T000000Z --backup-target s3
+    Create a new instance from a backup stored in the S3 backup target of the server.`)
 
 	cmd.RunE = c.run
 	cmd.Flags().StringVarP(&c.flagStorage, "storage", "s", "", cli.FormatStringFlagLabel("Storage pool name"))
 	cmd.Flags().StringArrayVarP(&c.flagDevice, "device", "d", nil, cli.FormatStringFlagLabel("New key/value to apply to a specific device"))
+	cmd.Flags().StringArrayVar(&c.flagIncremental, "incremental", nil, cli.FormatStringFlagLabel("Incremental backup file to apply after the import (can be repeated, applied in order)"))
+	cmd.Flags().StringVar(&c.flagBackupTarget, "backup-target", "", cli.FormatStringFlagLabel("Backup target the server fetches the backups from, backup files are then locations in the target"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
 		if len(args) > 1 {
@@ -83,6 +93,44 @@ func (c *cmdImport) run(cmd *cobra.Command, args []string) error {
 
 	resource := resources[0]
 
+	deviceMap, err := parseDeviceOverrides(c.flagDevice)
+	if err != nil {
+		return err
+	}
+
+	// Import the backup followed by the incremental backups of the chain.
+	for _, path := range append([]string{srcFile}, c.flagIncremental...) {
+		err = c.importFile(resource.server, path, instanceName, deviceMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importFile imports a single backup file.
+func (c *cmdImport) importFile(server lxd.InstanceServer, srcFile string, instanceName string, deviceMap map[string]map[string]string) error {
+	progress := cli.ProgressRenderer{
+		Format: "Importing instance: %s",
+		Quiet:  c.global.flagQuiet,
+	}
+
+	createArgs := lxd.InstanceBackupArgs{
+		PoolName: c.flagStorage,
+		Name:     instanceName,
+		Devices:  deviceMap,
+	}
+
+	if c.flagBackupTarget != "" {
+		// The server fetches the backup itself.
+		createArgs.BackupTarget = c.flagBackupTarget
+		createArgs.BackupLocation = srcFile
+
+		return c.createFromBackup(server, createArgs, &progress)
+	}
+
+	var err error
 	var file *os.File
 	if srcFile == "-" {
 		file = os.Stdin
@@ -101,30 +149,20 @@ func (c *cmdImport) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	progress := cli.ProgressRenderer{
-		Format: "Importing instance: %s",
-		Quiet:  c.global.flagQuiet,
-	}
+	createArgs.BackupFile = ioprogress.NewProgressReader(file, ioprogress.WithLength(fstat.Size()), ioprogress.WithProgressUpdater(&progress))
 
-	deviceMap, err := parseDeviceOverrides(c.flagDevice)
-	if err != nil {
-		return err
-	}
-
-	createArgs := lxd.InstanceBackupArgs{
-		BackupFile: ioprogress.NewProgressReader(file, ioprogress.WithLength(fstat.Size()), ioprogress.WithProgressUpdater(&progress)),
-		PoolName:   c.flagStorage,
-		Name:       instanceName,
-		Devices:    deviceMap,
-	}
+	return c.createFromBackup(server, createArgs, &progress)
+}
 
-	op, err := resource.server.CreateInstanceFromBackup(createArgs)
+// createFromBackup creates the instance from the backup and waits for the import to finish.
+func (c *cmdImport) createFromBackup(server lxd.InstanceServer, createArgs lxd.InstanceBackupArgs, progress *cli.ProgressRenderer) error {
+	op, err := server.CreateInstanceFromBackup(createArgs)
 	if err != nil {
 		return err
 	}
 
 	// Wait for operation to finish.
-	err = cli.CancelableWait(op, &progress)
+	err = cli.CancelableWait(op, progress)
 	if err != nil {
 		progress.Done("")
 		return err
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -542,6 +543,27 @@ func (c *cmdInfo) instanceInfo(d lxd.InstanceServer, name string, showLog bool)
 			fmt.Print(cpuInfo.String())
 		}
 
+		// NUMA topology
+		if len(inst.State.NUMANodes) > 0 {
+			fmt.Printf("  NUMA nodes:\n")
+			for _, node := range inst.State.NUMANodes {
+				hostNodes := make([]string, 0, len(node.HostNodes))
+				for _, hostNode := range node.HostNodes {
+					hostNodes = append(hostNodes, strconv.FormatUint(hostNode, 10))
+				}
+
+				cpus := make([]string, 0, len(node.CPUs))
+				for _, cpu := range node.CPUs {
+					cpus = append(cpus, strconv.FormatUint(cpu, 10))
+				}
+
+				fmt.Printf("    Node %d:\n", node.ID)
+				fmt.Printf("      Host nodes: %s\n", strings.Join(hostNodes, ", "))
+				fmt.Printf("      vCPUs: %s\n", strings.Join(cpus, ", "))
+				fmt.Printf("      Memory: %s\n", units.GetByteSizeStringIEC(node.Memory, 2))
+			}
+		}
+
 		// Memory usage
 		var memoryInfo strings.Builder
 		if inst.State.Memory.Usage != 0 {
@@ -79,6 +79,10 @@ func (c *cmdNetwork) command() *cobra.Command {
 	networkListAllocationsCmd := cmdNetworkListAllocations{global: c.global, network: c}
 	cmd.AddCommand(networkListAllocationsCmd.command())
 
+	// List BGP peers
+	networkListBGPPeersCmd := cmdNetworkListBGPPeers{global: c.global, network: c}
+	cmd.AddCommand(networkListBGPPeersCmd.command())
+
 	// List leases
 	networkListLeasesCmd := cmdNetworkListLeases{global: c.global, network: c}
 	cmd.AddCommand(networkListLeasesCmd.command())
@@ -103,10 +107,18 @@ func (c *cmdNetwork) command() *cobra.Command {
 	networkACLCmd := cmdNetworkACL{global: c.global}
 	cmd.AddCommand(networkACLCmd.command())
 
+	// Address set
+	networkAddressSetCmd := cmdNetworkAddressSet{global: c.global}
+	cmd.AddCommand(networkAddressSetCmd.command())
+
 	// Forward
 	networkForwardCmd := cmdNetworkForward{global: c.global}
 	cmd.AddCommand(networkForwardCmd.command())
 
+	// Lease
+	networkLeaseCmd := cmdNetworkLease{global: c.global, network: c}
+	cmd.AddCommand(networkLeaseCmd.command())
+
 	// Load Balancer
 	networkLoadBalancerCmd := cmdNetworkLoadBalancer{global: c.global}
 	cmd.AddCommand(networkLoadBalancerCmd.command())
@@ -115,6 +127,10 @@ func (c *cmdNetwork) command() *cobra.Command {
 	networkPeerCmd := cmdNetworkPeer{global: c.global}
 	cmd.AddCommand(networkPeerCmd.command())
 
+	// WireGuard
+	networkWireGuardCmd := cmdNetworkWireGuard{global: c.global}
+	cmd.AddCommand(networkWireGuardCmd.command())
+
 	// Zone
 	networkZoneCmd := cmdNetworkZone{global: c.global}
 	cmd.AddCommand(networkZoneCmd.command())
@@ -1005,6 +1021,37 @@ func (c *cmdNetworkInfo) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Chassis: %s\n", state.OVN.Chassis)
 	}
 
+	// WireGuard information.
+	if state.WireGuard != nil {
+		fmt.Println("")
+		fmt.Println("WireGuard:")
+		fmt.Printf("  Public key: %s\n", state.WireGuard.PublicKey)
+		fmt.Printf("  Listen port: %d\n", state.WireGuard.ListenPort)
+
+		if len(state.WireGuard.Peers) > 0 {
+			fmt.Println("  Peers:")
+		}
+
+		for _, peer := range state.WireGuard.Peers {
+			peerName := peer.Name
+			if peerName == "" {
+				peerName = peer.PublicKey
+			}
+
+			latestHandshake := "never"
+			if !peer.LatestHandshake.IsZero() {
+				latestHandshake = peer.LatestHandshake.Local().Format("2006/01/02 15:04 MST")
+			}
+
+			fmt.Printf("    %s:\n", peerName)
+			fmt.Printf("      Endpoint: %s\n", peer.Endpoint)
+			fmt.Printf("      Allowed IPs: %s\n", strings.Join(peer.AllowedIPs, ", "))
+			fmt.Printf("      Latest handshake: %s\n", latestHandshake)
+			fmt.Printf("      Bytes received: %s\n", units.GetByteSizeString(peer.BytesReceived, 2))
+			fmt.Printf("      Bytes sent: %s\n", units.GetByteSizeString(peer.BytesSent, 2))
+		}
+	}
+
 	return nil
 }
 
@@ -1177,6 +1224,105 @@ func (c *cmdNetworkList) stateColumnData(network api.Network) string {
 	return strings.ToUpper(network.Status)
 }
 
+// List BGP peers.
+type cmdNetworkListBGPPeers struct {
+	global  *cmdGlobal
+	network *cmdNetwork
+
+	flagFormat string
+}
+
+func (c *cmdNetworkListBGPPeers) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list-bgp-peers", "[<remote>:]<network>")
+	cmd.Short = "List BGP peer sessions"
+	cmd.Long = cli.FormatSection("Description", `List BGP peer sessions
+
+Shows the state of the BGP and BFD sessions with the peers of the network along with
+the number of prefixes received from, imported from and advertised to each peer.
+The prefixes themselves are included in the json and yaml formats.`)
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().StringVar(&c.network.flagTarget, "target", "", cli.FormatStringFlagLabel("Cluster member name"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.global.cmpTopLevelResource("network", toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkListBGPPeers) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	client := resource.server
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	// Targeting
+	if c.network.flagTarget != "" {
+		client = client.UseTarget(c.network.flagTarget)
+	}
+
+	bgp, err := client.GetNetworkBGP(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, peer := range bgp.Peers {
+		imported := 0
+		for _, prefix := range peer.ReceivedPrefixes {
+			if prefix.Imported {
+				imported++
+			}
+		}
+
+		data = append(data, []string{
+			peer.Name,
+			peer.Address,
+			strconv.FormatUint(uint64(peer.ASN), 10),
+			strings.ToUpper(peer.State),
+			strings.ToUpper(peer.BFDState),
+			strconv.Itoa(len(peer.ReceivedPrefixes)),
+			strconv.Itoa(imported),
+			strconv.Itoa(len(peer.AdvertisedPrefixes)),
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		"NAME",
+		"ADDRESS",
+		"ASN",
+		"STATE",
+		"BFD",
+		"RECEIVED",
+		"IMPORTED",
+		"ADVERTISED",
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, bgp.Peers)
+}
+
 // List leases.
 type cmdNetworkListLeases struct {
 	global  *cmdGlobal
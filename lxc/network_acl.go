@@ -19,6 +19,7 @@ import (
 	"github.com/canonical/lxd/shared/api"
 	cli "github.com/canonical/lxd/shared/cmd"
 	"github.com/canonical/lxd/shared/termios"
+	"github.com/canonical/lxd/shared/units"
 )
 
 type cmdNetworkACL struct {
@@ -43,6 +44,10 @@ func (c *cmdNetworkACL) command() *cobra.Command {
 	networkACLShowLogCmd := cmdNetworkACLShowLog{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLShowLogCmd.command())
 
+	// Info.
+	networkACLInfoCmd := cmdNetworkACLInfo{global: c.global, networkACL: c}
+	cmd.AddCommand(networkACLInfoCmd.command())
+
 	// Get.
 	networkACLGetCmd := cmdNetworkACLGet{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLGetCmd.command())
@@ -318,6 +323,79 @@ func (c *cmdNetworkACLShowLog) run(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// Info.
+type cmdNetworkACLInfo struct {
+	global     *cmdGlobal
+	networkACL *cmdNetworkACL
+}
+
+func (c *cmdNetworkACLInfo) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("info", "[<remote>:]<ACL>")
+	cmd.Short = "Show the hit counters of network ACL rules"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_acl", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkACLInfo) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	if resource.name == "" {
+		return errors.New("Missing network ACL name")
+	}
+
+	netACL, _, err := resource.server.GetNetworkACL(resource.name)
+	if err != nil {
+		return err
+	}
+
+	state, err := resource.server.GetNetworkACLState(resource.name)
+	if err != nil {
+		return err
+	}
+
+	printRules := func(title string, rules []api.NetworkACLRule, counters []api.NetworkACLRuleCounters) {
+		fmt.Println(title)
+		for i, rule := range rules {
+			fmt.Printf("  Rule %d (%s, %s):\n", i, rule.Action, rule.State)
+			if rule.Description != "" {
+				fmt.Printf("    Description: %s\n", rule.Description)
+			}
+
+			if i < len(counters) {
+				fmt.Printf("    Packets: %d\n", counters[i].Packets)
+				fmt.Printf("    Bytes: %s\n", units.GetByteSizeString(int64(counters[i].Bytes), 2))
+			}
+		}
+	}
+
+	printRules("Ingress rules:", netACL.Ingress, state.Ingress)
+	printRules("Egress rules:", netACL.Egress, state.Egress)
+
+	return nil
+}
+
 // Get.
 type cmdNetworkACLGet struct {
 	global     *cmdGlobal
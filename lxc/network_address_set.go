@@ -0,0 +1,932 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/canonical/lxd/client"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	cli "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/termios"
+)
+
+type cmdNetworkAddressSet struct {
+	global *cmdGlobal
+}
+
+func (c *cmdNetworkAddressSet) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("address-set")
+	cmd.Short = "Manage network address sets"
+	cmd.Long = cli.FormatSection("Description", `Manage network address sets
+
+Network address sets are named lists of addresses and subnets that can be
+referenced in network ACL rules as "$<name>".`)
+
+	// List.
+	networkAddressSetListCmd := cmdNetworkAddressSetList{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetListCmd.command())
+
+	// Show.
+	networkAddressSetShowCmd := cmdNetworkAddressSetShow{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetShowCmd.command())
+
+	// Get.
+	networkAddressSetGetCmd := cmdNetworkAddressSetGet{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetGetCmd.command())
+
+	// Create.
+	networkAddressSetCreateCmd := cmdNetworkAddressSetCreate{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetCreateCmd.command())
+
+	// Set.
+	networkAddressSetSetCmd := cmdNetworkAddressSetSet{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetSetCmd.command())
+
+	// Unset.
+	networkAddressSetUnsetCmd := cmdNetworkAddressSetUnset{global: c.global, networkAddressSet: c, networkAddressSetSet: &networkAddressSetSetCmd}
+	cmd.AddCommand(networkAddressSetUnsetCmd.command())
+
+	// Edit.
+	networkAddressSetEditCmd := cmdNetworkAddressSetEdit{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetEditCmd.command())
+
+	// Rename.
+	networkAddressSetRenameCmd := cmdNetworkAddressSetRename{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetRenameCmd.command())
+
+	// Delete.
+	networkAddressSetDeleteCmd := cmdNetworkAddressSetDelete{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetDeleteCmd.command())
+
+	// Add/Remove addresses.
+	networkAddressSetAddressCmd := cmdNetworkAddressSetAddress{global: c.global, networkAddressSet: c}
+	cmd.AddCommand(networkAddressSetAddressCmd.commandAdd())
+	cmd.AddCommand(networkAddressSetAddressCmd.commandRemove())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+// cmdNetworkAddressSetList handles listing network address sets.
+type cmdNetworkAddressSetList struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+
+	flagFormat  string
+	flagColumns string
+}
+
+// columns returns the ordered column definitions for network address set list.
+func (c *cmdNetworkAddressSetList) columns() []cli.ShorthandColumn[api.NetworkAddressSet] {
+	return []cli.ShorthandColumn[api.NetworkAddressSet]{
+		{Shorthand: 'n', Name: "NAME", Data: c.nameColumnData},
+		{Shorthand: 'd', Name: "DESCRIPTION", Data: c.descriptionColumnData},
+		{Shorthand: 'a', Name: "ADDRESSES", Data: c.addressesColumnData},
+		{Shorthand: 'u', Name: "USED BY", Data: c.usedByColumnData},
+	}
+}
+
+func (c *cmdNetworkAddressSetList) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", "[<remote>:]")
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = "List network address sets"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", cli.DefaultColumnString(c.columns()), cli.FormatStringFlagLabel("Columns"))
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, ":", true, instanceServerRemoteCompletionFilters(*c.global.conf)...)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetList) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name != "" {
+		return errors.New("Filtering is not supported yet")
+	}
+
+	sets, err := resource.server.GetNetworkAddressSets()
+	if err != nil {
+		return err
+	}
+
+	// Parse column flags.
+	columns, err := cli.ParseShorthandColumns(c.flagColumns, c.columns())
+	if err != nil {
+		return err
+	}
+
+	data := cli.ColumnData(columns, sets)
+	sort.Sort(cli.SortColumnsNaturally(data))
+	header := cli.ColumnHeaders(columns)
+
+	return cli.RenderTable(c.flagFormat, header, data, sets)
+}
+
+func (c *cmdNetworkAddressSetList) nameColumnData(set api.NetworkAddressSet) string {
+	return set.Name
+}
+
+func (c *cmdNetworkAddressSetList) descriptionColumnData(set api.NetworkAddressSet) string {
+	return set.Description
+}
+
+func (c *cmdNetworkAddressSetList) addressesColumnData(set api.NetworkAddressSet) string {
+	return strings.Join(set.Addresses, "\n")
+}
+
+func (c *cmdNetworkAddressSetList) usedByColumnData(set api.NetworkAddressSet) string {
+	return strconv.Itoa(len(set.UsedBy))
+}
+
+// Show.
+type cmdNetworkAddressSetShow struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetShow) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", "[<remote>:]<address set>")
+	cmd.Short = "Show network address set configurations"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetShow) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Show the network address set config.
+	netAddressSet, _, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(netAddressSet.UsedBy)
+
+	data, err := yaml.Marshal(&netAddressSet)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+// Get.
+type cmdNetworkAddressSetGet struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+
+	flagIsProperty bool
+}
+
+func (c *cmdNetworkAddressSetGet) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("get", "[<remote>:]<address set> <key>")
+	cmd.Short = "Get value for network address set configuration key"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.Flags().BoolVarP(&c.flagIsProperty, "property", "p", false, "Get the key as a network address set property")
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetGet) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	resp, _, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if c.flagIsProperty {
+		w := resp.Writable()
+		res, err := getFieldByJSONTag(&w, args[1])
+		if err != nil {
+			return fmt.Errorf("The property %q does not exist on the network address set %q: %v", args[1], resource.name, err)
+		}
+
+		fmt.Printf("%v\n", res)
+	} else {
+		for k, v := range resp.Config {
+			if k == args[1] {
+				fmt.Printf("%s\n", v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Create.
+type cmdNetworkAddressSetCreate struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetCreate) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", "[<remote>:]<address set> [<address>...] [key=value...]")
+	cmd.Short = "Create new network address set"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Example = cli.FormatSection("", `lxc network address-set create office 192.0.2.0/24 2001:db8::/32
+    Create network address set "office" containing two subnets
+
+lxc network address-set create office < config.yaml
+    Create network address set with configuration from config.yaml`)
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetCreate) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// If stdin isn't a terminal, read yaml from it.
+	var setPut api.NetworkAddressSetPut
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &setPut)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the network address set.
+	set := api.NetworkAddressSetsPost{
+		NetworkAddressSetPost: api.NetworkAddressSetPost{
+			Name: resource.name,
+		},
+		NetworkAddressSetPut: setPut,
+	}
+
+	if set.Config == nil {
+		set.Config = map[string]string{}
+	}
+
+	for _, arg := range args[1:] {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			// Anything that isn't a key/value pair is an address.
+			set.Addresses = append(set.Addresses, arg)
+			continue
+		}
+
+		set.Config[key] = value
+	}
+
+	op, err := resource.server.CreateNetworkAddressSet(set)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Network address set %s created\n", resource.name)
+	}
+
+	return nil
+}
+
+// Set.
+type cmdNetworkAddressSetSet struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+
+	flagIsProperty bool
+}
+
+func (c *cmdNetworkAddressSetSet) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("set", "[<remote>:]<address set> <key>=<value>...")
+	cmd.Short = "Set network address set configuration keys"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.Flags().BoolVarP(&c.flagIsProperty, "property", "p", false, "Set the key as a network address set property")
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetSet) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Get the network address set.
+	netAddressSet, etag, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	// Set the keys.
+	keys, err := getConfig(args[1:]...)
+	if err != nil {
+		return err
+	}
+
+	writable := netAddressSet.Writable()
+	if c.flagIsProperty {
+		if cmd.Name() == "unset" {
+			for k := range keys {
+				err := unsetFieldByJSONTag(&writable, k)
+				if err != nil {
+					return fmt.Errorf("Error unsetting property: %v", err)
+				}
+			}
+		} else {
+			err := unpackKVToWritable(&writable, keys)
+			if err != nil {
+				return fmt.Errorf("Error setting properties: %v", err)
+			}
+		}
+	} else {
+		maps.Copy(writable.Config, keys)
+	}
+
+	op, err := resource.server.UpdateNetworkAddressSet(resource.name, writable, etag)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	return err
+}
+
+// Unset.
+type cmdNetworkAddressSetUnset struct {
+	global               *cmdGlobal
+	networkAddressSet    *cmdNetworkAddressSet
+	networkAddressSetSet *cmdNetworkAddressSetSet
+
+	flagIsProperty bool
+}
+
+func (c *cmdNetworkAddressSetUnset) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("unset", "[<remote>:]<address set> <key>")
+	cmd.Short = "Unset network address set configuration key"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.Flags().BoolVarP(&c.flagIsProperty, "property", "p", false, "Unset the key as a network address set property")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetUnset) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	c.networkAddressSetSet.flagIsProperty = c.flagIsProperty
+
+	args = append(args, "")
+	return c.networkAddressSetSet.run(cmd, args)
+}
+
+// Edit.
+type cmdNetworkAddressSetEdit struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetEdit) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", "[<remote>:]<address set>")
+	cmd.Short = "Edit network address set configurations as YAML"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetEdit) helpTemplate() string {
+	return `### This is a YAML representation of the network address set.
+### Any line starting with a '# will be ignored.
+###
+### A network address set consists of a list of addresses and configuration items.
+###
+### An example would look like:
+### name: office
+### description: Office and VPN ranges
+### addresses:
+### - 192.0.2.0/24
+### - 2001:db8::/32
+### config:
+###  user.foo: bah
+###
+### Note that only the addresses, description and configuration keys can be changed.`
+}
+
+func (c *cmdNetworkAddressSetEdit) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		// Allow output of `lxc network address-set show` command to be passed in here, but only take the
+		// contents of the NetworkAddressSetPut fields when updating. The other fields are silently discarded.
+		newdata := api.NetworkAddressSet{}
+		err = yaml.UnmarshalStrict(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		op, err := resource.server.UpdateNetworkAddressSet(resource.name, newdata.Writable(), "")
+		if err == nil {
+			err = op.Wait()
+		}
+
+		return err
+	}
+
+	// Get the current config.
+	netAddressSet, etag, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&netAddressSet)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor.
+	content, err := shared.TextEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor.
+		newdata := api.NetworkAddressSet{} // We show the full info, but only send the writable fields.
+		err = yaml.UnmarshalStrict(content, &newdata)
+		if err == nil {
+			var op lxd.Operation
+			op, err = resource.server.UpdateNetworkAddressSet(resource.name, newdata.Writable(), etag)
+			if err == nil {
+				err = op.Wait()
+			}
+		}
+
+		// Respawn the editor.
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Config parsing error: %s\n", err)
+			fmt.Println("Press enter to open the editor again or ctrl+c to abort change")
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = shared.TextEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Rename.
+type cmdNetworkAddressSetRename struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetRename) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("rename", "[<remote>:]<address set> <new-name>")
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = "Rename network address set"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetRename) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Rename the network address set.
+	op, err := resource.server.RenameNetworkAddressSet(resource.name, api.NetworkAddressSetPost{Name: args[1]})
+	if err == nil {
+		err = op.Wait()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Network address set %s renamed to %s\n", resource.name, args[1])
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdNetworkAddressSetDelete struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetDelete) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", "[<remote>:]<address set>")
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = "Delete network address set"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetDelete) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Delete the network address set.
+	op, err := resource.server.DeleteNetworkAddressSet(resource.name)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Network address set %s deleted\n", resource.name)
+	}
+
+	return nil
+}
+
+// Add/Remove addresses.
+type cmdNetworkAddressSetAddress struct {
+	global            *cmdGlobal
+	networkAddressSet *cmdNetworkAddressSet
+}
+
+func (c *cmdNetworkAddressSetAddress) commandAdd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("add", "[<remote>:]<address set> <address>...")
+	cmd.Short = "Add addresses to a network address set"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Example = cli.FormatSection("", `lxc network address-set add office 198.51.100.7 203.0.113.0/24`)
+	cmd.RunE = c.runAdd
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetAddress) runAdd(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Get the network address set.
+	netAddressSet, etag, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	writable := netAddressSet.Writable()
+	for _, address := range args[1:] {
+		if slices.Contains(writable.Addresses, address) {
+			return fmt.Errorf("Address %q already exists in the network address set", address)
+		}
+
+		writable.Addresses = append(writable.Addresses, address)
+	}
+
+	op, err := resource.server.UpdateNetworkAddressSet(resource.name, writable, etag)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	return err
+}
+
+func (c *cmdNetworkAddressSetAddress) commandRemove() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("remove", "[<remote>:]<address set> <address>...")
+	cmd.Short = "Remove addresses from a network address set"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.runRemove
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network_address_set", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkAddressSetAddress) runRemove(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network address set name")
+	}
+
+	// Get the network address set.
+	netAddressSet, etag, err := resource.server.GetNetworkAddressSet(resource.name)
+	if err != nil {
+		return err
+	}
+
+	writable := netAddressSet.Writable()
+	for _, address := range args[1:] {
+		index := slices.Index(writable.Addresses, address)
+		if index < 0 {
+			return fmt.Errorf("Address %q not found in the network address set", address)
+		}
+
+		writable.Addresses = slices.Delete(writable.Addresses, index, index+1)
+	}
+
+	op, err := resource.server.UpdateNetworkAddressSet(resource.name, writable, etag)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	return err
+}
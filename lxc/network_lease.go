@@ -0,0 +1,296 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/lxd/shared/api"
+	cli "github.com/canonical/lxd/shared/cmd"
+)
+
+type cmdNetworkLease struct {
+	global  *cmdGlobal
+	network *cmdNetwork
+}
+
+func (c *cmdNetworkLease) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("lease")
+	cmd.Short = "Manage network DHCP leases"
+	cmd.Long = cli.FormatSection("Description", `Manage network DHCP leases
+
+Reservations assign a fixed address (and optionally a hostname) to the MAC address of a client
+that isn't managed by LXD. Releasing an address removes its reservation or its dynamic lease.`)
+
+	// List.
+	networkListLeasesCmd := cmdNetworkListLeases{global: c.global, network: c.network}
+	networkLeaseListCmd := networkListLeasesCmd.command()
+	networkLeaseListCmd.Use = usage("list", "[<remote>:]<network>")
+	networkLeaseListCmd.Aliases = []string{"ls"}
+	cmd.AddCommand(networkLeaseListCmd)
+
+	// Option.
+	networkLeaseOptionCmd := cmdNetworkLeaseOption{global: c.global, networkLease: c}
+	cmd.AddCommand(networkLeaseOptionCmd.command())
+
+	// Release.
+	networkLeaseReleaseCmd := cmdNetworkLeaseRelease{global: c.global, networkLease: c}
+	cmd.AddCommand(networkLeaseReleaseCmd.command())
+
+	// Reserve.
+	networkLeaseReserveCmd := cmdNetworkLeaseReserve{global: c.global, networkLease: c}
+	cmd.AddCommand(networkLeaseReserveCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Reserve.
+type cmdNetworkLeaseReserve struct {
+	global       *cmdGlobal
+	networkLease *cmdNetworkLease
+
+	flagHostname string
+}
+
+func (c *cmdNetworkLeaseReserve) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reserve", "[<remote>:]<network> <MAC> <address>")
+	cmd.Short = "Reserve an address for a MAC address"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.Example = cli.FormatSection("", `lxc network lease reserve lxdbr0 00:16:3e:2c:89:d9 10.0.0.98 --hostname printer
+    Always give 10.0.0.98 and the hostname "printer" to the client with MAC address 00:16:3e:2c:89:d9`)
+
+	cmd.Flags().StringVar(&c.flagHostname, "hostname", "", cli.FormatStringFlagLabel("Hostname given to the client"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkLeaseReserve) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	lease := api.NetworkLeasesPost{
+		Hwaddr:   args[1],
+		Address:  args[2],
+		Hostname: c.flagHostname,
+	}
+
+	err = resource.server.CreateNetworkLease(resource.name, lease)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Address %s reserved for %s\n", args[2], args[1])
+	}
+
+	return nil
+}
+
+// Release.
+type cmdNetworkLeaseRelease struct {
+	global       *cmdGlobal
+	networkLease *cmdNetworkLease
+}
+
+func (c *cmdNetworkLeaseRelease) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("release", "[<remote>:]<network> <address>")
+	cmd.Short = "Release a DHCP lease or reservation"
+	cmd.Long = cli.FormatSection("Description", `Release a DHCP lease or reservation
+
+If the address is reserved, the reservation is removed. Otherwise, the dynamic lease of the address is released.`)
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkLeaseRelease) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	err = resource.server.DeleteNetworkLease(resource.name, args[1])
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Address %s released\n", args[1])
+	}
+
+	return nil
+}
+
+// Option.
+type cmdNetworkLeaseOption struct {
+	global       *cmdGlobal
+	networkLease *cmdNetworkLease
+
+	flagFormat string
+	flagIPv6   bool
+}
+
+func (c *cmdNetworkLeaseOption) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("option", "[<remote>:]<network> [<code> [<value>]]")
+	cmd.Short = "Manage the DHCP options of a network"
+	cmd.Long = cli.FormatSection("Description", `Manage the DHCP options of a network
+
+Without a code, the DHCP options sent by the network are listed.
+With a code and a value, the option is set. With a code and an empty value, the option is removed.
+
+DHCP options are stored in the "ipv4.dhcp.options.<code>" and "ipv6.dhcp.options.<code>" network configuration keys.`)
+	cmd.Example = cli.FormatSection("", `lxc network lease option lxdbr0 42 10.0.0.1
+    Send 10.0.0.1 as the NTP server (option 42) to the DHCPv4 clients
+
+lxc network lease option lxdbr0 42 ""
+    Stop sending the NTP server option`)
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", cli.FormatStringFlagLabel("Format (csv|json|table|yaml|compact)"))
+	cmd.Flags().BoolVar(&c.flagIPv6, "ipv6", false, "Manage the DHCPv6 options instead of the DHCPv4 ones")
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkLeaseOption) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 3)
+	if exit {
+		return err
+	}
+
+	if len(args) == 2 {
+		return errors.New("Missing option value")
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	client := resource.server
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	network, etag, err := client.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	prefix := "ipv4.dhcp.options."
+	if c.flagIPv6 {
+		prefix = "ipv6.dhcp.options."
+	}
+
+	// List the options.
+	if len(args) == 1 {
+		data := [][]string{}
+		options := map[string]string{}
+		for k, v := range network.Config {
+			code, found := strings.CutPrefix(k, prefix)
+			if !found {
+				continue
+			}
+
+			options[code] = v
+			data = append(data, []string{code, v})
+		}
+
+		sort.Sort(cli.SortColumnsNaturally(data))
+
+		header := []string{
+			"CODE",
+			"VALUE",
+		}
+
+		return cli.RenderTable(c.flagFormat, header, data, options)
+	}
+
+	// Set or remove the option.
+	_, err = strconv.ParseUint(args[1], 10, 16)
+	if err != nil {
+		return fmt.Errorf("Invalid DHCP option code %q", args[1])
+	}
+
+	writable := network.Writable()
+	if args[2] == "" {
+		delete(writable.Config, prefix+args[1])
+	} else {
+		writable.Config[prefix+args[1]] = args[2]
+	}
+
+	op, err := client.UpdateNetwork(resource.name, writable, etag)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	return err
+}
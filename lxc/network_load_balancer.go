@@ -42,6 +42,10 @@ func (c *cmdNetworkLoadBalancer) command() *cobra.Command {
 	networkLoadBalancerShowCmd := cmdNetworkLoadBalancerShow{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerShowCmd.command())
 
+	// Info.
+	networkLoadBalancerInfoCmd := cmdNetworkLoadBalancerInfo{global: c.global, networkLoadBalancer: c}
+	cmd.AddCommand(networkLoadBalancerInfoCmd.command())
+
 	// Create.
 	networkLoadBalancerCreateCmd := cmdNetworkLoadBalancerCreate{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerCreateCmd.command())
@@ -268,6 +272,103 @@ func (c *cmdNetworkLoadBalancerShow) run(cmd *cobra.Command, args []string) erro
 	return nil
 }
 
+// Info.
+type cmdNetworkLoadBalancerInfo struct {
+	global              *cmdGlobal
+	networkLoadBalancer *cmdNetworkLoadBalancer
+}
+
+func (c *cmdNetworkLoadBalancerInfo) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("info", "[<remote>:]<network> <listen_address>")
+	cmd.Short = "Show the health of network load balancer backends"
+	cmd.Long = cli.FormatSection("Description", cmd.Short)
+	cmd.RunE = c.run
+
+	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", cli.FormatStringFlagLabel("Cluster member name"))
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkLoadBalancers(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkLoadBalancerInfo) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	if args[1] == "" {
+		return errors.New("Missing listen address")
+	}
+
+	client := resource.server
+
+	// If a target was specified, use the load balancer on the given member.
+	if c.networkLoadBalancer.flagTarget != "" {
+		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
+	}
+
+	state, err := client.GetNetworkLoadBalancerState(resource.name, args[1])
+	if err != nil {
+		return err
+	}
+
+	backendNames := make([]string, 0, len(state.BackendHealth))
+	for name := range state.BackendHealth {
+		backendNames = append(backendNames, name)
+	}
+
+	sort.Strings(backendNames)
+
+	const layout = "2006/01/02 15:04:05 MST"
+
+	fmt.Println("Backend health:")
+	for _, name := range backendNames {
+		health := state.BackendHealth[name]
+
+		fmt.Printf("  %s:\n", name)
+		fmt.Printf("    Address: %s\n", health.Address)
+		if health.Port > 0 {
+			fmt.Printf("    Port: %d\n", health.Port)
+		}
+
+		fmt.Printf("    Status: %s\n", health.Status)
+		if !health.LastCheckedAt.IsZero() {
+			fmt.Printf("    Last checked: %s\n", health.LastCheckedAt.Local().Format(layout))
+		}
+
+		if health.Error != "" {
+			fmt.Printf("    Error: %s\n", health.Error)
+		}
+	}
+
+	return nil
+}
+
 // Create.
 type cmdNetworkLoadBalancerCreate struct {
 	global              *cmdGlobal
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/canonical/lxd/shared/cmd"
+)
+
+// networkWireGuardPeerToken represents the configuration of a WireGuard network exchanged with its peers.
+type networkWireGuardPeerToken struct {
+	PublicKey  string   `json:"public_key"`
+	Endpoint   string   `json:"endpoint,omitempty"`
+	AllowedIPs []string `json:"allowed_ips"`
+}
+
+type cmdNetworkWireGuard struct {
+	global *cmdGlobal
+}
+
+func (c *cmdNetworkWireGuard) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("wireguard")
+	cmd.Short = "Manage WireGuard network peers"
+	cmd.Long = cli.FormatSection("Description", `Manage WireGuard network peers
+
+The configuration exported from a WireGuard network on one server is imported as a peer
+into the WireGuard network of another server, and the other way around.`)
+
+	// Export.
+	networkWireGuardExportCmd := cmdNetworkWireGuardExport{global: c.global, networkWireGuard: c}
+	cmd.AddCommand(networkWireGuardExportCmd.command())
+
+	// Import.
+	networkWireGuardImportCmd := cmdNetworkWireGuardImport{global: c.global, networkWireGuard: c}
+	cmd.AddCommand(networkWireGuardImportCmd.command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Export.
+type cmdNetworkWireGuardExport struct {
+	global           *cmdGlobal
+	networkWireGuard *cmdNetworkWireGuard
+
+	flagEndpoint string
+}
+
+func (c *cmdNetworkWireGuardExport) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("export", "[<remote>:]<network>")
+	cmd.Short = "Export the peer configuration of a WireGuard network"
+	cmd.Long = cli.FormatSection("Description", `Export the peer configuration of a WireGuard network
+
+The output is a token containing the public key of the network, the endpoint it can be reached on
+and the addresses of the network and of the networks listed in "wireguard.networks".`)
+	cmd.Example = cli.FormatSection("", `lxc network wireguard export wg0 --endpoint 203.0.113.10
+    Export the peer configuration of wg0, reachable on 203.0.113.10 and its listen port`)
+
+	cmd.Flags().StringVar(&c.flagEndpoint, "endpoint", "", cli.FormatStringFlagLabel("Address (and port) the peers reach the network on"))
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkWireGuardExport) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	client := resource.server
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	network, _, err := client.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if network.Type != "wireguard" {
+		return fmt.Errorf("Network %q is not a WireGuard network", resource.name)
+	}
+
+	state, err := client.GetNetworkState(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if state.WireGuard == nil {
+		return fmt.Errorf("Network %q is not running", resource.name)
+	}
+
+	token := networkWireGuardPeerToken{
+		PublicKey:  state.WireGuard.PublicKey,
+		AllowedIPs: []string{},
+	}
+
+	// Add the port to the endpoint if not specified.
+	if c.flagEndpoint != "" {
+		_, _, err := net.SplitHostPort(c.flagEndpoint)
+		if err != nil {
+			token.Endpoint = net.JoinHostPort(c.flagEndpoint, strconv.FormatUint(state.WireGuard.ListenPort, 10))
+		} else {
+			token.Endpoint = c.flagEndpoint
+		}
+	}
+
+	// The peers reach the tunnel address of the network.
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		address, _, err := net.ParseCIDR(network.Config[key])
+		if err != nil {
+			continue
+		}
+
+		if address.To4() != nil {
+			token.AllowedIPs = append(token.AllowedIPs, address.String()+"/32")
+		} else {
+			token.AllowedIPs = append(token.AllowedIPs, address.String()+"/128")
+		}
+	}
+
+	// And the subnets of the local networks made reachable through the tunnel.
+	for localNetworkName := range strings.SplitSeq(network.Config["wireguard.networks"], ",") {
+		localNetworkName = strings.TrimSpace(localNetworkName)
+		if localNetworkName == "" {
+			continue
+		}
+
+		localNetwork, _, err := client.GetNetwork(localNetworkName)
+		if err != nil {
+			return fmt.Errorf("Failed loading network %q: %w", localNetworkName, err)
+		}
+
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			_, subnet, err := net.ParseCIDR(localNetwork.Config[key])
+			if err != nil {
+				continue
+			}
+
+			token.AllowedIPs = append(token.AllowedIPs, subnet.String())
+		}
+	}
+
+	if len(token.AllowedIPs) == 0 {
+		return fmt.Errorf("Network %q has no addresses to export", resource.name)
+	}
+
+	tokenJSON, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(base64.StdEncoding.EncodeToString(tokenJSON))
+
+	return nil
+}
+
+// Import.
+type cmdNetworkWireGuardImport struct {
+	global           *cmdGlobal
+	networkWireGuard *cmdNetworkWireGuard
+}
+
+func (c *cmdNetworkWireGuardImport) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("import", "[<remote>:]<network> <peer> <token>")
+	cmd.Short = "Add or update a WireGuard network peer from its exported configuration"
+	cmd.Long = cli.FormatSection("Description", `Add or update a WireGuard network peer from its exported configuration
+
+This sets the "peers.<peer>.public_key", "peers.<peer>.endpoint" and "peers.<peer>.allowed_ips"
+configuration keys of the network from a token generated with "lxc network wireguard export".`)
+	cmd.Example = cli.FormatSection("", `lxc network wireguard import wg0 site2 "$(lxc network wireguard export site2:wg0 --endpoint 198.51.100.10)"
+    Add the WireGuard network wg0 of the site2 remote as a peer of the local wg0 network`)
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("network", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkWireGuardImport) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	client := resource.server
+
+	if resource.name == "" {
+		return errors.New("Missing network name")
+	}
+
+	peerName := args[1]
+	if peerName == "" || strings.Contains(peerName, ".") {
+		return fmt.Errorf("Invalid peer name %q", peerName)
+	}
+
+	// Decode the token.
+	tokenJSON, err := base64.StdEncoding.DecodeString(args[2])
+	if err != nil {
+		return fmt.Errorf("Invalid WireGuard peer token: %w", err)
+	}
+
+	token := networkWireGuardPeerToken{}
+	err = json.Unmarshal(tokenJSON, &token)
+	if err != nil {
+		return fmt.Errorf("Invalid WireGuard peer token: %w", err)
+	}
+
+	network, etag, err := client.GetNetwork(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if network.Type != "wireguard" {
+		return fmt.Errorf("Network %q is not a WireGuard network", resource.name)
+	}
+
+	writable := network.Writable()
+	if writable.Config == nil {
+		writable.Config = map[string]string{}
+	}
+
+	prefix := "peers." + peerName + "."
+	writable.Config[prefix+"public_key"] = token.PublicKey
+	writable.Config[prefix+"allowed_ips"] = strings.Join(token.AllowedIPs, ",")
+
+	if token.Endpoint != "" {
+		writable.Config[prefix+"endpoint"] = token.Endpoint
+	} else {
+		delete(writable.Config, prefix+"endpoint")
+	}
+
+	op, err := client.UpdateNetwork(resource.name, writable, etag)
+	if err == nil {
+		err = op.Wait()
+	}
+
+	return err
+}
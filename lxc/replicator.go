@@ -19,6 +19,7 @@ import (
 	"github.com/canonical/lxd/shared/api"
 	cli "github.com/canonical/lxd/shared/cmd"
 	"github.com/canonical/lxd/shared/termios"
+	"github.com/canonical/lxd/shared/units"
 )
 
 type cmdReplicator struct {
@@ -43,6 +44,14 @@ func (c *cmdReplicator) command() *cobra.Command {
 	replicatorEditCmd := cmdReplicatorEdit{global: c.global}
 	cmd.AddCommand(replicatorEditCmd.command())
 
+	// Failback.
+	replicatorFailbackCmd := cmdReplicatorFailover{global: c.global, action: "failback"}
+	cmd.AddCommand(replicatorFailbackCmd.command())
+
+	// Failover.
+	replicatorFailoverCmd := cmdReplicatorFailover{global: c.global, action: "failover"}
+	cmd.AddCommand(replicatorFailoverCmd.command())
+
 	// Get.
 	replicatorGetCmd := cmdReplicatorGet{global: c.global}
 	cmd.AddCommand(replicatorGetCmd.command())
@@ -233,6 +242,87 @@ func (c *cmdReplicatorDelete) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Failover and failback.
+type cmdReplicatorFailover struct {
+	global *cmdGlobal
+
+	action string
+}
+
+func (c *cmdReplicatorFailover) command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage(c.action, "[<remote>:]<replicator>")
+
+	if c.action == "failback" {
+		cmd.Short = "Fail back to a replicator's project"
+		cmd.Long = cli.FormatSection("Description", `Fail back to a replicator's project
+
+Makes the replicator's project the leader again after a failover to the target cluster.
+The instances on the target cluster are stopped and a final sync is performed from the
+target cluster. The project on the target cluster is then set to standby, the local
+project is set to leader and its instances are started in boot.autostart.priority order.
+
+The target cluster must be reachable.`)
+		cmd.Example = cli.FormatSection("", `lxc replicator failback my-replicator
+    Fail back to the project of the replicator "my-replicator".`)
+	} else {
+		cmd.Short = "Fail over to a replicator's project"
+		cmd.Long = cli.FormatSection("Description", `Fail over to a replicator's project
+
+Promotes the replicator's standby project to leader.
+If the target cluster is reachable, its instances are stopped, a final sync is performed
+from it and its project is set to standby. The local project is then set to leader and
+its instances are started in boot.autostart.priority order.
+
+If the target cluster is unreachable, the failover proceeds without a final sync.`)
+		cmd.Example = cli.FormatSection("", `lxc replicator failover my-replicator
+    Fail over to the project of the replicator "my-replicator".`)
+	}
+
+	cmd.RunE = c.run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpTopLevelResource("replicator", toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdReplicatorFailover) run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New("Missing replicator name")
+	}
+
+	if !resource.server.HasExtension("replicator_failover") {
+		return errors.New(`The server is missing the required "replicator_failover" API extension`)
+	}
+
+	op, err := resource.server.RunReplicator(c.global.flagProject, resource.name, api.ReplicatorStatePut{Action: c.action})
+	if err != nil {
+		return err
+	}
+
+	return op.Wait()
+}
+
 // Get.
 type cmdReplicatorGet struct {
 	global *cmdGlobal
@@ -782,6 +872,8 @@ func (c *cmdReplicatorEdit) run(cmd *cobra.Command, args []string) error {
 // Info.
 type cmdReplicatorInfo struct {
 	global *cmdGlobal
+
+	flagRuns bool
 }
 
 func (c *cmdReplicatorInfo) command() *cobra.Command {
@@ -793,8 +885,12 @@ func (c *cmdReplicatorInfo) command() *cobra.Command {
 Displays the current state of the replicator including status, source project,
 instances in the project, and child operation details when a run is in progress.`)
 	cmd.Example = cli.FormatSection("", `lxc replicator info my-replicator
-    Show the current state of the replicator "my-replicator".`)
+    Show the current state of the replicator "my-replicator".
+
+lxc replicator info my-replicator --runs
+    Show the current state and the history of recent runs of the replicator "my-replicator".`)
 
+	cmd.Flags().BoolVar(&c.flagRuns, "runs", false, "Show the history of recent runs")
 	cmd.RunE = c.run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -827,28 +923,20 @@ func (c *cmdReplicatorInfo) run(cmd *cobra.Command, args []string) error {
 		return errors.New("Missing replicator name")
 	}
 
-	replicator, _, err := resource.server.GetReplicator(c.global.flagProject, resource.name)
-	if err != nil {
-		return err
+	if c.flagRuns && !resource.server.HasExtension("replicator_runs") {
+		return errors.New(`The server is missing the required "replicator_runs" API extension`)
 	}
 
-	state, err := resource.server.GetReplicatorState(c.global.flagProject, resource.name)
+	replicator, _, err := resource.server.GetReplicator(c.global.flagProject, resource.name)
 	if err != nil {
 		return err
 	}
 
-	instances, err := resource.server.GetInstances(lxd.GetInstancesArgs{})
+	state, err := resource.server.GetReplicatorState(c.global.flagProject, resource.name)
 	if err != nil {
 		return err
 	}
 
-	instanceNames := make([]string, 0, len(instances))
-	for _, inst := range instances {
-		instanceNames = append(instanceNames, inst.Name)
-	}
-
-	sort.Strings(instanceNames)
-
 	const layout = "2006/01/02 15:04 MST"
 
 	fmt.Printf("Name: %s\n", replicator.Name)
@@ -864,6 +952,11 @@ func (c *cmdReplicatorInfo) run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Schedule: %s\n", schedule)
 	}
 
+	rpo := replicator.Config["replication.rpo"]
+	if rpo != "" {
+		fmt.Printf("RPO: %s\n", rpo)
+	}
+
 	if shared.TimeIsSet(replicator.LastRunAt) {
 		fmt.Printf("Last run: %s\n", replicator.LastRunAt.Local().Format(layout))
 	}
@@ -890,6 +983,43 @@ func (c *cmdReplicatorInfo) run(cmd *cobra.Command, args []string) error {
 
 	// Render instances as a table.
 	fmt.Println("Instances:")
+	if resource.server.HasExtension("replicator_rpo") {
+		instanceData := make([][]string, 0, len(state.Instances))
+		for _, inst := range state.Instances {
+			lastSync := ""
+			lag := ""
+			if shared.TimeIsSet(inst.LastSyncAt) {
+				lastSync = inst.LastSyncAt.Local().Format(layout)
+				lag = (time.Duration(inst.Lag) * time.Second).String()
+			}
+
+			instanceData = append(instanceData, []string{inst.Name, inst.Status, lastSync, lag})
+		}
+
+		err = cli.RenderTable(cli.TableFormatTable, []string{"NAME", "STATUS", "LAST SYNC", "LAG"}, instanceData, state.Instances)
+		if err != nil {
+			return err
+		}
+
+		if c.flagRuns {
+			return c.renderRuns(resource)
+		}
+
+		return nil
+	}
+
+	instances, err := resource.server.GetInstances(lxd.GetInstancesArgs{})
+	if err != nil {
+		return err
+	}
+
+	instanceNames := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		instanceNames = append(instanceNames, inst.Name)
+	}
+
+	sort.Strings(instanceNames)
+
 	instanceData := make([][]string, 0, len(instanceNames))
 	for _, name := range instanceNames {
 		instanceData = append(instanceData, []string{name})
@@ -900,9 +1030,41 @@ func (c *cmdReplicatorInfo) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagRuns {
+		return c.renderRuns(resource)
+	}
+
 	return nil
 }
 
+// renderRuns renders the history of recent runs of the replicator as a table, one row per instance and run.
+func (c *cmdReplicatorInfo) renderRuns(resource remoteResource) error {
+	runs, err := resource.server.GetReplicatorRuns(c.global.flagProject, resource.name)
+	if err != nil {
+		return err
+	}
+
+	const layout = "2006/01/02 15:04 MST"
+
+	runData := [][]string{}
+	for _, run := range runs {
+		started := run.StartedAt.Local().Format(layout)
+		if len(run.Instances) == 0 {
+			runData = append(runData, []string{started, run.Status, "", "", "", "", ""})
+			continue
+		}
+
+		for _, inst := range run.Instances {
+			duration := (time.Duration(inst.Duration) * time.Millisecond).Round(time.Second).String()
+			transferred := units.GetByteSizeStringIEC(inst.BytesTransferred, 2)
+			runData = append(runData, []string{started, run.Status, inst.Name, inst.Status, transferred, duration, inst.Error})
+		}
+	}
+
+	fmt.Println("Runs:")
+	return cli.RenderTable(cli.TableFormatTable, []string{"STARTED", "STATUS", "INSTANCE", "INSTANCE STATUS", "TRANSFERRED", "DURATION", "ERROR"}, runData, runs)
+}
+
 // Rename.
 type cmdReplicatorRename struct {
 	global *cmdGlobal
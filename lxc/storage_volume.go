@@ -2654,6 +2654,8 @@ type cmdStorageVolumeExport struct {
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
 	flagExportVersion        string
+	flagIncrementalFrom      string
+	flagBackupTarget         string
 }
 
 func (c *cmdStorageVolumeExport) command() *cobra.Command {
@@ -2666,6 +2668,8 @@ func (c *cmdStorageVolumeExport) command() *cobra.Command {
 	cmd.Flags().BoolVar(&c.flagOptimizedStorage, "optimized-storage", false, "Use storage driver optimized format (can only be restored on a similar pool)")
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", cli.FormatStringFlagLabel("Define a compression algorithm: for backup or none"))
 	cmd.Flags().StringVar(&c.flagExportVersion, "export-version", "", cli.FormatStringFlagLabel("Use a different metadata format version than the latest one supported by the server (to support imports on older LXD versions)"))
+	cmd.Flags().StringVar(&c.flagIncrementalFrom, "incremental-from", "", cli.FormatStringFlagLabel("Only include the changes made since the given snapshot or backup (requires --optimized-storage)"))
+	cmd.Flags().StringVar(&c.flagBackupTarget, "backup-target", "", cli.FormatStringFlagLabel("Store the backup in the given backup target of the server rather than downloading it"))
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", cli.FormatStringFlagLabel("Cluster member name"))
 	cmd.RunE = c.run
 
@@ -2722,6 +2726,7 @@ func (c *cmdStorageVolumeExport) run(cmd *cobra.Command, args []string) error {
 		VolumeOnly:           volumeOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		IncrementalFrom:      c.flagIncrementalFrom,
 	}
 
 	req.Version, err = getExportVersion(d, c.flagExportVersion)
@@ -2729,6 +2734,18 @@ func (c *cmdStorageVolumeExport) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagBackupTarget != "" {
+		if len(args) > 2 {
+			return errors.New("A target file can't be used together with --backup-target")
+		}
+
+		// Backups stored in a backup target are kept until deleted.
+		req.ExpiresAt = time.Time{}
+		req.Target = c.flagBackupTarget
+
+		return c.exportToTarget(d, name, volName, req)
+	}
+
 	op, err := d.CreateStoragePoolVolumeBackup(name, volName, req)
 	if err != nil {
 		return fmt.Errorf("Failed creating storage volume backup for volume %q: %w", volName, err)
@@ -2820,6 +2837,49 @@ func (c *cmdStorageVolumeExport) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// exportToTarget creates a volume backup stored in a backup target of the server and prints its location.
+func (c *cmdStorageVolumeExport) exportToTarget(d lxd.InstanceServer, poolName string, volName string, req api.StoragePoolVolumeBackupsPost) error {
+	op, err := d.CreateStoragePoolVolumeBackup(poolName, volName, req)
+	if err != nil {
+		return fmt.Errorf("Failed creating storage volume backup for volume %q: %w", volName, err)
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: "Backing up storage volume: %s",
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = cli.CancelableWait(op, &progress)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done("")
+
+	backupName, _, err := getEntityFromOperationMetadata(op.Get().Metadata)
+	if err != nil {
+		return fmt.Errorf("Failed getting custom volume backup name from operation: %w", err)
+	}
+
+	backup, _, err := d.GetStoragePoolVolumeBackup(poolName, volName, backupName)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf("Backup %s stored at: %s\n", backup.Name, backup.TargetLocation)
+	}
+
+	return nil
+}
+
 // Import.
 type cmdStorageVolumeImport struct {
 	global        *cmdGlobal
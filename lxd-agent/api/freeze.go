@@ -0,0 +1,17 @@
+package api
+
+// SnapshotHooksPath is the directory of the hook scripts run inside an instance around an application-consistent snapshot.
+// The hooks are run in lexical order with the "freeze" argument before the snapshot and in reverse order with the "thaw"
+// argument after it.
+const SnapshotHooksPath = "/etc/lxd-agent/hooks.d"
+
+// FreezePost contains the fields used to freeze the guest filesystems.
+type FreezePost struct {
+	// Number of seconds after which the filesystems are thawed if no thaw request was received
+	// Example: 30
+	Timeout int `json:"timeout" yaml:"timeout"`
+
+	// Whether to run the snapshot hooks before freezing and after thawing the filesystems
+	// Example: true
+	Hooks bool `json:"hooks" yaml:"hooks"`
+}
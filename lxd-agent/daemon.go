@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/canonical/lxd/lxd/events"
 )
@@ -30,6 +31,13 @@ type Daemon struct {
 	devlxdRunning bool
 	devlxdMu      sync.Mutex
 	devlxdEnabled bool
+
+	// Filesystems frozen through the freeze endpoint, whether the snapshot hooks were run and the timer thawing them.
+	freezeMu          sync.Mutex
+	frozenFilesystems []string
+	freezeHooks       bool
+	freezeTimeout     time.Duration
+	freezeTimer       *time.Timer
 }
 
 // newDaemon returns a new Daemon object with the given configuration.
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	agentAPI "github.com/canonical/lxd/lxd-agent/api"
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// Filesystem freeze ioctls, _IOWR('X', 119, int) and _IOWR('X', 120, int).
+const (
+	ioctlFIFREEZE = 0xC0045877
+	ioctlFITHAW   = 0xC0045878
+)
+
+// freezeDefaultTimeout is the time after which the filesystems are thawed if the caller didn't thaw them.
+const freezeDefaultTimeout = 60 * time.Second
+
+var freezeCmd = APIEndpoint{
+	Name: "freeze",
+	Path: "freeze",
+
+	Post:   APIEndpointAction{Handler: freezePost},
+	Delete: APIEndpointAction{Handler: freezeDelete},
+}
+
+// freezePost runs the freeze hooks if requested and freezes all the writable filesystems of the guest, returning
+// their mount points. The filesystems are thawed automatically once the timeout expires.
+func freezePost(d *Daemon, r *http.Request) response.Response {
+	req := agentAPI.FreezePost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Timeout < 0 {
+		return response.BadRequest(errors.New("Invalid timeout"))
+	}
+
+	timeout := freezeDefaultTimeout
+	if req.Timeout > 0 {
+		timeout = time.Duration(req.Timeout) * time.Second
+	}
+
+	d.freezeMu.Lock()
+	defer d.freezeMu.Unlock()
+
+	if d.freezeTimer != nil {
+		return response.Conflict(errors.New("Filesystems are already frozen"))
+	}
+
+	mountPoints, err := freezeMountPoints()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	// Let the applications prepare for the snapshot, undoing it if any hook fails.
+	if req.Hooks {
+		err = runSnapshotHooks("freeze", timeout)
+		if err != nil {
+			_ = runSnapshotHooks("thaw", timeout)
+			return response.InternalError(err)
+		}
+	}
+
+	// Freeze the filesystems, thawing the ones already frozen on failure.
+	frozen := make([]string, 0, len(mountPoints))
+	for _, mountPoint := range mountPoints {
+		err = freezeFilesystem(mountPoint, ioctlFIFREEZE)
+		if err != nil {
+			// Filesystems without freeze support and already frozen filesystems are skipped.
+			if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EBUSY) {
+				continue
+			}
+
+			_ = thawFilesystems(frozen)
+			if req.Hooks {
+				_ = runSnapshotHooks("thaw", timeout)
+			}
+
+			return response.InternalError(fmt.Errorf("Failed freezing %q: %w", mountPoint, err))
+		}
+
+		frozen = append(frozen, mountPoint)
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		d.freezeMu.Lock()
+		defer d.freezeMu.Unlock()
+
+		// Skip if the filesystems were thawed in the meantime.
+		if d.freezeTimer != timer {
+			return
+		}
+
+		logger.Warn("Thawing filesystems after freeze timeout", logger.Ctx{"timeout": timeout})
+
+		err := d.thaw()
+		if err != nil {
+			logger.Error("Failed thawing filesystems", logger.Ctx{"err": err})
+		}
+	})
+
+	d.frozenFilesystems = frozen
+	d.freezeHooks = req.Hooks
+	d.freezeTimeout = timeout
+	d.freezeTimer = timer
+
+	return response.SyncResponse(true, frozen)
+}
+
+// freezeDelete thaws the filesystems frozen by freezePost and runs the thaw hooks if the freeze hooks were run.
+// Fails if the filesystems aren't frozen anymore, for example because they were thawed after the freeze timeout.
+func freezeDelete(d *Daemon, r *http.Request) response.Response {
+	d.freezeMu.Lock()
+	defer d.freezeMu.Unlock()
+
+	if d.freezeTimer == nil {
+		return response.Conflict(errors.New("Filesystems aren't frozen"))
+	}
+
+	d.freezeTimer.Stop()
+
+	err := d.thaw()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// thaw thaws the frozen filesystems and runs the thaw hooks if needed.
+// The caller must hold freezeMu.
+func (d *Daemon) thaw() error {
+	err := thawFilesystems(d.frozenFilesystems)
+
+	if d.freezeHooks {
+		err = errors.Join(err, runSnapshotHooks("thaw", d.freezeTimeout))
+	}
+
+	d.frozenFilesystems = nil
+	d.freezeHooks = false
+	d.freezeTimer = nil
+
+	return err
+}
+
+// runSnapshotHooks runs the executables of the snapshot hooks directory with the given action as argument.
+// The hooks are run in lexical order for the "freeze" action and in reverse order otherwise, each one being killed
+// if it doesn't complete within the timeout.
+func runSnapshotHooks(action string, timeout time.Duration) error {
+	entries, err := os.ReadDir(agentAPI.SnapshotHooksPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed listing snapshot hooks: %w", err)
+	}
+
+	if action != "freeze" {
+		slices.Reverse(entries)
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		hookPath := filepath.Join(agentAPI.SnapshotHooksPath, entry.Name())
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err = shared.RunCommand(ctx, hookPath, action)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("Failed running snapshot hook %q (%s): %w", hookPath, action, err)
+		}
+	}
+
+	return nil
+}
+
+// freezeMountPoints returns the mount points of the writable filesystems to freeze.
+func freezeMountPoints() ([]string, error) {
+	mounts, err := os.ReadFile("/proc/self/mounts")
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading /proc/self/mounts: %w", err)
+	}
+
+	return parseFreezeMountPoints(mounts)
+}
+
+// parseFreezeMountPoints returns the mount points of the writable filesystems to freeze from the content of
+// /proc/self/mounts. Nested mounts come before their parents so that they are frozen first.
+func parseFreezeMountPoints(mounts []byte) ([]string, error) {
+	mountPoints := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(mounts))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("Invalid /proc/self/mounts content: %q", line)
+		}
+
+		// Skip virtual and read-only filesystems.
+		if slices.Contains(defFSTypesExcluded, fields[2]) || defMountPointsExcluded.MatchString(fields[1]) {
+			continue
+		}
+
+		if !strings.HasPrefix(fields[0], "/dev/") || slices.Contains(strings.Split(fields[3], ","), "ro") {
+			continue
+		}
+
+		// A mount point is listed once per filesystem mounted over it, but only the topmost one can be
+		// reached through it. Other mount points of an already frozen filesystem are skipped when freezing.
+		if slices.Contains(mountPoints, fields[1]) {
+			continue
+		}
+
+		mountPoints = append(mountPoints, fields[1])
+	}
+
+	slices.Reverse(mountPoints)
+
+	return mountPoints, nil
+}
+
+// freezeFilesystem runs the given freeze ioctl on the filesystem mounted at mountPoint.
+func freezeFilesystem(mountPoint string, ioctl uint) error {
+	f, err := os.Open(mountPoint)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return unix.IoctlSetInt(int(f.Fd()), ioctl, 0)
+}
+
+// thawFilesystems thaws the filesystems mounted at the given mount points, in the reverse order of their freeze.
+func thawFilesystems(mountPoints []string) error {
+	var errs []error
+	for _, mountPoint := range slices.Backward(mountPoints) {
+		err := freezeFilesystem(mountPoint, ioctlFITHAW)
+		if err != nil && !errors.Is(err, unix.EINVAL) {
+			errs = append(errs, fmt.Errorf("Failed thawing %q: %w", mountPoint, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
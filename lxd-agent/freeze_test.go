@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFreezeMountPoints(t *testing.T) {
+	tests := []struct {
+		name                string
+		mounts              string
+		expectedMountPoints []string
+		expectedError       string
+	}{
+		{
+			name: "Virtual, read-only and excluded filesystems are skipped",
+			mounts: `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+udev /dev devtmpfs rw,nosuid,relatime,size=1967560k,nr_inodes=491890,mode=755 0 0
+/dev/sda1 / ext4 rw,relatime,discard,errors=remount-ro 0 0
+tmpfs /run tmpfs rw,nosuid,nodev,noexec,relatime,size=398720k,mode=755 0 0
+/dev/loop0 /snap/core22/1380 squashfs ro,nodev,relatime,errors=continue 0 0
+/dev/sr0 /media/cdrom iso9660 ro,relatime 0 0
+/dev/sdb1 /mnt/data xfs ro,relatime 0 0
+`,
+			expectedMountPoints: []string{"/"},
+		},
+		{
+			name: "Nested mounts come before their parents",
+			mounts: `/dev/sda1 / ext4 rw,relatime 0 0
+/dev/sda15 /boot/efi vfat rw,relatime,fmask=0077,dmask=0077 0 0
+/dev/sdb1 /srv xfs rw,relatime 0 0
+/dev/sdc1 /srv/db btrfs rw,relatime,space_cache=v2,subvolid=5,subvol=/ 0 0
+`,
+			expectedMountPoints: []string{"/srv/db", "/srv", "/boot/efi", "/"},
+		},
+		{
+			name: "Mount points are only listed once",
+			mounts: `/dev/sda1 / ext4 rw,relatime 0 0
+/dev/sdb1 /mnt xfs rw,relatime 0 0
+/dev/sdc1 /mnt ext4 rw,relatime 0 0
+/dev/sdb1 /srv xfs rw,relatime 0 0
+`,
+			expectedMountPoints: []string{"/srv", "/mnt", "/"},
+		},
+		{
+			name:          "Invalid content",
+			mounts:        "/dev/sda1 / ext4\n",
+			expectedError: `Invalid /proc/self/mounts content: "/dev/sda1 / ext4"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mountPoints, err := parseFreezeMountPoints([]byte(tt.mounts))
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedMountPoints, mountPoints)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"slices"
 	"strings"
@@ -11,10 +12,14 @@ import (
 	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/auth/bearer"
 	clusterConfig "github.com/canonical/lxd/lxd/cluster/config"
+	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/identity"
 	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/lxd/state"
+	storagePools "github.com/canonical/lxd/lxd/storage"
+	"github.com/canonical/lxd/lxd/storage/s3"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/entity"
 	"github.com/canonical/lxd/shared/logger"
@@ -116,6 +121,86 @@ func metricsServer(d *Daemon) *http.Server {
 	}
 }
 
+func storageBucketsServer(d *Daemon) *http.Server {
+	return &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-d.setupChan
+
+			err := serveStorageBucket(d.State(), w, r)
+			if err != nil {
+				logger.Debug("Failed serving storage bucket request", logger.Ctx{"url": r.URL, "method": r.Method, "remote": r.RemoteAddr, "err": err})
+				s3.AsError(err).Response(w)
+			}
+		}),
+		IdleTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+}
+
+// serveStorageBucket authenticates an S3 request against the bucket keys stored in the database and serves it
+// from the bucket's volume on the local storage pool.
+func serveStorageBucket(s *state.State, w http.ResponseWriter, r *http.Request) error {
+	auth, err := s3.ParseAuth(r)
+	if err != nil {
+		return err
+	}
+
+	var bucket *db.StorageBucket
+	var bucketKey *db.StorageBucketKey
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolLocalBucketByAccessKey(ctx, auth.AccessKey)
+		if err != nil {
+			return err
+		}
+
+		keys, err := tx.GetStoragePoolBucketKeys(ctx, bucket.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if key.AccessKey == auth.AccessKey {
+				bucketKey = key
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return s3.ErrInvalidAccessKeyID
+		}
+
+		return err
+	}
+
+	if bucketKey == nil {
+		return s3.ErrInvalidAccessKeyID
+	}
+
+	err = auth.Verify(r, bucketKey.SecretKey)
+	if err != nil {
+		return err
+	}
+
+	pool, err := storagePools.LoadByName(s, bucket.PoolName)
+	if err != nil {
+		return err
+	}
+
+	mountPath, err := pool.MountBucket(bucket.Project, bucket.Name)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = pool.UnmountBucket(bucket.Project, bucket.Name) }()
+
+	s3.NewBucket(bucket.Name, mountPath).Serve(w, r, bucketKey.Role == "read-only")
+
+	return nil
+}
+
 type lxdHTTPServer struct {
 	r *mux.Router
 	d *Daemon
@@ -68,6 +68,7 @@ var api10 = []APIEndpoint{
 	replicatorCmd,
 	replicatorsCmd,
 	replicatorStateCmd,
+	replicatorRunsCmd,
 	instanceBackupCmd,
 	instanceBackupExportCmd,
 	instanceBackupsCmd,
@@ -98,17 +99,23 @@ var api10 = []APIEndpoint{
 	imageSecretCmd,
 	metadataConfigurationCmd,
 	networkCmd,
+	networkBGPCmd,
 	networkLeasesCmd,
+	networkLeaseCmd,
 	networksCmd,
 	networkStateCmd,
 	networkACLCmd,
 	networkACLsCmd,
+	networkAddressSetCmd,
+	networkAddressSetsCmd,
 	networkACLLogCmd,
+	networkACLStateCmd,
 	networkAllocationsCmd,
 	networkForwardCmd,
 	networkForwardsCmd,
 	networkLoadBalancerCmd,
 	networkLoadBalancersCmd,
+	networkLoadBalancerStateCmd,
 	networkPeerCmd,
 	networkPeersCmd,
 	networkZoneCmd,
@@ -163,6 +170,7 @@ var api10 = []APIEndpoint{
 	oidcSessionCmd,
 	placementGroupsCmd,
 	placementGroupCmd,
+	placementGroupComplianceCmd,
 }
 
 // swagger:operation GET /1.0?public server server_get_untrusted
@@ -1181,6 +1189,14 @@ func doAPI10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	value, ok = nodeChanged["core.storage_buckets_address"]
+	if ok {
+		err := s.Endpoints.StorageBucketsUpdateAddress(value, s.Endpoints.NetworkCert())
+		if err != nil {
+			return err
+		}
+	}
+
 	value, ok = nodeChanged["storage.backups_volume"]
 	if ok {
 		oldValue := oldNodeConfig["storage.backups_volume"]
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/locking"
 	"github.com/canonical/lxd/lxd/metrics"
+	"github.com/canonical/lxd/lxd/network/acl"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/state"
@@ -288,6 +290,27 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 	wg.Wait()
 	close(instMetricsCh)
 
+	// Add the hit counters of the network ACL rules applied on this member.
+	for _, project := range projectsToFetch {
+		projectName := *project.Project
+
+		aclMetrics, err := networkACLMetrics(r.Context(), s, projectName)
+		if err != nil {
+			logger.Warn("Failed getting network ACL metrics", logger.Ctx{"project": projectName, "err": err})
+			continue
+		}
+
+		if aclMetrics == nil {
+			continue
+		}
+
+		if newMetrics[projectName] == nil {
+			newMetrics[projectName] = metrics.NewMetricSet(nil)
+		}
+
+		newMetrics[projectName].Merge(aclMetrics)
+	}
+
 	// Put the new data in the global cache and in response.
 	metricsCacheLock.Lock()
 
@@ -380,6 +403,33 @@ func getFilteredMetrics(s *state.State, r *http.Request, compress bool, metricSe
 	return response.SyncResponsePlain(true, compress, metricSet.String())
 }
 
+// networkACLMetrics returns the hit counters of the rules of the project network ACLs applied on this member.
+// Returns nil if none of the project network ACLs are applied on this member.
+func networkACLMetrics(ctx context.Context, s *state.State, projectName string) (*metrics.MetricSet, error) {
+	aclStates, err := acl.ProjectRuleCounters(ctx, s, projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(aclStates) == 0 {
+		return nil, nil
+	}
+
+	out := metrics.NewMetricSet(nil)
+	for aclName, aclState := range aclStates {
+		for direction, rulesCounters := range map[string][]api.NetworkACLRuleCounters{"ingress": aclState.Ingress, "egress": aclState.Egress} {
+			for ruleIndex, ruleCounters := range rulesCounters {
+				labels := map[string]string{"project": projectName, "name": aclName, "direction": direction, "rule": strconv.Itoa(ruleIndex)}
+
+				out.AddSamples(metrics.NetworkACLRulePacketsTotal, metrics.Sample{Labels: labels, Value: float64(ruleCounters.Packets)})
+				out.AddSamples(metrics.NetworkACLRuleBytesTotal, metrics.Sample{Labels: labels, Value: float64(ruleCounters.Bytes)})
+			}
+		}
+	}
+
+	return out, nil
+}
+
 // clusterMemberWarnings returns the list of unresolved and unacknowledged warnings related to this cluster member.
 // If this member is the leader, also include nodeless warnings.
 // This way we include them while avoiding counting them redundantly across cluster members.
@@ -430,6 +480,29 @@ func internalMetrics(ctx context.Context, s *state.State, tx *db.ClusterTx) *met
 		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(operations))})
 	}
 
+	// Replication lag of the instances located on this member.
+	replicatorInstances, err := dbCluster.GetReplicatorInstancesByNode(ctx, tx.Tx(), nodeID)
+	if err != nil {
+		logger.Warn("Failed getting replicator instances", logger.Ctx{"err": err})
+	} else {
+		for _, inst := range replicatorInstances {
+			if !inst.LastSyncDate.Valid {
+				continue
+			}
+
+			out.AddSamples(
+				metrics.ReplicatorLagSeconds,
+				metrics.Sample{
+					Labels: map[string]string{"project": inst.ProjectName, "replicator": inst.ReplicatorName, "name": inst.InstanceName},
+					Value:  time.Since(inst.LastSyncDate.Time).Seconds(),
+				},
+			)
+		}
+	}
+
+	// Bytes transferred by the replicator runs executed on this member.
+	out.Merge(metrics.GetReplicatorMetrics())
+
 	// API request metrics
 	for _, entityType := range entity.APIMetricsEntityTypes() {
 		out.AddSamples(
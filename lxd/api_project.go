@@ -238,6 +238,7 @@ func projectUsedByMap(ctx context.Context, tx *sql.Tx, projectName string) (map[
 		entity.TypeStorageVolume,
 		entity.TypeNetwork,
 		entity.TypeNetworkACL,
+		entity.TypeNetworkAddressSet,
 		entity.TypeStorageBucket,
 		entity.TypePlacementGroup,
 	}
@@ -251,7 +252,7 @@ func projectUsedByMap(ctx context.Context, tx *sql.Tx, projectName string) (map[
 }
 
 // projectUsedBy returns a list of URLs for all instances, images, profiles,
-// storage volumes, storage buckets, networks, acls, and placement groups that use this project.
+// storage volumes, storage buckets, networks, acls, address sets and placement groups that use this project.
 func projectUsedBy(ctx context.Context, tx *db.ClusterTx, project *dbCluster.Project) ([]string, error) {
 	m, err := projectUsedByMap(ctx, tx.Tx(), project.Name)
 	if err != nil {
@@ -1175,6 +1176,8 @@ func doProjectForceDelete(ctx context.Context, clientType request.ClientType, op
 				return 1 // Delete instances first.
 			case entity.TypeProfile:
 				return 2 // Delete profiles after instances to avoid "profile is currently in use" errors.
+			case entity.TypeNetworkAddressSet:
+				return 4 // Delete address sets after the ACLs referencing them.
 			default:
 				return 3 // Everything else can be deleted in any order.
 			}
@@ -9,7 +9,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -21,16 +24,19 @@ import (
 	"github.com/canonical/lxd/lxd/db"
 	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
 	"github.com/canonical/lxd/lxd/db/operationtype"
+	"github.com/canonical/lxd/lxd/db/warningtype"
 	deviceConfig "github.com/canonical/lxd/lxd/device/config"
 	"github.com/canonical/lxd/lxd/instance"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/metrics"
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/lxd/task"
 	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/lxd/warnings"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/entity"
@@ -67,6 +73,13 @@ var replicatorStateCmd = APIEndpoint{
 	Put: APIEndpointAction{Handler: replicatorStatePut, AccessHandler: allowPermission(entity.TypeReplicator, auth.EntitlementCanEdit, "name")},
 }
 
+var replicatorRunsCmd = APIEndpoint{
+	Path:        "replicators/{name}/runs",
+	MetricsType: entity.TypeReplicator,
+
+	Get: APIEndpointAction{Handler: replicatorRunsGet, AccessHandler: allowPermission(entity.TypeReplicator, auth.EntitlementCanView, "name")},
+}
+
 // swagger:operation GET /1.0/replicators replicators replicators_get
 //
 //	Get the replicators
@@ -383,6 +396,28 @@ func replicatorValidateConfig(ctx context.Context, s *state.State, config map[st
 		//  shortdesc: Cron expression for the replication schedule.
 		//  scope: global
 		"schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+
+		// lxdmeta:generate(entities=replicator; group=conf; key=replication.rpo)
+		// Specify a duration, for example `5m` or `1h`, to replicate continuously instead of on a schedule.
+		// A new run starts as soon as the previous one completes, and each run only transfers the changes since the
+		// snapshot sent by the previous run. The replication lag of each instance is reported in the replicator state.
+		// This key cannot be combined with {config:option}`replicator-conf:schedule`.
+		// ---
+		//  type: string
+		//  shortdesc: Recovery point objective for continuous replication.
+		//  scope: global
+		"replication.rpo": validate.Optional(func(value string) error {
+			rpo, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+
+			if rpo <= 0 {
+				return errors.New("Duration must be greater than zero")
+			}
+
+			return nil
+		}),
 	}
 
 	for k, v := range config {
@@ -410,6 +445,10 @@ func replicatorValidateConfig(ctx context.Context, s *state.State, config map[st
 		return fmt.Errorf("Replicator configuration key %q is required", "cluster")
 	}
 
+	if config["replication.rpo"] != "" && config["schedule"] != "" {
+		return fmt.Errorf("Replicator configuration keys %q and %q are mutually exclusive", "replication.rpo", "schedule")
+	}
+
 	return nil
 }
 
@@ -572,6 +611,9 @@ func replicatorPost(d *Daemon, r *http.Request) response.Response {
 //	Triggers a replicator run using the specified action.
 //	The "restore" action requires all local project instances to be stopped;
 //	it returns 400 if any instance is running to prevent partial restores.
+//	The "failover" and "failback" actions promote the local project to leader
+//	and demote the project on the target cluster to standby, after a final sync
+//	from the target cluster when it is reachable.
 //
 //	---
 //	consumes:
@@ -621,7 +663,7 @@ func replicatorStatePut(d *Daemon, r *http.Request) response.Response {
 	}
 
 	switch req.Action {
-	case "start", "restore":
+	case "start", "restore", "failover", "failback":
 	default:
 		return response.BadRequest(fmt.Errorf("Unknown action %q", req.Action))
 	}
@@ -654,7 +696,11 @@ func replicatorStatePut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Replicator %q has no cluster link configured", name))
 	}
 
-	opArgs, err := prepareReplicatorRunOperation(r.Context(), s, projectName, name, clusterLinkName, restore, dbReplicator.Row.ID, shared.IsTrue(apiReplicator.Config["snapshot"]))
+	if req.Action == "failover" || req.Action == "failback" {
+		return replicatorFailover(s, r, projectName, name, clusterLinkName, dbReplicator.Row.ID, req.Action == "failback")
+	}
+
+	opArgs, err := prepareReplicatorRunOperation(r.Context(), s, projectName, name, clusterLinkName, restore, dbReplicator.Row.ID, shared.IsTrue(apiReplicator.Config["snapshot"]), apiReplicator.Config["replication.rpo"] != "")
 	if err != nil {
 		return response.SmartError(err)
 	}
@@ -687,6 +733,345 @@ func replicatorStatePut(d *Daemon, r *http.Request) response.Response {
 	return operations.OperationResponse(op)
 }
 
+// replicatorFailover promotes the local project of a replicator to leader and demotes the project on the other side of
+// the cluster link to standby. When the other side is reachable, its instances are stopped and a final sync is
+// performed before switching roles. A failback requires the other side to be reachable, and also recovers a local
+// project that was left in leader mode after failing over while this cluster was unreachable.
+// Once promoted, the local instances are started in boot.autostart.priority order.
+func replicatorFailover(s *state.State, r *http.Request, projectName string, name string, clusterLinkName string, replicatorID int64, failback bool) response.Response {
+	action := "failover"
+	opType := operationtype.ReplicatorFailover
+	lifecycleAction := lifecycle.ReplicatorFailover
+	lifecycleFailedAction := lifecycle.ReplicatorFailoverFailed
+	if failback {
+		action = "failback"
+		opType = operationtype.ReplicatorFailback
+		lifecycleAction = lifecycle.ReplicatorFailback
+		lifecycleFailedAction = lifecycle.ReplicatorFailbackFailed
+	}
+
+	requestor := request.CreateRequestor(r.Context())
+	sendLifecycle := func(a lifecycle.ReplicatorAction, ctx logger.Ctx) {
+		ctx["action"] = action
+		s.Events.SendLifecycle(projectName, a.Event(name, projectName, requestor, ctx))
+	}
+
+	run := func(ctx context.Context, op *operations.Operation) (err error) {
+		defer func() {
+			if err != nil {
+				sendLifecycle(lifecycleFailedAction, logger.Ctx{"error": err.Error()})
+			}
+		}()
+
+		reverter := revert.New()
+		defer reverter.Fail()
+
+		var clusterLink *api.ClusterLink
+		var targetCert *x509.Certificate
+		var localProject *api.Project
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+			_, clusterLink, targetCert, err = lxdCluster.LoadClusterLinkAndCert(ctx, tx.Tx(), clusterLinkName)
+			if err != nil {
+				return err
+			}
+
+			dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+			if err != nil {
+				return err
+			}
+
+			localProject, err = dbProject.ToAPI(ctx, tx.Tx())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading replicator %s state: %w", action, err)
+		}
+
+		localMode := localProject.Config["replica.mode"]
+		if !failback && localMode != api.ReplicatorProjectModeStandby {
+			return errors.New(`Project must have "replica.mode" set to standby to fail over`)
+		}
+
+		if failback && localMode == "" {
+			return errors.New(`Project must have "replica.mode" set to fail back`)
+		}
+
+		clusterCert, err := util.LoadClusterCert(s.OS.VarDir)
+		if err != nil {
+			return fmt.Errorf("Failed loading cluster certificate: %w", err)
+		}
+
+		// Connect to the current leader. It may be unreachable during a disaster, in which case a failover proceeds
+		// without a final sync and leaves the former leader to be recovered with a failback.
+		var targetProject *api.Project
+		var targetProjectETag string
+		targetClient, err := lxdCluster.ConnectCluster(ctx, *clusterLink, lxdCluster.GetClusterLinkConnectionArgs(clusterCert, targetCert))
+		if err == nil {
+			targetClient = targetClient.UseProject(projectName)
+			targetProject, targetProjectETag, err = targetClient.GetProject(projectName)
+		}
+
+		if err != nil {
+			if failback {
+				return fmt.Errorf("Failed reaching project on target cluster: %w", err)
+			}
+
+			logger.Warn("Failing over without a final sync, target cluster is unreachable", logger.Ctx{"replicator": name, "project": projectName, "clusterLink": clusterLinkName, "err": err})
+			targetClient = nil
+		}
+
+		synced := false
+		if targetClient != nil {
+			if targetProject.Config["replica.mode"] != api.ReplicatorProjectModeLeader {
+				return fmt.Errorf(`Target project must have "replica.mode" set to leader to %s`, action)
+			}
+
+			// Stop the instances on the current leader so that the final sync captures a consistent state.
+			// The restart hook is added first so that it runs last, once the target project is back in leader mode.
+			var stopped []string
+			stopped, err = replicatorStopInstances(targetClient)
+			reverter.Add(func() {
+				err := replicatorStartTargetInstances(targetClient, stopped)
+				if err != nil {
+					logger.Error("Failed restarting instances on target cluster", logger.Ctx{"replicator": name, "project": projectName, "clusterLink": clusterLinkName, "err": err})
+				}
+			})
+
+			if err != nil {
+				return err
+			}
+
+			// A local project left in leader mode must be demoted before it can be synced from the target cluster.
+			if localMode == api.ReplicatorProjectModeLeader {
+				err = updateReplicatorProjectMode(ctx, s, localProject, api.ReplicatorProjectModeStandby)
+				if err != nil {
+					return err
+				}
+
+				reverter.Add(func() {
+					err := updateReplicatorProjectMode(context.Background(), s, localProject, api.ReplicatorProjectModeLeader)
+					if err != nil {
+						logger.Error("Failed restoring project mode", logger.Ctx{"replicator": name, "project": projectName, "err": err})
+					}
+				})
+
+				sendLifecycle(lifecycle.ReplicatorProjectDemoted, logger.Ctx{"project": projectName})
+			}
+
+			err = replicatorFinalSync(ctx, s, op, projectName, name, clusterLinkName, replicatorID)
+			if err != nil {
+				return err
+			}
+
+			synced = true
+			sendLifecycle(lifecycle.ReplicatorSynced, logger.Ctx{"cluster": clusterLinkName})
+
+			targetProject.Config["replica.mode"] = api.ReplicatorProjectModeStandby
+			err = targetClient.UpdateProject(projectName, targetProject.Writable(), targetProjectETag)
+			if err != nil {
+				return fmt.Errorf("Failed demoting project on target cluster: %w", err)
+			}
+
+			reverter.Add(func() {
+				err := replicatorRestoreTargetProjectMode(targetClient, projectName, api.ReplicatorProjectModeLeader)
+				if err != nil {
+					logger.Error("Failed restoring project mode on target cluster", logger.Ctx{"replicator": name, "project": projectName, "clusterLink": clusterLinkName, "err": err})
+				}
+			})
+
+			sendLifecycle(lifecycle.ReplicatorProjectDemoted, logger.Ctx{"project": projectName, "cluster": clusterLinkName})
+		}
+
+		// The target project has just been demoted, or is unreachable, so the checks performed when setting
+		// "replica.mode" through the project API don't apply here.
+		err = updateReplicatorProjectMode(ctx, s, localProject, api.ReplicatorProjectModeLeader)
+		if err != nil {
+			return err
+		}
+
+		sendLifecycle(lifecycle.ReplicatorProjectPromoted, logger.Ctx{"project": projectName})
+
+		// Roles have been switched, a failure to start instances from here on doesn't undo the promotion.
+		reverter.Success()
+
+		started, err := replicatorStartInstances(ctx, s, projectName)
+		if err != nil {
+			return err
+		}
+
+		sendLifecycle(lifecycle.ReplicatorInstancesStarted, logger.Ctx{"instances": started})
+		sendLifecycle(lifecycleAction, logger.Ctx{"synced": synced})
+
+		return nil
+	}
+
+	projectURL := entity.ProjectURL(projectName)
+	op, err := operations.ScheduleUserOperationFromRequest(s, r, operations.OperationArgs{
+		ProjectName:       projectName,
+		EntityURL:         entity.ReplicatorURL(projectName, name),
+		Type:              opType,
+		Class:             operations.OperationClassTask,
+		ConflictReference: projectURL.String(), // Prevents concurrent failovers and failbacks of the same project.
+		RunHook:           run,
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// replicatorStopInstances stops all running instances of the project the client is connected to.
+// Returns the names of the stopped instances, including when stopping one of them fails.
+func replicatorStopInstances(client lxd.InstanceServer) ([]string, error) {
+	instances, err := client.GetInstances(lxd.GetInstancesArgs{InstanceType: api.InstanceTypeAny})
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing instances on target cluster: %w", err)
+	}
+
+	stopped := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		if !inst.IsActive() {
+			continue
+		}
+
+		op, err := client.UpdateInstanceState(inst.Name, api.InstanceStatePut{Action: "stop", Timeout: -1}, "")
+		if err != nil {
+			return stopped, fmt.Errorf("Failed stopping instance %q on target cluster: %w", inst.Name, err)
+		}
+
+		err = op.Wait()
+		if err != nil {
+			return stopped, fmt.Errorf("Failed stopping instance %q on target cluster: %w", inst.Name, err)
+		}
+
+		stopped = append(stopped, inst.Name)
+	}
+
+	return stopped, nil
+}
+
+// replicatorStartTargetInstances starts the given instances of the project the client is connected to again when a
+// failover or failback fails after stopping them.
+func replicatorStartTargetInstances(client lxd.InstanceServer, names []string) error {
+	var errs []error
+	for _, instName := range names {
+		op, err := client.UpdateInstanceState(instName, api.InstanceStatePut{Action: "start", Timeout: -1}, "")
+		if err == nil {
+			err = op.Wait()
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed starting instance %q: %w", instName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// replicatorRestoreTargetProjectMode sets the "replica.mode" of the project on the other side of the cluster link
+// back to the given mode when a failover or failback fails after demoting it.
+func replicatorRestoreTargetProjectMode(client lxd.InstanceServer, projectName string, mode string) error {
+	project, etag, err := client.GetProject(projectName)
+	if err != nil {
+		return err
+	}
+
+	project.Config["replica.mode"] = mode
+	return client.UpdateProject(projectName, project.Writable(), etag)
+}
+
+// replicatorFinalSync syncs the local project from the target cluster as part of a failover or failback.
+func replicatorFinalSync(ctx context.Context, s *state.State, op *operations.Operation, projectName string, name string, clusterLinkName string, replicatorID int64) error {
+	syncArgs, err := prepareReplicatorRunOperation(ctx, s, projectName, name, clusterLinkName, true, replicatorID, false, false)
+	if err != nil {
+		return fmt.Errorf("Failed preparing final sync: %w", err)
+	}
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.UpdateReplicatorLastRun(ctx, tx.Tx(), replicatorID, time.Now(), api.ReplicatorStatusRunning)
+	})
+	if err != nil {
+		logger.Warn("Failed updating replicator last run status to running", logger.Ctx{"name": name, "project": projectName, "err": err})
+	}
+
+	syncOp, err := operations.ScheduleUserOperationFromOperation(s, op, syncArgs)
+	if err != nil {
+		_ = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return dbCluster.UpdateReplicatorLastRunStatus(ctx, tx.Tx(), replicatorID, api.ReplicatorStatusFailed)
+		})
+
+		return fmt.Errorf("Failed starting final sync: %w", err)
+	}
+
+	err = syncOp.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("Final sync failed: %w", err)
+	}
+
+	for _, child := range syncOp.Children() {
+		if child.Status() != api.Success {
+			_, childOp := child.Render()
+			return fmt.Errorf("Final sync failed: %s", childOp.Err)
+		}
+	}
+
+	return nil
+}
+
+// updateReplicatorProjectMode sets the "replica.mode" of the given local project.
+func updateReplicatorProjectMode(ctx context.Context, s *state.State, project *api.Project, mode string) error {
+	project.Config["replica.mode"] = mode
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.UpdateProject(ctx, tx.Tx(), project.Name, project.Writable())
+	})
+	if err != nil {
+		return fmt.Errorf("Failed setting project %q to %s: %w", project.Name, mode, err)
+	}
+
+	return nil
+}
+
+// replicatorStartInstances starts the local instances of the given project in boot.autostart.priority order,
+// honoring boot.autostart.delay. Instances with boot.autostart set to false or protected from starting are skipped.
+// Returns the names of the started instances.
+func replicatorStartInstances(ctx context.Context, s *state.State, projectName string) ([]string, error) {
+	instances, err := instanceLoadNodeProjectAll(ctx, s, projectName, instancetype.Any)
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing instances: %w", err)
+	}
+
+	sort.Sort(instanceAutostartList(instances))
+
+	started := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		config := inst.ExpandedConfig()
+		if inst.IsRunning() || shared.IsFalse(config["boot.autostart"]) || shared.IsTrue(config["security.protection.start"]) {
+			continue
+		}
+
+		err = inst.Start(ctx, nil, false)
+		if err != nil {
+			return started, fmt.Errorf("Failed starting instance %q: %w", inst.Name(), err)
+		}
+
+		started = append(started, inst.Name())
+
+		delay, err := strconv.Atoi(config["boot.autostart.delay"])
+		if err == nil {
+			select {
+			case <-ctx.Done():
+				return started, ctx.Err()
+			case <-time.After(time.Duration(delay) * time.Second):
+			}
+		}
+	}
+
+	return started, nil
+}
+
 // updateReplicator is shared between [replicatorPut] and [replicatorPatch].
 func updateReplicator(d *Daemon, r *http.Request, isPatch bool) response.Response {
 	s := d.State()
@@ -952,6 +1337,7 @@ func replicatorStateGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	var status string
+	var replicatorInstances []dbCluster.ReplicatorInstance
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbReplicator, err := dbCluster.GetReplicator(ctx, tx.Tx(), name, projectName)
 		if err != nil {
@@ -963,13 +1349,131 @@ func replicatorStateGet(d *Daemon, r *http.Request) response.Response {
 			status = dbReplicator.Row.LastRunStatus
 		}
 
-		return nil
+		replicatorInstances, err = dbCluster.GetReplicatorInstances(ctx, tx.Tx(), dbReplicator.Row.ID, projectName)
+		return err
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed loading replicator state for %q: %w", name, err))
 	}
 
-	return response.SyncResponse(true, api.ReplicatorState{Status: status})
+	now := time.Now()
+	instances := make([]api.ReplicatorInstanceState, 0, len(replicatorInstances))
+	for _, replicatorInstance := range replicatorInstances {
+		instanceState := api.ReplicatorInstanceState{
+			Name:   replicatorInstance.InstanceName,
+			Status: api.ReplicatorStatusPending,
+		}
+
+		if replicatorInstance.LastSyncStatus != "" {
+			instanceState.Status = replicatorInstance.LastSyncStatus
+		}
+
+		if replicatorInstance.LastSyncDate.Valid {
+			instanceState.LastSyncAt = replicatorInstance.LastSyncDate.Time
+			instanceState.Lag = int64(now.Sub(replicatorInstance.LastSyncDate.Time).Seconds())
+		}
+
+		instances = append(instances, instanceState)
+	}
+
+	return response.SyncResponse(true, api.ReplicatorState{Status: status, Instances: instances})
+}
+
+// swagger:operation GET /1.0/replicators/{name}/runs replicators replicator_runs_get
+//
+//	Get the replicator runs
+//
+//	Gets the history of the most recent runs of the replicator, most recent first.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Replicator runs
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of replicator runs
+//	          items:
+//	            $ref: "#/definitions/ReplicatorRun"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func replicatorRunsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName, _, err := request.ProjectParams(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var runs []dbCluster.ReplicatorRun
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbReplicator, err := dbCluster.GetReplicator(ctx, tx.Tx(), name, projectName)
+		if err != nil {
+			return err
+		}
+
+		runs, err = dbCluster.GetReplicatorRuns(ctx, tx.Tx(), dbReplicator.Row.ID)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading replicator runs for %q: %w", name, err))
+	}
+
+	apiRuns := make([]api.ReplicatorRun, 0, len(runs))
+	for _, run := range runs {
+		apiRun := api.ReplicatorRun{
+			StartedAt:  run.StartDate,
+			FinishedAt: run.EndDate,
+			Status:     run.Status,
+			Instances:  make([]api.ReplicatorRunInstance, 0, len(run.Instances)),
+		}
+
+		for _, inst := range run.Instances {
+			apiRun.Instances = append(apiRun.Instances, api.ReplicatorRunInstance{
+				Name:             inst.InstanceName,
+				Status:           inst.Status,
+				BytesTransferred: inst.BytesTransferred,
+				Duration:         inst.Duration.Milliseconds(),
+				Error:            inst.Error,
+			})
+		}
+
+		apiRuns = append(apiRuns, apiRun)
+	}
+
+	return response.SyncResponse(true, apiRuns)
 }
 
 // runScheduledReplicatorsTask returns a background task that checks replicator schedules every minute
@@ -997,8 +1501,29 @@ func runScheduledReplicatorsTask(stateFunc func() *state.State) (task.Func, task
 	return f, schedule
 }
 
+// replicatorRPORuns tracks the replicators with a continuous replication run in progress on this member.
+var replicatorRPORuns sync.Map
+
+// replicatorRunsHistorySize is the number of most recent runs kept in the history of each replicator.
+const replicatorRunsHistorySize = 100
+
+// runContinuousReplicatorsTask returns a background task that starts a new run of each replicator configured with
+// "replication.rpo" as soon as its previous run has completed.
+func runContinuousReplicatorsTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := runContinuousReplicators(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed running continuous replicator task", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(10 * time.Second)
+}
+
 // prepareReplicatorRunOperation builds the operation used to run a replicator.
-func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectName string, name string, clusterLinkName string, restore bool, replicatorID int64, snapshot bool) (operations.OperationArgs, error) {
+// When incremental is true, a snapshot is taken and sent on every run so that only the changes since the previous
+// run are transferred.
+func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectName string, name string, clusterLinkName string, restore bool, replicatorID int64, snapshot bool, incremental bool) (operations.OperationArgs, error) {
 	// Load all DB state in a single transaction before any network I/O.
 	var clusterLink *api.ClusterLink
 	var targetCert *x509.Certificate
@@ -1101,10 +1626,38 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 	projectURL := entity.ProjectURL(projectName)
 	childArgs := make([]*operations.OperationArgs, 0, len(iterNames))
 
+	// Collect the result of each instance so that the run can be recorded in the replicator history.
+	runStart := time.Now()
+	var runInstancesMu sync.Mutex
+	runInstances := make([]dbCluster.ReplicatorRunInstance, 0, len(iterNames))
+
 	for _, instName := range iterNames {
 		inst := localInstsByName[instName] // nil for instances that only exist on the remote leader
 
-		copyFunc := func(ctx context.Context, op *operations.Operation) error {
+		copyFunc := func(ctx context.Context, op *operations.Operation) (err error) {
+			instStart := time.Now()
+			var conns map[string]*migrationConn
+
+			defer func() {
+				runInstance := dbCluster.ReplicatorRunInstance{
+					InstanceName:     instName,
+					Status:           api.ReplicatorStatusCompleted,
+					BytesTransferred: migrationBytesTransferred(conns),
+					Duration:         time.Since(instStart),
+				}
+
+				if err != nil {
+					runInstance.Status = api.ReplicatorStatusFailed
+					runInstance.Error = err.Error()
+				}
+
+				metrics.AddReplicatorTransferredBytes(projectName, name, instName, runInstance.BytesTransferred)
+
+				runInstancesMu.Lock()
+				runInstances = append(runInstances, runInstance)
+				runInstancesMu.Unlock()
+			}()
+
 			dstClient, err := lxdCluster.ConnectCluster(ctx, *clusterLink, lxdCluster.GetClusterLinkConnectionArgs(clusterCert, targetCert))
 			if err != nil {
 				return fmt.Errorf("Failed connecting to target cluster: %w", err)
@@ -1192,6 +1745,8 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 						return fmt.Errorf("Failed setting up migration sink for instance %q: %w", instName, err)
 					}
 
+					conns = sink.conns
+
 					err = sink.Do(ctx, instOp, op)
 					if err != nil {
 						instOp.Done(err)
@@ -1299,6 +1854,8 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 					return fmt.Errorf("Failed setting up migration sink for new instance %q: %w", instName, err)
 				}
 
+				conns = sink.conns
+
 				err = sink.Do(ctx, instOp, op)
 				if err != nil {
 					instOp.Done(err)
@@ -1310,7 +1867,33 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 				return remoteMigrateOp.Wait()
 			}
 
-			if snapshot && inst.ExpandedConfig()["snapshots.schedule"] == "" {
+			syncDate := time.Now()
+			var syncSnapName string
+
+			// Record the outcome in the per-instance replication state once the instance has been processed.
+			defer func() {
+				updateReplicatorInstanceState(s, replicatorID, inst, syncDate, syncSnapName, err)
+			}()
+
+			err = updateReplicatorInstanceStatus(ctx, s, replicatorID, inst.ID(), api.ReplicatorStatusRunning)
+			if err != nil {
+				return err
+			}
+
+			if incremental {
+				// Take a new snapshot on every run. The refresh below then only transfers the difference between
+				// this snapshot and the one sent by the previous run, which both clusters already have.
+				syncSnapName, err = instance.NextSnapshotName(s, inst, "snap%d")
+				if err != nil {
+					return fmt.Errorf("Failed generating snapshot name for instance %q: %w", instName, err)
+				}
+
+				err = inst.Snapshot(ctx, syncSnapName, nil, false, api.DiskVolumesModeRoot, nil)
+				if err != nil {
+					syncSnapName = ""
+					return fmt.Errorf("Failed creating snapshot of instance %q: %w", instName, err)
+				}
+			} else if snapshot && inst.ExpandedConfig()["snapshots.schedule"] == "" {
 				snapName, err := instance.NextSnapshotName(s, inst, "snap%d")
 				if err != nil {
 					return fmt.Errorf("Failed generating snapshot name for instance %q: %w", instName, err)
@@ -1337,6 +1920,8 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 				return fmt.Errorf("Failed setting up migration source for instance %q: %w", instName, err)
 			}
 
+			conns = srcMigration.conns
+
 			networkCert := s.Endpoints.NetworkCert()
 
 			migrArgs := operations.OperationArgs{
@@ -1430,18 +2015,112 @@ func prepareReplicatorRunOperation(ctx context.Context, s *state.State, projectN
 				}
 			}
 
+			runInstancesMu.Lock()
+			run := dbCluster.ReplicatorRun{
+				StartDate: runStart,
+				EndDate:   time.Now(),
+				Status:    runStatus,
+				Instances: runInstances,
+			}
+
+			runInstancesMu.Unlock()
+
 			// Use a fresh context so the status write always completes, even if the operation context was cancelled.
 			// Only the status is updated here; last_run_date was already set when the operation started.
 			return s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
-				return dbCluster.UpdateReplicatorLastRunStatus(ctx, tx.Tx(), replicatorID, runStatus)
+				err := dbCluster.UpdateReplicatorLastRunStatus(ctx, tx.Tx(), replicatorID, runStatus)
+				if err != nil {
+					return err
+				}
+
+				_, err = dbCluster.CreateReplicatorRun(ctx, tx.Tx(), replicatorID, run, replicatorRunsHistorySize)
+				return err
 			})
 		},
 	}, nil
 }
 
-// runScheduledReplicators loads all replicators, checks their schedule config key against the current
-// time, and triggers replication for those that are due.
-func runScheduledReplicators(ctx context.Context, s *state.State) error {
+// updateReplicatorInstanceStatus updates the status in the replication state of an instance.
+func updateReplicatorInstanceStatus(ctx context.Context, s *state.State, replicatorID int64, instanceID int, status string) error {
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return dbCluster.UpdateReplicatorInstanceStatus(ctx, tx.Tx(), replicatorID, int64(instanceID), status)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed updating replication state of instance: %w", err)
+	}
+
+	return nil
+}
+
+// updateReplicatorInstanceState records the outcome of the replication of an instance in its replication state.
+// For incremental runs, only the most recently sent snapshot is kept on the source as the base for the next run.
+func updateReplicatorInstanceState(s *state.State, replicatorID int64, inst instance.Instance, syncDate time.Time, syncSnapName string, runErr error) {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "replicatorID": replicatorID})
+
+	// Use a fresh context so the state is always recorded, even if the operation context was cancelled.
+	if runErr != nil {
+		err := updateReplicatorInstanceStatus(context.Background(), s, replicatorID, inst.ID(), api.ReplicatorStatusFailed)
+		if err != nil {
+			l.Warn("Failed recording failed replication of instance", logger.Ctx{"err": err})
+		}
+
+		// Remove the snapshot of the failed run so that snapshots don't pile up while the target is unreachable.
+		// If the target already received it, the next refresh removes it there too.
+		if syncSnapName != "" {
+			deleteReplicatorSnapshot(s, inst, syncSnapName)
+		}
+
+		return
+	}
+
+	var prevSnapName string
+	err := s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		prevSnapName, err = dbCluster.GetReplicatorInstanceLastSnapshot(ctx, tx.Tx(), replicatorID, int64(inst.ID()))
+		if err != nil {
+			return err
+		}
+
+		// Keep track of the previous snapshot if this run didn't send a new one.
+		if syncSnapName == "" {
+			syncSnapName = prevSnapName
+		}
+
+		return dbCluster.UpdateReplicatorInstanceSynced(ctx, tx.Tx(), replicatorID, int64(inst.ID()), syncDate, syncSnapName)
+	})
+	if err != nil {
+		l.Warn("Failed recording replication of instance", logger.Ctx{"err": err})
+		return
+	}
+
+	// The new snapshot is now the common base with the target, so the previous one is no longer needed.
+	if prevSnapName != "" && prevSnapName != syncSnapName {
+		deleteReplicatorSnapshot(s, inst, prevSnapName)
+	}
+}
+
+// deleteReplicatorSnapshot deletes a snapshot created by a replicator run.
+func deleteReplicatorSnapshot(s *state.State, inst instance.Instance, snapName string) {
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "snapshot": snapName})
+
+	snapInst, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name()+shared.SnapshotDelimiter+snapName)
+	if err != nil {
+		// The snapshot may have been deleted manually in the meantime.
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			l.Warn("Failed loading replicator snapshot", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	err = snapInst.Delete(context.Background(), false, api.DiskVolumesModeRoot, nil)
+	if err != nil {
+		l.Warn("Failed deleting replicator snapshot", logger.Ctx{"err": err})
+	}
+}
+
+// loadLeaderReplicators loads all replicators across all projects that have "replica.mode" set to leader.
+func loadLeaderReplicators(ctx context.Context, s *state.State) ([]*api.Replicator, []dbCluster.Replicator, error) {
 	// Load all replicators across all projects.
 	var apiReplicators []*api.Replicator
 	var replicatorRows []dbCluster.Replicator
@@ -1466,7 +2145,7 @@ func runScheduledReplicators(ctx context.Context, s *state.State) error {
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Build a per-project replica.mode map so the loop can skip standby projects
@@ -1490,15 +2169,33 @@ func runScheduledReplicators(ctx context.Context, s *state.State) error {
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	now := time.Now()
+	leaderReplicators := make([]*api.Replicator, 0, len(apiReplicators))
+	leaderRows := make([]dbCluster.Replicator, 0, len(replicatorRows))
 	for i, replicator := range apiReplicators {
 		if projectModes[replicator.Project] != api.ReplicatorProjectModeLeader {
 			continue
 		}
 
+		leaderReplicators = append(leaderReplicators, replicator)
+		leaderRows = append(leaderRows, replicatorRows[i])
+	}
+
+	return leaderReplicators, leaderRows, nil
+}
+
+// runScheduledReplicators loads all replicators, checks their schedule config key against the current
+// time, and triggers replication for those that are due.
+func runScheduledReplicators(ctx context.Context, s *state.State) error {
+	apiReplicators, replicatorRows, err := loadLeaderReplicators(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i, replicator := range apiReplicators {
 		schedule, ok := replicator.Config["schedule"]
 		if !ok || schedule == "" {
 			continue
@@ -1524,6 +2221,98 @@ func runScheduledReplicators(ctx context.Context, s *state.State) error {
 	return nil
 }
 
+// runContinuousReplicators starts a new run of each replicator configured with "replication.rpo" that has no run in
+// progress on this member. Runs are started in the background so that replicators don't wait on each other.
+func runContinuousReplicators(ctx context.Context, s *state.State) error {
+	apiReplicators, replicatorRows, err := loadLeaderReplicators(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	for i, replicator := range apiReplicators {
+		if replicator.Config["replication.rpo"] == "" {
+			continue
+		}
+
+		row := &replicatorRows[i]
+		err := checkReplicatorRPO(ctx, s, replicator, row)
+		if err != nil {
+			logger.Warn("Failed checking replicator recovery point objective", logger.Ctx{"replicator": replicator.Name, "project": replicator.Project, "err": err})
+		}
+
+		_, running := replicatorRPORuns.LoadOrStore(row.Row.ID, struct{}{})
+		if running {
+			continue
+		}
+
+		go func() {
+			defer replicatorRPORuns.Delete(row.Row.ID)
+
+			err := triggerScheduledReplicator(ctx, s, replicator, row)
+			if err != nil {
+				logger.Error("Failed running continuous replicator", logger.Ctx{
+					"replicator": replicator.Name,
+					"project":    replicator.Project,
+					"err":        err,
+				})
+			}
+		}()
+	}
+
+	return nil
+}
+
+// checkReplicatorRPO compares the replication lag of each instance replicated by the replicator against its
+// "replication.rpo" and raises a warning if it is exceeded. The warning is resolved once all instances are back
+// within the recovery point objective. Warnings aren't tied to a cluster member as the continuous replication task
+// runs on every member.
+func checkReplicatorRPO(ctx context.Context, s *state.State, replicator *api.Replicator, row *dbCluster.Replicator) error {
+	rpo, err := time.ParseDuration(replicator.Config["replication.rpo"])
+	if err != nil {
+		return fmt.Errorf("Invalid recovery point objective: %w", err)
+	}
+
+	var replicatorInstances []dbCluster.ReplicatorInstance
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		replicatorInstances, err = dbCluster.GetReplicatorInstances(ctx, tx.Tx(), row.Row.ID, replicator.Project)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	lagging, maxLag := replicatorRPOLagging(replicatorInstances, rpo, time.Now())
+	if len(lagging) == 0 {
+		return warnings.ResolveWarningsByNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", replicator.Project, warningtype.ReplicatorRPOExceeded, entity.TypeReplicator, int(row.Row.ID))
+	}
+
+	message := fmt.Sprintf("Replication lag of %s exceeds the recovery point objective of %s for instances: %s", maxLag.Truncate(time.Second), rpo, strings.Join(lagging, ", "))
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpsertWarning(ctx, "", replicator.Project, entity.TypeReplicator, int(row.Row.ID), warningtype.ReplicatorRPOExceeded, message)
+	})
+}
+
+// replicatorRPOLagging returns the names of the replicated instances whose replication lag at the given time exceeds
+// the recovery point objective, along with the largest of their lags.
+func replicatorRPOLagging(replicatorInstances []dbCluster.ReplicatorInstance, rpo time.Duration, now time.Time) (lagging []string, maxLag time.Duration) {
+	for _, replicatorInstance := range replicatorInstances {
+		// Instances that were never replicated have no recovery point to compare against yet.
+		if !replicatorInstance.LastSyncDate.Valid {
+			continue
+		}
+
+		lag := now.Sub(replicatorInstance.LastSyncDate.Time)
+		if lag <= rpo {
+			continue
+		}
+
+		lagging = append(lagging, replicatorInstance.InstanceName)
+		maxLag = max(maxLag, lag)
+	}
+
+	return lagging, maxLag
+}
+
 // replicatorIsScheduledNow returns true if any of the (comma-separated) cron expressions in spec matches the provided minute.
 func replicatorIsScheduledNow(spec string, now time.Time) bool {
 	t := now.Truncate(time.Minute)
@@ -1554,7 +2343,7 @@ func triggerScheduledReplicator(ctx context.Context, s *state.State, replicator
 		return fmt.Errorf("Replicator %q has no cluster link configured", replicator.Name)
 	}
 
-	opArgs, err := prepareReplicatorRunOperation(ctx, s, replicator.Project, replicator.Name, clusterLinkName, false, row.Row.ID, shared.IsTrue(replicator.Config["snapshot"]))
+	opArgs, err := prepareReplicatorRunOperation(ctx, s, replicator.Project, replicator.Name, clusterLinkName, false, row.Row.ID, shared.IsTrue(replicator.Config["snapshot"]), replicator.Config["replication.rpo"] != "")
 	if err != nil {
 		return err
 	}
@@ -1574,7 +2363,15 @@ func triggerScheduledReplicator(ctx context.Context, s *state.State, replicator
 	op, err := operations.ScheduleServerOperation(s, opArgs)
 	if err != nil {
 		if api.StatusErrorCheck(err, http.StatusConflict) {
-			logger.Warn("Skipping scheduled replicator, a run is already in progress", logger.Ctx{"replicator": replicator.Name, "project": replicator.Project})
+			// Every member checks the continuous replicators every few seconds, so only those on a schedule
+			// are worth a warning.
+			logCtx := logger.Ctx{"replicator": replicator.Name, "project": replicator.Project}
+			if replicator.Config["replication.rpo"] != "" {
+				logger.Debug("Skipping continuous replicator, a run is already in progress", logCtx)
+			} else {
+				logger.Warn("Skipping scheduled replicator, a run is already in progress", logCtx)
+			}
+
 			// Don't revert Running: another operation is in progress and owns the status;
 			// it will write its own terminal state when it completes.
 			return nil
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
 )
 
 func TestReplicatorIsScheduledNow(t *testing.T) {
@@ -66,3 +69,32 @@ func TestReplicatorIsScheduledNow(t *testing.T) {
 		})
 	}
 }
+
+func TestReplicatorRPOLagging(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	syncedAt := func(ago time.Duration) sql.NullTime {
+		return sql.NullTime{Time: now.Add(-ago), Valid: true}
+	}
+
+	replicatorInstances := []dbCluster.ReplicatorInstance{
+		{InstanceName: "never-synced"},
+		{InstanceName: "recent", LastSyncDate: syncedAt(time.Minute)},
+		{InstanceName: "at-rpo", LastSyncDate: syncedAt(5 * time.Minute)},
+		{InstanceName: "late", LastSyncDate: syncedAt(6 * time.Minute)},
+		{InstanceName: "later", LastSyncDate: syncedAt(time.Hour)},
+	}
+
+	// Instances are only lagging once their lag exceeds the recovery point objective.
+	lagging, maxLag := replicatorRPOLagging(replicatorInstances, 5*time.Minute, now)
+	assert.Equal(t, []string{"late", "later"}, lagging)
+	assert.Equal(t, time.Hour, maxLag)
+
+	// Nothing is lagging within the recovery point objective.
+	lagging, maxLag = replicatorRPOLagging(replicatorInstances, 2*time.Hour, now)
+	assert.Empty(t, lagging)
+	assert.Zero(t, maxLag)
+
+	// Instances that were never replicated aren't lagging.
+	lagging, _ = replicatorRPOLagging(replicatorInstances[:1], time.Nanosecond, now)
+	assert.Empty(t, lagging)
+}
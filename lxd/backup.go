@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"time"
 
 	"go.yaml.in/yaml/v2"
@@ -25,6 +28,7 @@ import (
 	"github.com/canonical/lxd/lxd/lifecycle"
 	"github.com/canonical/lxd/lxd/operations"
 	"github.com/canonical/lxd/lxd/project"
+	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/state"
 	storagePools "github.com/canonical/lxd/lxd/storage"
 	"github.com/canonical/lxd/lxd/task"
@@ -36,9 +40,10 @@ import (
 )
 
 // Create a new backup.
-func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, sourceInst instance.Instance, version uint32, op *operations.Operation) error {
+// If parentSnapshot is set, an incremental backup containing only the changes since that snapshot is created.
+func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, sourceInst instance.Instance, parentSnapshot string, version uint32, op *operations.Operation) error {
 	projectName := sourceInst.Project().Name
-	l := logger.AddContext(logger.Ctx{"project": projectName, "instance": sourceInst.Name(), "name": args.Name})
+	l := logger.AddContext(logger.Ctx{"project": projectName, "instance": sourceInst.Name(), "name": args.Name, "parentSnapshot": parentSnapshot})
 	l.Debug("Instance backup started")
 	defer l.Debug("Instance backup finished")
 
@@ -56,6 +61,10 @@ func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, s
 		args.OptimizedStorage = false
 	}
 
+	if args.Target != "" {
+		args.TargetLocation = backup.InstanceTargetLocation(projectName, args.Name, args.CreationDate)
+	}
+
 	// Create the database entry.
 	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.CreateInstanceBackup(ctx, args)
@@ -104,33 +113,41 @@ func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, s
 		}
 	}
 
-	// Create the target path if needed.
-	backupsPathBase := s.BackupsStoragePath(projectName)
+	// Setup the tarball writer.
+	var tarFileWriter io.WriteCloser
+	if b.Target() != "" {
+		tarFileWriter, err = backupTargetWriter(ctx, s, b.Target(), b.TargetLocation(), revert)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Create the target path if needed.
+		backupsPathBase := s.BackupsStoragePath(projectName)
 
-	backupsPath := filepath.Join(backupsPathBase, "instances", project.Instance(projectName, sourceInst.Name()))
-	err = os.MkdirAll(filepath.Dir(backupsPath), 0700)
-	if err != nil {
-		return err
-	}
+		backupsPath := filepath.Join(backupsPathBase, "instances", project.Instance(projectName, sourceInst.Name()))
+		err = os.MkdirAll(filepath.Dir(backupsPath), 0700)
+		if err != nil {
+			return err
+		}
 
-	err = os.Mkdir(backupsPath, 0700)
-	if err != nil && !errors.Is(err, fs.ErrExist) {
-		return err
-	} else if err == nil {
-		revert.Add(func() { _ = os.Remove(backupsPath) })
-	}
+		err = os.Mkdir(backupsPath, 0700)
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		} else if err == nil {
+			revert.Add(func() { _ = os.Remove(backupsPath) })
+		}
 
-	target := filepath.Join(backupsPathBase, "instances", project.Instance(projectName, b.Name()))
+		target := filepath.Join(backupsPathBase, "instances", project.Instance(projectName, b.Name()))
 
-	// Setup the tarball writer.
-	l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
-	tarFileWriter, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
-	}
+		l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
+		tarFileWriter, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
+		}
 
-	defer func() { _ = tarFileWriter.Close() }()
-	revert.Add(func() { _ = os.Remove(target) })
+		defer func() { _ = tarFileWriter.Close() }()
+		revert.Add(func() { _ = os.Remove(target) })
+	}
 
 	// Get IDMap to unshift container as the tarball is created.
 	var idmap *idmap.IdmapSet
@@ -175,7 +192,7 @@ func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, s
 
 	// Write index file.
 	l.Debug("Adding backup index file")
-	err = backupWriteIndex(sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), version, tarWriter)
+	err = backupWriteIndex(sourceInst, pool, b.OptimizedStorage(), !b.InstanceOnly(), parentSnapshot, version, tarWriter)
 
 	// Check compression errors.
 	if compressErr != nil {
@@ -187,7 +204,7 @@ func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, s
 		return fmt.Errorf("Error writing backup index file: %w", err)
 	}
 
-	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), !b.InstanceOnly(), version, nil)
+	err = pool.BackupInstance(sourceInst, tarWriter, b.OptimizedStorage(), !b.InstanceOnly(), parentSnapshot, version, nil)
 	if err != nil {
 		return fmt.Errorf("Backup create: %w", err)
 	}
@@ -221,7 +238,7 @@ func backupCreate(ctx context.Context, s *state.State, args db.InstanceBackup, s
 }
 
 // backupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
-func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, version uint32, tarWriter *instancewriter.InstanceTarWriter) error {
+func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, parentSnapshot string, version uint32, tarWriter *instancewriter.InstanceTarWriter) error {
 	driverInfo := pool.Driver().Info()
 
 	// Indicate whether the driver will include a driver-specific optimized header.
@@ -280,6 +297,11 @@ func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, opti
 		}
 	}
 
+	err = backupIndexSetParent(&indexInfo, parentSnapshot)
+	if err != nil {
+		return err
+	}
+
 	// Convert to YAML.
 	indexData, err := yaml.Marshal(&indexInfo)
 	if err != nil {
@@ -381,6 +403,7 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 		}
 
 		instBackup := backup.NewInstanceBackup(s, inst, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.InstanceOnly, b.OptimizedStorage)
+		instBackup.SetTarget(b.Target, b.TargetLocation)
 		err = instBackup.Delete(ctx)
 		if err != nil {
 			return fmt.Errorf("Error deleting instance backup %q: %w", b.Name, err)
@@ -390,8 +413,8 @@ func pruneExpiredInstanceBackups(ctx context.Context, s *state.State) error {
 	return nil
 }
 
-func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, projectName string, poolName string, volumeName string, version uint32) error {
-	l := logger.AddContext(logger.Ctx{"project": projectName, "storage_volume": volumeName, "name": args.Name})
+func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, projectName string, poolName string, volumeName string, parentSnapshot string, version uint32) error {
+	l := logger.AddContext(logger.Ctx{"project": projectName, "storage_volume": volumeName, "name": args.Name, "parentSnapshot": parentSnapshot})
 	l.Debug("Volume backup started")
 	defer l.Debug("Volume backup finished")
 
@@ -409,6 +432,10 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		args.OptimizedStorage = false
 	}
 
+	if args.Target != "" {
+		args.TargetLocation = backup.VolumeTargetLocation(projectName, poolName, args.Name, args.CreationDate)
+	}
+
 	// Create the database entry.
 	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.CreateStoragePoolVolumeBackup(ctx, args)
@@ -444,33 +471,41 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		compress = s.GlobalConfig.BackupsCompressionAlgorithm()
 	}
 
-	// Create the target path if needed.
-	backupsPathBase := s.BackupsStoragePath(projectName)
+	// Setup the tarball writer.
+	var tarFileWriter io.WriteCloser
+	if backupRow.Target != "" {
+		tarFileWriter, err = backupTargetWriter(context.TODO(), s, backupRow.Target, backupRow.TargetLocation, revert)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Create the target path if needed.
+		backupsPathBase := s.BackupsStoragePath(projectName)
 
-	backupsPath := filepath.Join(backupsPathBase, "custom", poolName, project.StorageVolume(projectName, volumeName))
-	err = os.MkdirAll(filepath.Dir(backupsPath), 0700)
-	if err != nil {
-		return err
-	}
+		backupsPath := filepath.Join(backupsPathBase, "custom", poolName, project.StorageVolume(projectName, volumeName))
+		err = os.MkdirAll(filepath.Dir(backupsPath), 0700)
+		if err != nil {
+			return err
+		}
 
-	err = os.Mkdir(backupsPath, 0700)
-	if err != nil && !errors.Is(err, fs.ErrExist) {
-		return err
-	} else if err == nil {
-		revert.Add(func() { _ = os.Remove(backupsPath) })
-	}
+		err = os.Mkdir(backupsPath, 0700)
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		} else if err == nil {
+			revert.Add(func() { _ = os.Remove(backupsPath) })
+		}
 
-	target := filepath.Join(backupsPathBase, "custom", poolName, project.StorageVolume(projectName, backupRow.Name))
+		target := filepath.Join(backupsPathBase, "custom", poolName, project.StorageVolume(projectName, backupRow.Name))
 
-	// Setup the tarball writer.
-	l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
-	tarFileWriter, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
-	}
+		l.Debug("Opening backup tarball for writing", logger.Ctx{"path": target})
+		tarFileWriter, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("Error opening backup tarball for writing %q: %w", target, err)
+		}
 
-	defer func() { _ = tarFileWriter.Close() }()
-	revert.Add(func() { _ = os.Remove(target) })
+		defer func() { _ = tarFileWriter.Close() }()
+		revert.Add(func() { _ = os.Remove(target) })
+	}
 
 	// Create the tarball.
 	tarPipeReader, tarPipeWriter := io.Pipe()
@@ -500,7 +535,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 
 	// Write index file.
 	l.Debug("Adding backup index file")
-	err = volumeBackupWriteIndex(projectName, volumeName, pool, backupRow.OptimizedStorage, !backupRow.VolumeOnly, version, tarWriter)
+	err = volumeBackupWriteIndex(projectName, volumeName, pool, backupRow.OptimizedStorage, !backupRow.VolumeOnly, parentSnapshot, version, tarWriter)
 
 	// Check compression errors.
 	if compressErr != nil {
@@ -512,7 +547,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		return fmt.Errorf("Error writing backup index file: %w", err)
 	}
 
-	err = pool.BackupCustomVolume(projectName, volumeName, tarWriter, backupRow.OptimizedStorage, !backupRow.VolumeOnly, nil)
+	err = pool.BackupCustomVolume(projectName, volumeName, tarWriter, backupRow.OptimizedStorage, !backupRow.VolumeOnly, parentSnapshot, nil)
 	if err != nil {
 		return fmt.Errorf("Backup create: %w", err)
 	}
@@ -544,7 +579,7 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 }
 
 // volumeBackupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
-func volumeBackupWriteIndex(projectName string, volumeName string, pool storagePools.Pool, optimized bool, snapshots bool, version uint32, tarWriter *instancewriter.InstanceTarWriter) error {
+func volumeBackupWriteIndex(projectName string, volumeName string, pool storagePools.Pool, optimized bool, snapshots bool, parentSnapshot string, version uint32, tarWriter *instancewriter.InstanceTarWriter) error {
 	driverInfo := pool.Driver().Info()
 	poolName := pool.Name()
 
@@ -587,6 +622,11 @@ func volumeBackupWriteIndex(projectName string, volumeName string, pool storageP
 		}
 	}
 
+	err = backupIndexSetParent(&indexInfo, parentSnapshot)
+	if err != nil {
+		return err
+	}
+
 	// Convert to YAML.
 	indexData, err := yaml.Marshal(indexInfo)
 	if err != nil {
@@ -636,6 +676,7 @@ func pruneExpiredStorageVolumeBackups(ctx context.Context, s *state.State) error
 			}
 
 			volBackup := backup.NewVolumeBackup(s, vol.ProjectName, vol.PoolName, vol.Name, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.VolumeOnly, b.OptimizedStorage)
+			volBackup.SetTarget(b.Target, b.TargetLocation)
 
 			volumeBackups = append(volumeBackups, volBackup)
 		}
@@ -657,3 +698,129 @@ func pruneExpiredStorageVolumeBackups(ctx context.Context, s *state.State) error
 
 	return nil
 }
+
+// backupTargetWriter returns a writer streaming the backup tarball to the given location of a backup target.
+// The upload is cancelled and any stored data removed if the revert fails.
+func backupTargetWriter(ctx context.Context, s *state.State, target string, location string, reverter *revert.Reverter) (io.WriteCloser, error) {
+	if target != backup.TargetS3 {
+		return nil, fmt.Errorf("Unknown backup target %q", target)
+	}
+
+	logger.Debug("Opening backup target object for writing", logger.Ctx{"target": target, "location": location})
+	upload, err := backup.NewTargetUpload(ctx, s, location)
+	if err != nil {
+		return nil, fmt.Errorf("Failed starting backup upload: %w", err)
+	}
+
+	reverter.Add(upload.Abort)
+
+	return upload, nil
+}
+
+// backupTargetReader returns a reader for the backup tarball stored at the given location of a backup target
+// along with its size (-1 if unknown). The caller is responsible for closing the reader.
+func backupTargetReader(ctx context.Context, s *state.State, target string, location string) (io.ReadCloser, int64, error) {
+	err := backup.ValidateTarget(s, target)
+	if err != nil {
+		return nil, -1, api.NewStatusError(http.StatusBadRequest, err.Error())
+	}
+
+	if location == "" {
+		return nil, -1, api.NewStatusError(http.StatusBadRequest, "A backup location is required")
+	}
+
+	client, err := backup.NewTargetS3Client(s)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	reader, size, err := client.GetObject(ctx, location)
+	if err != nil {
+		return nil, -1, fmt.Errorf("Failed fetching backup from S3 target: %w", err)
+	}
+
+	return reader, size, nil
+}
+
+// backupTargetExportResponse returns a response streaming the backup tarball stored at the given location of
+// a backup target.
+func backupTargetExportResponse(ctx context.Context, s *state.State, target string, location string) response.Response {
+	reader, size, err := backupTargetReader(ctx, s, target, location)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		defer func() { _ = reader.Close() }()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if size >= 0 {
+			w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		_, err := io.Copy(w, reader)
+
+		return err
+	})
+}
+
+// backupIndexSetParent marks a backup index as incremental from the parent snapshot (if any), restricting its
+// snapshots to those taken after the parent.
+func backupIndexSetParent(indexInfo *backup.Info, parentSnapshot string) error {
+	if parentSnapshot == "" {
+		return nil
+	}
+
+	parentIndex := slices.Index(indexInfo.Snapshots, parentSnapshot)
+	if parentIndex < 0 {
+		return fmt.Errorf("Parent snapshot %q not found", parentSnapshot)
+	}
+
+	indexInfo.Parent = parentSnapshot
+	indexInfo.Snapshots = indexInfo.Snapshots[parentIndex+1:]
+
+	return nil
+}
+
+// backupIncrementalParent resolves the base of an incremental backup to one of the given snapshot names.
+// The base is either the name of a snapshot or the name of an existing backup stored at backupPath, in which
+// case the most recent snapshot included in that backup is used.
+func backupIncrementalParent(s *state.State, incrementalFrom string, snapshots []string, backupPath string) (string, error) {
+	if slices.Contains(snapshots, incrementalFrom) {
+		return incrementalFrom, nil
+	}
+
+	f, err := os.Open(backupPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", api.StatusErrorf(http.StatusNotFound, "No snapshot or backup named %q", incrementalFrom)
+		}
+
+		return "", err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	bInfo, err := backup.GetInfo(s, f, backupPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading backup %q: %w", incrementalFrom, err)
+	}
+
+	// An incremental backup without any snapshot of its own leaves the volume at its parent snapshot.
+	parentSnapshot := bInfo.Parent
+	if len(bInfo.Snapshots) > 0 {
+		parentSnapshot = bInfo.Snapshots[len(bInfo.Snapshots)-1]
+	}
+
+	if parentSnapshot == "" {
+		return "", api.StatusErrorf(http.StatusBadRequest, "Backup %q doesn't include any snapshot", incrementalFrom)
+	}
+
+	if !slices.Contains(snapshots, parentSnapshot) {
+		return "", api.StatusErrorf(http.StatusBadRequest, "Snapshot %q included in backup %q no longer exists", parentSnapshot, incrementalFrom)
+	}
+
+	return parentSnapshot, nil
+}
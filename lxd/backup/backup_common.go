@@ -18,6 +18,8 @@ type CommonBackup struct {
 	expiryDate           time.Time
 	optimizedStorage     bool
 	compressionAlgorithm string
+	target               string
+	targetLocation       string
 }
 
 // Name returns the name of the backup.
@@ -40,3 +42,19 @@ func (b *CommonBackup) SetCompressionAlgorithm(compression string) {
 func (b *CommonBackup) OptimizedStorage() bool {
 	return b.optimizedStorage
 }
+
+// Target returns the backup target the tarball is stored in, empty when stored in the backups directory.
+func (b *CommonBackup) Target() string {
+	return b.target
+}
+
+// TargetLocation returns the location of the tarball in the backup target.
+func (b *CommonBackup) TargetLocation() string {
+	return b.targetLocation
+}
+
+// SetTarget sets the backup target and the location of the tarball in it.
+func (b *CommonBackup) SetTarget(target string, location string) {
+	b.target = target
+	b.targetLocation = location
+}
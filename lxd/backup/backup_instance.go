@@ -76,10 +76,12 @@ func (b *InstanceBackup) Rename(ctx context.Context, newName string) error {
 		return err
 	}
 
-	// Rename the backup directory.
-	err = os.Rename(oldBackupPath, newBackupPath)
-	if err != nil {
-		return err
+	// Rename the backup directory. Backups stored in a backup target keep their location.
+	if b.target == "" {
+		err = os.Rename(oldBackupPath, newBackupPath)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Check if we can remove the old parent directory.
@@ -116,6 +118,12 @@ func (b *InstanceBackup) Delete(ctx context.Context) error {
 		return err
 	}
 
+	// Delete the tarball from the backup target.
+	err = b.deleteFromTarget(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the instance directory.
 	backupsPath := filepath.Join(backupsPathBase, "instances", project.Instance(b.instance.Project().Name, b.instance.Name()))
 	empty, _ := shared.PathIsEmpty(backupsPath)
@@ -148,5 +156,7 @@ func (b *InstanceBackup) Render() *api.InstanceBackup {
 		InstanceOnly:     b.instanceOnly,
 		ContainerOnly:    b.instanceOnly,
 		OptimizedStorage: b.optimizedStorage,
+		Target:           b.target,
+		TargetLocation:   b.targetLocation,
 	}
 }
@@ -0,0 +1,192 @@
+package backup
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/storage/s3"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// TargetS3 is the backup target storing the tarballs in the bucket set by backups.target.s3.url.
+const TargetS3 = "s3"
+
+// ValidateTarget checks that the given backup target is known and configured.
+// An empty target refers to the backups directory of the server.
+func ValidateTarget(s *state.State, target string) error {
+	switch target {
+	case "":
+		return nil
+	case TargetS3:
+		bucketURL, _, _ := s.GlobalConfig.BackupsTargetS3()
+		if bucketURL == "" {
+			return errors.New(`The "s3" backup target requires "backups.target.s3.url" to be set`)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Unknown backup target %q", target)
+}
+
+// NewTargetS3Client returns a client for the bucket used as S3 backup target.
+// The server certificate is trusted in addition to the system CAs so that a bucket served by LXD itself can be used.
+func NewTargetS3Client(s *state.State) (*s3.Client, error) {
+	bucketURL, accessKey, secretKey := s.GlobalConfig.BackupsTargetS3()
+	if bucketURL == "" {
+		return nil, errors.New("No S3 backup target configured")
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	networkCert := s.Endpoints.NetworkCert()
+	rootCAs.AppendCertsFromPEM(networkCert.PublicKey())
+	if networkCert.CA() != nil {
+		rootCAs.AddCert(networkCert.CA())
+	}
+
+	transport := &http.Transport{
+		Proxy:           s.Proxy,
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+	}
+
+	return s3.NewClient(bucketURL, accessKey, secretKey, transport)
+}
+
+// InstanceTargetLocation returns the location of an instance backup tarball in a backup target.
+// The creation date is included so that a backup re-using the name of a previous one doesn't overwrite it.
+func InstanceTargetLocation(projectName string, backupName string, creationDate time.Time) string {
+	return path.Join(projectName, "instances", backupName) + "-" + creationDate.UTC().Format("20060102T150405Z")
+}
+
+// VolumeTargetLocation returns the location of a custom volume backup tarball in a backup target.
+func VolumeTargetLocation(projectName string, poolName string, backupName string, creationDate time.Time) string {
+	return path.Join(projectName, "custom", poolName, backupName) + "-" + creationDate.UTC().Format("20060102T150405Z")
+}
+
+// ValidateInstanceTargetLocation checks that the given location refers to an instance backup tarball of the
+// given project in a backup target.
+func ValidateInstanceTargetLocation(projectName string, location string) error {
+	if location == "" {
+		return errors.New("A backup location is required")
+	}
+
+	if path.IsAbs(location) || path.Clean(location) != location || slices.Contains(strings.Split(location, "/"), "..") {
+		return fmt.Errorf("Invalid backup location %q", location)
+	}
+
+	if !strings.HasPrefix(location, path.Join(projectName, "instances")+"/") {
+		return fmt.Errorf("Backup location %q doesn't belong to an instance of project %q", location, projectName)
+	}
+
+	return nil
+}
+
+// deleteFromTarget removes the backup tarball from its backup target, if any.
+func (b *CommonBackup) deleteFromTarget(ctx context.Context) error {
+	if b.target != TargetS3 {
+		return nil
+	}
+
+	bucketURL, _, _ := b.state.GlobalConfig.BackupsTargetS3()
+	if bucketURL == "" {
+		logger.Warn("Not deleting backup from unconfigured S3 target", logger.Ctx{"name": b.name, "location": b.targetLocation})
+		return nil
+	}
+
+	client, err := NewTargetS3Client(b.state)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteObject(ctx, b.targetLocation)
+	if err != nil {
+		return fmt.Errorf("Failed deleting backup from S3 target: %w", err)
+	}
+
+	return nil
+}
+
+// TargetUpload streams a backup tarball to the S3 backup target as it is written.
+type TargetUpload struct {
+	client     *s3.Client
+	location   string
+	pipeWriter *io.PipeWriter
+	done       chan error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewTargetUpload starts uploading a backup tarball to the given location of the S3 backup target.
+// Close must be called once the tarball is written to complete the upload.
+func NewTargetUpload(ctx context.Context, s *state.State, location string) (*TargetUpload, error) {
+	client, err := NewTargetS3Client(s)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+
+	u := &TargetUpload{
+		client:     client,
+		location:   location,
+		pipeWriter: pipeWriter,
+		done:       make(chan error, 1),
+	}
+
+	go func() {
+		_, err := client.PutObject(ctx, location, pipeReader)
+
+		// Unblock the writer if the upload failed before consuming all the data.
+		_ = pipeReader.CloseWithError(err)
+		u.done <- err
+	}()
+
+	return u, nil
+}
+
+// Write writes data to the uploaded tarball.
+func (u *TargetUpload) Write(p []byte) (int, error) {
+	return u.pipeWriter.Write(p)
+}
+
+// finish ends the stream of data, with the given error if any, and waits for the upload to complete.
+func (u *TargetUpload) finish(err error) error {
+	u.closeOnce.Do(func() {
+		_ = u.pipeWriter.CloseWithError(err)
+		u.closeErr = <-u.done
+	})
+
+	return u.closeErr
+}
+
+// Close completes the upload and returns its result.
+func (u *TargetUpload) Close() error {
+	err := u.finish(nil)
+	if err != nil {
+		return fmt.Errorf("Failed uploading backup to S3 target: %w", err)
+	}
+
+	return nil
+}
+
+// Abort cancels the upload and removes the tarball from the backup target in case it was already stored.
+func (u *TargetUpload) Abort() {
+	_ = u.finish(errors.New("Backup upload aborted"))
+	_ = u.client.DeleteObject(context.Background(), u.location)
+}
@@ -66,13 +66,15 @@ func (b *VolumeBackup) Rename(newName string) error {
 		return err
 	}
 
-	// Rename the backup directory.
-	err = os.Rename(oldBackupPath, newBackupPath)
-	if err != nil {
-		return err
-	}
+	// Rename the backup directory. Backups stored in a backup target keep their location.
+	if b.target == "" {
+		err = os.Rename(oldBackupPath, newBackupPath)
+		if err != nil {
+			return err
+		}
 
-	revert.Add(func() { _ = os.Rename(newBackupPath, oldBackupPath) })
+		revert.Add(func() { _ = os.Rename(newBackupPath, oldBackupPath) })
+	}
 
 	// Check if we can remove the old parent directory.
 	empty, _ := shared.PathIsEmpty(oldParentBackupsPath)
@@ -105,6 +107,12 @@ func (b *VolumeBackup) Delete() error {
 		return err
 	}
 
+	// Delete the tarball from the backup target.
+	err = b.deleteFromTarget(context.TODO())
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the volume directory.
 	backupsPath := filepath.Join(backupsPathBase, "custom", b.poolName, project.StorageVolume(b.projectName, b.volumeName))
 	empty, _ := shared.PathIsEmpty(backupsPath)
@@ -134,5 +142,7 @@ func (b *VolumeBackup) Render() *api.StoragePoolVolumeBackup {
 		ExpiresAt:        b.expiryDate,
 		VolumeOnly:       b.volumeOnly,
 		OptimizedStorage: b.optimizedStorage,
+		Target:           b.target,
+		TargetLocation:   b.targetLocation,
 	}
 }
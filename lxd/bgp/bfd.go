@@ -0,0 +1,806 @@
+package bgp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+	"golang.org/x/sys/unix"
+
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// Single-hop BFD (RFC 5880 and RFC 5881) in asynchronous mode, used to quickly detect the failure of BGP peers.
+const (
+	// bfdPort is the destination port of the BFD control packets.
+	bfdPort = 3784
+
+	// bfdVersion is the version of the BFD protocol.
+	bfdVersion = 1
+
+	// bfdPacketLength is the length of a BFD control packet without authentication.
+	bfdPacketLength = 24
+
+	// bfdDetectMultiplier is the number of missed packets after which the session goes down.
+	bfdDetectMultiplier = 3
+
+	// bfdInterval is the interval at which packets are sent and expected once the session is up.
+	bfdInterval = 300 * time.Millisecond
+
+	// bfdSlowInterval is the interval at which packets are sent while the session isn't up.
+	bfdSlowInterval = time.Second
+)
+
+// BFD diagnostic codes.
+const (
+	bfdDiagNone                 uint8 = 0
+	bfdDiagDetectionTimeExpired uint8 = 1
+	bfdDiagNeighborDown         uint8 = 3
+	bfdDiagAdminDown            uint8 = 7
+)
+
+// bfdState represents the state of a BFD session.
+type bfdState uint8
+
+// BFD session states.
+const (
+	bfdStateAdminDown bfdState = 0
+	bfdStateDown      bfdState = 1
+	bfdStateInit      bfdState = 2
+	bfdStateUp        bfdState = 3
+)
+
+// String returns the name of the BFD session state.
+func (s bfdState) String() string {
+	switch s {
+	case bfdStateAdminDown:
+		return "admin-down"
+	case bfdStateDown:
+		return "down"
+	case bfdStateInit:
+		return "init"
+	case bfdStateUp:
+		return "up"
+	}
+
+	return "unknown"
+}
+
+// bfdPacket represents a BFD control packet.
+type bfdPacket struct {
+	diag              uint8
+	state             bfdState
+	poll              bool
+	final             bool
+	detectMultiplier  uint8
+	myDiscriminator   uint32
+	yourDiscriminator uint32
+
+	// Intervals in microseconds.
+	desiredMinTx      uint32
+	requiredMinRx     uint32
+	requiredMinEchoRx uint32
+}
+
+// marshal returns the wire format of the packet.
+func (p *bfdPacket) marshal() []byte {
+	buf := make([]byte, bfdPacketLength)
+	buf[0] = bfdVersion<<5 | p.diag&0x1f
+	buf[1] = uint8(p.state) << 6
+
+	if p.poll {
+		buf[1] |= 0x20
+	}
+
+	if p.final {
+		buf[1] |= 0x10
+	}
+
+	buf[2] = p.detectMultiplier
+	buf[3] = bfdPacketLength
+	binary.BigEndian.PutUint32(buf[4:], p.myDiscriminator)
+	binary.BigEndian.PutUint32(buf[8:], p.yourDiscriminator)
+	binary.BigEndian.PutUint32(buf[12:], p.desiredMinTx)
+	binary.BigEndian.PutUint32(buf[16:], p.requiredMinRx)
+	binary.BigEndian.PutUint32(buf[20:], p.requiredMinEchoRx)
+
+	return buf
+}
+
+// parseBFDPacket parses and validates a BFD control packet.
+func parseBFDPacket(buf []byte) (*bfdPacket, error) {
+	if len(buf) < bfdPacketLength {
+		return nil, errors.New("Packet too short")
+	}
+
+	version := buf[0] >> 5
+	if version != bfdVersion {
+		return nil, fmt.Errorf("Unsupported BFD version %d", version)
+	}
+
+	length := int(buf[3])
+	if length < bfdPacketLength || length > len(buf) {
+		return nil, fmt.Errorf("Invalid packet length %d", length)
+	}
+
+	if buf[1]&0x04 != 0 {
+		return nil, errors.New("BFD authentication isn't supported")
+	}
+
+	if buf[1]&0x01 != 0 {
+		return nil, errors.New("Multipoint BFD isn't supported")
+	}
+
+	p := &bfdPacket{
+		diag:              buf[0] & 0x1f,
+		state:             bfdState(buf[1] >> 6),
+		poll:              buf[1]&0x20 != 0,
+		final:             buf[1]&0x10 != 0,
+		detectMultiplier:  buf[2],
+		myDiscriminator:   binary.BigEndian.Uint32(buf[4:]),
+		yourDiscriminator: binary.BigEndian.Uint32(buf[8:]),
+		desiredMinTx:      binary.BigEndian.Uint32(buf[12:]),
+		requiredMinRx:     binary.BigEndian.Uint32(buf[16:]),
+		requiredMinEchoRx: binary.BigEndian.Uint32(buf[20:]),
+	}
+
+	if p.detectMultiplier == 0 {
+		return nil, errors.New("Invalid detect multiplier")
+	}
+
+	if p.myDiscriminator == 0 {
+		return nil, errors.New("Missing discriminator")
+	}
+
+	if p.yourDiscriminator == 0 && p.state != bfdStateDown && p.state != bfdStateAdminDown {
+		return nil, errors.New("Missing remote discriminator")
+	}
+
+	return p, nil
+}
+
+// bfdSession represents a BFD session with a single peer.
+type bfdSession struct {
+	address  net.IP
+	send     func(packet []byte) error
+	onChange func()
+
+	mu                  sync.Mutex
+	state               bfdState
+	diag                uint8
+	localDiscriminator  uint32
+	remoteDiscriminator uint32
+	remoteMinRx         time.Duration
+	remoteMinTx         time.Duration
+	remoteMultiplier    uint8
+	pollActive          bool
+	sendFinal           bool
+	generation          uint64
+	detectTimer         *time.Timer
+
+	wake chan struct{}
+	done chan struct{}
+}
+
+// newBFDSession returns a new BFD session in the down state.
+func newBFDSession(address net.IP, localDiscriminator uint32, send func(packet []byte) error, onChange func()) *bfdSession {
+	return &bfdSession{
+		address:            address,
+		send:               send,
+		onChange:           onChange,
+		state:              bfdStateDown,
+		localDiscriminator: localDiscriminator,
+		remoteMinRx:        time.Microsecond,
+		wake:               make(chan struct{}, 1),
+		done:               make(chan struct{}),
+	}
+}
+
+// State returns the current state of the session.
+func (s *bfdSession) State() bfdState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state
+}
+
+// desiredMinTx returns the transmit interval advertised to the peer.
+func (s *bfdSession) desiredMinTx() time.Duration {
+	if s.state == bfdStateUp {
+		return bfdInterval
+	}
+
+	return bfdSlowInterval
+}
+
+// txInterval returns the interval until the next packet, or zero if the peer doesn't want any.
+func (s *bfdSession) txInterval() time.Duration {
+	if s.remoteMinRx == 0 {
+		return 0
+	}
+
+	interval := max(s.desiredMinTx(), s.remoteMinRx)
+
+	// Apply a jitter of up to 25% to avoid self-synchronization.
+	return interval - rand.N(interval/4+1)
+}
+
+// detectionTime returns the time after which the session goes down without packets from the peer.
+func (s *bfdSession) detectionTime() time.Duration {
+	return time.Duration(s.remoteMultiplier) * max(bfdInterval, s.remoteMinTx)
+}
+
+// packet returns a control packet reflecting the current state of the session.
+func (s *bfdSession) packet(final bool) *bfdPacket {
+	return &bfdPacket{
+		diag:              s.diag,
+		state:             s.state,
+		poll:              s.pollActive && !final,
+		final:             final,
+		detectMultiplier:  bfdDetectMultiplier,
+		myDiscriminator:   s.localDiscriminator,
+		yourDiscriminator: s.remoteDiscriminator,
+		desiredMinTx:      uint32(s.desiredMinTx().Microseconds()),
+		requiredMinRx:     uint32(bfdInterval.Microseconds()),
+	}
+}
+
+// setState changes the state of the session and returns whether it changed.
+func (s *bfdSession) setState(state bfdState, diag uint8) bool {
+	if s.state == state {
+		return false
+	}
+
+	s.state = state
+	s.diag = diag
+
+	// The transmit interval is lowered once up, which must be confirmed by the peer with a poll sequence.
+	s.pollActive = state == bfdStateUp
+
+	return true
+}
+
+// receive processes a control packet received from the peer.
+func (s *bfdSession) receive(p *bfdPacket) {
+	s.mu.Lock()
+
+	s.remoteDiscriminator = p.myDiscriminator
+	s.remoteMinRx = time.Duration(p.requiredMinRx) * time.Microsecond
+	s.remoteMinTx = time.Duration(p.desiredMinTx) * time.Microsecond
+	s.remoteMultiplier = p.detectMultiplier
+
+	if p.final {
+		s.pollActive = false
+	}
+
+	if s.state == bfdStateAdminDown {
+		s.mu.Unlock()
+		return
+	}
+
+	changed := false
+	if p.state == bfdStateAdminDown {
+		if s.state != bfdStateDown {
+			changed = s.setState(bfdStateDown, bfdDiagNeighborDown)
+		}
+	} else {
+		switch s.state {
+		case bfdStateDown:
+			if p.state == bfdStateDown {
+				changed = s.setState(bfdStateInit, bfdDiagNone)
+			} else if p.state == bfdStateInit {
+				changed = s.setState(bfdStateUp, bfdDiagNone)
+			}
+
+		case bfdStateInit:
+			if p.state == bfdStateInit || p.state == bfdStateUp {
+				changed = s.setState(bfdStateUp, bfdDiagNone)
+			}
+
+		case bfdStateUp:
+			if p.state == bfdStateDown {
+				changed = s.setState(bfdStateDown, bfdDiagNeighborDown)
+			}
+		}
+	}
+
+	// Reply to a poll right away.
+	if p.poll {
+		s.sendFinal = true
+	}
+
+	// Restart the detection timer.
+	if s.detectTimer != nil {
+		s.detectTimer.Stop()
+	}
+
+	s.generation++
+	generation := s.generation
+	s.detectTimer = time.AfterFunc(s.detectionTime(), func() { s.expire(generation) })
+
+	s.mu.Unlock()
+
+	if changed || p.poll {
+		s.wakeup()
+	}
+
+	if changed && s.onChange != nil {
+		s.onChange()
+	}
+}
+
+// expire brings the session down when no packet was received from the peer within the detection time.
+func (s *bfdSession) expire(generation uint64) {
+	s.mu.Lock()
+
+	if generation != s.generation || (s.state != bfdStateInit && s.state != bfdStateUp) {
+		s.mu.Unlock()
+		return
+	}
+
+	s.setState(bfdStateDown, bfdDiagDetectionTimeExpired)
+	s.remoteDiscriminator = 0
+
+	s.mu.Unlock()
+
+	s.wakeup()
+
+	if s.onChange != nil {
+		s.onChange()
+	}
+}
+
+// wakeup triggers the transmission of a packet.
+func (s *bfdSession) wakeup() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends the control packets to the peer until the session is stopped.
+func (s *bfdSession) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+
+		s.mu.Lock()
+		final := s.sendFinal
+		s.sendFinal = false
+		packet := s.packet(final).marshal()
+		interval := s.txInterval()
+		s.mu.Unlock()
+
+		if interval > 0 || final {
+			err := s.send(packet)
+			if err != nil {
+				logger.Debug("Failed sending BFD packet", logger.Ctx{"peer": s.address.String(), "err": err})
+			}
+		}
+
+		// Check again later if the peer doesn't want any packet for now.
+		if interval == 0 {
+			interval = bfdSlowInterval
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// stop stops the session and lets the peer know about it.
+func (s *bfdSession) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.detectTimer != nil {
+		s.detectTimer.Stop()
+	}
+
+	s.generation++
+	s.setState(bfdStateAdminDown, bfdDiagAdminDown)
+	close(s.done)
+
+	_ = s.send(s.packet(false).marshal())
+}
+
+// bfdListener receives the BFD control packets and dispatches them to the sessions.
+type bfdListener struct {
+	conn *net.UDPConn
+
+	mu       sync.Mutex
+	sessions map[uint32]*bfdSession
+	conns    map[uint32]*net.UDPConn
+}
+
+// newBFDListener starts listening for BFD control packets.
+func newBFDListener() (*bfdListener, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: bfdPort})
+	if err != nil {
+		return nil, err
+	}
+
+	// Request the TTL of the received packets to only accept packets from directly connected peers.
+	err = bfdSetSockopt(conn, func(fd int) error {
+		err := unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_RECVTTL, 1)
+		if err != nil {
+			return err
+		}
+
+		return unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_RECVHOPLIMIT, 1)
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	l := &bfdListener{
+		conn:     conn,
+		sessions: map[uint32]*bfdSession{},
+		conns:    map[uint32]*net.UDPConn{},
+	}
+
+	go l.run()
+
+	return l, nil
+}
+
+// run receives the control packets until the listener is closed.
+func (l *bfdListener) run() {
+	buf := make([]byte, 1500)
+	oob := make([]byte, 128)
+
+	for {
+		n, oobn, _, addr, err := l.conn.ReadMsgUDP(buf, oob)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			continue
+		}
+
+		// Single-hop BFD packets are always sent with a TTL of 255 (RFC 5881).
+		if !bfdCheckTTL(oob[:oobn]) {
+			continue
+		}
+
+		packet, err := parseBFDPacket(buf[:n])
+		if err != nil {
+			logger.Debug("Invalid BFD packet", logger.Ctx{"peer": addr.IP.String(), "err": err})
+			continue
+		}
+
+		session := l.lookup(packet.yourDiscriminator, addr.IP)
+		if session == nil {
+			continue
+		}
+
+		session.receive(packet)
+	}
+}
+
+// lookup returns the session a packet belongs to.
+func (l *bfdListener) lookup(discriminator uint32, address net.IP) *bfdSession {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if discriminator != 0 {
+		session := l.sessions[discriminator]
+		if session == nil || !session.address.Equal(address) {
+			return nil
+		}
+
+		return session
+	}
+
+	for _, session := range l.sessions {
+		if session.address.Equal(address) {
+			return session
+		}
+	}
+
+	return nil
+}
+
+// addSession starts a new session with the given peer.
+func (l *bfdListener) addSession(address net.IP, onChange func()) (*bfdSession, error) {
+	conn, err := bfdDial(address)
+	if err != nil {
+		return nil, fmt.Errorf("Failed setting up BFD socket for %q: %w", address.String(), err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Pick a unique non-zero discriminator.
+	var discriminator uint32
+	for discriminator == 0 || l.sessions[discriminator] != nil {
+		discriminator = rand.Uint32()
+	}
+
+	session := newBFDSession(address, discriminator, func(packet []byte) error {
+		_, err := conn.Write(packet)
+		return err
+	}, onChange)
+
+	l.sessions[discriminator] = session
+	l.conns[discriminator] = conn
+
+	go session.run()
+
+	return session, nil
+}
+
+// removeSession stops the given session.
+func (l *bfdListener) removeSession(session *bfdSession) {
+	session.stop()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	conn := l.conns[session.localDiscriminator]
+	if conn != nil {
+		_ = conn.Close()
+	}
+
+	delete(l.sessions, session.localDiscriminator)
+	delete(l.conns, session.localDiscriminator)
+}
+
+// close stops listening for control packets.
+func (l *bfdListener) close() {
+	_ = l.conn.Close()
+}
+
+// bfdDial returns a socket for sending control packets to the given peer.
+func bfdDial(address net.IP) (*net.UDPConn, error) {
+	network := "udp4"
+	if address.To4() == nil {
+		network = "udp6"
+	}
+
+	// The source port must be in the 49152-65535 range (RFC 5881).
+	for range 100 {
+		localAddr := &net.UDPAddr{Port: 49152 + rand.IntN(16384)}
+
+		conn, err := net.DialUDP(network, localAddr, &net.UDPAddr{IP: address, Port: bfdPort})
+		if err != nil {
+			if errors.Is(err, syscall.EADDRINUSE) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		err = bfdSetSockopt(conn, func(fd int) error {
+			if address.To4() != nil {
+				return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_TTL, 255)
+			}
+
+			return unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, 255)
+		})
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	return nil, errors.New("No source port available")
+}
+
+// bfdSetSockopt applies socket options to the given connection.
+func bfdSetSockopt(conn *net.UDPConn, fn func(fd int) error) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockoptErr error
+	err = rawConn.Control(func(fd uintptr) {
+		sockoptErr = fn(int(fd))
+	})
+	if err != nil {
+		return err
+	}
+
+	return sockoptErr
+}
+
+// bfdCheckTTL checks that a packet was received with a TTL of 255.
+func bfdCheckTTL(oob []byte) bool {
+	messages, err := unix.ParseSocketControlMessage(oob)
+	if err != nil {
+		return false
+	}
+
+	for _, message := range messages {
+		isTTL := message.Header.Level == unix.IPPROTO_IP && message.Header.Type == unix.IP_TTL
+		isHopLimit := message.Header.Level == unix.IPPROTO_IPV6 && message.Header.Type == unix.IPV6_HOPLIMIT
+		if (isTTL || isHopLimit) && len(message.Data) >= 4 {
+			return binary.NativeEndian.Uint32(message.Data) == 255
+		}
+	}
+
+	// The TTL isn't known, accept the packet.
+	return true
+}
+
+// bfdPeer represents a BGP peer monitored with BFD.
+type bfdPeer struct {
+	session  *bfdSession
+	owners   []string
+	wasUp    bool
+	disabled bool
+}
+
+// AddBFD enables BFD for the given peer.
+func (s *Server) AddBFD(address net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addrStr := address.String()
+
+	// Re-use the existing session.
+	peer, found := s.bfdPeers[addrStr]
+	if found {
+		if !slices.Contains(peer.owners, owner) {
+			peer.owners = append(peer.owners, owner)
+		}
+
+		return nil
+	}
+
+	// Start listening for control packets with the first session.
+	if s.bfdListener == nil {
+		listener, err := newBFDListener()
+		if err != nil {
+			return fmt.Errorf("Failed starting BFD listener: %w", err)
+		}
+
+		s.bfdListener = listener
+	}
+
+	session, err := s.bfdListener.addSession(address, func() { s.bfdUpdated(addrStr) })
+	if err != nil {
+		if len(s.bfdPeers) == 0 {
+			s.bfdListener.close()
+			s.bfdListener = nil
+		}
+
+		return err
+	}
+
+	s.bfdPeers[addrStr] = &bfdPeer{
+		session: session,
+		owners:  []string{owner},
+	}
+
+	return nil
+}
+
+// RemoveBFD disables BFD for the given peer.
+func (s *Server) RemoveBFD(address net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.removeBFD(address.String(), owner)
+}
+
+// RemoveBFDByOwner disables BFD for all peers of the provided owner.
+func (s *Server) RemoveBFDByOwner(owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, addrStr := range slices.Collect(maps.Keys(s.bfdPeers)) {
+		err := s.removeBFD(addrStr, owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) removeBFD(addrStr string, owner string) error {
+	peer, found := s.bfdPeers[addrStr]
+	if !found || !slices.Contains(peer.owners, owner) {
+		return nil
+	}
+
+	// Keep the session while used by other owners.
+	peer.owners = slices.DeleteFunc(peer.owners, func(o string) bool { return o == owner })
+	if len(peer.owners) > 0 {
+		return nil
+	}
+
+	s.bfdListener.removeSession(peer.session)
+	delete(s.bfdPeers, addrStr)
+
+	if len(s.bfdPeers) == 0 {
+		s.bfdListener.close()
+		s.bfdListener = nil
+	}
+
+	// Bring back the BGP session if it was shut down by BFD.
+	if peer.disabled && s.bgp != nil {
+		err := s.bgp.EnablePeer(context.Background(), &bgpAPI.EnablePeerRequest{Address: addrStr})
+		if err != nil && s.peers[addrStr].count > 0 {
+			return err
+		}
+	}
+
+	return s.updateImportedRoutes()
+}
+
+// bfdUpdated shuts down the BGP session with a peer when its BFD session goes down and brings it back once up.
+func (s *Server) bfdUpdated(addrStr string) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peer, found := s.bfdPeers[addrStr]
+	if !found {
+		return
+	}
+
+	if peer.session.State() == bfdStateUp {
+		peer.wasUp = true
+		if !peer.disabled {
+			return
+		}
+
+		logger.Info("BFD session with BGP peer is up, enabling the BGP session", logger.Ctx{"peer": addrStr})
+		peer.disabled = false
+
+		if s.bgp != nil {
+			err := s.bgp.EnablePeer(context.Background(), &bgpAPI.EnablePeerRequest{Address: addrStr})
+			if err != nil {
+				logger.Warn("Failed enabling BGP peer", logger.Ctx{"peer": addrStr, "err": err})
+			}
+		}
+
+		return
+	}
+
+	// Only act on peers that are known to run BFD.
+	if !peer.wasUp || peer.disabled {
+		return
+	}
+
+	logger.Warn("BFD session with BGP peer is down, shutting down the BGP session", logger.Ctx{"peer": addrStr})
+	peer.disabled = true
+
+	if s.bgp != nil {
+		err := s.bgp.DisablePeer(context.Background(), &bgpAPI.DisablePeerRequest{Address: addrStr, Communication: "BFD session down"})
+		if err != nil {
+			logger.Warn("Failed disabling BGP peer", logger.Ctx{"peer": addrStr, "err": err})
+		}
+	}
+
+	// Withdraw the routes imported from the peer right away.
+	err := s.updateImportedRoutes()
+	if err != nil {
+		logger.Warn("Failed updating imported BGP routes", logger.Ctx{"err": err})
+	}
+}
@@ -0,0 +1,125 @@
+package bgp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBFDPacket verifies that control packets survive a marshal and parse round trip.
+func TestBFDPacket(t *testing.T) {
+	packet := &bfdPacket{
+		diag:              bfdDiagNeighborDown,
+		state:             bfdStateUp,
+		poll:              true,
+		detectMultiplier:  3,
+		myDiscriminator:   1,
+		yourDiscriminator: 2,
+		desiredMinTx:      300000,
+		requiredMinRx:     300000,
+	}
+
+	buf := packet.marshal()
+	require.Len(t, buf, bfdPacketLength)
+	require.Equal(t, byte(0x23), buf[0])
+	require.Equal(t, byte(0xe0), buf[1])
+
+	parsed, err := parseBFDPacket(buf)
+	require.NoError(t, err)
+	require.Equal(t, packet, parsed)
+}
+
+// TestBFDPacketInvalid verifies that invalid control packets are rejected.
+func TestBFDPacketInvalid(t *testing.T) {
+	valid := func() *bfdPacket {
+		return &bfdPacket{state: bfdStateUp, detectMultiplier: 3, myDiscriminator: 1, yourDiscriminator: 2}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(buf []byte) []byte
+	}{
+		{"Too short", func(buf []byte) []byte { return buf[:20] }},
+		{"Bad version", func(buf []byte) []byte { buf[0] = 0x40; return buf }},
+		{"Bad length", func(buf []byte) []byte { buf[3] = 48; return buf }},
+		{"Authentication", func(buf []byte) []byte { buf[1] |= 0x04; return buf }},
+		{"Multipoint", func(buf []byte) []byte { buf[1] |= 0x01; return buf }},
+		{"No multiplier", func(buf []byte) []byte { buf[2] = 0; return buf }},
+		{"No discriminator", func(buf []byte) []byte { clear(buf[4:8]); return buf }},
+		{"No remote discriminator while up", func(buf []byte) []byte { clear(buf[8:12]); return buf }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseBFDPacket(tc.mutate(valid().marshal()))
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestBFDSessionStates verifies the three-way handshake and the ways a session goes down.
+func TestBFDSessionStates(t *testing.T) {
+	changes := 0
+	session := newBFDSession(net.ParseIP("192.0.2.1"), 10, func(packet []byte) error { return nil }, func() { changes++ })
+	defer session.stop()
+
+	remote := func(state bfdState) *bfdPacket {
+		return &bfdPacket{state: state, detectMultiplier: 3, myDiscriminator: 20, yourDiscriminator: 10, desiredMinTx: 1000, requiredMinRx: 1000}
+	}
+
+	require.Equal(t, bfdStateDown, session.State())
+
+	// Down to init when the peer is down, then up once the peer confirms.
+	session.receive(remote(bfdStateDown))
+	require.Equal(t, bfdStateInit, session.State())
+	require.Equal(t, uint32(20), session.remoteDiscriminator)
+
+	session.receive(remote(bfdStateUp))
+	require.Equal(t, bfdStateUp, session.State())
+	require.True(t, session.pollActive)
+	require.Equal(t, 2, changes)
+
+	// The final bit ends the poll sequence.
+	final := remote(bfdStateUp)
+	final.final = true
+	session.receive(final)
+	require.False(t, session.pollActive)
+	require.Equal(t, bfdStateUp, session.State())
+
+	// The peer going down brings the session down.
+	session.receive(remote(bfdStateDown))
+	require.Equal(t, bfdStateDown, session.State())
+	require.Equal(t, bfdDiagNeighborDown, session.diag)
+
+	// Going straight up from down when the peer is in init.
+	session.receive(remote(bfdStateInit))
+	require.Equal(t, bfdStateUp, session.State())
+
+	// The session goes down when no packets are received within the detection time.
+	require.Eventually(t, func() bool { return session.State() == bfdStateDown }, time.Second, 10*time.Millisecond)
+	session.mu.Lock()
+	require.Equal(t, bfdDiagDetectionTimeExpired, session.diag)
+	require.Equal(t, uint32(0), session.remoteDiscriminator)
+	session.mu.Unlock()
+}
+
+// TestBFDSessionPacket verifies the intervals advertised by a session.
+func TestBFDSessionPacket(t *testing.T) {
+	session := newBFDSession(net.ParseIP("192.0.2.1"), 10, func(packet []byte) error { return nil }, nil)
+	defer session.stop()
+
+	// Packets are sent slowly until the session is up.
+	packet := session.packet(false)
+	require.Equal(t, bfdStateDown, packet.state)
+	require.Equal(t, uint32(10), packet.myDiscriminator)
+	require.Equal(t, uint32(bfdSlowInterval.Microseconds()), packet.desiredMinTx)
+	require.Equal(t, uint32(bfdInterval.Microseconds()), packet.requiredMinRx)
+	require.LessOrEqual(t, session.txInterval(), bfdSlowInterval)
+	require.GreaterOrEqual(t, session.txInterval(), bfdSlowInterval*3/4)
+
+	// Nothing is sent to peers that don't want any packet.
+	session.receive(&bfdPacket{state: bfdStateDown, detectMultiplier: 3, myDiscriminator: 20})
+	require.Equal(t, time.Duration(0), session.txInterval())
+}
@@ -0,0 +1,682 @@
+package bgp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+	bgpPacket "github.com/osrg/gobgp/v3/pkg/packet/bgp"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// evpnFamily is the address family of the EVPN routes.
+var evpnFamily = &bgpAPI.Family{Afi: bgpAPI.Family_AFI_L2VPN, Safi: bgpAPI.Family_SAFI_EVPN}
+
+// evpnPath represents an EVPN route advertised by the server.
+type evpnPath struct {
+	owner string
+	vni   uint32
+	vtep  net.IP
+
+	// Unset for the inclusive multicast route of the VTEP.
+	mac net.HardwareAddr
+
+	// Unset for the MAC only advertisement route.
+	ip net.IP
+}
+
+// evpnPeer represents a VTEP of the overlay to exchange EVPN routes with.
+type evpnPeer struct {
+	address net.IP
+	owners  map[string]struct{}
+}
+
+// evpnRoute represents an EVPN route found in the routing table.
+type evpnRoute struct {
+	// Unset for inclusive multicast routes.
+	mac  net.HardwareAddr
+	vtep net.IP
+
+	// MAC mobility sequence number, -1 when the route doesn't carry one.
+	sequence int
+}
+
+// EVPNState represents the remote endpoints of an EVPN segment.
+type EVPNState struct {
+	// VTEPs to send the broadcast, unknown unicast and multicast traffic to.
+	Flood []net.IP
+
+	// VTEPs of the MAC addresses advertised by the other VTEPs, keyed by MAC address.
+	MACs map[string]net.IP
+
+	// Local MAC addresses that have since been advertised by another VTEP.
+	Moved []net.HardwareAddr
+}
+
+// SetEVPNPeers sets the VTEPs to exchange EVPN routes with.
+// The peers use the local ASN and the same port as the local listener.
+func (s *Server) SetEVPNPeers(addresses []net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.setEVPNPeers(addresses, owner)
+}
+
+func (s *Server) setEVPNPeers(addresses []net.IP, owner string) error {
+	// Remove the peers that aren't used anymore.
+	for addrStr, p := range s.evpnPeers {
+		if slices.ContainsFunc(addresses, p.address.Equal) {
+			continue
+		}
+
+		delete(p.owners, owner)
+		if len(p.owners) > 0 {
+			continue
+		}
+
+		if s.bgp != nil {
+			err := s.bgp.DeletePeer(context.Background(), &bgpAPI.DeletePeerRequest{Address: addrStr})
+			if err != nil {
+				return err
+			}
+		}
+
+		delete(s.evpnPeers, addrStr)
+	}
+
+	// Add the new peers.
+	for _, address := range addresses {
+		addrStr := address.String()
+
+		p, found := s.evpnPeers[addrStr]
+		if !found {
+			_, found = s.peers[addrStr]
+			if found {
+				return fmt.Errorf("Peer %q already used as a BGP peer", addrStr)
+			}
+
+			p = &evpnPeer{address: address, owners: map[string]struct{}{}}
+
+			err := s.addEVPNPeer(p)
+			if err != nil {
+				return err
+			}
+
+			s.evpnPeers[addrStr] = p
+		}
+
+		p.owners[owner] = struct{}{}
+	}
+
+	return nil
+}
+
+// addEVPNPeer adds an internal BGP session for the EVPN routes with the given VTEP.
+func (s *Server) addEVPNPeer(p *evpnPeer) error {
+	if s.bgp == nil {
+		return nil
+	}
+
+	// Other VTEPs listen on the same port.
+	port := uint64(179)
+	_, addrPort, err := net.SplitHostPort(s.address)
+	if err == nil {
+		port, err = strconv.ParseUint(addrPort, 10, 16)
+		if err != nil {
+			return err
+		}
+	}
+
+	n := &bgpAPI.Peer{
+		Conf: &bgpAPI.PeerConf{
+			NeighborAddress: p.address.String(),
+			PeerAsn:         s.asn,
+		},
+
+		GracefulRestart: &bgpAPI.GracefulRestart{
+			Enabled:     true,
+			RestartTime: 120,
+		},
+
+		Transport: &bgpAPI.Transport{
+			RemotePort: uint32(port),
+		},
+
+		AfiSafis: []*bgpAPI.AfiSafi{{
+			MpGracefulRestart: &bgpAPI.MpGracefulRestart{
+				Config: &bgpAPI.MpGracefulRestartConfig{
+					Enabled: true,
+				},
+			},
+			Config: &bgpAPI.AfiSafiConfig{Family: evpnFamily},
+		}},
+	}
+
+	return s.bgp.AddPeer(context.Background(), &bgpAPI.AddPeerRequest{Peer: n})
+}
+
+// SetEVPNHandler sets a function to call when the routes received from the peers change.
+func (s *Server) SetEVPNHandler(owner string, handler func()) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evpnHandlers[owner] = handler
+}
+
+// RemoveEVPNByOwner removes the EVPN routes, peers and handler of the provided owner.
+func (s *Server) RemoveEVPNByOwner(owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.evpnHandlers, owner)
+
+	err := s.setEVPNPeers(nil, owner)
+	if err != nil {
+		return err
+	}
+
+	for pathUUID, p := range s.evpnPaths {
+		if p.owner != owner {
+			continue
+		}
+
+		err := s.removeEVPNPath(pathUUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncEVPN advertises the VTEP and the given local MAC addresses on the VNI and returns the remote endpoints
+// to forward the traffic of the segment to.
+// The IP addresses of the local MAC addresses, keyed by MAC address, are advertised alongside them.
+func (s *Server) SyncEVPN(vni uint32, vtep net.IP, macs []net.HardwareAddr, ips map[string][]net.IP, owner string) (*EVPNState, error) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove the routes left over from a previous configuration.
+	multicast := false
+	advertised := []net.HardwareAddr{}
+	for pathUUID, p := range s.evpnPaths {
+		if p.owner != owner {
+			continue
+		}
+
+		if p.vni != vni || !p.vtep.Equal(vtep) {
+			err := s.removeEVPNPath(pathUUID)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if p.mac == nil {
+			multicast = true
+		} else if p.ip == nil {
+			advertised = append(advertised, p.mac)
+		}
+	}
+
+	// Advertise the VTEP for the broadcast, unknown unicast and multicast traffic.
+	if !multicast {
+		err := s.addEVPNPath(evpnPath{owner: owner, vni: vni, vtep: vtep})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	routes, err := s.evpnRoutes(vni)
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing EVPN routes: %w", err)
+	}
+
+	state, add, remove := evpnResolve(vtep, macs, advertised, routes)
+
+	for _, mac := range remove {
+		for pathUUID, p := range s.evpnPaths {
+			if p.owner != owner || !bytes.Equal(p.mac, mac) {
+				continue
+			}
+
+			err := s.removeEVPNPath(pathUUID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, mac := range add {
+		err := s.addEVPNPath(evpnPath{owner: owner, vni: vni, vtep: vtep, mac: mac})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.syncEVPNIPPaths(vni, vtep, ips, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// syncEVPNIPPaths advertises a MAC/IP advertisement route for each IP address of the advertised MAC addresses
+// and withdraws the routes of the IP addresses that went away.
+// The MAC only route is kept alongside so that MAC addresses without known IP addresses stay reachable.
+func (s *Server) syncEVPNIPPaths(vni uint32, vtep net.IP, ips map[string][]net.IP, owner string) error {
+	macs := map[string]net.HardwareAddr{}
+	advertised := map[string]bool{}
+	for pathUUID, p := range s.evpnPaths {
+		if p.owner != owner || p.mac == nil {
+			continue
+		}
+
+		if p.ip == nil {
+			macs[p.mac.String()] = p.mac
+			continue
+		}
+
+		if !slices.ContainsFunc(ips[p.mac.String()], p.ip.Equal) {
+			err := s.removeEVPNPath(pathUUID)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		advertised[p.mac.String()+"/"+p.ip.String()] = true
+	}
+
+	for key, mac := range macs {
+		for _, ip := range ips[key] {
+			if advertised[key+"/"+ip.String()] {
+				continue
+			}
+
+			err := s.addEVPNPath(evpnPath{owner: owner, vni: vni, vtep: vtep, mac: mac, ip: ip})
+			if err != nil {
+				return err
+			}
+
+			advertised[key+"/"+ip.String()] = true
+		}
+	}
+
+	return nil
+}
+
+// evpnResolve works out the remote endpoints of a segment from the EVPN routes as well as the local MAC
+// addresses to start and to stop advertising.
+//
+// A MAC address is reachable through the VTEP advertising it with the highest MAC mobility sequence number, and
+// the lowest address on a tie. Local MAC addresses advertised by a winning remote VTEP have moved away.
+func evpnResolve(vtep net.IP, macs []net.HardwareAddr, advertised []net.HardwareAddr, routes []evpnRoute) (state *EVPNState, add []net.HardwareAddr, remove []net.HardwareAddr) {
+	state = &EVPNState{MACs: map[string]net.IP{}}
+	localSequences := map[string]int{}
+	best := map[string]evpnRoute{}
+
+	for _, r := range routes {
+		if r.vtep.Equal(vtep) {
+			if r.mac != nil {
+				localSequences[r.mac.String()] = r.sequence
+			}
+
+			continue
+		}
+
+		if r.mac == nil {
+			if !slices.ContainsFunc(state.Flood, r.vtep.Equal) {
+				state.Flood = append(state.Flood, r.vtep)
+			}
+
+			continue
+		}
+
+		current, found := best[r.mac.String()]
+		if !found || evpnRouteWins(r, current) {
+			best[r.mac.String()] = r
+		}
+	}
+
+	local := map[string]bool{}
+	for _, mac := range macs {
+		local[mac.String()] = true
+	}
+
+	keep := map[string]bool{}
+	for _, mac := range advertised {
+		key := mac.String()
+
+		sequence, found := localSequences[key]
+		if !found {
+			sequence = -1
+		}
+
+		remote, found := best[key]
+		if found && evpnRouteWins(remote, evpnRoute{mac: mac, vtep: vtep, sequence: sequence}) {
+			remove = append(remove, mac)
+			keep[key] = true
+
+			if local[key] {
+				state.Moved = append(state.Moved, mac)
+			}
+
+			continue
+		}
+
+		if !local[key] {
+			remove = append(remove, mac)
+			continue
+		}
+
+		keep[key] = true
+		delete(best, key)
+	}
+
+	// New local MAC addresses get advertised with a higher sequence number than the remote routes.
+	for _, mac := range macs {
+		key := mac.String()
+		if keep[key] {
+			continue
+		}
+
+		keep[key] = true
+		add = append(add, mac)
+		delete(best, key)
+	}
+
+	for key, r := range best {
+		state.MACs[key] = r.vtep
+	}
+
+	slices.SortFunc(state.Flood, func(a, b net.IP) int { return bytes.Compare(a.To16(), b.To16()) })
+
+	return state, add, remove
+}
+
+// evpnRouteWins returns whether the first route to a MAC address is preferred over the second one.
+func evpnRouteWins(a evpnRoute, b evpnRoute) bool {
+	if a.sequence != b.sequence {
+		return a.sequence > b.sequence
+	}
+
+	return bytes.Compare(a.vtep.To16(), b.vtep.To16()) < 0
+}
+
+// evpnRoutes returns the EVPN routes of the VNI found in the routing table.
+func (s *Server) evpnRoutes(vni uint32) ([]evpnRoute, error) {
+	routes := []evpnRoute{}
+	if s.bgp == nil {
+		return routes, nil
+	}
+
+	req := &bgpAPI.ListPathRequest{
+		TableType: bgpAPI.TableType_GLOBAL,
+		Family:    evpnFamily,
+	}
+
+	err := s.bgp.ListPath(context.Background(), req, func(d *bgpAPI.Destination) {
+		for _, p := range d.Paths {
+			if p.IsWithdraw {
+				continue
+			}
+
+			r, err := parseEVPNPath(p, vni)
+			if err != nil {
+				continue
+			}
+
+			routes = append(routes, *r)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// parseEVPNPath returns the EVPN route of a BGP path if it belongs to the VNI.
+func parseEVPNPath(p *bgpAPI.Path, vni uint32) (*evpnRoute, error) {
+	r := &evpnRoute{sequence: -1}
+
+	nlri, err := p.Nlri.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := nlri.(type) {
+	case *bgpAPI.EVPNMACIPAdvertisementRoute:
+		r.mac, err = net.ParseMAC(n.MacAddress)
+		if err != nil {
+			return nil, err
+		}
+
+	case *bgpAPI.EVPNInclusiveMulticastEthernetTagRoute:
+	default:
+		return nil, errors.New("Unsupported EVPN route type")
+	}
+
+	// The VNI is identified by the route target.
+	found := false
+	for _, attr := range p.Pattrs {
+		msg, err := attr.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+
+		communities, ok := msg.(*bgpAPI.ExtendedCommunitiesAttribute)
+		if !ok {
+			continue
+		}
+
+		for _, community := range communities.Communities {
+			msg, err := community.UnmarshalNew()
+			if err != nil {
+				continue
+			}
+
+			switch c := msg.(type) {
+			case *bgpAPI.TwoOctetAsSpecificExtended:
+				if c.SubType == uint32(bgpPacket.EC_SUBTYPE_ROUTE_TARGET) && c.LocalAdmin == vni {
+					found = true
+				}
+
+			case *bgpAPI.MacMobilityExtended:
+				r.sequence = int(c.SequenceNum)
+			}
+		}
+	}
+
+	if !found {
+		return nil, errors.New("Route target not found")
+	}
+
+	r.vtep = pathNextHop(p)
+	if r.vtep == nil {
+		return nil, errors.New("Next hop not found")
+	}
+
+	return r, nil
+}
+
+// addEVPNPath advertises an EVPN route.
+func (s *Server) addEVPNPath(p evpnPath) error {
+	var pathUUID string
+	if s.bgp != nil {
+		apiPath, err := s.evpnAPIPath(p)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.bgp.AddPath(context.Background(), &bgpAPI.AddPathRequest{Path: apiPath})
+		if err != nil {
+			return err
+		}
+
+		pathUUID = string(resp.Uuid)
+	} else {
+		// Generate a dummy UUID.
+		pathUUID = uuid.New().String()
+	}
+
+	s.evpnPaths[pathUUID] = p
+
+	return nil
+}
+
+// evpnAPIPath returns the BGP path of an EVPN route.
+// The MAC mobility sequence number of MAC/IP advertisement routes is set by the BGP server.
+func (s *Server) evpnAPIPath(p evpnPath) (*bgpAPI.Path, error) {
+	// The route distinguisher is unique to the local server and the ethernet tag keeps the VNIs apart.
+	rd, err := anypb.New(&bgpAPI.RouteDistinguisherIPAddress{Admin: s.routerID.String(), Assigned: p.vni & 0xffff})
+	if err != nil {
+		return nil, err
+	}
+
+	// Use AS_TRANS in the route target for 4-byte ASNs.
+	rtASN := s.asn
+	if rtASN > 0xffff {
+		rtASN = 23456
+	}
+
+	rt, err := anypb.New(&bgpAPI.TwoOctetAsSpecificExtended{IsTransitive: true, SubType: uint32(bgpPacket.EC_SUBTYPE_ROUTE_TARGET), Asn: rtASN, LocalAdmin: p.vni})
+	if err != nil {
+		return nil, err
+	}
+
+	encap, err := anypb.New(&bgpAPI.EncapExtended{TunnelType: uint32(bgpPacket.TUNNEL_TYPE_VXLAN)})
+	if err != nil {
+		return nil, err
+	}
+
+	aCommunities, err := anypb.New(&bgpAPI.ExtendedCommunitiesAttribute{Communities: []*anypb.Any{rt, encap}})
+	if err != nil {
+		return nil, err
+	}
+
+	aOrigin, err := anypb.New(&bgpAPI.OriginAttribute{Origin: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	pattrs := []*anypb.Any{aOrigin, aCommunities}
+
+	var nlri *anypb.Any
+	if p.mac != nil {
+		route := &bgpAPI.EVPNMACIPAdvertisementRoute{
+			Rd:          rd,
+			Esi:         &bgpAPI.EthernetSegmentIdentifier{},
+			EthernetTag: p.vni,
+			MacAddress:  p.mac.String(),
+			Labels:      []uint32{p.vni},
+		}
+
+		if p.ip != nil {
+			route.IpAddress = p.ip.String()
+		}
+
+		nlri, err = anypb.New(route)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		nlri, err = anypb.New(&bgpAPI.EVPNInclusiveMulticastEthernetTagRoute{
+			Rd:          rd,
+			EthernetTag: p.vni,
+			IpAddress:   p.vtep.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		id := p.vtep.To4()
+		if id == nil {
+			id = p.vtep.To16()
+		}
+
+		aPmsi, err := anypb.New(&bgpAPI.PmsiTunnelAttribute{Type: uint32(bgpPacket.PMSI_TUNNEL_TYPE_INGRESS_REPL), Label: p.vni, Id: id})
+		if err != nil {
+			return nil, err
+		}
+
+		pattrs = append(pattrs, aPmsi)
+	}
+
+	aMpReach, err := anypb.New(&bgpAPI.MpReachNLRIAttribute{
+		Family:   evpnFamily,
+		NextHops: []string{p.vtep.String()},
+		Nlris:    []*anypb.Any{nlri},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bgpAPI.Path{
+		Family: evpnFamily,
+		Nlri:   nlri,
+		Pattrs: append(pattrs, aMpReach),
+	}, nil
+}
+
+// removeEVPNPath withdraws an EVPN route.
+func (s *Server) removeEVPNPath(pathUUID string) error {
+	if s.bgp != nil {
+		// The BGP server withdraws the local MAC/IP advertisement routes of MAC addresses that moved away itself.
+		err := s.bgp.DeletePath(context.Background(), &bgpAPI.DeletePathRequest{Uuid: []byte(pathUUID)})
+		if err != nil && err.Error() != "can't find a specified path" {
+			return err
+		}
+	}
+
+	delete(s.evpnPaths, pathUUID)
+
+	return nil
+}
+
+// restoreEVPN adds the EVPN peers and routes to a newly started listener.
+func (s *Server) restoreEVPN() {
+	for addrStr, p := range s.evpnPeers {
+		err := s.addEVPNPeer(p)
+		if err != nil {
+			logger.Warn("Cannot add EVPN peer to BGP server", logger.Ctx{"peer": addrStr, "err": err})
+		}
+	}
+
+	oldPaths := maps.Clone(s.evpnPaths)
+	s.evpnPaths = map[string]evpnPath{}
+	for _, p := range oldPaths {
+		err := s.addEVPNPath(p)
+		if err != nil {
+			logger.Warn("Cannot add EVPN route to BGP server", logger.Ctx{"vni": p.vni, "mac": p.mac.String(), "err": err})
+		}
+	}
+}
+
+// notifyEVPN calls the handlers of the EVPN routes.
+func (s *Server) notifyEVPN() {
+	s.mu.Lock()
+	handlers := slices.Collect(maps.Values(s.evpnHandlers))
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+}
@@ -0,0 +1,155 @@
+package bgp
+
+import (
+	"net"
+	"testing"
+
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+	"github.com/stretchr/testify/require"
+)
+
+// mustParseMAC parses the given MAC address string.
+func mustParseMAC(s string) net.HardwareAddr {
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		panic("invalid MAC: " + s)
+	}
+
+	return mac
+}
+
+// TestEVPNResolve verifies the selection of the VTEPs of the MAC addresses and the handling of moved addresses.
+func TestEVPNResolve(t *testing.T) {
+	local := mustParseIP("192.0.2.2")
+	mac1 := mustParseMAC("00:16:3e:00:00:01")
+	mac2 := mustParseMAC("00:16:3e:00:00:02")
+	mac3 := mustParseMAC("00:16:3e:00:00:03")
+	mac4 := mustParseMAC("00:16:3e:00:00:04")
+	mac5 := mustParseMAC("00:16:3e:00:00:05")
+
+	routes := []evpnRoute{
+		// Inclusive multicast routes.
+		{vtep: mustParseIP("192.0.2.3")},
+		{vtep: mustParseIP("192.0.2.1")},
+		{vtep: local},
+
+		// The highest sequence number wins, then the lowest address.
+		{mac: mac1, vtep: mustParseIP("192.0.2.1"), sequence: -1},
+		{mac: mac1, vtep: mustParseIP("192.0.2.3"), sequence: 0},
+		{mac: mac2, vtep: mustParseIP("192.0.2.3"), sequence: 1},
+		{mac: mac2, vtep: mustParseIP("192.0.2.1"), sequence: 1},
+
+		// Local route that moved away.
+		{mac: mac3, vtep: local, sequence: -1},
+		{mac: mac3, vtep: mustParseIP("192.0.2.1"), sequence: 0},
+
+		// Local route that moved in.
+		{mac: mac4, vtep: local, sequence: 2},
+		{mac: mac4, vtep: mustParseIP("192.0.2.1"), sequence: 1},
+	}
+
+	state, add, remove := evpnResolve(local, []net.HardwareAddr{mac3, mac4, mac5}, []net.HardwareAddr{mac3, mac4}, routes)
+	require.Equal(t, []net.IP{mustParseIP("192.0.2.1"), mustParseIP("192.0.2.3")}, state.Flood)
+	require.Equal(t, map[string]net.IP{
+		mac1.String(): mustParseIP("192.0.2.3"),
+		mac2.String(): mustParseIP("192.0.2.1"),
+		mac3.String(): mustParseIP("192.0.2.1"),
+	}, state.MACs)
+	require.Equal(t, []net.HardwareAddr{mac3}, state.Moved)
+	require.Equal(t, []net.HardwareAddr{mac5}, add)
+	require.Equal(t, []net.HardwareAddr{mac3}, remove)
+
+	// Addresses that went away are withdrawn and addresses seen elsewhere are taken over.
+	state, add, remove = evpnResolve(local, []net.HardwareAddr{mac1}, []net.HardwareAddr{mac4}, routes)
+	require.Empty(t, state.Moved)
+	require.Equal(t, []net.HardwareAddr{mac1}, add)
+	require.Equal(t, []net.HardwareAddr{mac4}, remove)
+	require.NotContains(t, state.MACs, mac1.String())
+}
+
+// TestEVPNPath verifies that the advertised EVPN routes are found back in the table of their VNI only.
+func TestEVPNPath(t *testing.T) {
+	s := NewServer()
+	s.asn = 4200000000
+	s.routerID = mustParseIP("192.0.2.2")
+
+	mac := mustParseMAC("00:16:3e:00:00:01")
+	paths := []evpnPath{
+		{vni: 100000, vtep: mustParseIP("192.0.2.2")},
+		{vni: 100000, vtep: mustParseIP("192.0.2.2"), mac: mac},
+		{vni: 100000, vtep: mustParseIP("192.0.2.2"), mac: mac, ip: mustParseIP("198.51.100.10")},
+		{vni: 100000, vtep: mustParseIP("192.0.2.2"), mac: mac, ip: mustParseIP("2001:db8::10")},
+	}
+
+	for _, path := range paths {
+		p, err := s.evpnAPIPath(path)
+		require.NoError(t, err)
+		require.Equal(t, bgpAPI.Family_AFI_L2VPN, p.Family.Afi)
+
+		nlri, err := p.Nlri.UnmarshalNew()
+		require.NoError(t, err)
+
+		route, ok := nlri.(*bgpAPI.EVPNMACIPAdvertisementRoute)
+		if ok && path.ip != nil {
+			require.Equal(t, path.ip.String(), route.IpAddress)
+		} else if ok {
+			require.Empty(t, route.IpAddress)
+		}
+
+		r, err := parseEVPNPath(p, 100000)
+		require.NoError(t, err)
+		require.Equal(t, &evpnRoute{mac: path.mac, vtep: mustParseIP("192.0.2.2"), sequence: -1}, r)
+
+		_, err = parseEVPNPath(p, 100001)
+		require.Error(t, err)
+	}
+}
+
+// TestSyncEVPN verifies the routes advertised while the listener isn't running.
+func TestSyncEVPN(t *testing.T) {
+	s := NewServer()
+	mac1 := mustParseMAC("00:16:3e:00:00:01")
+	mac2 := mustParseMAC("00:16:3e:00:00:02")
+
+	ips := map[string][]net.IP{
+		mac1.String(): {mustParseIP("198.51.100.1"), mustParseIP("2001:db8::1")},
+		mac2.String(): {mustParseIP("198.51.100.2")},
+	}
+
+	// Each MAC address gets a MAC only route and a route per IP address.
+	state, err := s.SyncEVPN(10, mustParseIP("192.0.2.2"), []net.HardwareAddr{mac1, mac2}, ips, "owner")
+	require.NoError(t, err)
+	require.Empty(t, state.Flood)
+	require.Empty(t, state.MACs)
+	require.Len(t, s.evpnPaths, 6)
+
+	// IP addresses that went away are withdrawn.
+	ips[mac1.String()] = []net.IP{mustParseIP("198.51.100.1")}
+	_, err = s.SyncEVPN(10, mustParseIP("192.0.2.2"), []net.HardwareAddr{mac1, mac2}, ips, "owner")
+	require.NoError(t, err)
+	require.Len(t, s.evpnPaths, 5)
+
+	// Changing the VNI replaces all the routes.
+	_, err = s.SyncEVPN(11, mustParseIP("192.0.2.2"), []net.HardwareAddr{mac2}, ips, "owner")
+	require.NoError(t, err)
+	require.Len(t, s.evpnPaths, 3)
+	for _, p := range s.evpnPaths {
+		require.Equal(t, uint32(11), p.vni)
+	}
+
+	err = s.SetEVPNPeers([]net.IP{mustParseIP("192.0.2.1"), mustParseIP("192.0.2.3")}, "owner")
+	require.NoError(t, err)
+	require.Len(t, s.evpnPeers, 2)
+
+	err = s.AddPeer(mustParseIP("192.0.2.1"), 65000, "", 0)
+	require.Error(t, err)
+
+	err = s.SetEVPNPeers([]net.IP{mustParseIP("192.0.2.3")}, "owner")
+	require.NoError(t, err)
+	require.Len(t, s.evpnPeers, 1)
+
+	err = s.RemoveEVPNByOwner("owner")
+	require.NoError(t, err)
+	require.Empty(t, s.evpnPaths)
+	require.Empty(t, s.evpnPeers)
+}
@@ -0,0 +1,417 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+
+	bgpAPI "github.com/osrg/gobgp/v3/api"
+
+	"github.com/canonical/lxd/lxd/ip"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// routeImport represents the prefixes imported as host routes from a BGP peer.
+type routeImport struct {
+	owner    string
+	address  net.IP
+	prefixes []net.IPNet
+	device   string
+}
+
+// importedRoute represents a host route installed for a prefix received from a BGP peer.
+type importedRoute struct {
+	prefix  net.IPNet
+	nexthop net.IP
+	device  string
+	peer    string
+}
+
+// receivedPrefix represents a prefix exchanged with a BGP peer.
+type receivedPrefix struct {
+	prefix  net.IPNet
+	nexthop net.IP
+}
+
+// PeerInfo represents the state of a BGP peer.
+type PeerInfo struct {
+	State      string
+	BFDState   string
+	Received   []PrefixInfo
+	Advertised []PrefixInfo
+}
+
+// PrefixInfo represents a prefix exchanged with a BGP peer.
+type PrefixInfo struct {
+	Prefix   net.IPNet
+	Nexthop  net.IP
+	Imported bool
+}
+
+// AddImport imports the prefixes received from the given peer that are within the given subnets as host routes through the device.
+func (s *Server) AddImport(address net.IP, prefixes []net.IPNet, device string, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.imports[owner+"/"+address.String()] = routeImport{
+		owner:    owner,
+		address:  address,
+		prefixes: prefixes,
+		device:   device,
+	}
+
+	return s.updateImportedRoutes()
+}
+
+// RemoveImport stops importing the prefixes received from the given peer.
+func (s *Server) RemoveImport(address net.IP, owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.imports, owner+"/"+address.String())
+
+	return s.updateImportedRoutes()
+}
+
+// RemoveImportByOwner stops importing the prefixes for the provided owner.
+func (s *Server) RemoveImportByOwner(owner string) error {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	maps.DeleteFunc(s.imports, func(_ string, imp routeImport) bool { return imp.owner == owner })
+
+	return s.updateImportedRoutes()
+}
+
+// triggerImport schedules an update of the imported routes.
+func (s *Server) triggerImport() {
+	select {
+	case s.importEvents <- struct{}{}:
+	default:
+	}
+}
+
+// importWorker updates the imported routes and notifies the EVPN handlers on changes until the context is cancelled.
+func (s *Server) importWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.importEvents:
+		}
+
+		s.mu.Lock()
+		err := s.updateImportedRoutes()
+		s.mu.Unlock()
+
+		if err != nil {
+			logger.Warn("Failed updating imported BGP routes", logger.Ctx{"err": err})
+		}
+
+		s.notifyEVPN()
+	}
+}
+
+// updateImportedRoutes installs the host routes for the prefixes received from the peers and removes the stale ones.
+func (s *Server) updateImportedRoutes() error {
+	if len(s.imports) == 0 && len(s.routes) == 0 {
+		return nil
+	}
+
+	received := map[string][]receivedPrefix{}
+	for _, imp := range s.imports {
+		addrStr := imp.address.String()
+
+		_, found := received[addrStr]
+		if found {
+			continue
+		}
+
+		prefixes, err := s.receivedPrefixes(addrStr)
+		if err != nil {
+			return fmt.Errorf("Failed listing prefixes received from %q: %w", addrStr, err)
+		}
+
+		received[addrStr] = prefixes
+	}
+
+	s.applyImportedRoutes(importRoutes(s.imports, received))
+
+	return nil
+}
+
+// receivedPrefixes returns the prefixes received from the given peer if the session is established.
+func (s *Server) receivedPrefixes(addrStr string) ([]receivedPrefix, error) {
+	if s.bgp == nil {
+		return nil, nil
+	}
+
+	// Routes from peers brought down by BFD are withdrawn right away.
+	bfdPeer, found := s.bfdPeers[addrStr]
+	if found && bfdPeer.disabled {
+		return nil, nil
+	}
+
+	state, err := s.peerState(addrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if state != bgpAPI.PeerState_ESTABLISHED {
+		return nil, nil
+	}
+
+	return s.listPrefixes(bgpAPI.TableType_ADJ_IN, addrStr)
+}
+
+// peerState returns the state of the BGP session with the given peer.
+func (s *Server) peerState(addrStr string) (bgpAPI.PeerState_SessionState, error) {
+	state := bgpAPI.PeerState_UNKNOWN
+	err := s.bgp.ListPeer(context.Background(), &bgpAPI.ListPeerRequest{Address: addrStr}, func(p *bgpAPI.Peer) {
+		if p.State != nil {
+			state = p.State.SessionState
+		}
+	})
+	if err != nil {
+		return bgpAPI.PeerState_UNKNOWN, err
+	}
+
+	return state, nil
+}
+
+// listPrefixes returns the prefixes of the given table for the given peer.
+func (s *Server) listPrefixes(tableType bgpAPI.TableType, addrStr string) ([]receivedPrefix, error) {
+	prefixes := []receivedPrefix{}
+	for _, afi := range []bgpAPI.Family_Afi{bgpAPI.Family_AFI_IP, bgpAPI.Family_AFI_IP6} {
+		req := &bgpAPI.ListPathRequest{
+			TableType: tableType,
+			Name:      addrStr,
+			Family:    &bgpAPI.Family{Afi: afi, Safi: bgpAPI.Family_SAFI_UNICAST},
+		}
+
+		err := s.bgp.ListPath(context.Background(), req, func(d *bgpAPI.Destination) {
+			_, subnet, err := net.ParseCIDR(d.Prefix)
+			if err != nil {
+				return
+			}
+
+			for _, p := range d.Paths {
+				if p.IsWithdraw {
+					continue
+				}
+
+				prefixes = append(prefixes, receivedPrefix{prefix: *subnet, nexthop: pathNextHop(p)})
+				break
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(prefixes, func(a, b receivedPrefix) int {
+		return strings.Compare(a.prefix.String(), b.prefix.String())
+	})
+
+	return prefixes, nil
+}
+
+// pathNextHop returns the next hop of a BGP path.
+func pathNextHop(p *bgpAPI.Path) net.IP {
+	for _, attr := range p.Pattrs {
+		msg, err := attr.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+
+		switch a := msg.(type) {
+		case *bgpAPI.NextHopAttribute:
+			return net.ParseIP(a.NextHop)
+		case *bgpAPI.MpReachNLRIAttribute:
+			if len(a.NextHops) > 0 {
+				return net.ParseIP(a.NextHops[0])
+			}
+		}
+	}
+
+	return nil
+}
+
+// importRoutes returns the host routes for the received prefixes allowed by the imports, keyed by prefix.
+func importRoutes(imports map[string]routeImport, received map[string][]receivedPrefix) map[string]importedRoute {
+	routes := map[string]importedRoute{}
+
+	// Go through the imports in a stable order so the same peer wins for prefixes received from multiple peers.
+	for _, key := range slices.Sorted(maps.Keys(imports)) {
+		imp := imports[key]
+		addrStr := imp.address.String()
+
+		for _, r := range received[addrStr] {
+			// The next hop must be usable for a host route.
+			if r.nexthop == nil || r.nexthop.IsUnspecified() || (r.nexthop.To4() == nil) != (r.prefix.IP.To4() == nil) {
+				continue
+			}
+
+			if !slices.ContainsFunc(imp.prefixes, func(subnet net.IPNet) bool { return subnetContains(subnet, r.prefix) }) {
+				continue
+			}
+
+			_, found := routes[r.prefix.String()]
+			if found {
+				continue
+			}
+
+			routes[r.prefix.String()] = importedRoute{
+				prefix:  r.prefix,
+				nexthop: r.nexthop,
+				device:  imp.device,
+				peer:    addrStr,
+			}
+		}
+	}
+
+	return routes
+}
+
+// subnetContains returns whether the inner subnet is fully contained within the outer subnet.
+func subnetContains(outer net.IPNet, inner net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+
+	return outerBits == innerBits && innerOnes >= outerOnes && outer.Contains(inner.IP)
+}
+
+// applyImportedRoutes brings the installed host routes in line with the given routes.
+func (s *Server) applyImportedRoutes(routes map[string]importedRoute) {
+	// Remove the routes that went away or moved to another device.
+	for key, route := range s.routes {
+		newRoute, found := routes[key]
+		if found && newRoute.device == route.device {
+			continue
+		}
+
+		err := s.routeDelete(route)
+		if err != nil {
+			logger.Warn("Failed removing imported BGP route", logger.Ctx{"prefix": key, "err": err})
+		}
+
+		delete(s.routes, key)
+	}
+
+	// Add the new routes and update the changed ones.
+	for key, route := range routes {
+		oldRoute, found := s.routes[key]
+		if found && oldRoute.nexthop.Equal(route.nexthop) {
+			// Keep track of the current peer.
+			s.routes[key] = route
+			continue
+		}
+
+		err := s.routeReplace(route)
+		if err != nil {
+			logger.Warn("Failed installing imported BGP route", logger.Ctx{"prefix": key, "nexthop": route.nexthop.String(), "err": err})
+			continue
+		}
+
+		s.routes[key] = route
+	}
+}
+
+// importedRouteReplace installs or updates a host route.
+func importedRouteReplace(route importedRoute) error {
+	r := &ip.Route{
+		DevName: route.device,
+		Proto:   "bgp",
+		Family:  ip.FamilyV4,
+	}
+
+	if route.prefix.IP.To4() == nil {
+		r.Family = ip.FamilyV6
+	}
+
+	return r.Replace([]string{route.prefix.String(), "via", route.nexthop.String()})
+}
+
+// importedRouteDelete removes a host route.
+func importedRouteDelete(route importedRoute) error {
+	r := &ip.Route{
+		DevName: route.device,
+		Route:   route.prefix.String(),
+		Table:   "main",
+		Family:  ip.FamilyV4,
+	}
+
+	if route.prefix.IP.To4() == nil {
+		r.Family = ip.FamilyV6
+	}
+
+	return r.Delete()
+}
+
+// PeerInfo returns the state of the session with the given peer along with the prefixes exchanged with it.
+func (s *Server) PeerInfo(address net.IP) (*PeerInfo, error) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addrStr := address.String()
+
+	_, found := s.peers[addrStr]
+	if !found {
+		return nil, ErrPeerNotFound
+	}
+
+	info := &PeerInfo{
+		State:      strings.ToLower(bgpAPI.PeerState_IDLE.String()),
+		Received:   []PrefixInfo{},
+		Advertised: []PrefixInfo{},
+	}
+
+	bfdPeer, found := s.bfdPeers[addrStr]
+	if found {
+		info.BFDState = bfdPeer.session.State().String()
+	}
+
+	if s.bgp == nil {
+		return info, nil
+	}
+
+	state, err := s.peerState(addrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	info.State = strings.ToLower(state.String())
+	if state != bgpAPI.PeerState_ESTABLISHED {
+		return info, nil
+	}
+
+	received, err := s.listPrefixes(bgpAPI.TableType_ADJ_IN, addrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range received {
+		route, found := s.routes[r.prefix.String()]
+		imported := found && route.peer == addrStr && route.nexthop.Equal(r.nexthop)
+
+		info.Received = append(info.Received, PrefixInfo{Prefix: r.prefix, Nexthop: r.nexthop, Imported: imported})
+	}
+
+	advertised, err := s.listPrefixes(bgpAPI.TableType_ADJ_OUT, addrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range advertised {
+		info.Advertised = append(info.Advertised, PrefixInfo{Prefix: r.prefix, Nexthop: r.nexthop})
+	}
+
+	return info, nil
+}
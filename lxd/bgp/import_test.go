@@ -0,0 +1,103 @@
+package bgp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSubnetContains verifies the matching of received prefixes against the import filters.
+func TestSubnetContains(t *testing.T) {
+	require.True(t, subnetContains(mustParseCIDR("10.0.0.0/8"), mustParseCIDR("10.1.0.0/16")))
+	require.True(t, subnetContains(mustParseCIDR("10.0.0.0/8"), mustParseCIDR("10.0.0.0/8")))
+	require.True(t, subnetContains(mustParseCIDR("0.0.0.0/0"), mustParseCIDR("192.0.2.0/24")))
+	require.False(t, subnetContains(mustParseCIDR("10.1.0.0/16"), mustParseCIDR("10.0.0.0/8")))
+	require.False(t, subnetContains(mustParseCIDR("10.0.0.0/8"), mustParseCIDR("192.0.2.0/24")))
+	require.False(t, subnetContains(mustParseCIDR("::/0"), mustParseCIDR("192.0.2.0/24")))
+	require.True(t, subnetContains(mustParseCIDR("2001:db8::/32"), mustParseCIDR("2001:db8:1::/48")))
+}
+
+// TestImportRoutes verifies that only the allowed prefixes are imported and that the first import wins.
+func TestImportRoutes(t *testing.T) {
+	imports := map[string]routeImport{
+		"network_1/192.0.2.1": {
+			owner:    "network_1",
+			address:  mustParseIP("192.0.2.1"),
+			prefixes: []net.IPNet{mustParseCIDR("10.0.0.0/8"), mustParseCIDR("2001:db8::/32")},
+			device:   "br0",
+		},
+		"network_2/192.0.2.2": {
+			owner:    "network_2",
+			address:  mustParseIP("192.0.2.2"),
+			prefixes: []net.IPNet{mustParseCIDR("0.0.0.0/0")},
+			device:   "br1",
+		},
+	}
+
+	received := map[string][]receivedPrefix{
+		"192.0.2.1": {
+			{prefix: mustParseCIDR("10.1.0.0/16"), nexthop: mustParseIP("192.0.2.1")},
+			{prefix: mustParseCIDR("198.51.100.0/24"), nexthop: mustParseIP("192.0.2.1")},
+			{prefix: mustParseCIDR("2001:db8:1::/48"), nexthop: mustParseIP("2001:db8::1")},
+			{prefix: mustParseCIDR("2001:db8:2::/48"), nexthop: mustParseIP("192.0.2.1")},
+		},
+		"192.0.2.2": {
+			{prefix: mustParseCIDR("10.1.0.0/16"), nexthop: mustParseIP("192.0.2.2")},
+			{prefix: mustParseCIDR("203.0.113.0/24"), nexthop: mustParseIP("192.0.2.2")},
+			{prefix: mustParseCIDR("203.0.114.0/24"), nexthop: nil},
+		},
+	}
+
+	routes := importRoutes(imports, received)
+	require.Equal(t, map[string]importedRoute{
+		"10.1.0.0/16":     {prefix: mustParseCIDR("10.1.0.0/16"), nexthop: mustParseIP("192.0.2.1"), device: "br0", peer: "192.0.2.1"},
+		"2001:db8:1::/48": {prefix: mustParseCIDR("2001:db8:1::/48"), nexthop: mustParseIP("2001:db8::1"), device: "br0", peer: "192.0.2.1"},
+		"203.0.113.0/24":  {prefix: mustParseCIDR("203.0.113.0/24"), nexthop: mustParseIP("192.0.2.2"), device: "br1", peer: "192.0.2.2"},
+	}, routes)
+}
+
+// TestApplyImportedRoutes verifies that only the changed host routes are installed or removed.
+func TestApplyImportedRoutes(t *testing.T) {
+	s := NewServer()
+
+	var replaced []string
+	var deleted []string
+	s.routeReplace = func(route importedRoute) error {
+		replaced = append(replaced, route.prefix.String()+" via "+route.nexthop.String())
+		return nil
+	}
+
+	s.routeDelete = func(route importedRoute) error {
+		deleted = append(deleted, route.prefix.String())
+		return nil
+	}
+
+	route1 := importedRoute{prefix: mustParseCIDR("10.1.0.0/16"), nexthop: mustParseIP("192.0.2.1"), device: "br0", peer: "192.0.2.1"}
+	route2 := importedRoute{prefix: mustParseCIDR("10.2.0.0/16"), nexthop: mustParseIP("192.0.2.1"), device: "br0", peer: "192.0.2.1"}
+
+	s.applyImportedRoutes(map[string]importedRoute{"10.1.0.0/16": route1, "10.2.0.0/16": route2})
+	require.ElementsMatch(t, []string{"10.1.0.0/16 via 192.0.2.1", "10.2.0.0/16 via 192.0.2.1"}, replaced)
+	require.Empty(t, deleted)
+
+	// Unchanged routes are left alone, changed next hops are replaced and missing routes are removed.
+	replaced = nil
+	route1.nexthop = mustParseIP("192.0.2.2")
+	s.applyImportedRoutes(map[string]importedRoute{"10.1.0.0/16": route1})
+	require.Equal(t, []string{"10.1.0.0/16 via 192.0.2.2"}, replaced)
+	require.Equal(t, []string{"10.2.0.0/16"}, deleted)
+	require.Len(t, s.routes, 1)
+
+	// Nothing is received while the listener isn't running, so the routes are removed.
+	replaced = nil
+	deleted = nil
+	err := s.AddImport(mustParseIP("192.0.2.1"), []net.IPNet{mustParseCIDR("10.0.0.0/8")}, "br0", "owner")
+	require.NoError(t, err)
+	require.Empty(t, replaced)
+	require.Equal(t, []string{"10.1.0.0/16"}, deleted)
+	require.Empty(t, s.routes)
+
+	err = s.RemoveImportByOwner("owner")
+	require.NoError(t, err)
+	require.Empty(t, s.imports)
+}
@@ -32,6 +32,23 @@ type Server struct {
 	paths    map[string]path
 	peers    map[string]peer
 
+	// Route import.
+	imports      map[string]routeImport
+	routes       map[string]importedRoute
+	importEvents chan struct{}
+	watchCancel  context.CancelFunc
+	routeReplace func(route importedRoute) error
+	routeDelete  func(route importedRoute) error
+
+	// BFD.
+	bfdListener *bfdListener
+	bfdPeers    map[string]*bfdPeer
+
+	// EVPN.
+	evpnPaths    map[string]evpnPath
+	evpnPeers    map[string]*evpnPeer
+	evpnHandlers map[string]func()
+
 	mu sync.Mutex
 }
 
@@ -53,8 +70,17 @@ type peer struct {
 func NewServer() *Server {
 	// Setup new struct.
 	s := &Server{
-		paths: map[string]path{},
-		peers: map[string]peer{},
+		paths:        map[string]path{},
+		peers:        map[string]peer{},
+		imports:      map[string]routeImport{},
+		routes:       map[string]importedRoute{},
+		importEvents: make(chan struct{}, 1),
+		routeReplace: importedRouteReplace,
+		routeDelete:  importedRouteDelete,
+		bfdPeers:     map[string]*bfdPeer{},
+		evpnPaths:    map[string]evpnPath{},
+		evpnPeers:    map[string]*evpnPeer{},
+		evpnHandlers: map[string]func(){},
 	}
 
 	return s
@@ -97,8 +123,8 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 		RouterId: routerID.String(),
 		Asn:      asn,
 
-		// Always setup for IPv4 and IPv6.
-		Families: []uint32{0, 1},
+		// Always setup for IPv4, IPv6 and EVPN.
+		Families: []uint32{0, 1, 9},
 
 		// Listen address.
 		ListenAddresses: []string{addrHost},
@@ -111,6 +137,24 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 		return err
 	}
 
+	// Keep the imported routes in sync with the peers and the prefixes they send.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+	watchReq := &bgpAPI.WatchEventRequest{
+		Peer: &bgpAPI.WatchEventRequest_Peer{},
+		Table: &bgpAPI.WatchEventRequest_Table{
+			Filters: []*bgpAPI.WatchEventRequest_Table_Filter{{Type: bgpAPI.WatchEventRequest_Table_Filter_ADJIN}},
+		},
+	}
+
+	err = s.bgp.WatchEvent(watchCtx, watchReq, func(_ *bgpAPI.WatchEventResponse) { s.triggerImport() })
+	if err != nil {
+		watchCancel()
+		return err
+	}
+
+	s.watchCancel = watchCancel
+	go s.importWorker(watchCtx)
+
 	// Copy the path list
 	oldPaths := map[string]path{}
 	maps.Copy(oldPaths, s.paths)
@@ -142,6 +186,9 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 	s.asn = asn
 	s.routerID = routerID
 
+	// Add existing EVPN peers and routes.
+	s.restoreEVPN()
+
 	return nil
 }
 
@@ -167,6 +214,20 @@ func (s *Server) stop() error {
 	// Restore peer list.
 	s.peers = oldPeers
 
+	// Remove the EVPN peers.
+	for addrStr := range s.evpnPeers {
+		err := s.bgp.DeletePeer(context.Background(), &bgpAPI.DeletePeerRequest{Address: addrStr})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stop watching for changes.
+	if s.watchCancel != nil {
+		s.watchCancel()
+		s.watchCancel = nil
+	}
+
 	// Stop the listener.
 	err := s.bgp.StopBgp(context.Background(), &bgpAPI.StopBgpRequest{})
 	if err != nil {
@@ -179,7 +240,8 @@ func (s *Server) stop() error {
 	s.routerID = nil
 	s.bgp = nil
 
-	return nil
+	// Remove the imported routes.
+	return s.updateImportedRoutes()
 }
 
 // Configure updates the listener with a new configuration..
@@ -391,6 +453,11 @@ func (s *Server) AddPeer(address net.IP, asn uint32, password string, holdTime u
 func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime uint64) error {
 	addrStr := address.String()
 
+	_, found := s.evpnPeers[addrStr]
+	if found {
+		return fmt.Errorf("Peer %q already used as an EVPN peer", addrStr)
+	}
+
 	// Look for an existing peer.
 	bgpPeer, bgpPeerExists := s.peers[addrStr]
 	if bgpPeerExists {
@@ -469,6 +536,15 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 		if err != nil {
 			return err
 		}
+
+		// Keep the session down while the BFD session with the peer is down.
+		bfdPeer, found := s.bfdPeers[addrStr]
+		if found && bfdPeer.disabled {
+			err := s.bgp.DisablePeer(context.Background(), &bgpAPI.DisablePeerRequest{Address: addrStr, Communication: "BFD session down"})
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// Add the peer to the list.
@@ -57,6 +57,11 @@ func (c *Config) BackupsCompressionAlgorithm() string {
 	return c.m.GetString("backups.compression_algorithm")
 }
 
+// BackupsTargetS3 returns the settings needed to connect to the S3 backup target.
+func (c *Config) BackupsTargetS3() (bucketURL string, accessKey string, secretKey string) {
+	return c.m.GetString("backups.target.s3.url"), c.m.GetString("backups.target.s3.access_key"), c.m.GetString("backups.target.s3.secret_key")
+}
+
 // MetricsAuthentication checks whether metrics API requires authentication.
 func (c *Config) MetricsAuthentication() bool {
 	return c.m.GetBool("core.metrics_authentication")
@@ -343,6 +348,32 @@ var ConfigSchema = config.Schema{
 		//  shortdesc: Compression algorithm to use for backups
 		"backups.compression_algorithm": {Default: "gzip", Validator: validate.IsCompressionAlgorithm},
 
+		// lxdmeta:generate(entities=server; group=miscellaneous; key=backups.target.s3.url)
+		// Specify the URL of the bucket in the form `https://<host>[:<port>]/<bucket>[/<prefix>]`.
+		// Backups created with the `s3` target are stored as objects in this bucket.
+		// Buckets on the LXD server itself can be used, in which case the server certificate is trusted.
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: URL of the S3 bucket used as backup target
+		"backups.target.s3.url": {Validator: validate.Optional(validate.IsRequestURL)},
+
+		// lxdmeta:generate(entities=server; group=miscellaneous; key=backups.target.s3.access_key)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Access key of the S3 backup target
+		"backups.target.s3.access_key": {},
+
+		// lxdmeta:generate(entities=server; group=miscellaneous; key=backups.target.s3.secret_key)
+		//
+		// ---
+		//  type: string
+		//  scope: global
+		//  shortdesc: Secret key of the S3 backup target
+		"backups.target.s3.secret_key": {},
+
 		// lxdmeta:generate(entities=server; group=cluster; key=cluster.offline_threshold)
 		// Specify the number of seconds after which an unresponsive member is considered offline.
 		// ---
@@ -1481,6 +1481,7 @@ func (d *Daemon) init() error {
 		ClusterAddress:       localClusterAddress,
 		DebugAddress:         debugAddress,
 		MetricsServer:        metricsServer(d),
+		StorageBucketsServer: storageBucketsServer(d),
 		VsockServer:          vSockServer(d),
 		VsockSupport:         false,
 	}
@@ -1777,6 +1778,7 @@ func (d *Daemon) init() error {
 		// Fill in the zone information.
 		resp := &dns.Zone{}
 		resp.Info = *zoneInfo
+		resp.DNSSECKey = zone.DNSSECKey()
 
 		if full {
 			// Full content was requested.
@@ -1799,6 +1801,14 @@ func (d *Daemon) init() error {
 		}
 
 		return resp, nil
+	}, func(name string, changes []dns.ZoneChange) error {
+		// Fetch the zone.
+		zone, err := networkZone.LoadByName(d.shutdownCtx, d.State(), name)
+		if err != nil {
+			return err
+		}
+
+		return zone.ApplyUpdate(d.shutdownCtx, changes)
 	})
 
 	// Setup the networks.
@@ -1841,6 +1851,14 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	storageBucketsAddress := d.localConfig.StorageBucketsAddress()
+	if storageBucketsAddress != "" {
+		err = d.endpoints.UpStorageBuckets(storageBucketsAddress)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Apply all patches that need to be run after networks are initialised.
 	err = patchesApply(d, patchPostNetworks)
 	if err != nil {
@@ -1964,6 +1982,9 @@ func (d *Daemon) init() error {
 		// Prune expired custom volume snapshots and take snapshots of custom volumes (minutely check of configurable cron expression)
 		d.tasks.Add(pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d.State))
 
+		// Replicate custom volumes to their target pools (minutely check of configurable cron expression)
+		d.tasks.Add(autoReplicateCustomVolumesTask(d.State))
+
 		// Remove resolved warnings (daily)
 		d.tasks.Add(pruneResolvedWarningsTask(d.State))
 
@@ -1975,6 +1996,15 @@ func (d *Daemon) init() error {
 
 		// Run scheduled replicators (minutely check of configurable cron expression)
 		d.tasks.Add(runScheduledReplicatorsTask(d.State))
+
+		// Run replicators with a recovery point objective continuously (every 10s check for completed runs)
+		d.tasks.Add(runContinuousReplicatorsTask(d.State))
+
+		// Check the health of network load balancer backends (every 5s check of configurable interval)
+		d.tasks.Add(healthCheckNetworkLoadBalancersTask(d.State))
+
+		// Sync the EVPN routes of the bridge networks in EVPN mode (every 5s)
+		d.tasks.Add(syncEVPNNetworksTask(d.State))
 	}
 
 	// Load Ubuntu Pro configuration before starting any instances.
@@ -2097,6 +2127,9 @@ func (d *Daemon) startClusterTasks() {
 	// Refresh cluster link volatile addresses (daily).
 	d.clusterTasks.Add(autoRefreshClusterLinkVolatileAddressesTask(d.State))
 
+	// Check placement groups for compliance and rebalance them if needed.
+	d.clusterTasks.Add(autoRebalancePlacementGroupsTask(d.State))
+
 	// Start all background tasks
 	d.clusterTasks.Start(d.shutdownCtx)
 }
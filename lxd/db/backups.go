@@ -24,6 +24,8 @@ type InstanceBackup struct {
 	InstanceOnly         bool
 	OptimizedStorage     bool
 	CompressionAlgorithm string
+	Target               string
+	TargetLocation       string
 }
 
 // StoragePoolVolumeBackup is a value object holding all db-related details about a storage volume backup.
@@ -36,6 +38,8 @@ type StoragePoolVolumeBackup struct {
 	VolumeOnly           bool
 	OptimizedStorage     bool
 	CompressionAlgorithm string
+	Target               string
+	TargetLocation       string
 }
 
 // Returns the ID of the instance backup with the given name.
@@ -63,7 +67,8 @@ func (c *ClusterTx) GetInstanceBackup(ctx context.Context, projectName string, n
 	q := `
 SELECT instances_backups.id, instances_backups.instance_id,
        instances_backups.creation_date, instances_backups.expiry_date,
-       instances_backups.container_only, instances_backups.optimized_storage
+       instances_backups.container_only, instances_backups.optimized_storage,
+       instances_backups.target, instances_backups.target_location
     FROM instances_backups
     JOIN instances ON instances.id=instances_backups.instance_id
     JOIN projects ON projects.id=instances.project_id
@@ -71,7 +76,7 @@ SELECT instances_backups.id, instances_backups.instance_id,
 `
 	arg1 := []any{projectName, name}
 	arg2 := []any{&args.ID, &args.InstanceID, &args.CreationDate,
-		&args.ExpiryDate, &instanceOnlyInt, &optimizedStorageInt}
+		&args.ExpiryDate, &instanceOnlyInt, &optimizedStorageInt, &args.Target, &args.TargetLocation}
 
 	err := dbQueryRowScan(ctx, c, q, arg1, arg2)
 	if err != nil {
@@ -103,7 +108,8 @@ func (c *ClusterTx) GetInstanceBackupWithID(ctx context.Context, backupID int) (
 	q := `
 SELECT instances_backups.name, instances_backups.instance_id,
        instances_backups.creation_date, instances_backups.expiry_date,
-       instances_backups.container_only, instances_backups.optimized_storage
+       instances_backups.container_only, instances_backups.optimized_storage,
+       instances_backups.target, instances_backups.target_location
     FROM instances_backups
     JOIN instances ON instances.id=instances_backups.instance_id
     JOIN projects ON projects.id=instances.project_id
@@ -111,7 +117,7 @@ SELECT instances_backups.name, instances_backups.instance_id,
 `
 	arg1 := []any{backupID}
 	arg2 := []any{&args.Name, &args.InstanceID, &args.CreationDate,
-		&args.ExpiryDate, &instanceOnlyInt, &optimizedStorageInt}
+		&args.ExpiryDate, &instanceOnlyInt, &optimizedStorageInt, &args.Target, &args.TargetLocation}
 
 	err := dbQueryRowScan(ctx, c, q, arg1, arg2)
 	if err != nil {
@@ -178,7 +184,7 @@ func (c *ClusterTx) CreateInstanceBackup(ctx context.Context, args InstanceBacku
 		optimizedStorageInt = 1
 	}
 
-	str := "INSERT INTO instances_backups (instance_id, name, creation_date, expiry_date, container_only, optimized_storage) VALUES (?, ?, ?, ?, ?, ?)"
+	str := "INSERT INTO instances_backups (instance_id, name, creation_date, expiry_date, container_only, optimized_storage, target, target_location) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 	stmt, err := c.tx.Prepare(str)
 	if err != nil {
 		return err
@@ -187,7 +193,7 @@ func (c *ClusterTx) CreateInstanceBackup(ctx context.Context, args InstanceBacku
 	defer func() { _ = stmt.Close() }()
 	result, err := stmt.Exec(args.InstanceID, args.Name,
 		args.CreationDate.Unix(), args.ExpiryDate.Unix(), instanceOnlyInt,
-		optimizedStorageInt)
+		optimizedStorageInt, args.Target, args.TargetLocation)
 	if err != nil {
 		return err
 	}
@@ -244,14 +250,16 @@ func (c *ClusterTx) RenameInstanceBackup(ctx context.Context, oldName, newName s
 func (c *ClusterTx) GetExpiredInstanceBackups(ctx context.Context) ([]InstanceBackup, error) {
 	var expiredInstanceBackups []InstanceBackup
 
-	q := `SELECT instances_backups.name, instances_backups.expiry_date, instances_backups.instance_id FROM instances_backups`
+	q := `SELECT instances_backups.name, instances_backups.expiry_date, instances_backups.instance_id, instances_backups.target, instances_backups.target_location FROM instances_backups`
 
 	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
 		var name string
 		var expiryDate string
 		var instanceID int
+		var target string
+		var targetLocation string
 
-		err := scan(&name, &expiryDate, &instanceID)
+		err := scan(&name, &expiryDate, &instanceID, &target, &targetLocation)
 		if err != nil {
 			return err
 		}
@@ -272,9 +280,11 @@ func (c *ClusterTx) GetExpiredInstanceBackups(ctx context.Context) ([]InstanceBa
 		// Backup has expired
 		if time.Now().Unix()-backupExpiry.Unix() >= 0 {
 			expiredInstanceBackups = append(expiredInstanceBackups, InstanceBackup{
-				Name:       name,
-				InstanceID: instanceID,
-				ExpiryDate: backupExpiry,
+				Name:           name,
+				InstanceID:     instanceID,
+				ExpiryDate:     backupExpiry,
+				Target:         target,
+				TargetLocation: targetLocation,
 			})
 		}
 
@@ -291,13 +301,13 @@ func (c *ClusterTx) GetExpiredInstanceBackups(ctx context.Context) ([]InstanceBa
 func (c *ClusterTx) GetExpiredStorageVolumeBackups(ctx context.Context) ([]StoragePoolVolumeBackup, error) {
 	var backups []StoragePoolVolumeBackup
 
-	q := `SELECT storage_volumes_backups.name, storage_volumes_backups.expiry_date, storage_volumes_backups.storage_volume_id FROM storage_volumes_backups`
+	q := `SELECT storage_volumes_backups.name, storage_volumes_backups.expiry_date, storage_volumes_backups.storage_volume_id, storage_volumes_backups.target, storage_volumes_backups.target_location FROM storage_volumes_backups`
 
 	err := query.Scan(ctx, c.Tx(), q, func(scan func(dest ...any) error) error {
 		var b StoragePoolVolumeBackup
 		var expiryTime sql.NullTime
 
-		err := scan(&b.Name, &expiryTime, &b.VolumeID)
+		err := scan(&b.Name, &expiryTime, &b.VolumeID, &b.Target, &b.TargetLocation)
 		if err != nil {
 			return err
 		}
@@ -335,7 +345,9 @@ func (c *ClusterTx) GetStoragePoolVolumeBackups(ctx context.Context, projectName
 		backups.creation_date,
 		backups.expiry_date,
 		backups.volume_only,
-		backups.optimized_storage
+		backups.optimized_storage,
+		backups.target,
+		backups.target_location
 	FROM storage_volumes_backups AS backups
 	JOIN storage_volumes ON storage_volumes.id=backups.storage_volume_id
 	JOIN projects ON projects.id=storage_volumes.project_id
@@ -349,7 +361,7 @@ func (c *ClusterTx) GetStoragePoolVolumeBackups(ctx context.Context, projectName
 		var b StoragePoolVolumeBackup
 		var expiryTime sql.NullTime
 
-		err := scan(&b.ID, &b.VolumeID, &b.Name, &b.CreationDate, &expiryTime, &b.VolumeOnly, &b.OptimizedStorage)
+		err := scan(&b.ID, &b.VolumeID, &b.Name, &b.CreationDate, &expiryTime, &b.VolumeOnly, &b.OptimizedStorage, &b.Target, &b.TargetLocation)
 		if err != nil {
 			return err
 		}
@@ -412,7 +424,7 @@ func (c *ClusterTx) CreateStoragePoolVolumeBackup(ctx context.Context, args Stor
 		optimizedStorageInt = 1
 	}
 
-	str := "INSERT INTO storage_volumes_backups (storage_volume_id, name, creation_date, expiry_date, volume_only, optimized_storage) VALUES (?, ?, ?, ?, ?, ?)"
+	str := "INSERT INTO storage_volumes_backups (storage_volume_id, name, creation_date, expiry_date, volume_only, optimized_storage, target, target_location) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
 	stmt, err := c.tx.Prepare(str)
 	if err != nil {
 		return err
@@ -421,7 +433,7 @@ func (c *ClusterTx) CreateStoragePoolVolumeBackup(ctx context.Context, args Stor
 	defer func() { _ = stmt.Close() }()
 	result, err := stmt.Exec(args.VolumeID, args.Name,
 		args.CreationDate.Unix(), args.ExpiryDate.Unix(), volumeOnlyInt,
-		optimizedStorageInt)
+		optimizedStorageInt, args.Target, args.TargetLocation)
 	if err != nil {
 		return err
 	}
@@ -475,14 +487,16 @@ SELECT
 	backups.creation_date,
 	backups.expiry_date,
 	backups.volume_only,
-	backups.optimized_storage
+	backups.optimized_storage,
+	backups.target,
+	backups.target_location
 FROM storage_volumes_backups AS backups
 JOIN storage_volumes ON storage_volumes.id=backups.storage_volume_id
 JOIN projects ON projects.id=storage_volumes.project_id
 WHERE projects.name=? AND backups.name=?
 `
 	arg1 := []any{projectName, backupName}
-	outfmt := []any{&args.ID, &args.VolumeID, &args.Name, &args.CreationDate, &args.ExpiryDate, &args.VolumeOnly, &args.OptimizedStorage}
+	outfmt := []any{&args.ID, &args.VolumeID, &args.Name, &args.CreationDate, &args.ExpiryDate, &args.VolumeOnly, &args.OptimizedStorage, &args.Target, &args.TargetLocation}
 
 	err := dbQueryRowScan(ctx, c, q, arg1, outfmt)
 	if err != nil {
@@ -507,14 +521,16 @@ SELECT
 	backups.creation_date,
 	backups.expiry_date,
 	backups.volume_only,
-	backups.optimized_storage
+	backups.optimized_storage,
+	backups.target,
+	backups.target_location
 FROM storage_volumes_backups AS backups
 JOIN storage_volumes ON storage_volumes.id=backups.storage_volume_id
 JOIN projects ON projects.id=storage_volumes.project_id
 WHERE backups.id=?
 `
 	arg1 := []any{backupID}
-	outfmt := []any{&args.ID, &args.VolumeID, &args.Name, &args.CreationDate, &args.ExpiryDate, &args.VolumeOnly, &args.OptimizedStorage}
+	outfmt := []any{&args.ID, &args.VolumeID, &args.Name, &args.CreationDate, &args.ExpiryDate, &args.VolumeOnly, &args.OptimizedStorage, &args.Target, &args.TargetLocation}
 
 	err := dbQueryRowScan(ctx, c, q, arg1, outfmt)
 	if err != nil {
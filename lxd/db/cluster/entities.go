@@ -94,6 +94,7 @@ var entityTypes = map[entity.Type]entityTypeDBInfo{
 	entity.TypeInstanceSnapshot:      entityTypeInstanceSnapshot{},
 	entity.TypeNetwork:               entityTypeNetwork{},
 	entity.TypeNetworkACL:            entityTypeNetworkACL{},
+	entity.TypeNetworkAddressSet:     entityTypeNetworkAddressSet{},
 	entity.TypeClusterMember:         entityTypeClusterMember{},
 	entity.TypeStoragePool:           entityTypeStoragePool{},
 	entity.TypeStorageVolume:         entityTypeStorageVolume{},
@@ -142,6 +143,7 @@ const (
 	entityTypeCodePlacementGroup        int64 = 25
 	entityTypeCodeClusterLink           int64 = 26
 	entityTypeCodeReplicator            int64 = 27
+	entityTypeCodeNetworkAddressSet     int64 = 28
 )
 
 var entityTypeByCode = map[int64]EntityType{
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"fmt"
+)
+
+// entityTypeNetworkAddressSet implements entityTypeDBInfo for a NetworkAddressSet.
+type entityTypeNetworkAddressSet struct {
+	entityTypeCommon
+}
+
+func (e entityTypeNetworkAddressSet) code() int64 {
+	return entityTypeCodeNetworkAddressSet
+}
+
+func (e entityTypeNetworkAddressSet) allURLsQuery() string {
+	return fmt.Sprintf(`
+SELECT %d, networks_address_sets.id, projects.name, '', json_array(networks_address_sets.name) 
+FROM networks_address_sets 
+JOIN projects ON networks_address_sets.project_id = projects.id`, e.code())
+}
+
+func (e entityTypeNetworkAddressSet) urlsByProjectQuery() string {
+	return e.allURLsQuery() + " WHERE projects.name = ?"
+}
+
+func (e entityTypeNetworkAddressSet) urlByIDQuery() string {
+	return e.allURLsQuery() + " WHERE networks_address_sets.id = ?"
+}
+
+func (e entityTypeNetworkAddressSet) idFromURLQuery() string {
+	return `
+SELECT ?, networks_address_sets.id 
+FROM networks_address_sets 
+JOIN projects ON networks_address_sets.project_id = projects.id 
+WHERE projects.name = ? 
+	AND '' = ? 
+	AND networks_address_sets.name = ?`
+}
+
+func (e entityTypeNetworkAddressSet) onDeleteTriggerSQL() (name string, sql string) {
+	name = "on_network_address_set_delete"
+	return name, fmt.Sprintf(`
+CREATE TRIGGER %s
+	AFTER DELETE ON networks_address_sets
+	BEGIN
+	DELETE FROM auth_groups_permissions 
+		WHERE entity_type = %d 
+		AND entity_id = OLD.id;
+	DELETE FROM warnings
+		WHERE entity_type_code = %d
+		AND entity_id = OLD.id;
+	END
+`, name, e.code(), e.code())
+}
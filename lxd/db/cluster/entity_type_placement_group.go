@@ -53,6 +53,9 @@ CREATE TRIGGER ` + name + `
 	DELETE FROM auth_groups_permissions 
 		WHERE entity_type = ` + strconv.Itoa(int(e.code())) + ` 
 		AND entity_id = OLD.id;
+	DELETE FROM warnings
+		WHERE entity_type_code = ` + strconv.Itoa(int(e.code())) + `
+		AND entity_id = OLD.id;
 	END
 `
 }
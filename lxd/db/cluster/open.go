@@ -141,11 +141,6 @@ func EnsureSchema(db *sql.DB, address string, dir string, serverUUID string) err
 			return fmt.Errorf("Failed updating cluster member version info for %q: %w", address, err)
 		}
 
-		err = checkNoLocalStorageBuckets(ctx, tx)
-		if err != nil {
-			return err
-		}
-
 		return checkClusterIsUpgradable(ctx, tx, [2]int{len(updates), apiExtensions})
 	}
 
@@ -269,8 +264,8 @@ func dqliteDriverName() string {
 var dqliteDriverSerial uint64
 
 // checkNoLocalStorageBuckets returns an error if the database contains any storage buckets backed by
-// a local (non-object) storage pool driver. Such buckets are no longer supported and must be removed
-// before the schema can be upgraded.
+// a local (non-object) storage pool driver. Such buckets created by older versions aren't supported and
+// must be removed before the schema can be upgraded.
 func checkNoLocalStorageBuckets(ctx context.Context, tx *sql.Tx) error {
 	// Check whether the storage_buckets table already exists (it may not in very old schemas).
 	var tableName string
@@ -295,7 +290,7 @@ WHERE sp.driver != 'cephobject'
 	}
 
 	if count > 0 {
-		return errors.New("This LXD version no longer supports storage buckets backed by local storage drivers. " +
+		return errors.New("This LXD version does not support storage buckets created on local storage drivers by older versions. " +
 			"This server has one or more such buckets and cannot be upgraded. " +
 			"To continue using those buckets, roll back to the previous LXD version. " +
 			"To proceed with the upgrade, back up and delete all locally-backed storage buckets first, then retry.")
@@ -213,3 +213,220 @@ func UpdateReplicatorLastRunStatus(ctx context.Context, tx *sql.Tx, id int64, st
 	_, err := tx.ExecContext(ctx, `UPDATE replicators SET last_run_status=? WHERE id=?`, status, id)
 	return err
 }
+
+// ReplicatorInstance represents the replication state of an instance for a replicator.
+type ReplicatorInstance struct {
+	InstanceID     int64
+	InstanceName   string
+	LastSyncDate   sql.NullTime
+	LastSyncStatus string
+	LastSnapshot   string
+
+	// Only populated by [GetReplicatorInstancesByNode].
+	ProjectName    string
+	ReplicatorName string
+}
+
+// GetReplicatorInstances returns the replication state of all instances in the given project for the replicator with the given ID.
+// Instances that have never been replicated are included with an empty status.
+func GetReplicatorInstances(ctx context.Context, tx *sql.Tx, replicatorID int64, projectName string) ([]ReplicatorInstance, error) {
+	q := `
+SELECT instances.id, instances.name, replicators_instances.last_sync_date, IFNULL(replicators_instances.last_sync_status, ''), IFNULL(replicators_instances.last_snapshot, '')
+FROM instances
+JOIN projects ON projects.id = instances.project_id
+LEFT JOIN replicators_instances ON replicators_instances.instance_id = instances.id AND replicators_instances.replicator_id = ?
+WHERE projects.name = ?
+ORDER BY instances.name
+`
+
+	var replicatorInstances []ReplicatorInstance
+	err := query.Scan(ctx, tx, q, func(scan func(dest ...any) error) error {
+		var replicatorInstance ReplicatorInstance
+
+		err := scan(&replicatorInstance.InstanceID, &replicatorInstance.InstanceName, &replicatorInstance.LastSyncDate, &replicatorInstance.LastSyncStatus, &replicatorInstance.LastSnapshot)
+		if err != nil {
+			return err
+		}
+
+		replicatorInstances = append(replicatorInstances, replicatorInstance)
+		return nil
+	}, replicatorID, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading replicator instances: %w", err)
+	}
+
+	return replicatorInstances, nil
+}
+
+// GetReplicatorInstanceLastSnapshot returns the name of the snapshot sent by the last successful replication of the
+// instance with the given ID by the replicator with the given ID. An empty string is returned if there is none.
+func GetReplicatorInstanceLastSnapshot(ctx context.Context, tx *sql.Tx, replicatorID int64, instanceID int64) (string, error) {
+	snapshots, err := query.SelectStrings(ctx, tx, `SELECT last_snapshot FROM replicators_instances WHERE replicator_id=? AND instance_id=?`, replicatorID, instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(snapshots) == 0 {
+		return "", nil
+	}
+
+	return snapshots[0], nil
+}
+
+// UpdateReplicatorInstanceSynced records a successful replication of the instance with the given ID by the replicator
+// with the given ID, along with the date of the replicated state and the name of the snapshot that was sent.
+func UpdateReplicatorInstanceSynced(ctx context.Context, tx *sql.Tx, replicatorID int64, instanceID int64, date time.Time, snapshot string) error {
+	_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO replicators_instances (replicator_id, instance_id, last_sync_date, last_sync_status, last_snapshot) VALUES (?, ?, ?, ?, ?)`, replicatorID, instanceID, date, api.ReplicatorStatusCompleted, snapshot)
+	return err
+}
+
+// UpdateReplicatorInstanceStatus updates only the last_sync_status field of the replication state of the instance
+// with the given ID for the replicator with the given ID.
+func UpdateReplicatorInstanceStatus(ctx context.Context, tx *sql.Tx, replicatorID int64, instanceID int64, status string) error {
+	_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO replicators_instances (replicator_id, instance_id, last_sync_status, last_snapshot) VALUES (?, ?, '', '')`, replicatorID, instanceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE replicators_instances SET last_sync_status=? WHERE replicator_id=? AND instance_id=?`, status, replicatorID, instanceID)
+	return err
+}
+
+// GetReplicatorInstancesByNode returns the replication state of all replicated instances located on the cluster
+// member with the given ID, across all replicators.
+func GetReplicatorInstancesByNode(ctx context.Context, tx *sql.Tx, nodeID int64) ([]ReplicatorInstance, error) {
+	q := `
+SELECT instances.id, instances.name, replicators_instances.last_sync_date, replicators_instances.last_sync_status, replicators_instances.last_snapshot, projects.name, replicators.name
+FROM replicators_instances
+JOIN replicators ON replicators.id = replicators_instances.replicator_id
+JOIN projects ON projects.id = replicators.project_id
+JOIN instances ON instances.id = replicators_instances.instance_id
+WHERE instances.node_id = ?
+ORDER BY projects.name, replicators.name, instances.name
+`
+
+	var replicatorInstances []ReplicatorInstance
+	err := query.Scan(ctx, tx, q, func(scan func(dest ...any) error) error {
+		var replicatorInstance ReplicatorInstance
+
+		err := scan(&replicatorInstance.InstanceID, &replicatorInstance.InstanceName, &replicatorInstance.LastSyncDate, &replicatorInstance.LastSyncStatus, &replicatorInstance.LastSnapshot, &replicatorInstance.ProjectName, &replicatorInstance.ReplicatorName)
+		if err != nil {
+			return err
+		}
+
+		replicatorInstances = append(replicatorInstances, replicatorInstance)
+		return nil
+	}, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading replicator instances: %w", err)
+	}
+
+	return replicatorInstances, nil
+}
+
+// ReplicatorRun represents a completed run of a replicator along with the result for each instance.
+type ReplicatorRun struct {
+	ID        int64
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+	Instances []ReplicatorRunInstance
+}
+
+// ReplicatorRunInstance represents the result of the replication of an instance during a replicator run.
+type ReplicatorRunInstance struct {
+	InstanceName     string
+	Status           string
+	BytesTransferred int64
+	Duration         time.Duration
+	Error            string
+}
+
+// CreateReplicatorRun records a completed run of the replicator with the given ID.
+// Only the given number of most recent runs of the replicator is kept, older runs are deleted.
+func CreateReplicatorRun(ctx context.Context, tx *sql.Tx, replicatorID int64, run ReplicatorRun, keep int) (int64, error) {
+	result, err := tx.ExecContext(ctx, `INSERT INTO replicators_runs (replicator_id, start_date, end_date, status) VALUES (?, ?, ?, ?)`, replicatorID, run.StartDate, run.EndDate, run.Status)
+	if err != nil {
+		return -1, fmt.Errorf("Failed creating replicator run: %w", err)
+	}
+
+	runID, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("Failed getting replicator run ID: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO replicators_runs_instances (replicator_run_id, instance_name, status, bytes_transferred, duration, error) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return -1, err
+	}
+
+	defer func() { _ = stmt.Close() }()
+
+	for _, inst := range run.Instances {
+		_, err = stmt.ExecContext(ctx, runID, inst.InstanceName, inst.Status, inst.BytesTransferred, inst.Duration.Milliseconds(), inst.Error)
+		if err != nil {
+			return -1, fmt.Errorf("Failed creating replicator run instance %q: %w", inst.InstanceName, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM replicators_runs WHERE replicator_id = ? AND id NOT IN (SELECT id FROM replicators_runs WHERE replicator_id = ? ORDER BY id DESC LIMIT ?)`, replicatorID, replicatorID, keep)
+	if err != nil {
+		return -1, fmt.Errorf("Failed pruning replicator runs: %w", err)
+	}
+
+	return runID, nil
+}
+
+// GetReplicatorRuns returns the recorded runs of the replicator with the given ID, most recent first.
+func GetReplicatorRuns(ctx context.Context, tx *sql.Tx, replicatorID int64) ([]ReplicatorRun, error) {
+	var runs []ReplicatorRun
+	runIndexes := map[int64]int{}
+	err := query.Scan(ctx, tx, `SELECT id, start_date, end_date, status FROM replicators_runs WHERE replicator_id = ? ORDER BY id DESC`, func(scan func(dest ...any) error) error {
+		var run ReplicatorRun
+
+		err := scan(&run.ID, &run.StartDate, &run.EndDate, &run.Status)
+		if err != nil {
+			return err
+		}
+
+		runIndexes[run.ID] = len(runs)
+		runs = append(runs, run)
+		return nil
+	}, replicatorID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading replicator runs: %w", err)
+	}
+
+	q := `
+SELECT replicators_runs_instances.replicator_run_id, replicators_runs_instances.instance_name, replicators_runs_instances.status, replicators_runs_instances.bytes_transferred, replicators_runs_instances.duration, replicators_runs_instances.error
+FROM replicators_runs_instances
+JOIN replicators_runs ON replicators_runs.id = replicators_runs_instances.replicator_run_id
+WHERE replicators_runs.replicator_id = ?
+ORDER BY replicators_runs_instances.instance_name
+`
+
+	err = query.Scan(ctx, tx, q, func(scan func(dest ...any) error) error {
+		var runID int64
+		var duration int64
+		var inst ReplicatorRunInstance
+
+		err := scan(&runID, &inst.InstanceName, &inst.Status, &inst.BytesTransferred, &duration, &inst.Error)
+		if err != nil {
+			return err
+		}
+
+		i, ok := runIndexes[runID]
+		if !ok {
+			return nil
+		}
+
+		inst.Duration = time.Duration(duration) * time.Millisecond
+		runs[i].Instances = append(runs[i].Instances, inst)
+		return nil
+	}, replicatorID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading replicator run instances: %w", err)
+	}
+
+	return runs, nil
+}
@@ -204,7 +204,7 @@ CREATE TABLE "instances_backups" (
     creation_date DATETIME,
     expiry_date DATETIME,
     container_only INTEGER NOT NULL default 0,
-    optimized_storage INTEGER NOT NULL default 0,
+    optimized_storage INTEGER NOT NULL default 0, target TEXT NOT NULL DEFAULT '', target_location TEXT NOT NULL DEFAULT '',
     FOREIGN KEY (instance_id) REFERENCES "instances" (id) ON DELETE CASCADE,
     UNIQUE (instance_id, name)
 );
@@ -313,6 +313,23 @@ CREATE TABLE "networks_acls_config" (
     UNIQUE (network_acl_id, key),
     FOREIGN KEY (network_acl_id) REFERENCES "networks_acls" (id) ON DELETE CASCADE
 );
+CREATE TABLE networks_address_sets (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	project_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	addresses TEXT NOT NULL,
+	UNIQUE (project_id, name),
+	FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+CREATE TABLE networks_address_sets_config (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_address_set_id INTEGER NOT NULL,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	UNIQUE (network_address_set_id, key),
+	FOREIGN KEY (network_address_set_id) REFERENCES networks_address_sets (id) ON DELETE CASCADE
+);
 CREATE TABLE "networks_config" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     network_id INTEGER NOT NULL,
@@ -323,6 +340,15 @@ CREATE TABLE "networks_config" (
     FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE,
     FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE
 );
+CREATE TABLE networks_dhcp_reservations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_id INTEGER NOT NULL,
+	hwaddr TEXT NOT NULL,
+	address TEXT NOT NULL,
+	hostname TEXT NOT NULL,
+	UNIQUE (network_id, address),
+	FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
 CREATE TABLE "networks_forwards" (
 	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
 	network_id INTEGER NOT NULL,
@@ -599,6 +625,36 @@ CREATE TABLE replicators_config (
 	PRIMARY KEY (replicator_id,
     key)
 ) WITHOUT ROWID;
+CREATE TABLE replicators_instances (
+	replicator_id INTEGER NOT NULL,
+	instance_id INTEGER NOT NULL,
+	last_sync_date DATETIME,
+	last_sync_status TEXT NOT NULL,
+	last_snapshot TEXT NOT NULL,
+	FOREIGN KEY (replicator_id) REFERENCES replicators (id) ON DELETE CASCADE,
+	FOREIGN KEY (instance_id) REFERENCES instances (id) ON DELETE CASCADE,
+	PRIMARY KEY (replicator_id,
+    instance_id)
+) WITHOUT ROWID;
+CREATE TABLE replicators_runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	replicator_id INTEGER NOT NULL,
+	start_date DATETIME NOT NULL,
+	end_date DATETIME NOT NULL,
+	status TEXT NOT NULL,
+	FOREIGN KEY (replicator_id) REFERENCES replicators (id) ON DELETE CASCADE
+);
+CREATE TABLE replicators_runs_instances (
+	replicator_run_id INTEGER NOT NULL,
+	instance_name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	bytes_transferred INTEGER NOT NULL,
+	duration INTEGER NOT NULL,
+	error TEXT NOT NULL,
+	FOREIGN KEY (replicator_run_id) REFERENCES replicators_runs (id) ON DELETE CASCADE,
+	PRIMARY KEY (replicator_run_id,
+    instance_name)
+) WITHOUT ROWID;
 CREATE TABLE secrets (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     entity_type INTEGER NOT NULL,
@@ -729,7 +785,7 @@ CREATE TABLE "storage_volumes_backups" (
     creation_date DATETIME,
     expiry_date DATETIME,
     volume_only INTEGER NOT NULL default 0,
-    optimized_storage INTEGER NOT NULL default 0,
+    optimized_storage INTEGER NOT NULL default 0, target TEXT NOT NULL DEFAULT '', target_location TEXT NOT NULL DEFAULT '',
     FOREIGN KEY (storage_volume_id) REFERENCES "storage_volumes" (id) ON DELETE CASCADE,
     UNIQUE (storage_volume_id, name)
 );
@@ -795,5 +851,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (84, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (90, strftime("%s"))
 `
@@ -128,6 +128,128 @@ var updates = map[int]schema.Update{
 	82: updateFromV81,
 	83: updateFromV82,
 	84: updateFromV83,
+	85: updateFromV84,
+	86: updateFromV85,
+	87: updateFromV86,
+	88: updateFromV87,
+	89: updateFromV88,
+	90: updateFromV89,
+}
+
+func updateFromV89(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE networks_dhcp_reservations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_id INTEGER NOT NULL,
+	hwaddr TEXT NOT NULL,
+	address TEXT NOT NULL,
+	hostname TEXT NOT NULL,
+	UNIQUE (network_id, address),
+	FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
+`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func updateFromV88(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE networks_address_sets (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	project_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	addresses TEXT NOT NULL,
+	UNIQUE (project_id, name),
+	FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE TABLE networks_address_sets_config (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	network_address_set_id INTEGER NOT NULL,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	UNIQUE (network_address_set_id, key),
+	FOREIGN KEY (network_address_set_id) REFERENCES networks_address_sets (id) ON DELETE CASCADE
+);
+`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func updateFromV87(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+ALTER TABLE instances_backups ADD COLUMN target TEXT NOT NULL DEFAULT '';
+ALTER TABLE instances_backups ADD COLUMN target_location TEXT NOT NULL DEFAULT '';
+ALTER TABLE storage_volumes_backups ADD COLUMN target TEXT NOT NULL DEFAULT '';
+ALTER TABLE storage_volumes_backups ADD COLUMN target_location TEXT NOT NULL DEFAULT '';
+`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateFromV86 marks the point from which local storage buckets are served by the built-in S3 server.
+// Local storage buckets created by older versions used a different on-disk format and can't be carried over.
+func updateFromV86(ctx context.Context, tx *sql.Tx) error {
+	return checkNoLocalStorageBuckets(ctx, tx)
+}
+
+func updateFromV85(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE replicators_runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	replicator_id INTEGER NOT NULL,
+	start_date DATETIME NOT NULL,
+	end_date DATETIME NOT NULL,
+	status TEXT NOT NULL,
+	FOREIGN KEY (replicator_id) REFERENCES replicators (id) ON DELETE CASCADE
+);
+
+CREATE TABLE replicators_runs_instances (
+	replicator_run_id INTEGER NOT NULL,
+	instance_name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	bytes_transferred INTEGER NOT NULL,
+	duration INTEGER NOT NULL,
+	error TEXT NOT NULL,
+	FOREIGN KEY (replicator_run_id) REFERENCES replicators_runs (id) ON DELETE CASCADE,
+	PRIMARY KEY (replicator_run_id, instance_name)
+) WITHOUT ROWID;
+`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func updateFromV84(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+CREATE TABLE replicators_instances (
+	replicator_id INTEGER NOT NULL,
+	instance_id INTEGER NOT NULL,
+	last_sync_date DATETIME,
+	last_sync_status TEXT NOT NULL,
+	last_snapshot TEXT NOT NULL,
+	FOREIGN KEY (replicator_id) REFERENCES replicators (id) ON DELETE CASCADE,
+	FOREIGN KEY (instance_id) REFERENCES instances (id) ON DELETE CASCADE,
+	PRIMARY KEY (replicator_id, instance_id)
+) WITHOUT ROWID;
+`)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func updateFromV83(ctx context.Context, tx *sql.Tx) error {
@@ -0,0 +1,92 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// GetNetworkDHCPReservations returns the DHCP reservations of the network with the given ID.
+func (c *ClusterTx) GetNetworkDHCPReservations(ctx context.Context, networkID int64) ([]api.NetworkLeasesPost, error) {
+	q := `
+		SELECT hwaddr, address, hostname
+		FROM networks_dhcp_reservations
+		WHERE network_id=?
+		ORDER BY id
+	`
+
+	reservations := []api.NetworkLeasesPost{}
+
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		reservation := api.NetworkLeasesPost{}
+
+		err := scan(&reservation.Hwaddr, &reservation.Address, &reservation.Hostname)
+		if err != nil {
+			return err
+		}
+
+		reservations = append(reservations, reservation)
+
+		return nil
+	}, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetNetworkDHCPReservation returns the ID and the DHCP reservation of the given address in the network with the given ID.
+func (c *ClusterTx) GetNetworkDHCPReservation(ctx context.Context, networkID int64, address string) (int64, *api.NetworkLeasesPost, error) {
+	q := `
+		SELECT id, hwaddr, address, hostname
+		FROM networks_dhcp_reservations
+		WHERE network_id=? AND address=?
+		LIMIT 1
+	`
+
+	var id int64
+	reservation := api.NetworkLeasesPost{}
+
+	err := c.tx.QueryRowContext(ctx, q, networkID, address).Scan(&id, &reservation.Hwaddr, &reservation.Address, &reservation.Hostname)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, nil, api.StatusErrorf(http.StatusNotFound, "Network DHCP reservation not found")
+		}
+
+		return -1, nil, err
+	}
+
+	return id, &reservation, nil
+}
+
+// CreateNetworkDHCPReservation creates a new DHCP reservation in the network with the given ID.
+func (c *ClusterTx) CreateNetworkDHCPReservation(ctx context.Context, networkID int64, info api.NetworkLeasesPost) (int64, error) {
+	result, err := c.tx.ExecContext(ctx, `
+		INSERT INTO networks_dhcp_reservations (network_id, hwaddr, address, hostname)
+		VALUES (?, ?, ?, ?)
+	`, networkID, info.Hwaddr, info.Address, info.Hostname)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// DeleteNetworkDHCPReservation deletes the DHCP reservation with the given ID.
+func (c *ClusterTx) DeleteNetworkDHCPReservation(ctx context.Context, id int64) error {
+	_, err := c.tx.ExecContext(ctx, "DELETE FROM networks_dhcp_reservations WHERE id=?", id)
+
+	return err
+}
@@ -481,3 +481,50 @@ func (c *ClusterTx) GetNetworkLoadBalancers(ctx context.Context, networkID int64
 
 	return loadBalancers, nil
 }
+
+// NetworkLoadBalancerHealthCheck identifies a network load balancer that has health checks enabled.
+type NetworkLoadBalancerHealthCheck struct {
+	ID            int64
+	ProjectName   string
+	NetworkName   string
+	ListenAddress string
+	NodeID        int64 // -1 if the load balancer doesn't belong to a specific member.
+}
+
+// GetNetworkLoadBalancersWithHealthCheck returns the network load balancers of all networks that have the
+// "healthcheck" config key set.
+func (c *ClusterTx) GetNetworkLoadBalancersWithHealthCheck(ctx context.Context) ([]NetworkLoadBalancerHealthCheck, error) {
+	q := `
+	SELECT
+		networks_load_balancers.id,
+		projects.name,
+		networks.name,
+		networks_load_balancers.listen_address,
+		IFNULL(networks_load_balancers.node_id, -1)
+	FROM networks_load_balancers
+	JOIN networks_load_balancers_config ON networks_load_balancers_config.network_load_balancer_id = networks_load_balancers.id
+	JOIN networks ON networks.id = networks_load_balancers.network_id
+	JOIN projects ON projects.id = networks.project_id
+	WHERE networks_load_balancers_config.key = 'healthcheck' AND networks_load_balancers_config.value != ''
+	`
+
+	var loadBalancers []NetworkLoadBalancerHealthCheck
+
+	err := query.Scan(ctx, c.Tx(), q, func(scan func(dest ...any) error) error {
+		lb := NetworkLoadBalancerHealthCheck{}
+
+		err := scan(&lb.ID, &lb.ProjectName, &lb.NetworkName, &lb.ListenAddress, &lb.NodeID)
+		if err != nil {
+			return err
+		}
+
+		loadBalancers = append(loadBalancers, lb)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
@@ -141,6 +141,14 @@ const (
 	NetworkZoneRecordDelete
 	ReplicatorRun
 	ReplicatorRunInstance
+	PlacementGroupRebalance
+	ReplicatorFailover
+	ReplicatorFailback
+	VolumeReplicateScheduled
+	NetworkAddressSetCreate
+	NetworkAddressSetUpdate
+	NetworkAddressSetDelete
+	NetworkAddressSetRename
 
 	// upperBound is used only to enforce consistency in the package on init.
 	// Make sure it's always the last item in this list.
@@ -384,6 +392,22 @@ func (t Type) Description() string {
 		return "Running replicator"
 	case ReplicatorRunInstance:
 		return "Replicating instance"
+	case PlacementGroupRebalance:
+		return "Rebalancing placement group"
+	case ReplicatorFailover:
+		return "Failing over replicator"
+	case ReplicatorFailback:
+		return "Failing back replicator"
+	case VolumeReplicateScheduled:
+		return "Replicating volumes"
+	case NetworkAddressSetCreate:
+		return "Creating network address set"
+	case NetworkAddressSetUpdate:
+		return "Updating network address set"
+	case NetworkAddressSetDelete:
+		return "Deleting network address set"
+	case NetworkAddressSetRename:
+		return "Renaming network address set"
 
 	// It should never be possible to reach the default clause.
 	// See the init function.
@@ -402,7 +426,7 @@ func (t Type) EntityType() entity.Type {
 		BackupsExpire, SnapshotsExpire, ClusterJoinToken, CertificateAddToken, RenewServerCertificate,
 		ClusterHeal, ImagesUpdate, VolumeSnapshotsCreateScheduled, SnapshotsCreateScheduled,
 		PruneExpiredOperations, RefreshClusterLinkVolatileAddresses,
-		StoragePoolCreate:
+		StoragePoolCreate, VolumeReplicateScheduled:
 		return entity.TypeServer
 
 	// Project level operations.
@@ -410,7 +434,7 @@ func (t Type) EntityType() entity.Type {
 	// (the entity being created is not yet referenceable).
 	case VolumeCreate, ProjectRename, InstanceCreate, ImageDownload, ImageUploadToken, CustomVolumeBackupRestore,
 		InstanceStateUpdateBulk, BackupRestore, ProjectDelete, NetworkCreate, NetworkACLCreate, StorageBucketCreate,
-		NetworkZoneCreate, ReplicatorRunInstance:
+		NetworkZoneCreate, ReplicatorRunInstance, NetworkAddressSetCreate:
 		return entity.TypeProject
 
 	// Storage bucket operations.
@@ -464,6 +488,10 @@ func (t Type) EntityType() entity.Type {
 	case NetworkACLUpdate, NetworkACLDelete, NetworkACLRename:
 		return entity.TypeNetworkACL
 
+	// Network address set operations.
+	case NetworkAddressSetUpdate, NetworkAddressSetDelete, NetworkAddressSetRename:
+		return entity.TypeNetworkAddressSet
+
 	// Network load balancer operations.
 	case NetworkLoadBalancerCreate, NetworkLoadBalancerUpdate, NetworkLoadBalancerDelete:
 		return entity.TypeNetwork
@@ -480,9 +508,13 @@ func (t Type) EntityType() entity.Type {
 	case NetworkZoneUpdate, NetworkZoneDelete, NetworkZoneRecordCreate, NetworkZoneRecordUpdate, NetworkZoneRecordDelete:
 		return entity.TypeNetworkZone
 	// Replicator operations.
-	case ReplicatorRun:
+	case ReplicatorRun, ReplicatorFailover, ReplicatorFailback:
 		return entity.TypeReplicator
 
+	// Placement group operations.
+	case PlacementGroupRebalance:
+		return entity.TypePlacementGroup
+
 	// It should never be possible to reach the default clause.
 	// See the init function.
 	default:
@@ -509,6 +541,10 @@ func (t Type) ConflictAction() ConflictAction {
 		return ConflictActionFail // Enforces cluster-wide evacuation exclusivity when used with a shared ConflictReference; this prevents evacuation race conditions.
 	case ReplicatorRun:
 		return ConflictActionFail // Prevents concurrent runs of the same replicator; the replicator URL is used as the per-replicator conflict reference.
+	case ReplicatorFailover, ReplicatorFailback:
+		return ConflictActionFail // Prevents concurrent failovers and failbacks of the same project; the project URL is used as the conflict reference.
+	case PlacementGroupRebalance:
+		return ConflictActionFail // Prevents concurrent rebalancing of the same placement group; the placement group URL is used as the conflict reference.
 	}
 
 	return ConflictActionNone
@@ -58,6 +58,10 @@ const (
 	StoragePoolUnvailable
 	// UnableToUpdateClusterCertificate represents the unable to update cluster certificate warning.
 	UnableToUpdateClusterCertificate
+	// PlacementGroupNonCompliant represents a placement group whose instances do not comply with its policy.
+	PlacementGroupNonCompliant
+	// ReplicatorRPOExceeded represents a replicator whose replication lag exceeds its recovery point objective.
+	ReplicatorRPOExceeded
 )
 
 // TypeNames associates a warning code to its name.
@@ -88,6 +92,8 @@ var TypeNames = map[Type]string{
 	InstanceTypeNotOperational:             "Instance type not operational",
 	StoragePoolUnvailable:                  "Storage pool unavailable",
 	UnableToUpdateClusterCertificate:       "Cannot update cluster certificate",
+	PlacementGroupNonCompliant:             "Placement group not compliant with its policy",
+	ReplicatorRPOExceeded:                  "Replicator recovery point objective exceeded",
 }
 
 // Severity returns the severity of the warning type.
@@ -145,6 +151,10 @@ func (t Type) Severity() Severity {
 		return SeverityHigh
 	case UnableToUpdateClusterCertificate:
 		return SeverityLow
+	case PlacementGroupNonCompliant:
+		return SeverityModerate
+	case ReplicatorRPOExceeded:
+		return SeverityModerate
 	}
 
 	return SeverityLow
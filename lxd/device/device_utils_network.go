@@ -474,7 +474,8 @@ func networkNICRouteDelete(routeDev string, routes ...string) {
 }
 
 // networkSetupHostVethLimits applies any network rate limits to the veth device specified in the config.
-func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device, bridged bool) error {
+// The QoS keys not set on the device default to those of the managed network configuration provided, if any.
+func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device, netConfig map[string]string, bridged bool) error {
 	var err error
 
 	veth := d.config["host_name"]
@@ -483,29 +484,54 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 		return fmt.Errorf("Unknown or missing host side veth device %q", veth)
 	}
 
-	// Apply max limit
-	if d.config["limits.max"] != "" {
-		d.config["limits.ingress"] = d.config["limits.max"]
-		d.config["limits.egress"] = d.config["limits.max"]
-	}
+	// Apply max limit and network defaults.
+	config := network.NICQoSConfig(d.config, netConfig)
 
 	// Parse the values
 	var ingressInt int64
-	if d.config["limits.ingress"] != "" {
-		ingressInt, err = units.ParseBitSizeString(d.config["limits.ingress"])
+	if config["limits.ingress"] != "" {
+		ingressInt, err = units.ParseBitSizeString(config["limits.ingress"])
 		if err != nil {
 			return err
 		}
 	}
 
+	var ingressMinInt int64
+	if config["limits.ingress"] != "" && config["limits.ingress.min"] != "" {
+		ingressMinInt, err = units.ParseBitSizeString(config["limits.ingress.min"])
+		if err != nil {
+			return fmt.Errorf("Failed parsing limits.ingress.min %q: %w", config["limits.ingress.min"], err)
+		}
+
+		if ingressMinInt > ingressInt {
+			return fmt.Errorf("Minimum bandwidth %q exceeds the incoming traffic limit %q", config["limits.ingress.min"], config["limits.ingress"])
+		}
+	}
+
 	var egressInt int64
-	if d.config["limits.egress"] != "" {
-		egressInt, err = units.ParseBitSizeString(d.config["limits.egress"])
+	if config["limits.egress"] != "" {
+		egressInt, err = units.ParseBitSizeString(config["limits.egress"])
 		if err != nil {
 			return err
 		}
 	}
 
+	// Parse the burst sizes (the egress policer keeps its historical default).
+	ingressBurst := ""
+	egressBurst := "1024k"
+	for key, burst := range map[string]*string{"limits.ingress.burst": &ingressBurst, "limits.egress.burst": &egressBurst} {
+		if config[key] == "" {
+			continue
+		}
+
+		burstInt, err := units.ParseByteSizeString(config[key])
+		if err != nil {
+			return fmt.Errorf("Failed parsing %s %q: %w", key, config[key], err)
+		}
+
+		*burst = strconv.FormatInt(burstInt, 10)
+	}
+
 	// Clean any existing entry
 	qdisc := &ip.Qdisc{Dev: veth, Root: true}
 	_ = qdisc.Delete()
@@ -513,14 +539,26 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 	_ = qdisc.Delete()
 
 	// Apply new limits
-	if d.config["limits.ingress"] != "" {
+	if config["limits.ingress"] != "" {
 		qdiscHTB := &ip.QdiscHTB{Qdisc: ip.Qdisc{Dev: veth, Handle: "1:0", Root: true}, Default: "10"}
 		err := qdiscHTB.Add()
 		if err != nil {
 			return fmt.Errorf("Failed creating root tc qdisc: %s", err)
 		}
 
-		classHTB := &ip.ClassHTB{Class: ip.Class{Dev: veth, Parent: "1:0", Classid: "1:10"}, Rate: fmt.Sprint(ingressInt, "bit")}
+		classHTB := &ip.ClassHTB{Class: ip.Class{Dev: veth, Parent: "1:0", Classid: "1:10"}, Rate: fmt.Sprint(ingressInt, "bit"), Burst: ingressBurst}
+		if ingressMinInt > 0 {
+			// The minimum bandwidth is guaranteed by the rate of the limit class, which can borrow unused
+			// bandwidth up to the limit from a parent class.
+			parentHTB := &ip.ClassHTB{Class: ip.Class{Dev: veth, Parent: "1:0", Classid: "1:1"}, Rate: fmt.Sprint(ingressInt, "bit"), Burst: ingressBurst}
+			err = parentHTB.Add()
+			if err != nil {
+				return fmt.Errorf("Failed creating limit tc class: %s", err)
+			}
+
+			classHTB = &ip.ClassHTB{Class: ip.Class{Dev: veth, Parent: "1:1", Classid: "1:10"}, Rate: fmt.Sprint(ingressMinInt, "bit"), Ceil: fmt.Sprint(ingressInt, "bit"), Burst: ingressBurst}
+		}
+
 		err = classHTB.Add()
 		if err != nil {
 			return fmt.Errorf("Failed creating limit tc class: %s", err)
@@ -533,14 +571,14 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 		}
 	}
 
-	if d.config["limits.egress"] != "" {
+	if config["limits.egress"] != "" {
 		qdisc = &ip.Qdisc{Dev: veth, Handle: "ffff:0", Ingress: true}
 		err := qdisc.Add()
 		if err != nil {
 			return fmt.Errorf("Failed creating ingress tc qdisc: %s", err)
 		}
 
-		police := &ip.ActionPolice{Rate: fmt.Sprint(egressInt, "bit"), Burst: "1024k", Mtu: "64kb", Drop: true}
+		police := &ip.ActionPolice{Rate: fmt.Sprint(egressInt, "bit"), Burst: egressBurst, Mtu: "64kb", Drop: true}
 		filter := &ip.U32Filter{Filter: ip.Filter{Dev: veth, Parent: "ffff:0", Protocol: "all"}, Value: "0", Mask: "0", Actions: []ip.Action{police}}
 		err = filter.Add()
 		if err != nil {
@@ -576,6 +614,26 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 		}
 	}
 
+	// Apply DSCP marking (the network default may have changed so always refresh it).
+	if oldConfig != nil {
+		err = d.state.Firewall.InstanceClearNetDSCP(d.inst.Project().Name, d.inst.Name(), veth)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config["limits.dscp"] != "" {
+		dscp, err := strconv.ParseUint(config["limits.dscp"], 10, 8)
+		if err != nil {
+			return fmt.Errorf("Failed parsing limits.dscp %q: %w", config["limits.dscp"], err)
+		}
+
+		err = d.state.Firewall.InstanceSetupNetDSCP(d.inst.Project().Name, d.inst.Name(), veth, uint8(dscp))
+		if err != nil {
+			return fmt.Errorf("Failed setting up instance device DSCP marking: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -586,6 +644,11 @@ func networkClearHostVethLimits(d *deviceCommon) error {
 		return err
 	}
 
+	err = d.state.Firewall.InstanceClearNetDSCP(d.inst.Project().Name, d.inst.Name(), d.config["host_name"])
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -231,6 +231,13 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		// ---
 		//  type: string
 		//  shortdesc: I/O limit for incoming traffic
+
+		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=limits.ingress)
+		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// The limit is applied through OVN QoS rules.
+		// ---
+		//  type: string
+		//  shortdesc: I/O limit for incoming traffic
 		"limits.ingress": validate.IsAny,
 		// lxdmeta:generate(entities=device-nic-bridged; group=device-conf; key=limits.egress)
 		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
@@ -244,6 +251,13 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		// ---
 		//  type: string
 		//  shortdesc: I/O limit for outgoing traffic
+
+		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=limits.egress)
+		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// The limit is applied through OVN QoS rules.
+		// ---
+		//  type: string
+		//  shortdesc: I/O limit for outgoing traffic
 		"limits.egress": validate.IsAny,
 		// lxdmeta:generate(entities=device-nic-bridged; group=device-conf; key=limits.max)
 		// This option is the same as setting both {config:option}`device-nic-bridged-device-conf:limits.ingress` and {config:option}`device-nic-bridged-device-conf:limits.egress`.
@@ -261,7 +275,56 @@ func nicValidationRules(requiredFields []string, optionalFields []string, instCo
 		// ---
 		//  type: string
 		//  shortdesc: I/O limit for both incoming and outgoing traffic
+
+		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=limits.max)
+		// This option is the same as setting both {config:option}`device-nic-ovn-device-conf:limits.ingress` and {config:option}`device-nic-ovn-device-conf:limits.egress`.
+		//
+		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// ---
+		//  type: string
+		//  shortdesc: I/O limit for both incoming and outgoing traffic
 		"limits.max": validate.IsAny,
+		// lxdmeta:generate(entities=device-nic-{bridged+ovn}; group=device-conf; key=limits.ingress.burst)
+		// Specify the size in bytes. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// Requires `limits.ingress` to be set.
+		// ---
+		//  type: string
+		//  defaultdesc: network `qos.ingress.burst`
+		//  shortdesc: Burst size for incoming traffic
+		"limits.ingress.burst": validate.Optional(validate.IsSize),
+		// lxdmeta:generate(entities=device-nic-{bridged+ovn}; group=device-conf; key=limits.egress.burst)
+		// Specify the size in bytes. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// Requires `limits.egress` to be set.
+		// ---
+		//  type: string
+		//  defaultdesc: network `qos.egress.burst`
+		//  shortdesc: Burst size for outgoing traffic
+		"limits.egress.burst": validate.Optional(validate.IsSize),
+		// lxdmeta:generate(entities=device-nic-bridged; group=device-conf; key=limits.ingress.min)
+		// The incoming traffic is guaranteed this bandwidth and can use unused bandwidth up to `limits.ingress`.
+		// Specify the bandwidth in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// Requires `limits.ingress` to be set.
+		// ---
+		//  type: string
+		//  defaultdesc: network `qos.ingress.min`
+		//  shortdesc: Minimum guaranteed bandwidth for incoming traffic
+		"limits.ingress.min": validate.Optional(validate.IsBitSize),
+		// lxdmeta:generate(entities=device-nic-ovn; group=device-conf; key=limits.egress.min)
+		// The guaranteed bandwidth is enforced on the uplink interface of the chassis the instance runs on.
+		// Specify the bandwidth in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// ---
+		//  type: string
+		//  defaultdesc: network `qos.egress.min`
+		//  shortdesc: Minimum guaranteed bandwidth for outgoing traffic
+		"limits.egress.min": validate.Optional(validate.IsBitSize),
+		// lxdmeta:generate(entities=device-nic-{bridged+ovn}; group=device-conf; key=limits.dscp)
+		// The Differentiated Services Code Point (DSCP) value (0 to 63) is set in the IP header of the outgoing packets,
+		// so that network equipment can prioritize them (for example, 46 for expedited forwarding of voice traffic).
+		// ---
+		//  type: integer
+		//  defaultdesc: network `qos.dscp`
+		//  shortdesc: DSCP value for outgoing traffic
+		"limits.dscp": validate.Optional(validate.IsInRange(0, 63)),
 		// lxdmeta:generate(entities=device-nic-bridged; group=device-conf; key=limits.priority)
 		// The `skb->priority` value for outgoing traffic is used by the kernel queuing discipline (qdisc) to prioritize network packets.
 		// Specify the value as a 32-bit unsigned integer.
@@ -1,14 +1,10 @@
 package device
 
 import (
-	"bufio"
 	"bytes"
 	"context"
-	"encoding/binary"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net"
 	"net/http"
 	"os"
@@ -16,8 +12,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/google/gopacket"
-	"github.com/google/gopacket/layers"
 	"github.com/mdlayher/netx/eui64"
 
 	"github.com/canonical/lxd/lxd/db"
@@ -59,6 +53,15 @@ func (d *nicBridged) CanMigrate() bool {
 	return d.network != nil
 }
 
+// networkConfig returns the configuration of the managed network the device is connected to, if any.
+func (d *nicBridged) networkConfig() map[string]string {
+	if d.network == nil {
+		return nil
+	}
+
+	return d.network.Config()
+}
+
 // validateConfig checks the supplied config for correctness.
 func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 	if !instanceSupported(instConf.Type(), instancetype.Container, instancetype.VM) {
@@ -78,6 +81,10 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		"limits.egress",
 		"limits.max",
 		"limits.priority",
+		"limits.ingress.burst",
+		"limits.egress.burst",
+		"limits.ingress.min",
+		"limits.dscp",
 		"ipv4.address",
 		"ipv6.address",
 		"ipv4.routes",
@@ -458,7 +465,7 @@ func (d *nicBridged) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering"}
+	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "limits.ingress.burst", "limits.egress.burst", "limits.ingress.min", "limits.dscp", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering"}
 }
 
 // Add is run when a device is added to a non-snapshot instance whether or not the instance is running.
@@ -562,7 +569,7 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 	}
 
 	// Apply host-side limits.
-	err = networkSetupHostVethLimits(&d.deviceCommon, nil, true)
+	err = networkSetupHostVethLimits(&d.deviceCommon, nil, d.networkConfig(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -630,22 +637,30 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 		}
 
 		if brNetfilterEnabled {
-			var listenAddresses map[int64]string
+			var listenAddresses, loadBalancerListenAddresses map[int64]string
 
 			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 				listenAddresses, err = tx.GetNetworkForwardListenAddresses(ctx, d.network.ID(), true)
+				if err != nil {
+					return fmt.Errorf("Failed loading network forwards: %w", err)
+				}
 
-				return err
+				loadBalancerListenAddresses, err = tx.GetNetworkLoadBalancerListenAddresses(ctx, d.network.ID(), true)
+				if err != nil {
+					return fmt.Errorf("Failed loading network load balancers: %w", err)
+				}
+
+				return nil
 			})
 			if err != nil {
-				return nil, fmt.Errorf("Failed loading network forwards: %w", err)
+				return nil, err
 			}
 
-			// If br_netfilter is enabled and bridge has forwards, we enable hairpin mode on NIC's
-			// bridge port in case any of the forwards target this NIC and the instance attempts to
-			// connect to the forward's listener. Without hairpin mode on the target of the forward
-			// will not be able to connect to the listener.
-			if len(listenAddresses) > 0 {
+			// If br_netfilter is enabled and bridge has forwards or load balancers, we enable hairpin
+			// mode on NIC's bridge port in case any of them target this NIC and the instance attempts to
+			// connect to the listener. Without hairpin mode on the target of the forward will not be
+			// able to connect to the listener.
+			if len(listenAddresses) > 0 || len(loadBalancerListenAddresses) > 0 {
 				link := &ip.Link{Name: saveData["host_name"]}
 				err = link.BridgeLinkSetHairpin(true)
 				if err != nil {
@@ -708,7 +723,7 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 	// isn't allocated old IP. This is important with IPv6 because DHCPv6 supports multiple IP
 	// address allocation and would result in instance having leases for both old and new IPs.
 	if d.config["hwaddr"] != "" && d.config["ipv6.address"] != oldConfig["ipv6.address"] {
-		err := d.networkClearLease(d.inst.Name(), d.config["parent"], d.config["hwaddr"], clearLeaseIPv6Only)
+		err := dnsmasq.ClearLease(d.inst.Name(), d.config["parent"], d.config["hwaddr"], dnsmasq.ClearLeaseIPv6Only)
 		if err != nil {
 			return err
 		}
@@ -753,7 +768,7 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 		}
 
 		// Apply host-side limits.
-		err = networkSetupHostVethLimits(&d.deviceCommon, oldConfig, true)
+		err = networkSetupHostVethLimits(&d.deviceCommon, oldConfig, d.networkConfig(), true)
 		if err != nil {
 			return err
 		}
@@ -906,7 +921,7 @@ func (d *nicBridged) Remove() error {
 		defer dnsmasq.ConfigMutex.Unlock()
 
 		if network.InterfaceExists(bridgeName) {
-			err := d.networkClearLease(d.inst.Name(), bridgeName, d.config["hwaddr"], clearLeaseAll)
+			err := dnsmasq.ClearLease(d.inst.Name(), bridgeName, d.config["hwaddr"], dnsmasq.ClearLeaseAll)
 			if err != nil {
 				return fmt.Errorf("Failed clearing leases: %w", err)
 			}
@@ -1245,270 +1260,6 @@ func allowedIPNets(config deviceConfig.Device) (IPv4Nets []*net.IPNet, IPv6Nets
 	return IPv4Nets, IPv6Nets, nil
 }
 
-const (
-	clearLeaseAll = iota
-	clearLeaseIPv4Only
-	clearLeaseIPv6Only
-)
-
-// networkClearLease clears leases from a running dnsmasq process.
-func (d *nicBridged) networkClearLease(name string, network string, hwaddr string, mode int) error {
-	leaseFile := shared.VarPath("networks", network, "dnsmasq.leases")
-
-	// Check that we are in fact running a dnsmasq for the network
-	file, err := os.Open(leaseFile)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil
-		}
-
-		return err
-	}
-
-	defer func() { _ = file.Close() }()
-
-	// Convert MAC string to bytes to avoid any case comparison issues later.
-	srcMAC, err := net.ParseMAC(hwaddr)
-	if err != nil {
-		return err
-	}
-
-	iface, err := net.InterfaceByName(network)
-	if err != nil {
-		return fmt.Errorf("Failed getting bridge interface state for %q: %w", network, err)
-	}
-
-	// Get IPv4 and IPv6 address of interface running dnsmasq on host.
-	addrs, err := iface.Addrs()
-	if err != nil {
-		return fmt.Errorf("Failed getting bridge interface addresses for %q: %w", network, err)
-	}
-
-	var dstIPv4, dstIPv6 net.IP
-	for _, addr := range addrs {
-		ip, _, err := net.ParseCIDR(addr.String())
-		if err != nil {
-			return err
-		}
-
-		if !ip.IsGlobalUnicast() {
-			continue
-		}
-
-		if ip.To4() == nil {
-			dstIPv6 = ip
-		} else {
-			dstIPv4 = ip
-		}
-	}
-
-	// Iterate the dnsmasq leases file looking for matching leases for this instance to release.
-	var dstDUID string
-	errs := []error{}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fields := strings.Fields(scanner.Text())
-		fieldsLen := len(fields)
-
-		// Handle lease lines
-		if fieldsLen == 5 {
-			if (mode == clearLeaseAll || mode == clearLeaseIPv4Only) && srcMAC.String() == fields[1] { // Handle IPv4 leases by matching MAC address to lease.
-				srcIP := net.ParseIP(fields[2])
-
-				if dstIPv4 == nil {
-					logger.Warnf("Failed releasing DHCPv4 lease for instance %q, IP %q, MAC %q, %v", name, srcIP, srcMAC, "No server address found")
-					continue // Cant send release packet if no dstIP found.
-				}
-
-				err = d.networkDHCPv4Release(srcMAC, srcIP, dstIPv4)
-				if err != nil {
-					errs = append(errs, fmt.Errorf("Failed releasing DHCPv4 lease for instance %q, IP %q, MAC %q, %v", name, srcIP, srcMAC, err))
-				}
-			} else if (mode == clearLeaseAll || mode == clearLeaseIPv6Only) && name == fields[3] { // Handle IPv6 addresses by matching hostname to lease.
-				IAID := fields[1]
-				srcIP := net.ParseIP(fields[2])
-				DUID := fields[4]
-
-				// Skip IPv4 addresses.
-				if srcIP.To4() != nil {
-					continue
-				}
-
-				if dstIPv6 == nil {
-					logger.Warnf("Failed releasing DHCPv6 lease for instance %q, IP %q, DUID %q, IAID %q: %q", name, srcIP, DUID, IAID, "No server address found")
-					continue // Cant send release packet if no dstIP found.
-				}
-
-				if dstDUID == "" {
-					errs = append(errs, fmt.Errorf("Failed releasing DHCPv6 lease for instance %q, IP %q, DUID %q, IAID %q: %s", name, srcIP, DUID, IAID, "No server DUID found"))
-					continue // Cant send release packet if no dstDUID found.
-				}
-
-				err = d.networkDHCPv6Release(DUID, IAID, srcIP, dstIPv6, dstDUID)
-				if err != nil {
-					errs = append(errs, fmt.Errorf("Failed releasing DHCPv6 lease for instance %q, IP %q, DUID %q, IAID %q: %w", name, srcIP, DUID, IAID, err))
-				}
-			}
-		} else if fieldsLen == 2 && fields[0] == "duid" {
-			// Handle server DUID line needed for releasing IPv6 leases.
-			// This should come before the IPv6 leases in the lease file.
-			dstDUID = fields[1]
-		}
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("%v", errs)
-	}
-
-	err = scanner.Err()
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// networkDHCPv4Release sends a DHCPv4 release packet to a DHCP server.
-func (d *nicBridged) networkDHCPv4Release(srcMAC net.HardwareAddr, srcIP net.IP, dstIP net.IP) error {
-	dstAddr, err := net.ResolveUDPAddr("udp", dstIP.String()+":67")
-	if err != nil {
-		return err
-	}
-
-	conn, err := net.DialUDP("udp", nil, dstAddr)
-	if err != nil {
-		return err
-	}
-
-	defer func() { _ = conn.Close() }()
-
-	// Random DHCP transaction ID
-	xid := rand.Uint32()
-
-	// Construct a DHCP packet pretending to be from the source IP and MAC supplied.
-	dhcp := layers.DHCPv4{
-		Operation:    layers.DHCPOpRequest,
-		HardwareType: layers.LinkTypeEthernet,
-		ClientHWAddr: srcMAC,
-		ClientIP:     srcIP,
-		Xid:          xid,
-	}
-
-	// Add options to DHCP release packet.
-	dhcp.Options = append(dhcp.Options,
-		layers.NewDHCPOption(layers.DHCPOptMessageType, []byte{byte(layers.DHCPMsgTypeRelease)}),
-		layers.NewDHCPOption(layers.DHCPOptServerID, dstIP.To4()),
-	)
-
-	buf := gopacket.NewSerializeBuffer()
-	opts := gopacket.SerializeOptions{
-		ComputeChecksums: true,
-		FixLengths:       true,
-	}
-
-	err = gopacket.SerializeLayers(buf, opts, &dhcp)
-	if err != nil {
-		return err
-	}
-
-	_, err = conn.Write(buf.Bytes())
-	if err != nil {
-		return err
-	}
-
-	return conn.Close()
-}
-
-// networkDHCPv6Release sends a DHCPv6 release packet to a DHCP server.
-func (d *nicBridged) networkDHCPv6Release(srcDUID string, srcIAID string, srcIP net.IP, dstIP net.IP, dstDUID string) error {
-	dstAddr, err := net.ResolveUDPAddr("udp6", "["+dstIP.String()+"]:547")
-	if err != nil {
-		return err
-	}
-
-	conn, err := net.DialUDP("udp6", nil, dstAddr)
-	if err != nil {
-		return err
-	}
-
-	defer func() { _ = conn.Close() }()
-
-	// Construct a DHCPv6 packet pretending to be from the source IP and MAC supplied.
-	dhcp := layers.DHCPv6{
-		MsgType: layers.DHCPv6MsgTypeRelease,
-	}
-
-	// Convert Server DUID from string to byte array
-	dstDUIDRaw, err := hex.DecodeString(strings.ReplaceAll(dstDUID, ":", ""))
-	if err != nil {
-		return err
-	}
-
-	// Convert DUID from string to byte array
-	srcDUIDRaw, err := hex.DecodeString(strings.ReplaceAll(srcDUID, ":", ""))
-	if err != nil {
-		return err
-	}
-
-	// Convert IAID string to int
-	srcIAIDRaw, err := strconv.ParseUint(srcIAID, 10, 32)
-	if err != nil {
-		return err
-	}
-
-	srcIAIDRaw32 := uint32(srcIAIDRaw)
-
-	// Build the Identity Association details option manually (as not provided by gopacket).
-	iaAddr := d.networkDHCPv6CreateIAAddress(srcIP)
-	ianaRaw := d.networkDHCPv6CreateIANA(srcIAIDRaw32, iaAddr)
-
-	// Add options to DHCP release packet.
-	dhcp.Options = append(dhcp.Options,
-		layers.NewDHCPv6Option(layers.DHCPv6OptServerID, dstDUIDRaw),
-		layers.NewDHCPv6Option(layers.DHCPv6OptClientID, srcDUIDRaw),
-		layers.NewDHCPv6Option(layers.DHCPv6OptIANA, ianaRaw),
-	)
-
-	buf := gopacket.NewSerializeBuffer()
-	opts := gopacket.SerializeOptions{
-		ComputeChecksums: true,
-		FixLengths:       true,
-	}
-
-	err = gopacket.SerializeLayers(buf, opts, &dhcp)
-	if err != nil {
-		return err
-	}
-
-	_, err = conn.Write(buf.Bytes())
-	if err != nil {
-		return err
-	}
-
-	return conn.Close()
-}
-
-// networkDHCPv6CreateIANA creates a DHCPv6 Identity Association for Non-temporary Address (rfc3315 IA_NA) option.
-func (d *nicBridged) networkDHCPv6CreateIANA(IAID uint32, IAAddr []byte) []byte {
-	data := make([]byte, 12, 12+len(IAAddr))
-	binary.BigEndian.PutUint32(data[0:4], IAID)       // Identity Association Identifier
-	binary.BigEndian.PutUint32(data[4:8], uint32(0))  // T1
-	binary.BigEndian.PutUint32(data[8:12], uint32(0)) // T2
-	data = append(data, IAAddr...)                    // Append the IA Address details
-	return data
-}
-
-// networkDHCPv6CreateIAAddress creates a DHCPv6 Identity Association Address (rfc3315) option.
-func (d *nicBridged) networkDHCPv6CreateIAAddress(IP net.IP) []byte {
-	data := make([]byte, 28)
-	binary.BigEndian.PutUint16(data[0:2], uint16(layers.DHCPv6OptIAAddr)) // Sub-Option type
-	binary.BigEndian.PutUint16(data[2:4], uint16(24))                     // Length (fixed at 24 bytes)
-	copy(data[4:20], IP)                                                  // IPv6 address to be released
-	binary.BigEndian.PutUint32(data[20:24], uint32(0))                    // Preferred liftetime
-	binary.BigEndian.PutUint32(data[24:28], uint32(0))                    // Valid lifetime
-	return data
-}
-
 // setupNativeBridgePortVLANs configures the bridge port with the specified VLAN settings on the native bridge.
 func (d *nicBridged) setupNativeBridgePortVLANs(hostName string) error {
 	link := &ip.Link{Name: hostName}
@@ -40,6 +40,7 @@ type ovnNet interface {
 	InstanceDevicePortValidateExternalRoutes(deviceInstance instance.Instance, deviceName string, externalRoutes []*net.IPNet) error
 	InstanceDevicePortAdd(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error
 	InstanceDevicePortStart(opts *network.OVNInstanceNICSetupOpts, securityACLsRemove []string) (openvswitch.OVNSwitchPort, error)
+	InstanceDevicePortSetQoS(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error
 	InstanceDevicePortRemove(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error
 	InstanceDevicePortIPs(instanceUUID string, deviceName string) ([]net.IP, error)
 }
@@ -68,7 +69,7 @@ func (d *nicOVN) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"security.acls"}
+	return []string{"security.acls", "limits.ingress", "limits.egress", "limits.max", "limits.ingress.burst", "limits.egress.burst", "limits.egress.min", "limits.dscp"}
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -98,6 +99,13 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		"security.acls.default.egress.action",
 		"security.acls.default.ingress.logged",
 		"security.acls.default.egress.logged",
+		"limits.ingress",
+		"limits.egress",
+		"limits.max",
+		"limits.ingress.burst",
+		"limits.egress.burst",
+		"limits.egress.min",
+		"limits.dscp",
 		"acceleration",
 		"acceleration.parent",
 		"nested",
@@ -925,6 +933,22 @@ func (d *nicOVN) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 		}
 	}
 
+	// Apply any changes needed when the QoS settings change.
+	if isRunning {
+		for _, key := range []string{"limits.ingress", "limits.egress", "limits.max", "limits.ingress.burst", "limits.egress.burst", "limits.egress.min", "limits.dscp"} {
+			if d.config[key] == oldConfig[key] {
+				continue
+			}
+
+			err := d.network.InstanceDevicePortSetQoS(d.inst.LocalConfig()["volatile.uuid"], d.name, d.config)
+			if err != nil {
+				return fmt.Errorf("Failed updating OVN port QoS: %w", err)
+			}
+
+			break
+		}
+	}
+
 	// If an external address changed, update the BGP advertisements.
 	err := bgpRemovePrefix(&d.deviceCommon, oldConfig)
 	if err != nil {
@@ -135,7 +135,7 @@ func (d *nicP2P) Start() (*deviceConfig.RunConfig, error) {
 	}
 
 	// Apply host-side limits.
-	err = networkSetupHostVethLimits(&d.deviceCommon, nil, false)
+	err = networkSetupHostVethLimits(&d.deviceCommon, nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +194,7 @@ func (d *nicP2P) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 	}
 
 	// Apply host-side limits.
-	err = networkSetupHostVethLimits(&d.deviceCommon, oldConfig, false)
+	err = networkSetupHostVethLimits(&d.deviceCommon, oldConfig, nil, false)
 	if err != nil {
 		return err
 	}
@@ -361,7 +361,7 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 	networkVethFillFromVolatile(d.config, saveData)
 
 	// Apply host-side limits.
-	err = networkSetupHostVethLimits(&d.deviceCommon, nil, false)
+	err = networkSetupHostVethLimits(&d.deviceCommon, nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -592,7 +592,7 @@ func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) erro
 		networkVethFillFromVolatile(d.config, v)
 
 		// Apply host-side limits.
-		err = networkSetupHostVethLimits(&d.deviceCommon, oldDevices[d.name], false)
+		err = networkSetupHostVethLimits(&d.deviceCommon, oldDevices[d.name], nil, false)
 		if err != nil {
 			return err
 		}
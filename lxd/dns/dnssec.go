@@ -0,0 +1,219 @@
+package dns
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// dnssecValidity is the validity period of the zone signatures.
+const dnssecValidity = 7 * 24 * time.Hour
+
+// GenerateDNSSECKey generates a new zone signing key and returns it encoded for storage.
+func GenerateDNSSECKey() (string, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := privateKey.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// dnssecKey returns the DNSKEY record and the private key of a zone from its stored key.
+// A single key is used as both the key signing key and the zone signing key.
+func dnssecKey(zoneName string, key string) (*dns.DNSKEY, *ecdsa.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed decoding DNSSEC key: %w", err)
+	}
+
+	privateKey, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed parsing DNSSEC key: %w", err)
+	}
+
+	publicKey, err := privateKey.PublicKey.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dnskey := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(zoneName), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+		// Strip the uncompressed point prefix.
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey[1:]),
+	}
+
+	return dnskey, privateKey, nil
+}
+
+// DNSSECDelegation returns the DS record to add to the parent zone to delegate a zone signed with the given key.
+func DNSSECDelegation(zoneName string, key string) (string, error) {
+	dnskey, _, err := dnssecKey(zoneName, key)
+	if err != nil {
+		return "", err
+	}
+
+	ds := dnskey.ToDS(dns.SHA256)
+	if ds == nil {
+		return "", errors.New("Failed computing DS record")
+	}
+
+	return ds.String(), nil
+}
+
+// signZone adds the RRSIG records to the records of a zone, as well as the DNSKEY and NSEC records when the records
+// are the full zone content. The records may start and end with the SOA record of the zone as in zone transfers.
+func signZone(zoneName string, key string, records []dns.RR, full bool) ([]dns.RR, error) {
+	dnskey, privateKey, err := dnssecKey(zoneName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := dns.Fqdn(zoneName)
+
+	// Drop the trailing SOA record of zone transfers and group the records by name and type.
+	var soa *dns.SOA
+	rrsets := map[string]map[uint16][]dns.RR{}
+	zoneRecords := records
+	if full {
+		zoneRecords = append(slices.Clone(records), dnskey)
+	}
+
+	for _, rr := range zoneRecords {
+		hdr := rr.Header()
+
+		if hdr.Rrtype == dns.TypeSOA {
+			if soa != nil {
+				continue
+			}
+
+			soa = rr.(*dns.SOA)
+		}
+
+		name := strings.ToLower(hdr.Name)
+		if rrsets[name] == nil {
+			rrsets[name] = map[uint16][]dns.RR{}
+		}
+
+		rrsets[name][hdr.Rrtype] = append(rrsets[name][hdr.Rrtype], rr)
+	}
+
+	if soa == nil {
+		return nil, errors.New("Zone has no SOA record")
+	}
+
+	names := make([]string, 0, len(rrsets))
+	for name := range rrsets {
+		names = append(names, name)
+	}
+
+	slices.SortFunc(names, canonicalCompare)
+
+	// Link the names of the zone with NSEC records to authenticate the denial of existence.
+	for i, name := range names {
+		if !full {
+			break
+		}
+
+		types := []uint16{dns.TypeNSEC, dns.TypeRRSIG}
+		for rrtype := range rrsets[name] {
+			types = append(types, rrtype)
+		}
+
+		slices.Sort(types)
+
+		rrsets[name][dns.TypeNSEC] = []dns.RR{&dns.NSEC{
+			Hdr:        dns.RR_Header{Name: name, Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: soa.Minttl},
+			NextDomain: names[(i+1)%len(names)],
+			TypeBitMap: types,
+		}}
+	}
+
+	// Sign every RRset.
+	now := time.Now()
+	signed := []dns.RR{}
+	for _, name := range names {
+		types := make([]uint16, 0, len(rrsets[name]))
+		for rrtype := range rrsets[name] {
+			types = append(types, rrtype)
+		}
+
+		// Keep the SOA record first.
+		slices.SortFunc(types, func(a uint16, b uint16) int {
+			if a == dns.TypeSOA {
+				return -1
+			}
+
+			if b == dns.TypeSOA {
+				return 1
+			}
+
+			return int(a) - int(b)
+		})
+
+		for _, rrtype := range types {
+			rrset := rrsets[name][rrtype]
+
+			// Delegations aren't signed.
+			if rrtype == dns.TypeNS && name != strings.ToLower(origin) {
+				signed = append(signed, rrset...)
+				continue
+			}
+
+			rrsig := &dns.RRSIG{
+				Hdr:        dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: rrset[0].Header().Ttl},
+				KeyTag:     dnskey.KeyTag(),
+				SignerName: origin,
+				Algorithm:  dnskey.Algorithm,
+				Inception:  uint32(now.Add(-time.Hour).Unix()),
+				Expiration: uint32(now.Add(dnssecValidity).Unix()),
+			}
+
+			err = rrsig.Sign(privateKey, rrset)
+			if err != nil {
+				return nil, fmt.Errorf("Failed signing %s records of %q: %w", dns.TypeToString[rrtype], name, err)
+			}
+
+			signed = append(signed, rrset...)
+			signed = append(signed, rrsig)
+		}
+	}
+
+	// Zone transfers end with the SOA record.
+	if len(records) > 1 && records[len(records)-1].Header().Rrtype == dns.TypeSOA {
+		signed = append(signed, soa)
+	}
+
+	return signed, nil
+}
+
+// canonicalCompare compares two domain names in the canonical DNS name order (RFC 4034).
+func canonicalCompare(a string, b string) int {
+	labelsA := dns.SplitDomainName(strings.ToLower(a))
+	labelsB := dns.SplitDomainName(strings.ToLower(b))
+
+	for i := 1; i <= len(labelsA) && i <= len(labelsB); i++ {
+		c := strings.Compare(labelsA[len(labelsA)-i], labelsB[len(labelsB)-i])
+		if c != 0 {
+			return c
+		}
+	}
+
+	return len(labelsA) - len(labelsB)
+}
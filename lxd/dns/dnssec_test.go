@@ -0,0 +1,88 @@
+package dns
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalCompare(t *testing.T) {
+	t.Parallel()
+
+	// Canonical order example from RFC 4034 section 6.1 (without the escaped labels).
+	names := []string{"z.example.", "example.", "*.z.example.", "Z.a.example.", "a.example.", "zABC.a.EXAMPLE.", "yljkjljk.a.example."}
+	slices.SortFunc(names, canonicalCompare)
+
+	assert.Equal(t, []string{"example.", "a.example.", "yljkjljk.a.example.", "Z.a.example.", "zABC.a.EXAMPLE.", "z.example.", "*.z.example."}, names)
+}
+
+func TestSignZone(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateDNSSECKey()
+	require.NoError(t, err)
+
+	ds, err := DNSSECDelegation("example.net", key)
+	require.NoError(t, err)
+	assert.Contains(t, ds, "IN\tDS\t")
+
+	soa := mustRR(t, "example.net. 300 IN SOA ns1.example.net. admin.example.net. 1 3600 900 604800 300")
+	records := []dns.RR{
+		soa,
+		mustRR(t, "example.net. 300 IN NS ns1.example.net."),
+		mustRR(t, "www.example.net. 300 IN A 192.0.2.1"),
+		mustRR(t, "sub.example.net. 300 IN NS ns1.sub.example.net."),
+		soa,
+	}
+
+	signed, err := signZone("example.net", key, records, true)
+	require.NoError(t, err)
+
+	// Zone transfers start and end with the SOA record.
+	assert.Equal(t, dns.TypeSOA, signed[0].Header().Rrtype)
+	assert.Equal(t, dns.TypeSOA, signed[len(signed)-1].Header().Rrtype)
+
+	dnskey, _, err := dnssecKey("example.net", key)
+	require.NoError(t, err)
+
+	// Every RRset but the delegation is signed with a valid signature.
+	signedTypes := map[string][]uint16{}
+	nsecCount := 0
+	for _, rr := range signed {
+		if rr.Header().Rrtype == dns.TypeNSEC {
+			nsecCount++
+		}
+
+		rrsig, ok := rr.(*dns.RRSIG)
+		if !ok {
+			continue
+		}
+
+		rrset := []dns.RR{}
+		for _, other := range signed[:len(signed)-1] {
+			if other.Header().Name == rrsig.Hdr.Name && other.Header().Rrtype == rrsig.TypeCovered {
+				rrset = append(rrset, other)
+			}
+		}
+
+		require.NoError(t, rrsig.Verify(dnskey, rrset))
+		assert.True(t, rrsig.ValidityPeriod(time.Now()))
+		signedTypes[rrsig.Hdr.Name] = append(signedTypes[rrsig.Hdr.Name], rrsig.TypeCovered)
+	}
+
+	assert.ElementsMatch(t, []uint16{dns.TypeSOA, dns.TypeNS, dns.TypeDNSKEY, dns.TypeNSEC}, signedTypes["example.net."])
+	assert.ElementsMatch(t, []uint16{dns.TypeA, dns.TypeNSEC}, signedTypes["www.example.net."])
+	assert.ElementsMatch(t, []uint16{dns.TypeNSEC}, signedTypes["sub.example.net."])
+	assert.Equal(t, 3, nsecCount)
+
+	// SOA only responses aren't given the DNSKEY and NSEC records.
+	signed, err = signZone("example.net", key, []dns.RR{soa}, false)
+	require.NoError(t, err)
+	require.Len(t, signed, 2)
+	assert.Equal(t, dns.TypeSOA, signed[0].Header().Rrtype)
+	assert.Equal(t, dns.TypeRRSIG, signed[1].Header().Rrtype)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 )
@@ -46,6 +47,12 @@ func (d *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
+	// Handle dynamic updates.
+	if r.Opcode == dns.OpcodeUpdate {
+		d.serveUpdate(w, r)
+		return
+	}
+
 	// Check that it's a supported request type.
 	if r.Question[0].Qtype != dns.TypeAXFR && r.Question[0].Qtype != dns.TypeIXFR && r.Question[0].Qtype != dns.TypeSOA {
 		writeRcode(w, r, dns.RcodeNotImplemented)
@@ -100,6 +107,16 @@ func (d *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		m.Answer = append(m.Answer, rr)
 	}
 
+	// Sign the zone.
+	if shared.IsTrue(zone.Info.Config["dnssec"]) {
+		m.Answer, err = signZone(zone.Info.Name, zone.DNSSECKey, m.Answer, r.Question[0].Qtype != dns.TypeSOA)
+		if err != nil {
+			logger.Error("Failed signing DNS zone", logger.Ctx{"zone": name, "err": err})
+			writeRcode(w, r, dns.RcodeServerFailure)
+			return
+		}
+	}
+
 	if tsig != nil && tsigOK {
 		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
 	}
@@ -110,14 +127,16 @@ func (d *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 }
 
-func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
-	type peer struct {
-		address string
-		key     string
-	}
+// zonePeer represents a DNS server allowed to access a zone.
+type zonePeer struct {
+	address string
+	key     string
+	update  bool
+}
 
-	// Build a list of peers.
-	peers := map[string]*peer{}
+// zonePeers returns the peers of the zone by name.
+func zonePeers(zone api.NetworkZone) map[string]*zonePeer {
+	peers := map[string]*zonePeer{}
 	for k, v := range zone.Config {
 		suffix, found := strings.CutPrefix(k, "peers.")
 		if !found {
@@ -131,20 +150,26 @@ func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG,
 		}
 
 		if peers[peerName] == nil {
-			peers[peerName] = &peer{}
+			peers[peerName] = &zonePeer{}
 		}
 
-		// Populate peer configuration fields (address, key) based on the last part of the key.
+		// Populate peer configuration fields (address, key, update) based on the last part of the key.
 		switch field {
 		case "address":
 			peers[peerName].address = v
 		case "key":
 			peers[peerName].key = v
+		case "update":
+			peers[peerName].update = shared.IsTrue(v)
 		}
 	}
 
+	return peers
+}
+
+func (d *dnsHandler) isAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
 	// Validate access.
-	for peerName, peer := range peers {
+	for peerName, peer := range zonePeers(zone) {
 		peerKeyName := fmt.Sprintf("%s_%s.", zone.Name, peerName)
 
 		if peer.address != "" && ip != peer.address {
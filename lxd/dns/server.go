@@ -15,6 +15,9 @@ import (
 // ZoneRetriever is a function which fetches a DNS zone.
 type ZoneRetriever func(name string, full bool) (*Zone, error)
 
+// ZoneUpdater is a function which applies changes to the records of a DNS zone.
+type ZoneUpdater func(name string, changes []ZoneChange) error
+
 // Server represents a DNS server instance.
 type Server struct {
 	tcpDNS *dns.Server
@@ -23,6 +26,7 @@ type Server struct {
 	// External dependencies.
 	db            *db.Cluster
 	zoneRetriever ZoneRetriever
+	zoneUpdater   ZoneUpdater
 
 	// Internal state (to handle reconfiguration).
 	address string
@@ -31,9 +35,9 @@ type Server struct {
 }
 
 // NewServer returns a new server instance.
-func NewServer(db *db.Cluster, retriever ZoneRetriever) *Server {
+func NewServer(db *db.Cluster, retriever ZoneRetriever, updater ZoneUpdater) *Server {
 	// Setup new struct.
-	s := &Server{db: db, zoneRetriever: retriever}
+	s := &Server{db: db, zoneRetriever: retriever, zoneUpdater: updater}
 	return s
 }
 
@@ -55,7 +59,7 @@ func (s *Server) start(address string) error {
 	handler.server = s
 
 	// Spawn the DNS server.
-	s.tcpDNS = &dns.Server{Addr: address, Net: "tcp", Handler: handler}
+	s.tcpDNS = &dns.Server{Addr: address, Net: "tcp", Handler: handler, MsgAcceptFunc: msgAcceptFunc}
 	go func() {
 		err := s.tcpDNS.ListenAndServe()
 		if err != nil {
@@ -63,7 +67,7 @@ func (s *Server) start(address string) error {
 		}
 	}()
 
-	s.udpDNS = &dns.Server{Addr: address, Net: "udp", Handler: handler}
+	s.udpDNS = &dns.Server{Addr: address, Net: "udp", Handler: handler, MsgAcceptFunc: msgAcceptFunc}
 	go func() {
 		err := s.udpDNS.ListenAndServe()
 		if err != nil {
@@ -173,3 +177,23 @@ func (s *Server) updateTSIG() error {
 
 	return nil
 }
+
+// msgAcceptFunc accepts the same messages as the default one as well as dynamic updates (RFC 2136).
+func msgAcceptFunc(dh dns.Header) dns.MsgAcceptAction {
+	opcode := int(dh.Bits>>11) & 0xF
+	if opcode != dns.OpcodeUpdate {
+		return dns.DefaultMsgAcceptFunc(dh)
+	}
+
+	// Ignore responses.
+	if dh.Bits&(1<<15) != 0 {
+		return dns.MsgIgnore
+	}
+
+	// The zone section must hold a single zone.
+	if dh.Qdcount != 1 {
+		return dns.MsgReject
+	}
+
+	return dns.MsgAccept
+}
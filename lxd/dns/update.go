@@ -0,0 +1,280 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// serveUpdate handles a dynamic update request (RFC 2136).
+func (d *dnsHandler) serveUpdate(w dns.ResponseWriter, r *dns.Msg) {
+	// Check that updates are supported.
+	if d.server.zoneUpdater == nil {
+		writeRcode(w, r, dns.RcodeNotImplemented)
+		return
+	}
+
+	// The zone section must hold the SOA of the zone to update.
+	if r.Question[0].Qtype != dns.TypeSOA {
+		writeRcode(w, r, dns.RcodeFormatError)
+		return
+	}
+
+	// Extract the request information.
+	name := strings.TrimSuffix(r.Question[0].Name, ".")
+	ip, _, err := net.SplitHostPort(w.RemoteAddr().String())
+	if err != nil {
+		writeRcode(w, r, dns.RcodeServerFailure)
+		return
+	}
+
+	// Load the zone.
+	zone, err := d.server.zoneRetriever(name, true)
+	if err != nil {
+		writeRcode(w, r, dns.RcodeNotAuth)
+		return
+	}
+
+	tsig := r.IsTsig()
+	tsigOK := w.TsigStatus() == nil
+
+	// Check access.
+	if !d.isUpdateAllowed(zone.Info, ip, tsig, tsigOK) {
+		writeRcode(w, r, dns.RcodeNotAuth)
+		return
+	}
+
+	// Parse the current records of the zone.
+	records := []dns.RR{}
+	zoneRR := dns.NewZoneParser(strings.NewReader(zone.Content), "", "")
+	for {
+		rr, ok := zoneRR.Next()
+		if !ok {
+			err := zoneRR.Err()
+			if err != nil {
+				logger.Errorf("Bad DNS record in zone %q: %v", name, err)
+				writeRcode(w, r, dns.RcodeServerFailure)
+				return
+			}
+
+			break
+		}
+
+		records = append(records, rr)
+	}
+
+	// Check the prerequisites.
+	rcode := checkUpdatePrerequisites(zone.Info.Name, records, r.Answer)
+	if rcode != dns.RcodeSuccess {
+		writeRcode(w, r, rcode)
+		return
+	}
+
+	// Convert the updates to changes of the zone records.
+	changes, rcode := updateChanges(zone.Info.Name, r.Ns)
+	if rcode != dns.RcodeSuccess {
+		writeRcode(w, r, rcode)
+		return
+	}
+
+	err = d.server.zoneUpdater(zone.Info.Name, changes)
+	if err != nil {
+		logger.Error("Failed applying DNS dynamic update", logger.Ctx{"zone": name, "err": err})
+		writeRcode(w, r, dns.RcodeServerFailure)
+		return
+	}
+
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	if tsig != nil && tsigOK {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+	}
+
+	err = w.WriteMsg(m)
+	if err != nil {
+		logger.Error("Cannot write message", logger.Ctx{"err": err})
+	}
+}
+
+// isUpdateAllowed returns whether the request comes from a peer allowed to update the zone.
+// Dynamic updates always require a valid TSIG signature.
+func (d *dnsHandler) isUpdateAllowed(zone api.NetworkZone, ip string, tsig *dns.TSIG, tsigStatus bool) bool {
+	if tsig == nil || !tsigStatus {
+		return false
+	}
+
+	for peerName, peer := range zonePeers(zone) {
+		if !peer.update || peer.key == "" {
+			continue
+		}
+
+		if peer.address != "" && ip != peer.address {
+			continue
+		}
+
+		if tsig.Hdr.Name != fmt.Sprintf("%s_%s.", zone.Name, peerName) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// checkUpdatePrerequisites checks the prerequisites of a dynamic update against the current records of the zone
+// and returns the response code to reply with on failure (RFC 2136 section 3.2).
+func checkUpdatePrerequisites(zoneName string, records []dns.RR, prerequisites []dns.RR) int {
+	// RRsets which must exist with the exact given records.
+	expected := map[string][]dns.RR{}
+
+	for _, prereq := range prerequisites {
+		hdr := prereq.Header()
+
+		if hdr.Ttl != 0 {
+			return dns.RcodeFormatError
+		}
+
+		if !dns.IsSubDomain(dns.Fqdn(zoneName), hdr.Name) {
+			return dns.RcodeNotZone
+		}
+
+		nameInUse := slices.ContainsFunc(records, func(rr dns.RR) bool {
+			return strings.EqualFold(rr.Header().Name, hdr.Name)
+		})
+
+		rrsetExists := slices.ContainsFunc(records, func(rr dns.RR) bool {
+			return strings.EqualFold(rr.Header().Name, hdr.Name) && rr.Header().Rrtype == hdr.Rrtype
+		})
+
+		switch hdr.Class {
+		case dns.ClassANY:
+			if hdr.Rrtype == dns.TypeANY && !nameInUse {
+				return dns.RcodeNameError
+			}
+
+			if hdr.Rrtype != dns.TypeANY && !rrsetExists {
+				return dns.RcodeNXRrset
+			}
+
+		case dns.ClassNONE:
+			if hdr.Rrtype == dns.TypeANY && nameInUse {
+				return dns.RcodeYXDomain
+			}
+
+			if hdr.Rrtype != dns.TypeANY && rrsetExists {
+				return dns.RcodeYXRrset
+			}
+
+		case dns.ClassINET:
+			key := strings.ToLower(hdr.Name) + "/" + dns.TypeToString[hdr.Rrtype]
+			expected[key] = append(expected[key], prereq)
+
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+
+	// Compare the value dependent RRsets.
+	for key, rrset := range expected {
+		current := []string{}
+		for _, rr := range records {
+			if strings.ToLower(rr.Header().Name)+"/"+dns.TypeToString[rr.Header().Rrtype] == key {
+				current = append(current, rdata(rr))
+			}
+		}
+
+		wanted := []string{}
+		for _, rr := range rrset {
+			wanted = append(wanted, rdata(rr))
+		}
+
+		slices.Sort(current)
+		slices.Sort(wanted)
+		if !slices.Equal(slices.Compact(current), slices.Compact(wanted)) {
+			return dns.RcodeNXRrset
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+// updateChanges converts the update section of a dynamic update into changes of the zone records and returns
+// the response code to reply with on failure (RFC 2136 section 3.4).
+// The records at the zone apex are generated from the zone configuration and can't be updated.
+func updateChanges(zoneName string, updates []dns.RR) ([]ZoneChange, int) {
+	origin := dns.Fqdn(zoneName)
+	changes := make([]ZoneChange, 0, len(updates))
+
+	for _, update := range updates {
+		hdr := update.Header()
+
+		if !dns.IsSubDomain(origin, hdr.Name) {
+			return nil, dns.RcodeNotZone
+		}
+
+		if strings.EqualFold(hdr.Name, origin) {
+			return nil, dns.RcodeRefused
+		}
+
+		if slices.Contains([]uint16{dns.TypeSOA, dns.TypeDNSKEY, dns.TypeRRSIG, dns.TypeNSEC, dns.TypeAXFR, dns.TypeIXFR}, hdr.Rrtype) {
+			return nil, dns.RcodeRefused
+		}
+
+		change := ZoneChange{Name: strings.TrimSuffix(hdr.Name, "."+origin)}
+
+		switch hdr.Class {
+		case dns.ClassINET:
+			if hdr.Rrtype == dns.TypeANY {
+				return nil, dns.RcodeFormatError
+			}
+
+			change.Type = dns.TypeToString[hdr.Rrtype]
+			change.Value = rdata(update)
+			change.TTL = uint64(hdr.Ttl)
+
+		case dns.ClassANY:
+			if hdr.Ttl != 0 {
+				return nil, dns.RcodeFormatError
+			}
+
+			change.Delete = true
+			if hdr.Rrtype != dns.TypeANY {
+				change.Type = dns.TypeToString[hdr.Rrtype]
+			}
+
+		case dns.ClassNONE:
+			if hdr.Ttl != 0 || hdr.Rrtype == dns.TypeANY {
+				return nil, dns.RcodeFormatError
+			}
+
+			change.Delete = true
+			change.Type = dns.TypeToString[hdr.Rrtype]
+			change.Value = rdata(update)
+
+		default:
+			return nil, dns.RcodeFormatError
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, dns.RcodeSuccess
+}
+
+// rdata returns the presentation format of the data of a record.
+func rdata(rr dns.RR) string {
+	rr = dns.Copy(rr)
+	rr.Header().Class = dns.ClassINET
+	rr.Header().Ttl = 0
+
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
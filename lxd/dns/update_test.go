@@ -0,0 +1,237 @@
+package dns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// mustRR parses a record in presentation format.
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+
+	return rr
+}
+
+// emptyRR returns a record without data as used in the prerequisite and update sections of dynamic updates.
+func emptyRR(name string, class uint16, rrtype uint16) dns.RR {
+	return &dns.ANY{Hdr: dns.RR_Header{Name: name, Rrtype: rrtype, Class: class}}
+}
+
+func TestServeDNS_UpdateNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{zoneRetriever: func(name string, full bool) (*Zone, error) {
+		return &Zone{}, nil
+	}}
+	h := &dnsHandler{server: s}
+	w := newMockWriter("127.0.0.1:12345", nil)
+
+	r := new(dns.Msg)
+	r.SetUpdate("example.net.")
+
+	h.ServeDNS(w, r)
+
+	require.NotNil(t, w.written)
+	assert.Equal(t, dns.RcodeNotImplemented, w.written.Rcode)
+}
+
+// TestIsUpdateAllowed checks that updates require both a valid TSIG and a peer allowed to update.
+func TestIsUpdateAllowed(t *testing.T) {
+	t.Parallel()
+
+	validTSIG := &dns.TSIG{
+		Hdr: dns.RR_Header{Name: "example.net_mypeer."},
+	}
+
+	tests := []struct {
+		name       string
+		config     map[string]string
+		ip         string
+		tsig       *dns.TSIG
+		tsigStatus bool
+		wantAllow  bool
+	}{
+		{
+			name:       "Key peer without update denied",
+			config:     map[string]string{"peers.mypeer.key": "secret"},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: true,
+			wantAllow:  false,
+		},
+		{
+			name:       "Key peer with update allowed",
+			config:     map[string]string{"peers.mypeer.key": "secret", "peers.mypeer.update": "true"},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: true,
+			wantAllow:  true,
+		},
+		{
+			name:       "Key peer with update and invalid TSIG denied",
+			config:     map[string]string{"peers.mypeer.key": "secret", "peers.mypeer.update": "true"},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: false,
+			wantAllow:  false,
+		},
+		{
+			name:      "IP peer with update denied",
+			config:    map[string]string{"peers.mypeer.address": "127.0.0.1", "peers.mypeer.update": "true"},
+			ip:        "127.0.0.1",
+			wantAllow: false,
+		},
+		{
+			name: "Address+key peer with update and wrong address denied",
+			config: map[string]string{
+				"peers.mypeer.address": "127.0.0.2",
+				"peers.mypeer.key":     "secret",
+				"peers.mypeer.update":  "true",
+			},
+			ip:         "127.0.0.1",
+			tsig:       validTSIG,
+			tsigStatus: true,
+			wantAllow:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &dnsHandler{}
+			zone := api.NetworkZone{
+				Name:   "example.net",
+				Config: tt.config,
+			}
+
+			got := h.isUpdateAllowed(zone, tt.ip, tt.tsig, tt.tsigStatus)
+			assert.Equal(t, tt.wantAllow, got)
+		})
+	}
+}
+
+func TestCheckUpdatePrerequisites(t *testing.T) {
+	t.Parallel()
+
+	records := []dns.RR{
+		mustRR(t, "example.net. 300 IN SOA ns1.example.net. admin.example.net. 1 3600 900 604800 300"),
+		mustRR(t, "www.example.net. 300 IN A 192.0.2.1"),
+		mustRR(t, "www.example.net. 300 IN A 192.0.2.2"),
+	}
+
+	tests := []struct {
+		name      string
+		prereq    dns.RR
+		wantRcode int
+	}{
+		{"Name in use", emptyRR("www.example.net.", dns.ClassANY, dns.TypeANY), dns.RcodeSuccess},
+		{"Name not in use", emptyRR("ftp.example.net.", dns.ClassANY, dns.TypeANY), dns.RcodeNameError},
+		{"RRset exists", emptyRR("www.example.net.", dns.ClassANY, dns.TypeA), dns.RcodeSuccess},
+		{"RRset doesn't exist", emptyRR("www.example.net.", dns.ClassANY, dns.TypeAAAA), dns.RcodeNXRrset},
+		{"Name not in use (NONE)", emptyRR("ftp.example.net.", dns.ClassNONE, dns.TypeANY), dns.RcodeSuccess},
+		{"Name in use (NONE)", emptyRR("www.example.net.", dns.ClassNONE, dns.TypeANY), dns.RcodeYXDomain},
+		{"RRset exists (NONE)", emptyRR("www.example.net.", dns.ClassNONE, dns.TypeA), dns.RcodeYXRrset},
+		{"Partial RRset", mustRR(t, "www.example.net. 0 IN A 192.0.2.1"), dns.RcodeNXRrset},
+		{"Out of zone", emptyRR("www.example.com.", dns.ClassANY, dns.TypeANY), dns.RcodeNotZone},
+		{"Non-zero TTL", &dns.ANY{Hdr: dns.RR_Header{Name: "www.example.net.", Rrtype: dns.TypeANY, Class: dns.ClassANY, Ttl: 300}}, dns.RcodeFormatError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rcode := checkUpdatePrerequisites("example.net", records, []dns.RR{tt.prereq})
+			assert.Equal(t, tt.wantRcode, rcode)
+		})
+	}
+
+	// The full RRset matches regardless of the order.
+	rcode := checkUpdatePrerequisites("example.net", records, []dns.RR{
+		mustRR(t, "www.example.net. 0 IN A 192.0.2.2"),
+		mustRR(t, "www.example.net. 0 IN A 192.0.2.1"),
+	})
+	assert.Equal(t, dns.RcodeSuccess, rcode)
+}
+
+func TestUpdateChanges(t *testing.T) {
+	t.Parallel()
+
+	changes, rcode := updateChanges("example.net", []dns.RR{
+		mustRR(t, "www.example.net. 300 IN A 192.0.2.1"),
+		&dns.A{Hdr: dns.RR_Header{Name: "www.example.net.", Rrtype: dns.TypeA, Class: dns.ClassNONE}, A: net.ParseIP("192.0.2.2")},
+		emptyRR("ftp.example.net.", dns.ClassANY, dns.TypeTXT),
+		emptyRR("old.example.net.", dns.ClassANY, dns.TypeANY),
+	})
+
+	require.Equal(t, dns.RcodeSuccess, rcode)
+	assert.Equal(t, []ZoneChange{
+		{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300},
+		{Name: "www", Delete: true, Type: "A", Value: "192.0.2.2"},
+		{Name: "ftp", Delete: true, Type: "TXT"},
+		{Name: "old", Delete: true},
+	}, changes)
+
+	refused := []string{
+		"example.net. 300 IN A 192.0.2.1",
+		"www.example.net. 300 IN DNSKEY 257 3 13 AAAA",
+	}
+
+	for _, update := range refused {
+		_, rcode = updateChanges("example.net", []dns.RR{mustRR(t, update)})
+		assert.Equal(t, dns.RcodeRefused, rcode, update)
+	}
+
+	_, rcode = updateChanges("example.net", []dns.RR{mustRR(t, "www.example.com. 300 IN A 192.0.2.1")})
+	assert.Equal(t, dns.RcodeNotZone, rcode)
+}
+
+func TestServeDNS_Update(t *testing.T) {
+	t.Parallel()
+
+	zone := &Zone{
+		Info: api.NetworkZone{
+			Name: "example.net",
+			Config: map[string]string{
+				"peers.mypeer.key":    "secret",
+				"peers.mypeer.update": "true",
+			},
+		},
+		Content: "example.net.\t300\tIN\tSOA\tns1.example.net. admin.example.net. 1 3600 900 604800 300\n",
+	}
+
+	var gotChanges []ZoneChange
+	s := &Server{
+		zoneRetriever: func(name string, full bool) (*Zone, error) {
+			return zone, nil
+		},
+		zoneUpdater: func(name string, changes []ZoneChange) error {
+			gotChanges = changes
+			return nil
+		},
+	}
+
+	h := &dnsHandler{server: s}
+	w := newMockWriter("127.0.0.1:12345", nil)
+
+	r := new(dns.Msg)
+	r.SetUpdate("example.net.")
+	r.NameNotUsed([]dns.RR{emptyRR("www.example.net.", dns.ClassINET, dns.TypeANY)})
+	r.Insert([]dns.RR{mustRR(t, "www.example.net. 300 IN A 192.0.2.1")})
+	r.SetTsig("example.net_mypeer.", dns.HmacSHA256, 300, 0)
+
+	h.ServeDNS(w, r)
+
+	require.NotNil(t, w.written)
+	assert.Equal(t, dns.RcodeSuccess, w.written.Rcode)
+	assert.Equal(t, []ZoneChange{{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300}}, gotChanges)
+}
@@ -6,6 +6,25 @@ import (
 
 // Zone represents a DNS zone configuration and its content.
 type Zone struct {
-	Info    api.NetworkZone
-	Content string
+	Info      api.NetworkZone
+	Content   string
+	DNSSECKey string
+}
+
+// ZoneChange represents a change to the records of a DNS zone requested through a dynamic update.
+type ZoneChange struct {
+	// Name of the record relative to the zone.
+	Name string
+
+	// Whether the matching entries are deleted rather than added.
+	Delete bool
+
+	// Type of the entry. When deleting, an empty type matches all the entries of the record.
+	Type string
+
+	// Value of the entry. When deleting, an empty value matches all the entries of the type.
+	Value string
+
+	// TTL of the added entry.
+	TTL uint64
 }
@@ -35,6 +35,22 @@ var ConfigMutex sync.Mutex
 // With --dhcp-hostsdir, dnsmasq uses inotify to automatically detect new and changed files,
 // so no SIGHUP is required after calling this function.
 func UpdateStaticEntry(network string, projectName string, instanceName string, deviceName string, netConfig map[string]string, hwaddr string, ipv4Address string, ipv6Address string) error {
+	hostname := ""
+	if netConfig["dns.mode"] == "" || netConfig["dns.mode"] == "managed" {
+		hostname = project.DNS(projectName, instanceName)
+	}
+
+	return writeStaticEntry(network, StaticAllocationFileName(projectName, instanceName, deviceName), hwaddr, ipv4Address, ipv6Address, hostname)
+}
+
+// UpdateReservationEntry writes the dhcp-host line of the DHCP reservations of a MAC address on a network.
+// The reservation files are removed along with the instance ones when the static allocations are rebuilt.
+func UpdateReservationEntry(network string, hwaddr string, ipv4Address string, ipv6Address string, hostname string) error {
+	return writeStaticEntry(network, ReservationFileName(hwaddr), hwaddr, ipv4Address, ipv6Address, hostname)
+}
+
+// writeStaticEntry writes a single dhcp-host line to a file of the network dnsmasq.hosts directory.
+func writeStaticEntry(network string, fileName string, hwaddr string, ipv4Address string, ipv6Address string, hostname string) error {
 	hwaddr = strings.ToLower(hwaddr)
 	line := hwaddr
 
@@ -47,16 +63,15 @@ func UpdateStaticEntry(network string, projectName string, instanceName string,
 		line += ",[" + ipv6Address + "]"
 	}
 
-	if netConfig["dns.mode"] == "" || netConfig["dns.mode"] == "managed" {
-		line += "," + project.DNS(projectName, instanceName)
+	if hostname != "" {
+		line += "," + hostname
 	}
 
 	if line == hwaddr {
 		return nil
 	}
 
-	deviceStaticFileName := StaticAllocationFileName(projectName, instanceName, deviceName)
-	filePath := shared.VarPath("networks", network, "dnsmasq.hosts", deviceStaticFileName)
+	filePath := shared.VarPath("networks", network, "dnsmasq.hosts", fileName)
 
 	// Check if file already has the same content, skip write to avoid unnecessary inotify events.
 	existingContent, readErr := os.ReadFile(filePath)
@@ -303,6 +318,12 @@ func StaticAllocationFileName(projectName string, instanceName string, deviceNam
 	return strings.Join([]string{project.Instance(projectName, instanceName), escapedDeviceName}, staticAllocationDeviceSeparator)
 }
 
+// ReservationFileName returns the file name to use for the DHCP reservations of a MAC address.
+// Instance static allocation file names can't start with an underscore so the two never conflict.
+func ReservationFileName(hwaddr string) string {
+	return "_reservation" + staticAllocationDeviceSeparator + strings.ReplaceAll(strings.ToLower(hwaddr), ":", "-")
+}
+
 // CleanupLeftoverRemovingFiles removes any leftover .removing files in the network directory.
 // These files can be left behind if LXD is stopped after renaming a file in RemoveStaticEntry
 // but before the file is actually deleted.
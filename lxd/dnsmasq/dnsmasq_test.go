@@ -13,3 +13,8 @@ func Test_staticAllocationFileName(t *testing.T) {
 	fileName := StaticAllocationFileName(projectName, instanceName, deviceName)
 	assert.Equal(t, "test.project_test-instance.test-.--_----.device", fileName)
 }
+
+func Test_reservationFileName(t *testing.T) {
+	fileName := ReservationFileName("00:16:3E:2C:89:D9")
+	assert.Equal(t, "_reservation.00-16-3e-2c-89-d9", fileName)
+}
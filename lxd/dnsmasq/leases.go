@@ -0,0 +1,315 @@
+package dnsmasq
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// Lease clearing modes.
+const (
+	ClearLeaseAll = iota
+	ClearLeaseIPv4Only
+	ClearLeaseIPv6Only
+)
+
+// ClearLease clears the leases of an instance from a running dnsmasq process.
+// IPv4 leases are matched using the MAC address and IPv6 leases using the instance name.
+func ClearLease(name string, network string, hwaddr string, mode int) error {
+	// Convert MAC string to bytes to avoid any case comparison issues later.
+	srcMAC, err := net.ParseMAC(hwaddr)
+	if err != nil {
+		return err
+	}
+
+	_, err = releaseLeases(network, func(srcIP net.IP, id string, hostname string) bool {
+		if srcIP.To4() != nil {
+			return (mode == ClearLeaseAll || mode == ClearLeaseIPv4Only) && srcMAC.String() == id
+		}
+
+		return (mode == ClearLeaseAll || mode == ClearLeaseIPv6Only) && name == hostname
+	})
+
+	return err
+}
+
+// ReleaseLease releases the lease of an address from a running dnsmasq process.
+// Returns whether a lease was found for the address.
+func ReleaseLease(network string, address net.IP) (bool, error) {
+	return releaseLeases(network, func(srcIP net.IP, id string, hostname string) bool {
+		return srcIP.Equal(address)
+	})
+}
+
+// releaseLeases sends a release packet to a running dnsmasq process for each lease accepted by the match function.
+// The match function is given the address of the lease, its MAC address (IPv4) or IAID (IPv6) and its hostname.
+// Returns whether any lease matched.
+func releaseLeases(network string, match func(srcIP net.IP, id string, hostname string) bool) (bool, error) {
+	leaseFile := shared.VarPath("networks", network, "dnsmasq.leases")
+
+	// Check that we are in fact running a dnsmasq for the network
+	file, err := os.Open(leaseFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	iface, err := net.InterfaceByName(network)
+	if err != nil {
+		return false, fmt.Errorf("Failed getting bridge interface state for %q: %w", network, err)
+	}
+
+	// Get IPv4 and IPv6 address of interface running dnsmasq on host.
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return false, fmt.Errorf("Failed getting bridge interface addresses for %q: %w", network, err)
+	}
+
+	var dstIPv4, dstIPv6 net.IP
+	for _, addr := range addrs {
+		ip, _, err := net.ParseCIDR(addr.String())
+		if err != nil {
+			return false, err
+		}
+
+		if !ip.IsGlobalUnicast() {
+			continue
+		}
+
+		if ip.To4() == nil {
+			dstIPv6 = ip
+		} else {
+			dstIPv4 = ip
+		}
+	}
+
+	// Iterate the dnsmasq leases file looking for matching leases to release.
+	var dstDUID string
+	found := false
+	errs := []error{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		fieldsLen := len(fields)
+
+		// Handle lease lines
+		if fieldsLen == 5 {
+			srcIP := net.ParseIP(fields[2])
+			if srcIP == nil || !match(srcIP, fields[1], fields[3]) {
+				continue
+			}
+
+			found = true
+
+			if srcIP.To4() != nil { // Handle IPv4 leases.
+				srcMAC, err := net.ParseMAC(fields[1])
+				if err != nil {
+					errs = append(errs, fmt.Errorf("Failed releasing DHCPv4 lease for IP %q: %w", srcIP, err))
+					continue
+				}
+
+				if dstIPv4 == nil {
+					logger.Warnf("Failed releasing DHCPv4 lease for IP %q, MAC %q, %v", srcIP, srcMAC, "No server address found")
+					continue // Cant send release packet if no dstIP found.
+				}
+
+				err = dhcpv4Release(srcMAC, srcIP, dstIPv4)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("Failed releasing DHCPv4 lease for IP %q, MAC %q, %v", srcIP, srcMAC, err))
+				}
+			} else { // Handle IPv6 leases.
+				IAID := fields[1]
+				DUID := fields[4]
+
+				if dstIPv6 == nil {
+					logger.Warnf("Failed releasing DHCPv6 lease for IP %q, DUID %q, IAID %q: %q", srcIP, DUID, IAID, "No server address found")
+					continue // Cant send release packet if no dstIP found.
+				}
+
+				if dstDUID == "" {
+					errs = append(errs, fmt.Errorf("Failed releasing DHCPv6 lease for IP %q, DUID %q, IAID %q: %s", srcIP, DUID, IAID, "No server DUID found"))
+					continue // Cant send release packet if no dstDUID found.
+				}
+
+				err = dhcpv6Release(DUID, IAID, srcIP, dstIPv6, dstDUID)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("Failed releasing DHCPv6 lease for IP %q, DUID %q, IAID %q: %w", srcIP, DUID, IAID, err))
+				}
+			}
+		} else if fieldsLen == 2 && fields[0] == "duid" {
+			// Handle server DUID line needed for releasing IPv6 leases.
+			// This should come before the IPv6 leases in the lease file.
+			dstDUID = fields[1]
+		}
+	}
+
+	if len(errs) > 0 {
+		return found, fmt.Errorf("%v", errs)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return found, err
+	}
+
+	return found, nil
+}
+
+// dhcpv4Release sends a DHCPv4 release packet to a DHCP server.
+func dhcpv4Release(srcMAC net.HardwareAddr, srcIP net.IP, dstIP net.IP) error {
+	dstAddr, err := net.ResolveUDPAddr("udp", dstIP.String()+":67")
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialUDP("udp", nil, dstAddr)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	// Random DHCP transaction ID
+	xid := rand.Uint32()
+
+	// Construct a DHCP packet pretending to be from the source IP and MAC supplied.
+	dhcp := layers.DHCPv4{
+		Operation:    layers.DHCPOpRequest,
+		HardwareType: layers.LinkTypeEthernet,
+		ClientHWAddr: srcMAC,
+		ClientIP:     srcIP,
+		Xid:          xid,
+	}
+
+	// Add options to DHCP release packet.
+	dhcp.Options = append(dhcp.Options,
+		layers.NewDHCPOption(layers.DHCPOptMessageType, []byte{byte(layers.DHCPMsgTypeRelease)}),
+		layers.NewDHCPOption(layers.DHCPOptServerID, dstIP.To4()),
+	)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		ComputeChecksums: true,
+		FixLengths:       true,
+	}
+
+	err = gopacket.SerializeLayers(buf, opts, &dhcp)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// dhcpv6Release sends a DHCPv6 release packet to a DHCP server.
+func dhcpv6Release(srcDUID string, srcIAID string, srcIP net.IP, dstIP net.IP, dstDUID string) error {
+	dstAddr, err := net.ResolveUDPAddr("udp6", "["+dstIP.String()+"]:547")
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialUDP("udp6", nil, dstAddr)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	// Construct a DHCPv6 packet pretending to be from the source IP and MAC supplied.
+	dhcp := layers.DHCPv6{
+		MsgType: layers.DHCPv6MsgTypeRelease,
+	}
+
+	// Convert Server DUID from string to byte array
+	dstDUIDRaw, err := hex.DecodeString(strings.ReplaceAll(dstDUID, ":", ""))
+	if err != nil {
+		return err
+	}
+
+	// Convert DUID from string to byte array
+	srcDUIDRaw, err := hex.DecodeString(strings.ReplaceAll(srcDUID, ":", ""))
+	if err != nil {
+		return err
+	}
+
+	// Convert IAID string to int
+	srcIAIDRaw, err := strconv.ParseUint(srcIAID, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	srcIAIDRaw32 := uint32(srcIAIDRaw)
+
+	// Build the Identity Association details option manually (as not provided by gopacket).
+	iaAddr := dhcpv6CreateIAAddress(srcIP)
+	ianaRaw := dhcpv6CreateIANA(srcIAIDRaw32, iaAddr)
+
+	// Add options to DHCP release packet.
+	dhcp.Options = append(dhcp.Options,
+		layers.NewDHCPv6Option(layers.DHCPv6OptServerID, dstDUIDRaw),
+		layers.NewDHCPv6Option(layers.DHCPv6OptClientID, srcDUIDRaw),
+		layers.NewDHCPv6Option(layers.DHCPv6OptIANA, ianaRaw),
+	)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		ComputeChecksums: true,
+		FixLengths:       true,
+	}
+
+	err = gopacket.SerializeLayers(buf, opts, &dhcp)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// dhcpv6CreateIANA creates a DHCPv6 Identity Association for Non-temporary Address (rfc3315 IA_NA) option.
+func dhcpv6CreateIANA(IAID uint32, IAAddr []byte) []byte {
+	data := make([]byte, 12, 12+len(IAAddr))
+	binary.BigEndian.PutUint32(data[0:4], IAID)       // Identity Association Identifier
+	binary.BigEndian.PutUint32(data[4:8], uint32(0))  // T1
+	binary.BigEndian.PutUint32(data[8:12], uint32(0)) // T2
+	data = append(data, IAAddr...)                    // Append the IA Address details
+	return data
+}
+
+// dhcpv6CreateIAAddress creates a DHCPv6 Identity Association Address (rfc3315) option.
+func dhcpv6CreateIAAddress(IP net.IP) []byte {
+	data := make([]byte, 28)
+	binary.BigEndian.PutUint16(data[0:2], uint16(layers.DHCPv6OptIAAddr)) // Sub-Option type
+	binary.BigEndian.PutUint16(data[2:4], uint16(24))                     // Length (fixed at 24 bytes)
+	copy(data[4:20], IP)                                                  // IPv6 address to be released
+	binary.BigEndian.PutUint32(data[20:24], uint32(0))                    // Preferred liftetime
+	binary.BigEndian.PutUint32(data[24:28], uint32(0))                    // Valid lifetime
+	return data
+}
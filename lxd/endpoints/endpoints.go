@@ -64,6 +64,9 @@ type Config struct {
 	// HTTP server handling requests for the LXD metrics API.
 	MetricsServer *http.Server
 
+	// HTTP server handling S3 requests for storage buckets on local storage pools.
+	StorageBucketsServer *http.Server
+
 	// HTTP server handling requests from VMs via the vsock.
 	VsockServer *http.Server
 
@@ -172,13 +175,14 @@ func (e *Endpoints) up(config *Config) error {
 	defer e.mu.Unlock()
 
 	e.servers = map[kind]*http.Server{
-		cluster: config.RestServer,
-		devlxd:  config.DevLxdServer,
-		local:   config.RestServer,
-		metrics: config.MetricsServer,
-		network: config.RestServer,
-		pprof:   pprofCreateServer(),
-		vmvsock: config.VsockServer,
+		cluster:        config.RestServer,
+		devlxd:         config.DevLxdServer,
+		local:          config.RestServer,
+		metrics:        config.MetricsServer,
+		network:        config.RestServer,
+		pprof:          pprofCreateServer(),
+		vmvsock:        config.VsockServer,
+		storageBuckets: config.StorageBucketsServer,
 	}
 
 	e.cert = config.Cert
@@ -375,6 +379,13 @@ func (e *Endpoints) Down() error {
 		}
 	}
 
+	if e.listeners[storageBuckets] != nil {
+		err := e.closeListener(storageBuckets)
+		if err != nil {
+			return err
+		}
+	}
+
 	if e.tomb != nil {
 		e.tomb.Kill(nil)
 		_ = e.tomb.Wait()
@@ -465,15 +476,17 @@ const (
 	cluster
 	metrics
 	vmvsock
+	storageBuckets
 )
 
 // Human-readable descriptions of the various kinds of endpoints.
 var descriptions = map[kind]string{
-	local:   "REST API Unix socket",
-	devlxd:  "devlxd socket",
-	network: "REST API TCP socket",
-	pprof:   "pprof socket",
-	cluster: "cluster socket",
-	metrics: "metrics socket",
-	vmvsock: "VM socket",
+	local:          "REST API Unix socket",
+	devlxd:         "devlxd socket",
+	network:        "REST API TCP socket",
+	pprof:          "pprof socket",
+	cluster:        "cluster socket",
+	metrics:        "metrics socket",
+	vmvsock:        "VM socket",
+	storageBuckets: "storage buckets socket",
 }
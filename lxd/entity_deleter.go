@@ -8,6 +8,7 @@ import (
 	dbCluster "github.com/canonical/lxd/lxd/db/cluster"
 	"github.com/canonical/lxd/lxd/lifecycle"
 	"github.com/canonical/lxd/lxd/network/acl"
+	"github.com/canonical/lxd/lxd/network/addressset"
 	"github.com/canonical/lxd/lxd/operations"
 	projectutils "github.com/canonical/lxd/lxd/project"
 	"github.com/canonical/lxd/lxd/request"
@@ -132,6 +133,27 @@ func (d networkACLDeleter) Delete(ctx context.Context, clientType request.Client
 	return nil
 }
 
+type networkAddressSetDeleter struct{}
+
+// Delete deletes a network address set.
+func (d networkAddressSetDeleter) Delete(ctx context.Context, clientType request.ClientType, op *operations.Operation, s *state.State, ref entity.Reference) error {
+	name := ref.Name()
+
+	netAddressSet, err := addressset.LoadByName(ctx, s, ref.ProjectName, name)
+	if err != nil {
+		return err
+	}
+
+	err = netAddressSet.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed deleting network address set %q: %w", name, err)
+	}
+
+	s.Events.SendLifecycle(ref.ProjectName, lifecycle.NetworkAddressSetDeleted.Event(netAddressSet, request.CreateRequestor(ctx), nil))
+
+	return nil
+}
+
 type networkZoneDeleter struct{}
 
 // Delete deletes a network zone.
@@ -244,6 +266,8 @@ func getEntityDeleter(t entity.Type) (entityDeleter, error) {
 		return networkDeleter{}, nil
 	case entity.TypeNetworkACL:
 		return networkACLDeleter{}, nil
+	case entity.TypeNetworkAddressSet:
+		return networkAddressSetDeleter{}, nil
 	case entity.TypeNetworkZone:
 		return networkZoneDeleter{}, nil
 	case entity.TypeStorageVolume:
@@ -28,6 +28,7 @@ type Opts struct {
 
 // ACLRule represents an ACL rule that can be added to a firewall.
 type ACLRule struct {
+	Name            string // Name identifying the rule counters (optional).
 	Direction       string // Either "ingress" or "egress.
 	Action          string
 	Log             bool   // Whether or not to log matched packets.
@@ -41,6 +42,20 @@ type ACLRule struct {
 	ICMPCode        string
 }
 
+// ACLRuleCounters represents the hit counters of the firewall rules generated from an ACL rule.
+type ACLRuleCounters struct {
+	Packets uint64
+	Bytes   uint64
+}
+
+// AddressSet represents a named set of addresses that ACL rules can reference in their source and destination
+// using the "$<name>" syntax.
+type AddressSet struct {
+	ID        int64
+	Name      string
+	Addresses []string // IP addresses or CIDR subnets.
+}
+
 // AddressForward represents a NAT address forward.
 type AddressForward struct {
 	ListenAddress net.IP
@@ -49,3 +64,17 @@ type AddressForward struct {
 	ListenPorts   []uint64
 	TargetPorts   []uint64
 }
+
+// LoadBalancerTarget represents a backend address and port of a NAT load balancer.
+type LoadBalancerTarget struct {
+	Address net.IP
+	Port    uint64
+}
+
+// LoadBalancer represents a NAT load balancer spreading connections to a listen port across its targets.
+type LoadBalancer struct {
+	ListenAddress net.IP
+	Protocol      string
+	ListenPort    uint64
+	Targets       []LoadBalancerTarget
+}
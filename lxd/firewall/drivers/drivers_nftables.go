@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -92,11 +93,11 @@ func (d Nftables) Compat() (bool, error) {
 
 // nftGenericItem represents some common fields amongst the different nftables types.
 type nftGenericItem struct {
-	itemType string // Type of item (table, chain or rule). Populated by LXD.
+	itemType string // Type of item (table, chain, set or rule). Populated by LXD.
 	Family   string `json:"family"` // Family of item (ip, ip6, bridge etc).
 	Table    string `json:"table"`  // Table the item belongs to (for chains and rules).
 	Chain    string `json:"chain"`  // Chain the item belongs to (for rules).
-	Name     string `json:"name"`   // Name of item (for tables and chains).
+	Name     string `json:"name"`   // Name of item (for tables, chains and sets).
 }
 
 // nftParseRuleset parses the ruleset and returns the generic parts as a slice of items.
@@ -128,11 +129,15 @@ func (d Nftables) nftParseRuleset() ([]nftGenericItem, error) {
 	items := []nftGenericItem{}
 	for _, item := range v.Nftables {
 		rule, foundRule := item["rule"]
+		set, foundSet := item["set"]
 		chain, foundChain := item["chain"]
 		table, foundTable := item["table"]
 		if foundRule {
 			rule.itemType = "rule"
 			items = append(items, rule)
+		} else if foundSet {
+			set.itemType = "set"
+			items = append(items, set)
 		} else if foundChain {
 			chain.itemType = "chain"
 			items = append(items, chain)
@@ -318,6 +323,7 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 		"fwd", "pstrt", "in", "out", // Chains used for network operation rules.
 		"aclin", "aclout", "aclfwd", "acl", // Chains used by ACL rules.
 		"fwdprert", "fwdout", "fwdpstrt", // Chains used by Address Forward rules.
+		"lbprert", "lbout", "lbpstrt", // Chains used by Load Balancer rules.
 		"egress", // Chains added for limits.priority option
 	}
 
@@ -328,6 +334,12 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 		return fmt.Errorf("Failed clearing nftables rules for network %q: %w", networkName, err)
 	}
 
+	// Remove the sets of the address sets referenced by ACL rules.
+	err = d.removeAddressSets(networkName)
+	if err != nil {
+		return fmt.Errorf("Failed clearing nftables address sets for network %q: %w", networkName, err)
+	}
+
 	return nil
 }
 
@@ -651,44 +663,111 @@ func (d Nftables) InstanceClearNetPrio(projectName string, instanceName string,
 	return nil
 }
 
+// InstanceSetupNetDSCP activates DSCP marking of the traffic coming from the specified instance device on the host interface.
+func (d Nftables) InstanceSetupNetDSCP(projectName string, instanceName string, deviceName string, dscp uint8) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"family":         "netdev",
+		"chainSeparator": nftablesChainSeparator,
+		"deviceLabel":    deviceLabel,
+		"deviceName":     deviceName,
+		"dscp":           dscp,
+	}
+
+	err := d.applyNftConfig(nftablesInstanceNetDSCP, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed adding DSCP rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
+// InstanceClearNetDSCP removes DSCP marking of the traffic coming from the specified instance device on the host interface.
+func (d Nftables) InstanceClearNetDSCP(projectName string, instanceName string, deviceName string) error {
+	if deviceName == "" {
+		return fmt.Errorf("Failed clearing DSCP rules for instance %q in project %q: device name is empty", instanceName, projectName)
+	}
+
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	chainLabel := "dscp" + nftablesChainSeparator + deviceLabel
+
+	err := d.removeChains([]string{"netdev"}, chainLabel, "ingress")
+	if err != nil {
+		return fmt.Errorf("Failed clearing DSCP rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
 // NetworkApplyACLRules applies ACL rules to the existing firewall chains.
-func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) error {
+// The address sets referenced by the rules are defined as named sets specific to the network, so that their
+// elements are replaced in place each time the rules are applied.
+func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule, addressSets []AddressSet) error {
 	nftRules := make([]string, 0)
 	for _, rule := range rules {
+		// Rules referencing address sets may not generate any rule for the sets that are empty.
+		usesAddressSets := aclRuleUsesAddressSets(&rule)
+
 		// First try generating rules with IPv4 or IP agnostic criteria.
-		nftRule, partial, err := d.aclRuleCriteriaToRules(networkName, 4, &rule)
+		ruleNftRules, partial, err := d.aclRuleCriteriaToRules(networkName, 4, &rule, addressSets)
 		if err != nil {
 			return err
 		}
 
-		if nftRule != "" {
-			nftRules = append(nftRules, nftRule)
-		}
+		nftRules = append(nftRules, ruleNftRules...)
 
 		if partial {
 			// If we couldn't fully generate the ruleset with only IPv4 or IP agnostic criteria, then
 			// fill in the remaining parts using IPv6 criteria.
-			nftRule, _, err = d.aclRuleCriteriaToRules(networkName, 6, &rule)
+			ruleNftRules, _, err = d.aclRuleCriteriaToRules(networkName, 6, &rule, addressSets)
 			if err != nil {
 				return err
 			}
 
-			if nftRule == "" {
+			if len(ruleNftRules) == 0 && !usesAddressSets {
 				return errors.New("Invalid empty rule generated")
 			}
 
-			nftRules = append(nftRules, nftRule)
-		} else if nftRule == "" {
+			nftRules = append(nftRules, ruleNftRules...)
+		} else if len(ruleNftRules) == 0 && !usesAddressSets {
 			return errors.New("Invalid empty rule generated")
 		}
 	}
 
+	nftAddressSets := make([]map[string]any, 0, len(addressSets)*2)
+	nftAddressSetNames := make([]string, 0, len(addressSets)*2)
+	for _, addressSet := range addressSets {
+		for _, ipVersion := range []uint{4, 6} {
+			elements := make([]string, 0, len(addressSet.Addresses))
+			for _, address := range addressSet.Addresses {
+				if addressFamily(address) == ipVersion {
+					elements = append(elements, address)
+				}
+			}
+
+			setType := "ipv4_addr"
+			if ipVersion == 6 {
+				setType = "ipv6_addr"
+			}
+
+			setName := d.addressSetName(networkName, addressSet.ID, ipVersion)
+			nftAddressSetNames = append(nftAddressSetNames, setName)
+			nftAddressSets = append(nftAddressSets, map[string]any{
+				"name":     setName,
+				"type":     setType,
+				"elements": strings.Join(elements, ", "),
+			})
+		}
+	}
+
 	tplFields := map[string]any{
 		"namespace":      nftablesNamespace,
 		"chainSeparator": nftablesChainSeparator,
 		"networkName":    networkName,
 		"family":         "inet",
 		"rules":          nftRules,
+		"addressSets":    nftAddressSets,
 	}
 
 	config := &strings.Builder{}
@@ -702,11 +781,112 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 		return err
 	}
 
+	// Remove the sets of the address sets that aren't referenced by the rules anymore.
+	err = d.removeAddressSets(networkName, nftAddressSetNames...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NetworkACLRuleCounters returns the hit counters of the ACL rules applied to the network, keyed by rule name.
+// The counters of the nftables rules generated from the same ACL rule are summed.
+func (d Nftables) NetworkACLRuleCounters(networkName string) (map[string]ACLRuleCounters, error) {
+	// Dump ruleset as JSON. Use -nn flags to avoid doing DNS lookups of IPs mentioned in any rules.
+	stdout := &bytes.Buffer{}
+	err := shared.RunCommandWithFds(context.TODO(), nil, stdout, "nft", "--json", "-nn", "list", "ruleset")
+	if err != nil {
+		return nil, err
+	}
+
+	// This only extracts the rules and their counters, see man libnftables-json for more info.
+	v := &struct {
+		Nftables []struct {
+			Rule *struct {
+				Family  string                       `json:"family"`
+				Table   string                       `json:"table"`
+				Chain   string                       `json:"chain"`
+				Comment string                       `json:"comment"`
+				Expr    []map[string]json.RawMessage `json:"expr"`
+			} `json:"rule"`
+		} `json:"nftables"`
+	}{}
+
+	err = json.Unmarshal(stdout.Bytes(), v)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing nftables ruleset: %w", err)
+	}
+
+	chainName := "acl" + nftablesChainSeparator + networkName
+	counters := make(map[string]ACLRuleCounters)
+	for _, item := range v.Nftables {
+		rule := item.Rule
+		if rule == nil || rule.Family != "inet" || rule.Table != nftablesNamespace || rule.Chain != chainName || rule.Comment == "" {
+			continue
+		}
+
+		for _, expr := range rule.Expr {
+			rawCounter, found := expr["counter"]
+			if !found {
+				continue
+			}
+
+			counter := ACLRuleCounters{}
+			err = json.Unmarshal(rawCounter, &counter)
+			if err != nil {
+				return nil, fmt.Errorf("Failed parsing counter of nftables rule %q: %w", rule.Comment, err)
+			}
+
+			ruleCounters := counters[rule.Comment]
+			ruleCounters.Packets += counter.Packets
+			ruleCounters.Bytes += counter.Bytes
+			counters[rule.Comment] = ruleCounters
+		}
+	}
+
+	return counters, nil
+}
+
+// addressSetName returns the name of the set holding the addresses of the given IP version of an address set
+// referenced by the ACL rules of a network.
+func (d Nftables) addressSetName(networkName string, addressSetID int64, ipVersion uint) string {
+	return fmt.Sprintf("addrset%d_ip%d%s%s", addressSetID, ipVersion, nftablesChainSeparator, networkName)
+}
+
+// removeAddressSets removes the address sets of the network except for the ones in keepSets.
+func (d Nftables) removeAddressSets(networkName string, keepSets ...string) error {
+	ruleset, err := d.nftParseRuleset()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range ruleset {
+		if item.itemType != "set" || item.Family != "inet" || item.Table != nftablesNamespace {
+			continue
+		}
+
+		setPrefix, setNetworkName, found := strings.Cut(item.Name, nftablesChainSeparator)
+		if !found || setNetworkName != networkName || !strings.HasPrefix(setPrefix, "addrset") {
+			continue
+		}
+
+		if slices.Contains(keepSets, item.Name) {
+			continue
+		}
+
+		_, err = shared.RunCommand(context.TODO(), "nft", "delete", "set", item.Family, nftablesNamespace, item.Name)
+		if err != nil {
+			return fmt.Errorf("Failed deleting nftables set %q: %w", item.Name, err)
+		}
+	}
+
 	return nil
 }
 
 // aclRuleCriteriaToRules converts an ACL rule into 1 or more nftables rules.
-func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rule *ACLRule) (string, bool, error) {
+// Returns whether the rule also has criteria for the other IP version.
+func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rule *ACLRule, addressSets []AddressSet) ([]string, bool, error) {
 	var args []string
 
 	if rule.Direction == "ingress" {
@@ -716,52 +896,58 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 	}
 
 	// Add subject filters.
+	// As a set referenced by name cannot be combined with other addresses in a single match, the subjects are
+	// converted into alternative matches which are then expanded into separate rules.
 	isPartialRule := false
+	sourceMatches := [][]string{nil}
+	destinationMatches := [][]string{nil}
 
 	if rule.Source != "" {
-		matchArgs, partial, err := d.aclRuleSubjectToACLMatch("saddr", ipVersion, shared.SplitNTrimSpace(rule.Source, ",", -1, false)...)
+		matches, partial, err := d.aclRuleSubjectToACLMatch(networkName, "saddr", ipVersion, addressSets, shared.SplitNTrimSpace(rule.Source, ",", -1, false)...)
 		if err != nil {
-			return "", false, err
+			return nil, false, err
 		}
 
-		if matchArgs == nil {
-			return "", true, nil // Rule is not appropriate for ipVersion.
+		if matches == nil {
+			return nil, true, nil // Rule is not appropriate for ipVersion.
 		}
 
 		if partial && !isPartialRule {
 			isPartialRule = true
 		}
 
-		args = append(args, matchArgs...)
+		sourceMatches = matches
 	}
 
 	if rule.Destination != "" {
-		matchArgs, partial, err := d.aclRuleSubjectToACLMatch("daddr", ipVersion, shared.SplitNTrimSpace(rule.Destination, ",", -1, false)...)
+		matches, partial, err := d.aclRuleSubjectToACLMatch(networkName, "daddr", ipVersion, addressSets, shared.SplitNTrimSpace(rule.Destination, ",", -1, false)...)
 		if err != nil {
-			return "", false, err
+			return nil, false, err
 		}
 
-		if matchArgs == nil {
-			return "", partial, nil // Rule is not appropriate for ipVersion.
+		if matches == nil {
+			return nil, partial, nil // Rule is not appropriate for ipVersion.
 		}
 
 		if partial && !isPartialRule {
 			isPartialRule = true
 		}
 
-		args = append(args, matchArgs...)
+		destinationMatches = matches
 	}
 
+	var suffixArgs []string
+
 	// Add protocol filters.
 	if slices.Contains([]string{"tcp", "udp"}, rule.Protocol) {
-		args = append(args, "meta", "l4proto", rule.Protocol)
+		suffixArgs = append(suffixArgs, "meta", "l4proto", rule.Protocol)
 
 		if rule.SourcePort != "" {
-			args = append(args, d.aclRulePortToACLMatch("sport", shared.SplitNTrimSpace(rule.SourcePort, ",", -1, false)...)...)
+			suffixArgs = append(suffixArgs, d.aclRulePortToACLMatch("sport", shared.SplitNTrimSpace(rule.SourcePort, ",", -1, false)...)...)
 		}
 
 		if rule.DestinationPort != "" {
-			args = append(args, d.aclRulePortToACLMatch("dport", shared.SplitNTrimSpace(rule.DestinationPort, ",", -1, false)...)...)
+			suffixArgs = append(suffixArgs, d.aclRulePortToACLMatch("dport", shared.SplitNTrimSpace(rule.DestinationPort, ",", -1, false)...)...)
 		}
 	} else if slices.Contains([]string{"icmp4", "icmp6"}, rule.Protocol) {
 		var icmpIPVersion uint
@@ -771,11 +957,11 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 		case "icmp4":
 			protoName = "icmp"
 			icmpIPVersion = 4
-			args = append(args, "ip", "protocol", protoName)
+			suffixArgs = append(suffixArgs, "ip", "protocol", protoName)
 		case "icmp6":
 			protoName = "icmpv6"
 			icmpIPVersion = 6
-			args = append(args, "ip6", "nexthdr", protoName)
+			suffixArgs = append(suffixArgs, "ip6", "nexthdr", protoName)
 		}
 
 		if ipVersion != icmpIPVersion {
@@ -783,31 +969,38 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 			// with at least some subjects in the same family as ipVersion. So if the icmpIPVersion
 			// doesn't match the ipVersion then it means the rule contains mixed-version subjects
 			// which is invalid when using an IP version specific ICMP protocol.
-			if rule.Source != "" || rule.Destination != "" {
-				return "", false, fmt.Errorf("Invalid use of %q protocol with non-IPv%d source/destination criteria", rule.Protocol, ipVersion)
+			// Address sets may contain addresses of both families, those of the other family are
+			// just ignored.
+			if (rule.Source != "" || rule.Destination != "") && !aclRuleUsesAddressSets(rule) {
+				return nil, false, fmt.Errorf("Invalid use of %q protocol with non-IPv%d source/destination criteria", rule.Protocol, ipVersion)
 			}
 
 			// Otherwise it means this is just a blanket ICMP rule and is only appropriate for use
 			// with the corresponding ipVersion nft command.
-			return "", true, nil // Rule is not appropriate for ipVersion.
+			return nil, true, nil // Rule is not appropriate for ipVersion.
 		}
 
 		if rule.ICMPType != "" {
-			args = append(args, protoName, "type", rule.ICMPType)
+			suffixArgs = append(suffixArgs, protoName, "type", rule.ICMPType)
 
 			if rule.ICMPCode != "" {
-				args = append(args, protoName, "code", rule.ICMPCode)
+				suffixArgs = append(suffixArgs, protoName, "code", rule.ICMPCode)
 			}
 		}
 	}
 
+	// Count the packets matched by named rules.
+	if rule.Name != "" {
+		suffixArgs = append(suffixArgs, "counter")
+	}
+
 	// Handle logging.
 	if rule.Log {
-		args = append(args, "log")
+		suffixArgs = append(suffixArgs, "log")
 
 		if rule.LogName != "" {
 			// Add a trailing space to prefix for readability in logs.
-			args = append(args, "prefix", `"`+rule.LogName+` "`)
+			suffixArgs = append(suffixArgs, "prefix", `"`+rule.LogName+` "`)
 		}
 	}
 
@@ -817,21 +1010,61 @@ func (d Nftables) aclRuleCriteriaToRules(networkName string, ipVersion uint, rul
 		action = "accept"
 	}
 
-	args = append(args, action)
+	suffixArgs = append(suffixArgs, action)
+
+	// Identify the rules generated from a named rule so that their counters can be retrieved.
+	if rule.Name != "" {
+		suffixArgs = append(suffixArgs, "comment", `"`+rule.Name+`"`)
+	}
+
+	nftRules := make([]string, 0, len(sourceMatches)*len(destinationMatches))
+	for _, sourceMatch := range sourceMatches {
+		for _, destinationMatch := range destinationMatches {
+			ruleArgs := slices.Concat(args, sourceMatch, destinationMatch, suffixArgs)
+			nftRules = append(nftRules, strings.Join(ruleArgs, " "))
+		}
+	}
 
-	return strings.Join(args, " "), isPartialRule, nil
+	return nftRules, isPartialRule, nil
 }
 
-// aclRuleSubjectToACLMatch converts direction (source/destination) and subject criteria list into xtables args.
+// aclRuleSubjectToACLMatch converts direction (source/destination) and subject criteria list into alternative
+// nftables matches. The IP addresses, ranges and subnets are combined into a single match and each referenced
+// address set gets its own match.
 // Returns nil if none of the subjects are appropriate for the ipVersion.
-func (d Nftables) aclRuleSubjectToACLMatch(direction string, ipVersion uint, subjectCriteria ...string) ([]string, bool, error) {
+func (d Nftables) aclRuleSubjectToACLMatch(networkName string, direction string, ipVersion uint, addressSets []AddressSet, subjectCriteria ...string) ([][]string, bool, error) {
 	fieldParts := make([]string, 0, len(subjectCriteria))
+	var setMatches [][]string
 
 	partial := false
 
+	ipFamily := "ip"
+	if ipVersion == 6 {
+		ipFamily = "ip6"
+	}
+
 	// For each criterion check if value looks like IP CIDR.
 	for _, subjectCriterion := range subjectCriteria {
-		if validate.IsNetworkRange(subjectCriterion) == nil {
+		addressSetName, isAddressSet := strings.CutPrefix(subjectCriterion, addressSetRefPrefix)
+		if isAddressSet {
+			idx := slices.IndexFunc(addressSets, func(set AddressSet) bool { return set.Name == addressSetName })
+			if idx < 0 {
+				return nil, false, fmt.Errorf("Unknown address set %q", addressSetName)
+			}
+
+			hasIPVersion := false
+			for _, address := range addressSets[idx].Addresses {
+				if addressFamily(address) == ipVersion {
+					hasIPVersion = true
+				} else {
+					partial = true
+				}
+			}
+
+			if hasIPVersion {
+				setMatches = append(setMatches, []string{ipFamily, direction, "@" + d.addressSetName(networkName, addressSets[idx].ID, ipVersion)})
+			}
+		} else if validate.IsNetworkRange(subjectCriterion) == nil {
 			criterionParts := strings.SplitN(subjectCriterion, "-", 2)
 
 			if len(criterionParts) <= 1 {
@@ -876,13 +1109,15 @@ func (d Nftables) aclRuleSubjectToACLMatch(direction string, ipVersion uint, sub
 		}
 	}
 
+	var matches [][]string
 	if len(fieldParts) > 0 {
-		ipFamily := "ip"
-		if ipVersion == 6 {
-			ipFamily = "ip6"
-		}
+		matches = append(matches, []string{ipFamily, direction, "{" + strings.Join(fieldParts, ",") + "}"})
+	}
+
+	matches = append(matches, setMatches...)
 
-		return []string{ipFamily, direction, "{" + strings.Join(fieldParts, ",") + "}"}, partial, nil
+	if len(matches) > 0 {
+		return matches, partial, nil
 	}
 
 	return nil, partial, nil // No subjects suitable for ipVersion.
@@ -1038,3 +1273,87 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 
 	return nil
 }
+
+// NetworkApplyLoadBalancers apply network load balancer rules to firewall.
+// Connections are spread across the targets of each load balancer in a round-robin fashion.
+func (d Nftables) NetworkApplyLoadBalancers(networkName string, rules []LoadBalancer) error {
+	var dnatRules []map[string]any
+	var snatRules []map[string]any
+
+	for ruleIndex, rule := range rules {
+		// Validate the rule.
+		if rule.ListenAddress == nil {
+			return fmt.Errorf("Invalid rule %d, listen address is required", ruleIndex)
+		}
+
+		if rule.Protocol == "" || rule.ListenPort == 0 {
+			return fmt.Errorf("Invalid rule %d, protocol and listen port are required", ruleIndex)
+		}
+
+		if len(rule.Targets) == 0 {
+			continue // Nothing to load balance to.
+		}
+
+		ipFamily := "ip"
+		if rule.ListenAddress.To4() == nil {
+			ipFamily = "ip6"
+		}
+
+		targets := make([]string, 0, len(rule.Targets))
+		for targetIndex, target := range rule.Targets {
+			if target.Address == nil {
+				return fmt.Errorf("Invalid rule %d, target %d address is required", ruleIndex, targetIndex)
+			}
+
+			targetPort := strconv.FormatUint(target.Port, 10)
+			targets = append(targets, fmt.Sprintf("%d : %s . %s", targetIndex, target.Address.String(), targetPort))
+
+			snatRules = append(snatRules, map[string]any{
+				"ipFamily":    ipFamily,
+				"protocol":    rule.Protocol,
+				"targetHost":  target.Address.String(),
+				"targetPorts": targetPort,
+			})
+		}
+
+		dnatRules = append(dnatRules, map[string]any{
+			"ipFamily":      ipFamily,
+			"protocol":      rule.Protocol,
+			"listenAddress": rule.ListenAddress.String(),
+			"listenPort":    rule.ListenPort,
+			"targetsLen":    len(targets),
+			"targets":       strings.Join(targets, ", "),
+		})
+	}
+
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"chainPrefix":    "lb",
+		"family":         "inet",
+		"label":          networkName,
+		"dnatRules":      dnatRules,
+		"snatRules":      snatRules,
+	}
+
+	// Apply rules or remove chains if no rules generated.
+	if len(dnatRules) > 0 {
+		config := &strings.Builder{}
+		err := nftablesNetLoadBalancer.Execute(config, tplFields)
+		if err != nil {
+			return fmt.Errorf("Failed running %q template: %w", nftablesNetLoadBalancer.Name(), err)
+		}
+
+		err = shared.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
+		if err != nil {
+			return err
+		}
+	} else {
+		err := d.removeChains([]string{"inet"}, networkName, "lbprert", "lbout", "lbpstrt")
+		if err != nil {
+			return fmt.Errorf("Failed clearing nftables load balancer rules for network %q: %w", networkName, err)
+		}
+	}
+
+	return nil
+}
@@ -121,6 +121,40 @@ table {{.family}} {{.namespace}} {
 }
 `))
 
+// nftablesNetLoadBalancer DNATs new connections to one of the targets of each load balancer in turn.
+var nftablesNetLoadBalancer = template.Must(template.New("nftablesNetLoadBalancer").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}prert{{.chainSeparator}}{{.label}} {type nat hook prerouting priority -100; policy accept;}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}out{{.chainSeparator}}{{.label}} {type nat hook output priority -100; policy accept;}
+add chain {{.family}} {{.namespace}} {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}} {type nat hook postrouting priority 100; policy accept;}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}prert{{.chainSeparator}}{{.label}}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}out{{.chainSeparator}}{{.label}}
+flush chain {{.family}} {{.namespace}} {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}}
+
+table {{.family}} {{.namespace}} {
+	chain {{.chainPrefix}}prert{{.chainSeparator}}{{.label}} {
+		type nat hook prerouting priority -100; policy accept;
+		{{- range .dnatRules}}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPort}} dnat {{.ipFamily}} to numgen inc mod {{.targetsLen}} map { {{.targets}} }
+		{{- end}}
+	}
+
+	chain {{.chainPrefix}}out{{.chainSeparator}}{{.label}} {
+		type nat hook output priority -100; policy accept;
+		{{- range .dnatRules}}
+		{{.ipFamily}} daddr {{.listenAddress}} {{.protocol}} dport {{.listenPort}} dnat {{.ipFamily}} to numgen inc mod {{.targetsLen}} map { {{.targets}} }
+		{{- end}}
+	}
+
+	chain {{.chainPrefix}}pstrt{{.chainSeparator}}{{.label}} {
+		type nat hook postrouting priority 100; policy accept;
+		{{- range .snatRules}}
+		{{.ipFamily}} saddr {{.targetHost}} {{.ipFamily}} daddr {{.targetHost}} {{.protocol}} dport {{.targetPorts}} masquerade
+		{{- end}}
+	}
+}
+`))
+
 var nftablesNetACLSetup = template.Must(template.New("nftablesNetACLSetup").Parse(`
 add table {{.family}} {{.namespace}}
 add chain {{.family}} {{.namespace}} acl{{.chainSeparator}}{{.networkName}}
@@ -176,6 +210,14 @@ var nftablesNetACLRules = template.Must(template.New("nftablesNetACLRules").Pars
 flush chain {{.family}} {{.namespace}} acl{{.chainSeparator}}{{.networkName}}
 
 table {{.family}} {{.namespace}} {
+	{{- range .addressSets}}
+	set {{.name}} {
+		type {{.type}}
+		flags interval
+		auto-merge
+	}
+	{{- end}}
+
 	chain acl{{.chainSeparator}}{{.networkName}} {
                 ct state established,related accept
 
@@ -184,6 +226,13 @@ table {{.family}} {{.namespace}} {
 		{{- end}}
 	}
 }
+
+{{- range .addressSets}}
+flush set {{$.family}} {{$.namespace}} {{.name}}
+{{- if .elements}}
+add element {{$.family}} {{$.namespace}} {{.name}} { {{.elements}} }
+{{- end}}
+{{- end}}
 `))
 
 // nftablesInstanceBridgeFilter defines the rules needed for MAC, IPv4 and IPv6 bridge security filtering.
@@ -280,3 +329,12 @@ chain egress{{.chainSeparator}}netprio{{.chainSeparator}}{{.deviceLabel}} {
 	meta priority set "{{.netPrio}}"
 }
 `))
+
+// nftablesInstanceNetDSCP defines the rules to perform DSCP marking of the traffic coming from an instance.
+var nftablesInstanceNetDSCP = template.Must(template.New("nftablesInstanceNetDSCP").Parse(`
+chain ingress{{.chainSeparator}}dscp{{.chainSeparator}}{{.deviceLabel}} {
+	type filter hook ingress device "{{.deviceName}}" priority 0 ;
+	meta protocol ip ip dscp set {{.dscp}}
+	meta protocol ip6 ip6 dscp set {{.dscp}}
+}
+`))
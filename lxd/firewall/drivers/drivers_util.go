@@ -5,7 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
 )
 
 // portRangesFromSlice checks if adjacent indices in the given slice contain consecutive
@@ -147,3 +151,88 @@ func subnetPrefixHex(ipNet *net.IPNet) (string, error) {
 
 	return hexStr[:ones/4], nil
 }
+
+// addressSetRefPrefix is the prefix of the ACL rule subjects referencing an address set.
+const addressSetRefPrefix = "$"
+
+// addressFamily returns the IP version of an IP address or CIDR subnet, or 0 if it is neither.
+func addressFamily(address string) uint {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		ip, _, _ = net.ParseCIDR(address)
+	}
+
+	if ip == nil {
+		return 0
+	}
+
+	if ip.To4() == nil {
+		return 6
+	}
+
+	return 4
+}
+
+// aclRuleUsesAddressSets returns whether the source or destination of the rule references an address set.
+func aclRuleUsesAddressSets(rule *ACLRule) bool {
+	return strings.Contains(rule.Source, addressSetRefPrefix) || strings.Contains(rule.Destination, addressSetRefPrefix)
+}
+
+// aclRuleExpandAddressSets returns a copy of the rule with the address set references in its source and
+// destination replaced by the addresses of the sets. Addresses of the wrong family for the ICMP protocol of the
+// rule are left out. Returns false if a source or destination only references empty sets, in which case the rule
+// cannot match any traffic.
+func aclRuleExpandAddressSets(rule ACLRule, addressSets []AddressSet) (ACLRule, bool, error) {
+	var protocolFamily uint
+	switch rule.Protocol {
+	case "icmp4":
+		protocolFamily = 4
+	case "icmp6":
+		protocolFamily = 6
+	}
+
+	expand := func(field string) (string, bool, error) {
+		if field == "" {
+			return "", true, nil
+		}
+
+		subjects := make([]string, 0)
+		for _, subject := range shared.SplitNTrimSpace(field, ",", -1, false) {
+			name, isRef := strings.CutPrefix(subject, addressSetRefPrefix)
+			if !isRef {
+				subjects = append(subjects, subject)
+				continue
+			}
+
+			idx := slices.IndexFunc(addressSets, func(set AddressSet) bool { return set.Name == name })
+			if idx < 0 {
+				return "", false, fmt.Errorf("Unknown address set %q", name)
+			}
+
+			for _, address := range addressSets[idx].Addresses {
+				if protocolFamily > 0 && addressFamily(address) != protocolFamily {
+					continue
+				}
+
+				subjects = append(subjects, address)
+			}
+		}
+
+		return strings.Join(subjects, ","), len(subjects) > 0, nil
+	}
+
+	var err error
+	var sourceMatches, destinationMatches bool
+
+	rule.Source, sourceMatches, err = expand(rule.Source)
+	if err != nil {
+		return ACLRule{}, false, err
+	}
+
+	rule.Destination, destinationMatches, err = expand(rule.Destination)
+	if err != nil {
+		return ACLRule{}, false, err
+	}
+
+	return rule, sourceMatches && destinationMatches, nil
+}
@@ -145,3 +145,61 @@ func Test_getOptimisedSNATRanges(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+func Test_aclRuleExpandAddressSets(t *testing.T) {
+	addressSets := []AddressSet{
+		{ID: 1, Name: "office", Addresses: []string{"192.0.2.0/24", "2001:db8::/32"}},
+		{ID: 2, Name: "empty", Addresses: []string{}},
+	}
+
+	tests := []struct {
+		name            string
+		rule            ACLRule
+		expectedRule    ACLRule
+		expectedMatches bool
+		expectedErr     bool
+	}{
+		{
+			name:            "No address sets",
+			rule:            ACLRule{Source: "192.0.2.1", Destination: "198.51.100.0/24"},
+			expectedRule:    ACLRule{Source: "192.0.2.1", Destination: "198.51.100.0/24"},
+			expectedMatches: true,
+		},
+		{
+			name:            "Address set mixed with addresses",
+			rule:            ACLRule{Source: "198.51.100.1,$office"},
+			expectedRule:    ACLRule{Source: "198.51.100.1,192.0.2.0/24,2001:db8::/32"},
+			expectedMatches: true,
+		},
+		{
+			name:            "ICMP protocol filters address set family",
+			rule:            ACLRule{Destination: "$office", Protocol: "icmp6"},
+			expectedRule:    ACLRule{Destination: "2001:db8::/32", Protocol: "icmp6"},
+			expectedMatches: true,
+		},
+		{
+			name:            "Empty address set",
+			rule:            ACLRule{Source: "$empty", Destination: "192.0.2.1"},
+			expectedRule:    ACLRule{Source: "", Destination: "192.0.2.1"},
+			expectedMatches: false,
+		},
+		{
+			name:        "Unknown address set",
+			rule:        ACLRule{Source: "$missing"},
+			expectedErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		log.Printf("Running test #%d: %s", i, tt.name)
+		rule, matches, err := aclRuleExpandAddressSets(tt.rule, addressSets)
+		if tt.expectedErr {
+			assert.Error(t, err)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expectedRule, rule)
+		assert.Equal(t, tt.expectedMatches, matches)
+	}
+}
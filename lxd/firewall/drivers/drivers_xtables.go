@@ -160,6 +160,11 @@ func (d Xtables) networkForwardIPTablesComment(networkName string) string {
 	return "LXD network-forward " + networkName
 }
 
+// networkLoadBalancerIPTablesComment returns the iptables comment that is added to each network load balancer related rule.
+func (d Xtables) networkLoadBalancerIPTablesComment(networkName string) string {
+	return "LXD network-load-balancer " + networkName
+}
+
 // networkSetupNICFilteringChain creates the NIC filtering chain if it doesn't exist, and adds the jump rules to
 // the INPUT and FORWARD filter chains. Must be called after networkSetupForwardingPolicy so that the rules are
 // prepended before the default fowarding policy rules.
@@ -521,10 +526,33 @@ func (d Xtables) NetworkSetup(networkName string, ipv4Address net.IP, ipv6Addres
 	return nil
 }
 
+// NetworkACLRuleCounters returns the hit counters of the ACL rules applied to the network.
+// This isn't supported by the xtables driver as the generated rules aren't named.
+func (d Xtables) NetworkACLRuleCounters(networkName string) (map[string]ACLRuleCounters, error) {
+	return nil, errors.New("ACL rule counters are not supported by the xtables firewall driver")
+}
+
 // NetworkApplyACLRules applies ACL rules to the existing firewall chains.
-func (d Xtables) NetworkApplyACLRules(networkName string, rules []ACLRule) error {
+// The address sets referenced by the rules are expanded into the rules themselves.
+func (d Xtables) NetworkApplyACLRules(networkName string, rules []ACLRule, addressSets []AddressSet) error {
 	chain := iptablesChainACLFilterPrefix + "_" + networkName
 
+	expandedRules := make([]ACLRule, 0, len(rules))
+	for _, rule := range rules {
+		expandedRule, matches, err := aclRuleExpandAddressSets(rule, addressSets)
+		if err != nil {
+			return err
+		}
+
+		if !matches {
+			continue // Rule only references empty address sets.
+		}
+
+		expandedRules = append(expandedRules, expandedRule)
+	}
+
+	rules = expandedRules
+
 	// Parse rules for both IP families before applying either family of rules.
 	iptCmdRules := make(map[string][][]string)
 	for _, ipVersion := range []uint{4, 6} {
@@ -765,6 +793,7 @@ func (d Xtables) NetworkClear(networkName string, remove bool, ipVersions []uint
 	comments := []string{
 		d.networkIPTablesComment(networkName),
 		d.networkForwardIPTablesComment(networkName),
+		d.networkLoadBalancerIPTablesComment(networkName),
 	}
 
 	for _, ipVersion := range ipVersions {
@@ -1437,6 +1466,19 @@ func (d Xtables) InstanceClearNetPrio(projectName string, instanceName string, d
 	return nil
 }
 
+// InstanceSetupNetDSCP activates DSCP marking of the traffic coming from the specified instance device.
+// This isn't supported by the xtables driver as the traffic of bridged instance devices doesn't traverse iptables
+// unless br_netfilter is loaded.
+func (d Xtables) InstanceSetupNetDSCP(projectName string, instanceName string, deviceName string, dscp uint8) error {
+	return errors.New("DSCP marking is not supported by the xtables firewall driver")
+}
+
+// InstanceClearNetDSCP removes DSCP marking of the traffic coming from the specified instance device.
+// This is a no-op as the xtables driver doesn't support DSCP marking.
+func (d Xtables) InstanceClearNetDSCP(projectName string, instanceName string, deviceName string) error {
+	return nil
+}
+
 // iptablesChainExists checks whether a chain exists in a table, and whether it has any rules.
 func (d Xtables) iptablesChainExists(ipVersion uint, table string, chain string) (exists, hasRules bool, err error) {
 	var cmd string
@@ -1667,3 +1709,105 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 	reverter.Success()
 	return nil
 }
+
+// NetworkApplyLoadBalancers apply network load balancer rules to firewall.
+// Connections are spread across the targets of each load balancer in a round-robin fashion.
+func (d Xtables) NetworkApplyLoadBalancers(networkName string, rules []LoadBalancer) error {
+	// Validate all rules first.
+	for i, rule := range rules {
+		if rule.ListenAddress == nil {
+			return fmt.Errorf("Invalid rule %d, listen address is required", i)
+		}
+
+		if rule.Protocol == "" || rule.ListenPort == 0 {
+			return fmt.Errorf("Invalid rule %d, protocol and listen port are required", i)
+		}
+
+		for j, target := range rule.Targets {
+			if target.Address == nil {
+				return fmt.Errorf("Invalid rule %d, target %d address is required", i, j)
+			}
+		}
+	}
+
+	comment := d.networkLoadBalancerIPTablesComment(networkName)
+
+	clearNetworkLoadBalancers := func() error {
+		for _, ipVersion := range []uint{4, 6} {
+			err := d.iptablesClear(ipVersion, []string{comment}, "nat")
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Clear any load balancer rules associated to the network.
+	err := clearNetworkLoadBalancers()
+	if err != nil {
+		return err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Clear all network load balancers if we fail, otherwise the load balancers are only partially applied.
+	reverter.Add(func() {
+		err := clearNetworkLoadBalancers()
+		if err != nil {
+			logger.Error("Failed clearing firewall rules after failing to apply network load balancers", logger.Ctx{"network_name": networkName, "err": err})
+		}
+	})
+
+	for _, rule := range rules {
+		ipVersion := uint(4)
+		if rule.ListenAddress.To4() == nil {
+			ipVersion = 6
+		}
+
+		listenAddressStr := rule.ListenAddress.String()
+		listenPortStr := strconv.FormatUint(rule.ListenPort, 10)
+		targetsLen := len(rule.Targets)
+
+		// Rules are prepended, so add them from the last target to the first one. The Nth target from the end
+		// gets every Nth connection reaching its rule, which spreads the connections evenly across the targets.
+		for i := targetsLen - 1; i >= 0; i-- {
+			target := rule.Targets[i]
+			targetAddressStr := target.Address.String()
+			targetPortStr := strconv.FormatUint(target.Port, 10)
+
+			targetDest := targetAddressStr + ":" + targetPortStr
+			if ipVersion == 6 {
+				targetDest = "[" + targetAddressStr + "]:" + targetPortStr
+			}
+
+			// instance <-> instance.
+			// Requires instance's bridge port has hairpin mode enabled when br_netfilter is loaded.
+			err := d.iptablesPrepend(ipVersion, comment, "nat", "POSTROUTING", "-p", rule.Protocol, "--source", targetAddressStr, "--destination", targetAddressStr, "--dport", targetPortStr, "-j", "MASQUERADE")
+			if err != nil {
+				return err
+			}
+
+			match := []string{"-p", rule.Protocol, "--destination", listenAddressStr, "--dport", listenPortStr}
+			if i < targetsLen-1 {
+				match = append(match, "-m", "statistic", "--mode", "nth", "--every", strconv.Itoa(targetsLen-i), "--packet", "0")
+			}
+
+			// outbound <-> instance.
+			err = d.iptablesPrepend(ipVersion, comment, "nat", "PREROUTING", append(match, "-j", "DNAT", "--to-destination", targetDest)...)
+			if err != nil {
+				return err
+			}
+
+			// host <-> instance.
+			err = d.iptablesPrepend(ipVersion, comment, "nat", "OUTPUT", append(match, "-j", "DNAT", "--to-destination", targetDest)...)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	reverter.Success()
+	return nil
+}
@@ -13,8 +13,10 @@ type Firewall interface {
 
 	NetworkSetup(networkName string, ip4Address net.IP, ip6Address net.IP, opts drivers.Opts) error
 	NetworkClear(networkName string, remove bool, ipVersions []uint) error
-	NetworkApplyACLRules(networkName string, rules []drivers.ACLRule) error
+	NetworkApplyACLRules(networkName string, rules []drivers.ACLRule, addressSets []drivers.AddressSet) error
+	NetworkACLRuleCounters(networkName string) (map[string]drivers.ACLRuleCounters, error)
 	NetworkApplyForwards(networkName string, rules []drivers.AddressForward) error
+	NetworkApplyLoadBalancers(networkName string, rules []drivers.LoadBalancer) error
 
 	InstanceSetupBridgeFilter(projectName string, instanceName string, deviceName string, parentName string, hostName string, hwAddr string, IPv4Nets []*net.IPNet, IPv6Nets []*net.IPNet, parentManaged bool) error
 	InstanceClearBridgeFilter(projectName string, instanceName string, deviceName string, parentName string, hostName string, hwAddr string, IPv4Nets []*net.IPNet, IPv6Nets []*net.IPNet) error
@@ -27,4 +29,7 @@ type Firewall interface {
 
 	InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error
 	InstanceClearNetPrio(projectName string, instanceName string, deviceName string) error
+
+	InstanceSetupNetDSCP(projectName string, instanceName string, deviceName string, dscp uint8) error
+	InstanceClearNetDSCP(projectName string, instanceName string, deviceName string) error
 }
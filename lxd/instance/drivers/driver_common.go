@@ -925,14 +925,34 @@ func (d *common) getAttachedVolumes(inst instance.Instance) (attachedVolumes map
 	return attachedVolumes, nil
 }
 
+// snapshotFreezeDefaultTimeout is the default number of seconds the snapshot hooks and the filesystem freeze may last.
+const snapshotFreezeDefaultTimeout = 30
+
+// snapshotFreezeTimeout returns the number of seconds the snapshot hooks and the filesystem freeze may last.
+func (d *common) snapshotFreezeTimeout() int {
+	timeout, err := strconv.Atoi(d.expandedConfig["snapshots.consistency.timeout"])
+	if err != nil || timeout <= 0 {
+		return snapshotFreezeDefaultTimeout
+	}
+
+	return timeout
+}
+
+// snapshotFreezeFailed reports that the instance couldn't be prepared for an application-consistent snapshot.
+func (d *common) snapshotFreezeFailed(ctx context.Context, inst instance.Instance, name string, err error) {
+	d.logger.Warn("Failed preparing instance for snapshot, snapshot will be crash-consistent", logger.Ctx{"snapshot": name, "err": err})
+	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceSnapshotFreezeFailed.Event(ctx, inst, map[string]any{"snapshot": name, "error": err.Error()}))
+}
+
 // snapshotCommon handles the common part of a snapshot.
 // It creates the DB record and snapshots the instance, derives expiry from
 // inst's "snapshots.expiry" if expiry is nil, mounts the instance to update
 // backup.yaml, and reverts on error. The snapshot is marked stateful when
-// stateful is true. When diskVolumesMode is set to [api.DiskVolumesModeAllExclusive],
-// the instance's attached exclusive volumes are included in a crash-consistent
-// snapshot.
-func (d *common) snapshotCommon(ctx context.Context, inst instance.Instance, name string, expiry *time.Time, stateful bool, diskVolumesMode string, progressReporter ioprogress.ProgressReporter) error {
+// stateful is true. A running instance is frozen during the snapshot when freeze is
+// true and the storage driver requires it. When diskVolumesMode is set to
+// [api.DiskVolumesModeAllExclusive], the instance's attached exclusive volumes are
+// included in a crash-consistent snapshot.
+func (d *common) snapshotCommon(ctx context.Context, inst instance.Instance, name string, expiry *time.Time, stateful bool, freeze bool, diskVolumesMode string, progressReporter ioprogress.ProgressReporter) error {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -990,7 +1010,7 @@ func (d *common) snapshotCommon(ctx context.Context, inst instance.Instance, nam
 		return err
 	}
 
-	if pool.Driver().Info().RunningCopyFreeze && inst.IsRunning() && !inst.IsFrozen() {
+	if freeze && pool.Driver().Info().RunningCopyFreeze && inst.IsRunning() && !inst.IsFrozen() {
 		// Freeze the processes.
 		err = inst.Freeze(ctx)
 		if err != nil {
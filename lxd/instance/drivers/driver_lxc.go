@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"net"
 	"net/http"
@@ -33,6 +34,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sys/unix"
 
+	agentAPI "github.com/canonical/lxd/lxd-agent/api"
 	"github.com/canonical/lxd/lxd/apparmor"
 	"github.com/canonical/lxd/lxd/backup/config"
 	"github.com/canonical/lxd/lxd/cgroup"
@@ -3350,10 +3352,90 @@ func (d *lxc) RenderState(hostInterfaces []net.Interface, opts ...instance.State
 
 // snapshot creates a snapshot of the instance.
 func (d *lxc) snapshot(ctx context.Context, name string, expiry *time.Time, diskVolumesMode string, progressReporter ioprogress.ProgressReporter) error {
+	// Let the applications prepare for an application-consistent snapshot.
+	if d.IsRunning() && d.expandedConfig["snapshots.consistency"] == "application" {
+		err := d.runSnapshotHooks("freeze")
+		if err != nil {
+			d.snapshotFreezeFailed(ctx, d, name, err)
+		}
+
+		defer func() {
+			err := d.runSnapshotHooks("thaw")
+			if err != nil {
+				d.logger.Warn("Failed running snapshot thaw hooks", logger.Ctx{"err": err})
+			}
+		}()
+	}
+
 	// Wait for any file operations to complete to have a more consistent snapshot.
 	d.stopForkfile(false)
 
-	return d.snapshotCommon(ctx, d, name, expiry, false, diskVolumesMode, progressReporter)
+	return d.snapshotCommon(ctx, d, name, expiry, false, true, diskVolumesMode, progressReporter)
+}
+
+// runSnapshotHooks runs the executables of the snapshot hooks directory of the container with the given action as
+// argument. The hooks are run in lexical order for the "freeze" action and in reverse order otherwise, each one
+// being killed if it doesn't complete within the timeout.
+func (d *lxc) runSnapshotHooks(action string) error {
+	client, err := d.FileSFTPNoLock()
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ReadDir(agentAPI.SnapshotHooksPath)
+	_ = client.Close()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed listing snapshot hooks: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	if action != "freeze" {
+		slices.Reverse(entries)
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = devNull.Close() }()
+
+	timeout := time.Duration(d.snapshotFreezeTimeout()) * time.Second
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || entry.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		hookPath := path.Join(agentAPI.SnapshotHooksPath, entry.Name())
+
+		req := api.InstanceExecPost{
+			Command:     []string{hookPath, action},
+			Environment: map[string]string{"PATH": "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+			Cwd:         "/",
+		}
+
+		cmd, err := d.Exec(context.Background(), req, devNull, devNull, devNull)
+		if err != nil {
+			return fmt.Errorf("Failed running snapshot hook %q (%s): %w", hookPath, action, err)
+		}
+
+		timer := time.AfterFunc(timeout, func() { _ = cmd.Signal(unix.SIGKILL) })
+		exitStatus, err := cmd.Wait()
+		timer.Stop()
+		if err == nil && exitStatus != 0 {
+			err = fmt.Errorf("Exit status %d", exitStatus)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed running snapshot hook %q (%s): %w", hookPath, action, err)
+		}
+	}
+
+	return nil
 }
 
 // Snapshot takes a new snapshot.
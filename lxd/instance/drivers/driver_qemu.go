@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"net"
 	"net/http"
 	"net/url"
@@ -81,6 +82,7 @@ import (
 	"github.com/canonical/lxd/shared/osarch"
 	"github.com/canonical/lxd/shared/revert"
 	"github.com/canonical/lxd/shared/units"
+	"github.com/canonical/lxd/shared/validate"
 	"github.com/canonical/lxd/shared/version"
 )
 
@@ -90,6 +92,12 @@ const QEMUDefaultCPUCores = 1
 // QEMUDefaultMemSize is the default memory size for VMs if no limit specified.
 const QEMUDefaultMemSize = "1GiB"
 
+// qemuMemoryHotplugSlots is the number of memory slots available for hotplugged memory.
+const qemuMemoryHotplugSlots = 16
+
+// qemuMemoryHotplugAlignMB is the alignment of the hotplugged memory blocks in MiB (the Linux memory block size).
+const qemuMemoryHotplugAlignMB = 128
+
 // QEMUDefaultMaxBusPorts is the default number of PCI ports available for VMs.
 const QEMUDefaultMaxBusPorts uint8 = 8
 
@@ -962,6 +970,21 @@ func (d *qemu) restoreState(monitor *qmp.Monitor) error {
 	return nil
 }
 
+// checkNoHotpluggedMemory returns an error if memory was hotplugged into the VM. The VM state can't be restored
+// in that case, as the memory layout of the QEMU process started to restore it wouldn't match the saved one.
+func (d *qemu) checkNoHotpluggedMemory(monitor *qmp.Monitor) error {
+	pluggedSizeBytes, err := monitor.GetPluggedMemorySizeBytes()
+	if err != nil {
+		return err
+	}
+
+	if pluggedSizeBytes > 0 {
+		return errors.New("Cannot transfer the state of a VM with hotplugged memory, restart the VM first")
+	}
+
+	return nil
+}
+
 // saveStateHandle dumps the current VM state to a file handle.
 // Once started, the VM is in a paused state and it's up to the caller to wait for the transfer to complete and
 // resume or kill the VM guest.
@@ -988,6 +1011,11 @@ func (d *qemu) saveState(monitor *qmp.Monitor) error {
 	d.logger.Debug("Stateful checkpoint starting", logger.Ctx{"target": statePath})
 	defer d.logger.Debug("Stateful checkpoint finished", logger.Ctx{"target": statePath})
 
+	err := d.checkNoHotpluggedMemory(monitor)
+	if err != nil {
+		return err
+	}
+
 	// Save the checkpoint to state file.
 	_ = os.Remove(statePath)
 
@@ -1550,6 +1578,11 @@ func (d *qemu) start(ctx context.Context, stateful bool, op *operationlock.Insta
 		"-D", d.LogFilePath(),
 	}
 
+	// Only expose the VNC socket when requested.
+	if shared.IsTrue(d.expandedConfig["security.vnc"]) {
+		qemuCmd = append(qemuCmd, "-vnc", "unix:"+d.vncPath())
+	}
+
 	// If user wants to run with debug version of edk2
 	if shared.IsTrue(d.expandedConfig["boot.debug_edk2"]) {
 		// Here we ask the Qemu to redirect debug console output from I/O port to the file.
@@ -1773,6 +1806,27 @@ func (d *qemu) start(ctx context.Context, stateful bool, op *operationlock.Insta
 			op.Done(err)
 			return err
 		}
+
+		// Keep the vCPUs of each guest NUMA node on the host NUMA node its memory is bound to.
+		// Pinned vCPUs are handled by the rebalance procedure below.
+		if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) && validate.IsStaticCPUPinning(d.expandedConfig["limits.cpu"]) != nil {
+			err = d.setNUMAAffinity(monitor, cpuInfo)
+			if err != nil {
+				err = fmt.Errorf("Failed setting NUMA affinity of vCPU threads: %w", err)
+				op.Done(err)
+				return err
+			}
+		}
+	}
+
+	// Record the guest NUMA topology so that the instance state doesn't need to query QEMU for it.
+	if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) {
+		err = d.saveNUMAState(monitor)
+		if err != nil {
+			err = fmt.Errorf("Failed saving NUMA state: %w", err)
+			op.Done(err)
+			return err
+		}
 	}
 
 	// Trigger a rebalance procedure which will set vCPU affinity (pinning) (explicit or implicit)
@@ -2941,6 +2995,10 @@ func (d *qemu) spicePath() string {
 	return filepath.Join(d.LogPath(), "qemu.spice")
 }
 
+func (d *qemu) vncPath() string {
+	return filepath.Join(d.LogPath(), "qemu.vnc")
+}
+
 func (d *qemu) spiceCmdlineConfig() string {
 	return "unix=on,disable-ticketing=on,addr=" + d.spicePath()
 }
@@ -4014,11 +4072,13 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 		//nolint:prealloc
 		numaIDs := []uint64{}
 		numaNode := uint64(0)
-		for hostNode, entry := range cpuInfo.nodes {
+
+		// Number the guest NUMA nodes in the order of the host NUMA nodes.
+		for _, hostNode := range slices.Sorted(maps.Keys(cpuInfo.nodes)) {
 			hostNodes = append(hostNodes, hostNode)
 
 			numaIDs = append(numaIDs, numaNode)
-			for _, vcpu := range entry {
+			for _, vcpu := range cpuInfo.nodes[hostNode] {
 				numa = append(numa, qemuNumaEntry{
 					node:   numaNode,
 					socket: vcpuSocket[vcpu],
@@ -4066,8 +4126,32 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 	nodeMemory := int64(memSizeMB / int64(len(hostNodes)))
 	cpuOpts.memory = nodeMemory
 
+	// The memory of the NUMA nodes must add up to the memory size.
+	memSizeMB = nodeMemory * int64(len(hostNodes))
+
+	memOpts := qemuMemoryOpts{memSizeMB: memSizeMB}
+
+	// Reserve the address space needed to hotplug memory up to the hotplug limit.
+	if d.expandedConfig["limits.memory.hotplug"] != "" && d.architectureSupportsMemoryHotplug() {
+		maxMemSizeBytes, err := units.ParseByteSizeString(d.expandedConfig["limits.memory.hotplug"])
+		if err != nil {
+			return fmt.Errorf("limits.memory.hotplug invalid: %w", err)
+		}
+
+		if maxMemSizeBytes < memSizeBytes {
+			return errors.New("limits.memory.hotplug must be greater than or equal to limits.memory")
+		}
+
+		if cpuOpts.hugepages != "" {
+			return errors.New("limits.memory.hotplug cannot be used with limits.memory.hugepages")
+		}
+
+		memOpts.maxMemSizeMB = maxMemSizeBytes / 1024 / 1024
+		memOpts.slots = qemuMemoryHotplugSlots
+	}
+
 	if cfg != nil {
-		*cfg = append(*cfg, qemuMemory(&qemuMemoryOpts{memSizeMB})...)
+		*cfg = append(*cfg, qemuMemory(&memOpts)...)
 		*cfg = append(*cfg, qemuCPU(&cpuOpts, cpuPinning)...)
 	}
 
@@ -5478,8 +5562,39 @@ func (d *qemu) snapshot(ctx context.Context, name string, expiry *time.Time, sta
 		}
 	}
 
+	var commitOverlay func() error
+	if !stateful && d.IsRunning() {
+		if diskVolumesMode != api.DiskVolumesModeAllExclusive && shared.IsTrue(d.expandedConfig["snapshots.overlay"]) {
+			// Redirect the writes of the VM to a temporary overlay instead of pausing it while its
+			// root disk is snapshotted.
+			monitor, err = qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+			if err != nil {
+				return err
+			}
+
+			commitOverlay, err = d.snapshotOverlay(ctx, monitor, name)
+			if err != nil {
+				return err
+			}
+		} else if d.expandedConfig["snapshots.consistency"] == "application" {
+			// Keep the guest frozen for the whole snapshot.
+			thaw := d.snapshotFreeze(ctx, name)
+			if thaw != nil {
+				defer thaw()
+			}
+		}
+	}
+
 	// Create the snapshot.
-	err = d.snapshotCommon(ctx, d, name, expiry, stateful, diskVolumesMode, progressReporter)
+	err = d.snapshotCommon(ctx, d, name, expiry, stateful, commitOverlay == nil, diskVolumesMode, progressReporter)
+	if commitOverlay != nil {
+		// Merge the overlay back into the root disk even if the snapshot failed so writes aren't lost.
+		commitErr := commitOverlay()
+		if commitErr != nil {
+			return commitErr
+		}
+	}
+
 	if err != nil {
 		return err
 	}
@@ -5501,6 +5616,181 @@ func (d *qemu) snapshot(ctx context.Context, name string, expiry *time.Time, sta
 	return nil
 }
 
+// snapshotOverlay redirects the writes of the running VM to a temporary qcow2 overlay of its root disk so that the
+// root volume can be snapshotted without pausing the VM. The guest is frozen while the overlay is added.
+// Returns a function that merges the overlay back into the root disk and removes it.
+func (d *qemu) snapshotOverlay(ctx context.Context, monitor *qmp.Monitor, name string) (func() error, error) {
+	rootDiskName := "lxd_root"                 // Name of the root disk device.
+	overlayDiskName := "lxd_root_snapshot_cow" // Name of the overlay disk device.
+
+	pool, err := d.getStoragePool()
+	if err != nil {
+		return nil, err
+	}
+
+	rootDiskSize, err := storagePools.InstanceDiskBlockSize(pool, d, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the overlay on the VM's config volume, so its size is limited by the root disk's `size.state` property.
+	overlayFile := filepath.Join(d.Path(), "snapshot_overlay.qcow2")
+
+	// Ensure there is no existing overlay file.
+	err = os.Remove(overlayFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// Always remove the overlay file so that if qemu-img fails the partially written file is removed.
+	defer func() { _ = os.Remove(overlayFile) }()
+
+	_, err = shared.RunCommand(d.state.ShutdownCtx, "qemu-img", "create", "-f", "qcow2", overlayFile, strconv.FormatInt(rootDiskSize, 10))
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating snapshot overlay %q: %w", overlayFile, err)
+	}
+
+	f, err := os.OpenFile(overlayFile, unix.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Failed opening snapshot overlay %q: %w", overlayFile, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	// Remove the overlay file as it is only needed until it is merged back.
+	err = os.Remove(overlayFile)
+	if err != nil {
+		return nil, err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	info, err := monitor.SendFileWithFDSet(overlayDiskName, f, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed sending file descriptor of %q for snapshot overlay: %w", f.Name(), err)
+	}
+
+	revert.Add(func() { _ = monitor.RemoveFDFromFDSet(overlayDiskName) })
+
+	_ = f.Close() // Do not prevent clean unmount when instance is stopped.
+
+	// Add the overlay file as a block device (not visible to the guest OS).
+	err = monitor.AddBlockDevice(map[string]any{
+		"driver":    "qcow2",
+		"node-name": overlayDiskName,
+		"read-only": false,
+		"file": map[string]any{
+			"driver":   "file",
+			"filename": fmt.Sprintf("/dev/fdset/%d", info.ID),
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed adding snapshot overlay block device: %w", err)
+	}
+
+	revert.Add(func() { _ = monitor.RemoveBlockDevice(overlayDiskName) })
+
+	// Freeze the guest so that the root disk is consistent when the writes are redirected.
+	thaw := d.snapshotFreeze(ctx, name)
+
+	// Redirect the writes to the overlay.
+	err = monitor.BlockDevSnapshot(rootDiskName, overlayDiskName)
+
+	if thaw != nil {
+		thaw()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed taking snapshot overlay: %w", err)
+	}
+
+	cleanup := revert.Clone().Fail
+	revert.Success()
+
+	d.logger.Debug("Setup snapshot overlay")
+
+	return func() error {
+		defer cleanup()
+
+		err := monitor.BlockCommit(overlayDiskName)
+		if err != nil {
+			return fmt.Errorf("Failed merging snapshot overlay: %w", err)
+		}
+
+		d.logger.Debug("Merged snapshot overlay")
+
+		return nil
+	}, nil
+}
+
+// snapshotFreeze freezes the guest filesystems through the lxd-agent, running the snapshot hooks of the guest when
+// application-consistent snapshots are enabled. Returns a function that thaws the guest, or nil if it couldn't be
+// frozen in which case the snapshot is crash-consistent. The snapshot is also crash-consistent if the lxd-agent
+// thawed the guest on its own because the freeze timeout expired before the snapshot completed.
+func (d *qemu) snapshotFreeze(ctx context.Context, name string) func() {
+	hooks := d.expandedConfig["snapshots.consistency"] == "application"
+
+	freezeFailed := func(err error) {
+		if hooks {
+			d.snapshotFreezeFailed(ctx, d, name, err)
+		} else {
+			d.logger.Warn("Failed freezing guest filesystems, snapshot will be crash-consistent", logger.Ctx{"err": err})
+		}
+	}
+
+	timeout := time.Duration(d.snapshotFreezeTimeout()) * time.Second
+	frozenAt := time.Now()
+
+	thaw, err := d.agentFreezeFilesystems(hooks)
+	if err != nil {
+		freezeFailed(err)
+		return nil
+	}
+
+	return func() {
+		err := thaw()
+		if api.StatusErrorCheck(err, http.StatusConflict) || time.Since(frozenAt) >= timeout {
+			freezeFailed(fmt.Errorf("Guest filesystems were thawed after the %s freeze timeout, before the snapshot completed", timeout))
+		} else if err != nil {
+			d.logger.Warn("Failed thawing guest filesystems", logger.Ctx{"err": err})
+		}
+	}
+}
+
+// agentFreezeFilesystems freezes the guest filesystems through the lxd-agent, running the snapshot hooks of the guest
+// first if hooks is true. Returns a function that thaws them.
+func (d *qemu) agentFreezeFilesystems(hooks bool) (func() error, error) {
+	client, err := d.getAgentClient()
+	if err != nil {
+		return nil, err
+	}
+
+	agent, err := lxd.ConnectLXDHTTP(nil, client)
+	if err != nil {
+		d.logger.Error("Failed connecting to lxd-agent", logger.Ctx{"err": err})
+		return nil, errors.New("Failed connecting to lxd-agent")
+	}
+
+	req := agentAPI.FreezePost{
+		Timeout: d.snapshotFreezeTimeout(),
+		Hooks:   hooks,
+	}
+
+	_, _, err = agent.RawQuery(http.MethodPost, "/1.0/freeze", req, "")
+	if err != nil {
+		agent.Disconnect()
+		return nil, err
+	}
+
+	return func() error {
+		defer agent.Disconnect()
+
+		_, _, err := agent.RawQuery(http.MethodDelete, "/1.0/freeze", nil, "")
+		return err
+	}, nil
+}
+
 // Snapshot takes a new snapshot.
 func (d *qemu) Snapshot(ctx context.Context, name string, expiry *time.Time, stateful bool, diskVolumesMode string, progressReporter ioprogress.ProgressReporter) error {
 	unlock, err := d.updateBackupFileLock(context.Background())
@@ -6062,6 +6352,10 @@ func (d *qemu) Update(ctx context.Context, args db.InstanceArgs, actionType inst
 					}
 				}
 
+				if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) {
+					return fmt.Errorf("Cannot update key %q when limits.cpu.numa is enabled and the VM is running", key)
+				}
+
 				// If the key is being unset, set it to default value.
 				if value == "" {
 					value = "1"
@@ -6238,7 +6532,8 @@ func (d *qemu) Update(ctx context.Context, args db.InstanceArgs, actionType inst
 	return nil
 }
 
-// updateMemoryLimit live updates the VM's memory limit by reszing the balloon device.
+// updateMemoryLimit live updates the VM's memory limit by hotplugging memory (when growing beyond the current
+// memory size) and by resizing the balloon device.
 func (d *qemu) updateMemoryLimit(newLimit string) error {
 	if newLimit == "" {
 		return nil
@@ -6267,7 +6562,12 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 		return err
 	}
 
-	baseSizeMB := baseSizeBytes / 1024 / 1024
+	pluggedSizeBytes, err := monitor.GetPluggedMemorySizeBytes()
+	if err != nil {
+		return err
+	}
+
+	totalSizeMB := (baseSizeBytes + pluggedSizeBytes) / 1024 / 1024
 
 	curSizeBytes, err := monitor.GetMemoryBalloonSizeBytes()
 	if err != nil {
@@ -6278,8 +6578,11 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 
 	if curSizeMB == newSizeMB {
 		return nil
-	} else if baseSizeMB < newSizeMB {
-		return fmt.Errorf("Cannot increase memory size beyond boot time size when VM is running (Boot time size %dMiB, new size %dMiB)", baseSizeMB, newSizeMB)
+	} else if totalSizeMB < newSizeMB {
+		err = d.hotplugMemory(monitor, totalSizeMB, newSizeMB)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Set effective memory size.
@@ -6315,6 +6618,85 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 	return fmt.Errorf("Failed setting memory to %dMiB (currently %dMiB) as it was taking too long", newSizeMB, curSizeMB)
 }
 
+// hotplugMemory grows the memory of a running VM from its current total size to at least the new size by
+// hotplugging a DIMM. The hotplugged memory is rounded up to the Linux memory block size and the balloon is then
+// used to reach the exact new size.
+func (d *qemu) hotplugMemory(monitor *qmp.Monitor, totalSizeMB int64, newSizeMB int64) error {
+	if d.expandedConfig["limits.memory.hotplug"] == "" {
+		return fmt.Errorf("Cannot increase memory size beyond boot time size when VM is running unless limits.memory.hotplug is set (Boot time size %dMiB, new size %dMiB)", totalSizeMB, newSizeMB)
+	}
+
+	if !d.architectureSupportsMemoryHotplug() {
+		return errors.New("Cannot increase memory size beyond boot time size as memory hotplug isn't supported by QEMU on this system")
+	}
+
+	// As limits.memory.hotplug can't be changed while the VM is running, this is the limit it was started with.
+	maxSizeBytes, err := units.ParseByteSizeString(d.expandedConfig["limits.memory.hotplug"])
+	if err != nil {
+		return fmt.Errorf("limits.memory.hotplug invalid: %w", err)
+	}
+
+	maxSizeMB := maxSizeBytes / 1024 / 1024
+
+	memDevices, err := monitor.QueryMemoryDevices()
+	if err != nil {
+		return err
+	}
+
+	if newSizeMB > maxSizeMB {
+		return fmt.Errorf("Cannot increase memory size beyond the memory hotplug limit when VM is running (Hotplug limit %dMiB, new size %dMiB)", maxSizeMB, newSizeMB)
+	}
+
+	if len(memDevices) >= qemuMemoryHotplugSlots {
+		return fmt.Errorf("Cannot hotplug memory as all %d memory slots are in use", qemuMemoryHotplugSlots)
+	}
+
+	// Round the hotplugged memory up to the memory block size, without going over the hotplug limit.
+	addSizeMB := newSizeMB - totalSizeMB
+	addSizeMB = ((addSizeMB + qemuMemoryHotplugAlignMB - 1) / qemuMemoryHotplugAlignMB) * qemuMemoryHotplugAlignMB
+	if totalSizeMB+addSizeMB > maxSizeMB {
+		return fmt.Errorf("Cannot hotplug %dMiB of memory as it would go beyond the memory hotplug limit (Hotplug limit %dMiB, current size %dMiB)", addSizeMB, maxSizeMB, totalSizeMB)
+	}
+
+	// Find a free DIMM index.
+	usedIDs := make([]string, 0, len(memDevices))
+	for _, memDevice := range memDevices {
+		usedIDs = append(usedIDs, memDevice.Data.ID)
+	}
+
+	var dimmID string
+	for i := range qemuMemoryHotplugSlots {
+		dimmID = fmt.Sprintf("%sdimm%d", qemuDeviceIDPrefix, i)
+		if !slices.Contains(usedIDs, dimmID) {
+			break
+		}
+	}
+
+	memBackendID := fmt.Sprintf("%smem-%s", qemuDeviceNamePrefix, strings.TrimPrefix(dimmID, qemuDeviceIDPrefix))
+
+	memBackend := map[string]any{
+		"qom-type": "memory-backend-memfd",
+		"id":       memBackendID,
+		"size":     addSizeMB * 1024 * 1024,
+		"share":    true,
+	}
+
+	memDevice := map[string]any{
+		"driver": "pc-dimm",
+		"id":     dimmID,
+		"memdev": memBackendID,
+	}
+
+	err = monitor.AddMemoryDevice(memBackend, memDevice)
+	if err != nil {
+		return fmt.Errorf("Failed hotplugging %dMiB of memory: %w", addSizeMB, err)
+	}
+
+	d.logger.Debug("Hotplugged memory", logger.Ctx{"device": dimmID, "sizeMiB": addSizeMB})
+
+	return nil
+}
+
 func (d *qemu) cleanup() {
 	// Unmount any leftovers
 	_ = d.removeUnixDevices()
@@ -7042,6 +7424,11 @@ func (d *qemu) migrateSendLive(ctx context.Context, pool storagePools.Pool, clus
 		return err
 	}
 
+	err = d.checkNoHotpluggedMemory(monitor)
+	if err != nil {
+		return err
+	}
+
 	rootDiskName := "lxd_root"                  // Name of source disk device to sync from
 	nbdTargetDiskName := "lxd_root_nbd"         // Name of NBD disk device added to local VM to sync to.
 	rootSnapshotDiskName := "lxd_root_snapshot" // Name of snapshot disk device to use.
@@ -8034,6 +8421,12 @@ func (d *qemu) Console(ctx context.Context, protocol string) (*os.File, chan err
 		path = d.consolePath()
 	case instance.ConsoleTypeVGA:
 		path = d.spicePath()
+	case instance.ConsoleTypeVNC:
+		if shared.IsFalseOrEmpty(d.expandedConfig["security.vnc"]) {
+			return nil, nil, errors.New("VNC console requires security.vnc to be enabled")
+		}
+
+		path = d.vncPath()
 	default:
 		return nil, nil, fmt.Errorf("Unknown protocol %q", protocol)
 	}
@@ -8059,6 +8452,29 @@ func (d *qemu) Console(ctx context.Context, protocol string) (*os.File, chan err
 	return file, chDisconnect, nil
 }
 
+// Screenshot returns a PNG image of the VGA display.
+func (d *qemu) Screenshot() ([]byte, error) {
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance is not running")
+	}
+
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return nil, err
+	}
+
+	// Have QEMU write the image in the log directory as it can create files there.
+	path := filepath.Join(d.LogPath(), "qemu.screenshot."+uuid.New().String()+".png")
+	defer func() { _ = os.Remove(path) }()
+
+	err = monitor.Screendump(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed taking screenshot: %w", err)
+	}
+
+	return os.ReadFile(path)
+}
+
 // Exec a command inside the instance.
 func (d *qemu) Exec(ctx context.Context, req api.InstanceExecPost, stdin *os.File, stdout *os.File, stderr *os.File) (instance.Cmd, error) {
 	revert := revert.New()
@@ -8352,6 +8768,14 @@ func (d *qemu) renderState(statusCode api.StatusCode, opts ...instance.StateRend
 				}
 			}
 		}
+
+		// Report the guest NUMA topology and its placement on the host.
+		if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) {
+			status.NUMANodes, err = d.numaState()
+			if err != nil {
+				d.logger.Warn("Failed getting NUMA state", logger.Ctx{"err": err})
+			}
+		}
 	}
 
 	status.Pid = int64(pid)
@@ -8827,6 +9251,17 @@ func (d *qemu) cpuTopology(limit string) (*cpuTopology, error) {
 					topology.vcpus[i] = i
 					topology.nodes[uint64(node)] = append(topology.nodes[uint64(node)], i)
 				}
+			} else if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) {
+				// Expose one guest NUMA node per host NUMA node, with the vCPUs split evenly across them.
+				if nrLimit < len(numaNodeIDs) {
+					return nil, fmt.Errorf("limits.cpu.numa requires at least one vCPU per NUMA node (%d vCPUs for %d NUMA nodes)", nrLimit, len(numaNodeIDs))
+				}
+
+				for i := range nrLimit {
+					node := uint64(numaNodeIDs[i*len(numaNodeIDs)/nrLimit])
+					topology.vcpus[uint64(i)] = uint64(i)
+					topology.nodes[node] = append(topology.nodes[node], uint64(i))
+				}
 			} else {
 				// If multiple NUMA nodes are given, distribute vCPUs evenly across specified nodes.
 				node := numaNodeIDs[0]
@@ -8838,6 +9273,16 @@ func (d *qemu) cpuTopology(limit string) (*cpuTopology, error) {
 			}
 		}
 
+		if shared.IsTrue(d.expandedConfig["limits.cpu.numa"]) {
+			if d.expandedConfig["limits.cpu.nodes"] == "" {
+				return nil, errors.New("limits.cpu.numa requires limits.cpu.nodes to be set when limits.cpu is a number of vCPUs")
+			}
+
+			if d.expandedConfig["limits.cpu.pin_strategy"] == "auto" {
+				return nil, errors.New("limits.cpu.numa cannot be used with limits.cpu.pin_strategy set to auto")
+			}
+		}
+
 		return topology, nil
 	}
 
@@ -9088,6 +9533,11 @@ func (d *qemu) checkFeatures(hostArch int, qemuPath string) (map[string]any, err
 		"-machine", qemuMachineType(hostArch),
 	}
 
+	if slices.Contains([]int{osarch.ARCH_64BIT_INTEL_X86, osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN}, hostArch) {
+		// Reserve some address space for the memory hotplug check.
+		qemuArgs = append(qemuArgs, "-m", fmt.Sprintf("%dM,slots=1,maxmem=%dM", qemuMemoryHotplugAlignMB, qemuMemoryHotplugAlignMB*2))
+	}
+
 	if hostArch == osarch.ARCH_64BIT_INTEL_X86 {
 		// On Intel, use KVM acceleration as it's needed for SEV detection.
 		// This also happens to be less resource intensive but can't
@@ -9209,6 +9659,28 @@ func (d *qemu) checkFeatures(hostArch int, qemuPath string) (map[string]any, err
 		features["cpu_hotplug"] = struct{}{}
 	}
 
+	// Check memory hotplug feature.
+	if slices.Contains([]int{osarch.ARCH_64BIT_INTEL_X86, osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN}, hostArch) {
+		memBackend := map[string]any{
+			"qom-type": "memory-backend-ram",
+			"id":       qemuDeviceNamePrefix + "feature-check-mem",
+			"size":     qemuMemoryHotplugAlignMB * 1024 * 1024,
+		}
+
+		memDevice := map[string]any{
+			"driver": "pc-dimm",
+			"id":     qemuDeviceNamePrefix + "feature-check-dimm",
+			"memdev": qemuDeviceNamePrefix + "feature-check-mem",
+		}
+
+		err = monitor.AddMemoryDevice(memBackend, memDevice)
+		if err != nil {
+			logger.Debug("Failed adding memory device during VM feature check", logger.Ctx{"err": err})
+		} else {
+			features["memory_hotplug"] = struct{}{}
+		}
+	}
+
 	// Check AMD SEV features (only for x86 architecture)
 	if hostArch == osarch.ARCH_64BIT_INTEL_X86 {
 		cmdline, err := os.ReadFile("/proc/cmdline")
@@ -9550,6 +10022,142 @@ func (d *qemu) setCPUs(count int) error {
 	return nil
 }
 
+// setNUMAAffinity restricts the vCPU threads of each guest NUMA node to the CPUs of the host NUMA node it is
+// placed on.
+func (d *qemu) setNUMAAffinity(monitor *qmp.Monitor, cpuInfo *cpuTopology) error {
+	cpus, err := resources.GetCPU()
+	if err != nil {
+		return err
+	}
+
+	// Get the CPUs of each host NUMA node.
+	hostNodeCPUs := map[uint64][]int64{}
+	for _, socket := range cpus.Sockets {
+		for _, core := range socket.Cores {
+			for _, thread := range core.Threads {
+				hostNodeCPUs[thread.NUMANode] = append(hostNodeCPUs[thread.NUMANode], thread.ID)
+			}
+		}
+	}
+
+	// Get the thread of each vCPU.
+	vcpuThreads, err := monitor.QueryCPUs()
+	if err != nil {
+		return err
+	}
+
+	for hostNode, vcpus := range cpuInfo.nodes {
+		if len(hostNodeCPUs[hostNode]) == 0 {
+			return fmt.Errorf("Host NUMA node %d has no CPUs", hostNode)
+		}
+
+		affinitySet := unix.CPUSet{}
+		for _, cpu := range hostNodeCPUs[hostNode] {
+			affinitySet.Set(int(cpu))
+		}
+
+		for _, vcpuThread := range vcpuThreads {
+			if !slices.Contains(vcpus, uint64(vcpuThread.Index)) {
+				continue
+			}
+
+			err := unix.SchedSetaffinity(vcpuThread.ThreadID, &affinitySet)
+			if err != nil {
+				return fmt.Errorf("Failed setting affinity of vCPU %d: %w", vcpuThread.Index, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// numaFilePath returns the path where the guest NUMA topology of the running VM is recorded.
+func (d *qemu) numaFilePath() string {
+	return filepath.Join(d.LogPath(), "qemu.numa")
+}
+
+// saveNUMAState records the NUMA nodes of the running VM so that they can be reported by numaState.
+// The topology can't change while the VM is running, so it only needs to be queried once at start.
+func (d *qemu) saveNUMAState(monitor *qmp.Monitor) error {
+	nodes, err := d.queryNUMAState(monitor)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(d.numaFilePath(), data, 0600)
+}
+
+// numaState returns the NUMA nodes of the running VM as recorded at start.
+func (d *qemu) numaState() ([]api.InstanceStateNUMANode, error) {
+	data, err := os.ReadFile(d.numaFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []api.InstanceStateNUMANode{}
+	err = json.Unmarshal(data, &nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// queryNUMAState returns the NUMA nodes of the running VM with the vCPUs they contain and the host NUMA nodes
+// their memory is bound to.
+func (d *qemu) queryNUMAState(monitor *qmp.Monitor) ([]api.InstanceStateNUMANode, error) {
+	memdevs, err := monitor.QueryMemdev()
+	if err != nil {
+		return nil, err
+	}
+
+	vcpus, err := monitor.QueryCPUs()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []api.InstanceStateNUMANode{}
+	for _, memdev := range memdevs {
+		// Only consider the memory backends of the NUMA nodes (hotplugged memory uses other backends).
+		var nodeID uint64
+		_, err := fmt.Sscanf(memdev.ID, "mem%d", &nodeID)
+		if err != nil {
+			continue
+		}
+
+		node := api.InstanceStateNUMANode{
+			ID:        nodeID,
+			HostNodes: memdev.HostNodes,
+			CPUs:      []uint64{},
+			Memory:    memdev.Size,
+		}
+
+		if node.HostNodes == nil {
+			node.HostNodes = []uint64{}
+		}
+
+		for _, vcpu := range vcpus {
+			if uint64(vcpu.Props.NodeID) == nodeID {
+				node.CPUs = append(node.CPUs, uint64(vcpu.Index))
+			}
+		}
+
+		slices.Sort(node.CPUs)
+		nodes = append(nodes, node)
+	}
+
+	slices.SortFunc(nodes, func(a api.InstanceStateNUMANode, b api.InstanceStateNUMANode) int {
+		return int(a.ID) - int(b.ID)
+	})
+
+	return nodes, nil
+}
+
 func (d *qemu) architectureSupportsCPUHotplug() bool {
 	// Check supported features.
 	info := DriverStatuses()[instancetype.VM].Info
@@ -9557,6 +10165,13 @@ func (d *qemu) architectureSupportsCPUHotplug() bool {
 	return found
 }
 
+func (d *qemu) architectureSupportsMemoryHotplug() bool {
+	// Check supported features.
+	info := DriverStatuses()[instancetype.VM].Info
+	_, found := info.Features["memory_hotplug"]
+	return found
+}
+
 // addFileDescriptor adds a file path to the list of files to open and pass file descriptor to other processes.
 // Returns the file descriptor number that the other process will receive.
 func (d *qemu) addFileDescriptor(fdFiles *[]*os.File, file *os.File) int {
@@ -142,14 +142,25 @@ func qemuBase(opts *qemuBaseOpts) []cfgSection {
 }
 
 type qemuMemoryOpts struct {
-	memSizeMB int64
+	memSizeMB    int64
+	maxMemSizeMB int64 // Memory hotplug ceiling (0 to disable memory hotplug).
+	slots        int
 }
 
 func qemuMemory(opts *qemuMemoryOpts) []cfgSection {
+	entries := []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}}
+
+	if opts.maxMemSizeMB > 0 {
+		entries = append(entries, []cfgEntry{
+			{key: "maxmem", value: fmt.Sprintf("%dM", opts.maxMemSizeMB)},
+			{key: "slots", value: strconv.Itoa(opts.slots)},
+		}...)
+	}
+
 	return []cfgSection{{
 		name:    "memory",
 		comment: "Memory",
-		entries: []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}},
+		entries: entries,
 	}}
 }
 
@@ -0,0 +1,160 @@
+package drivers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cfgSectionEntries returns the entries of the sections with the given name.
+func cfgSectionEntries(sections []cfgSection, name string) []map[string]string {
+	var result []map[string]string
+	for _, section := range sections {
+		if section.name != name {
+			continue
+		}
+
+		entries := map[string]string{}
+		for _, entry := range section.entries {
+			entries[entry.key] = entry.value
+		}
+
+		result = append(result, entries)
+	}
+
+	return result
+}
+
+func TestQemuCPUTopologyNUMA(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]string
+		expectedNodes map[uint64][]uint64
+		expectedErr   string
+	}{
+		{
+			name:          "vCPUs split across 2 host nodes",
+			config:        map[string]string{"limits.cpu": "4", "limits.cpu.nodes": "0,1", "limits.cpu.numa": "true"},
+			expectedNodes: map[uint64][]uint64{0: {0, 1}, 1: {2, 3}},
+		},
+		{
+			name:          "vCPUs split unevenly across 3 host nodes",
+			config:        map[string]string{"limits.cpu": "5", "limits.cpu.nodes": "0,2-3", "limits.cpu.numa": "true"},
+			expectedNodes: map[uint64][]uint64{0: {0, 1}, 2: {2, 3}, 3: {4}},
+		},
+		{
+			name:          "one vCPU per host node",
+			config:        map[string]string{"limits.cpu": "3", "limits.cpu.nodes": "1-3", "limits.cpu.numa": "true"},
+			expectedNodes: map[uint64][]uint64{1: {0}, 2: {1}, 3: {2}},
+		},
+		{
+			name:          "all vCPUs on the first node without limits.cpu.numa",
+			config:        map[string]string{"limits.cpu": "4", "limits.cpu.nodes": "0,1"},
+			expectedNodes: map[uint64][]uint64{0: {0, 1, 2, 3}},
+		},
+		{
+			name:        "fewer vCPUs than host nodes",
+			config:      map[string]string{"limits.cpu": "2", "limits.cpu.nodes": "0-2", "limits.cpu.numa": "true"},
+			expectedErr: "limits.cpu.numa requires at least one vCPU per NUMA node (2 vCPUs for 3 NUMA nodes)",
+		},
+		{
+			name:        "automatic CPU pinning",
+			config:      map[string]string{"limits.cpu": "4", "limits.cpu.nodes": "0,1", "limits.cpu.numa": "true", "limits.cpu.pin_strategy": "auto"},
+			expectedErr: "limits.cpu.numa cannot be used with limits.cpu.pin_strategy set to auto",
+		},
+		{
+			name:        "no host nodes",
+			config:      map[string]string{"limits.cpu": "4", "limits.cpu.numa": "true"},
+			expectedErr: "limits.cpu.numa requires limits.cpu.nodes to be set when limits.cpu is a number of vCPUs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &qemu{common: common{expandedConfig: tt.config}}
+
+			topology, err := d.cpuTopology(tt.config["limits.cpu"])
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, topology.sockets)
+			require.Equal(t, len(topology.vcpus), topology.cores)
+			require.Equal(t, 1, topology.threads)
+			require.Equal(t, tt.expectedNodes, topology.nodes)
+		})
+	}
+}
+
+func TestQemuAddCPUMemoryConfigNUMA(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            map[string]string
+		expectedMemory    string
+		expectedNodeSize  string
+		expectedHostNodes []string
+		expectedCPUNodes  []string
+	}{
+		{
+			name:              "2 host nodes",
+			config:            map[string]string{"limits.cpu": "4", "limits.cpu.nodes": "0,1", "limits.cpu.numa": "true", "limits.memory": "1025MiB"},
+			expectedMemory:    "1024M",
+			expectedNodeSize:  "512M",
+			expectedHostNodes: []string{"0", "1"},
+			expectedCPUNodes:  []string{"0", "0", "1", "1"},
+		},
+		{
+			name:              "3 host nodes",
+			config:            map[string]string{"limits.cpu": "5", "limits.cpu.nodes": "0,2-3", "limits.cpu.numa": "true", "limits.memory": "1000MiB"},
+			expectedMemory:    "999M",
+			expectedNodeSize:  "333M",
+			expectedHostNodes: []string{"0", "2", "3"},
+			expectedCPUNodes:  []string{"0", "0", "1", "1", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &qemu{common: common{expandedConfig: tt.config}, architectureName: "x86_64"}
+
+			cpuInfo, err := d.cpuTopology(tt.config["limits.cpu"])
+			require.NoError(t, err)
+
+			var sections []cfgSection
+			err = d.addCPUMemoryConfig(&sections, cpuInfo)
+			require.NoError(t, err)
+
+			memory := cfgSectionEntries(sections, "memory")
+			require.Len(t, memory, 1)
+			require.Equal(t, tt.expectedMemory, memory[0]["size"])
+
+			smp := cfgSectionEntries(sections, "smp-opts")
+			require.Len(t, smp, 1)
+			require.Equal(t, tt.config["limits.cpu"], smp[0]["cpus"])
+
+			// Each guest node has its memory bound to its host node.
+			for i, hostNode := range tt.expectedHostNodes {
+				memdev := cfgSectionEntries(sections, fmt.Sprintf(`object "mem%d"`, i))
+				require.Len(t, memdev, 1)
+				require.Equal(t, tt.expectedNodeSize, memdev[0]["size"])
+				require.Equal(t, "bind", memdev[0]["policy"])
+				require.Equal(t, hostNode, memdev[0]["host-nodes.0"])
+			}
+
+			require.Empty(t, cfgSectionEntries(sections, fmt.Sprintf(`object "mem%d"`, len(tt.expectedHostNodes))))
+
+			// Each vCPU is placed on its guest node.
+			var cpuNodes []string
+			for _, numa := range cfgSectionEntries(sections, "numa") {
+				if numa["type"] == "cpu" {
+					cpuNodes = append(cpuNodes, numa["node-id"])
+				}
+			}
+
+			require.Equal(t, tt.expectedCPUNodes, cpuNodes)
+		})
+	}
+}
@@ -439,6 +439,122 @@ func (m *Monitor) GetMemorySizeBytes() (int64, error) {
 	return resp.Return.BaseMemory, nil
 }
 
+// Screendump saves a PNG image of the display to the given path.
+func (m *Monitor) Screendump(path string) error {
+	var args struct {
+		Filename string `json:"filename"`
+		Format   string `json:"format"`
+	}
+
+	args.Filename = path
+	args.Format = "png"
+
+	err := m.run("screendump", args, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetPluggedMemorySizeBytes returns the current size of the hotplugged memory in bytes.
+func (m *Monitor) GetPluggedMemorySizeBytes() (int64, error) {
+	// Prepare the response.
+	var resp struct {
+		Return struct {
+			PluggedMemory int64 `json:"plugged-memory"`
+		} `json:"return"`
+	}
+
+	err := m.run("query-memory-size-summary", nil, &resp)
+	if err != nil {
+		return -1, err
+	}
+
+	return resp.Return.PluggedMemory, nil
+}
+
+// MemoryDevice contains information about a hotplugged memory device.
+type MemoryDevice struct {
+	Type string `json:"type"`
+	Data struct {
+		ID     string `json:"id"`
+		Slot   int    `json:"slot"`
+		Size   int64  `json:"size"`
+		Memdev string `json:"memdev"`
+	} `json:"data"`
+}
+
+// QueryMemoryDevices returns a list of hotplugged memory devices.
+func (m *Monitor) QueryMemoryDevices() ([]MemoryDevice, error) {
+	// Prepare the response.
+	var resp struct {
+		Return []MemoryDevice `json:"return"`
+	}
+
+	err := m.run("query-memory-devices", nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed querying memory devices: %w", err)
+	}
+
+	return resp.Return, nil
+}
+
+// Memdev contains information about a memory backend.
+type Memdev struct {
+	ID        string   `json:"id"`
+	Size      int64    `json:"size"`
+	Policy    string   `json:"policy"`
+	HostNodes []uint64 `json:"host-nodes"`
+}
+
+// QueryMemdev returns a list of memory backends.
+func (m *Monitor) QueryMemdev() ([]Memdev, error) {
+	// Prepare the response.
+	var resp struct {
+		Return []Memdev `json:"return"`
+	}
+
+	err := m.run("query-memdev", nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed querying memory backends: %w", err)
+	}
+
+	return resp.Return, nil
+}
+
+// AddMemoryDevice adds a memory backend object and the memory device using it.
+func (m *Monitor) AddMemoryDevice(memBackend map[string]any, device map[string]any) error {
+	revert := revert.New()
+	defer revert.Fail()
+
+	id, ok := memBackend["id"].(string)
+	if !ok {
+		return errors.New("Memory backend object ID not provided")
+	}
+
+	err := m.run("object-add", memBackend, nil)
+	if err != nil {
+		return fmt.Errorf("Failed adding memory backend object: %w", err)
+	}
+
+	revert.Add(func() {
+		memBackendID := map[string]string{
+			"id": id,
+		}
+
+		_ = m.run("object-del", memBackendID, nil)
+	})
+
+	err = m.AddDevice(device)
+	if err != nil {
+		return fmt.Errorf("Failed adding memory device: %w", err)
+	}
+
+	revert.Success()
+	return nil
+}
+
 // GetMemoryBalloonSizeBytes returns effective size of the memory in bytes (considering the current balloon size).
 func (m *Monitor) GetMemoryBalloonSizeBytes() (int64, error) {
 	// Prepare the response.
@@ -41,8 +41,12 @@ const HookStop = "onstop"
 const (
 	ConsoleTypeConsole = "console"
 	ConsoleTypeVGA     = "vga"
+	ConsoleTypeVNC     = "vnc"
 )
 
+// ConsoleTypeScreenshot is the console type used to retrieve a screenshot of the VGA display of a VM.
+const ConsoleTypeScreenshot = "screenshot"
+
 // TemplateTrigger trigger name.
 type TemplateTrigger string
 
@@ -213,6 +217,9 @@ type VM interface {
 	// UEFI vars handling.
 	UEFIVars() (*api.InstanceUEFIVars, error)
 	UEFIVarsUpdate(newUEFIVarsSet api.InstanceUEFIVars) error
+
+	// Screenshot returns a PNG image of the VGA display.
+	Screenshot() ([]byte, error)
 }
 
 // CriuMigrationArgs arguments for CRIU migration.
@@ -395,6 +395,28 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Template for the snapshot name
 	"snapshots.pattern": validate.IsAny,
 
+	// lxdmeta:generate(entities=instance; group=snapshots; key=snapshots.consistency)
+	// Possible values are `crash` and `application`.
+	// With `application`, the snapshot hooks of the instance are run before and after snapshotting it while it is running.
+	// For virtual machines, the guest filesystems are also frozen through the `lxd-agent`.
+	//
+	// See {ref}`instances-snapshots-consistency` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `crash`
+	//  liveupdate: yes
+	//  shortdesc: Consistency of the snapshots of running instances
+	"snapshots.consistency": validate.Optional(validate.IsOneOf("crash", "application")),
+
+	// lxdmeta:generate(entities=instance; group=snapshots; key=snapshots.consistency.timeout)
+	// Number of seconds each snapshot hook may run for, and after which the guest filesystems of virtual machines are thawed if the snapshot didn't complete.
+	// ---
+	//  type: integer
+	//  defaultdesc: `30`
+	//  liveupdate: yes
+	//  shortdesc: Timeout of the snapshot hooks and filesystem freeze
+	"snapshots.consistency.timeout": validate.Optional(validate.IsUint32),
+
 	// lxdmeta:generate(entities=instance; group=snapshots; key=snapshots.expiry)
 	// Specify an expression like `1M 2H 3d 4w 5m 6y`.
 	// ---
@@ -1060,6 +1082,32 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to back the instance using huge pages
 	"limits.memory.hugepages": validate.Optional(validate.IsBool),
 
+	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.memory.hotplug)
+	// Maximum memory size that {config:option}`instance-resource-limits:limits.memory` can be raised to while the VM is running.
+	// Specify a fixed value in bytes. Various suffixes are supported (see {ref}`instances-limit-units`).
+	//
+	// When set, memory is hotplugged into the running VM when its memory limit is raised beyond its current memory size.
+	// Hotplugged memory is only removed when the VM is restarted, and a VM with hotplugged memory can't be live-migrated or stopped statefully.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Memory hotplug limit
+	"limits.memory.hotplug": validate.Optional(validate.IsSize),
+
+	// lxdmeta:generate(entities=instance; group=snapshots; key=snapshots.overlay)
+	// When enabled, the writes of a running VM are redirected to a temporary overlay while its root disk is snapshotted, instead of pausing the VM.
+	// The guest filesystems are frozen through the `lxd-agent` while the overlay is added.
+	//
+	// See {ref}`instances-snapshots-overlay` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Whether to snapshot running VMs without pausing them
+	"snapshots.overlay": validate.Optional(validate.IsBool),
+
 	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.cpu.pin_strategy)
 	// Specify the strategy for VM CPU auto pinning.
 	// Possible values: `none` (disables CPU auto pinning) and `auto` (enables CPU auto pinning).
@@ -1073,6 +1121,19 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: VM CPU auto pinning strategy
 	"limits.cpu.pin_strategy": validate.Optional(validate.IsOneOf("none", "auto")),
 
+	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.cpu.numa)
+	// When {config:option}`instance-resource-limits:limits.cpu` is a number of vCPUs and {config:option}`instance-resource-limits:limits.cpu.nodes` lists several NUMA nodes,
+	// the guest gets one NUMA node per host NUMA node, with the vCPUs split evenly across them and the memory of each node bound to its host NUMA node.
+	//
+	// See {ref}`instance-options-limits-cpu-vm` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether to expose the NUMA topology to the guest
+	"limits.cpu.numa": validate.Optional(validate.IsBool),
+
 	// lxdmeta:generate(entities=instance; group=resource-limits; key=limits.max_bus_ports)
 	// Total number of user configurable PCI/PCIe devices that can be attached to the VM.
 	// ---
@@ -1123,6 +1184,18 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether the `lxd-agent` is queried for state information and metrics
 	"security.agent.metrics": validate.Optional(validate.IsBool),
 
+	// lxdmeta:generate(entities=instance; group=security; key=security.vnc)
+	// When enabled, QEMU exposes the VGA display of the VM over VNC and the `vnc` console type becomes available.
+	//
+	// See {ref}`instances-console-vga` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether to expose the VGA display over VNC
+	"security.vnc": validate.Optional(validate.IsBool),
+
 	// lxdmeta:generate(entities=instance; group=boot; key=boot.mode)
 	// The `uefi-secureboot` mode uses UEFI firmware with secure boot enabled.
 	// The `uefi-nosecureboot` mode uses UEFI firmware with secure boot disabled.
@@ -25,6 +25,7 @@ import (
 	"github.com/canonical/lxd/lxd/project/limits"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
+	storagePools "github.com/canonical/lxd/lxd/storage"
 	"github.com/canonical/lxd/lxd/util"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
@@ -351,10 +352,54 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = backup.ValidateTarget(s, req.Target)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	fullName := name + shared.SnapshotDelimiter + backupName
 	// We keep the req.ContainerOnly for backward compatibility.
 	instanceOnly := req.InstanceOnly || req.ContainerOnly //nolint:staticcheck,unused
 
+	// Resolve the base of an incremental backup to one of the instance's snapshots.
+	parentSnapshot := ""
+	if req.IncrementalFrom != "" {
+		if !req.OptimizedStorage || instanceOnly {
+			return response.BadRequest(errors.New("Incremental backups must use optimized storage and include snapshots"))
+		}
+
+		pool, err := storagePools.LoadByInstance(s, inst)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if !pool.Driver().Info().IncrementalBackups {
+			return response.BadRequest(fmt.Errorf("Incremental backups aren't supported by storage driver %q", pool.Driver().Info().Name))
+		}
+
+		_, err = backup.ValidateBackupName(req.IncrementalFrom)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		snapshots, err := inst.Snapshots()
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		snapNames := make([]string, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			_, snapName, _ := api.GetParentAndSnapshotName(snapshot.Name())
+			snapNames = append(snapNames, snapName)
+		}
+
+		backupPath := filepath.Join(s.BackupsStoragePath(projectName), "instances", project.Instance(projectName, name+shared.SnapshotDelimiter+req.IncrementalFrom))
+		parentSnapshot, err = backupIncrementalParent(s, req.IncrementalFrom, snapNames, backupPath)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	backup := func(ctx context.Context, op *operations.Operation) error {
 		args := db.InstanceBackup{
 			Name:                 fullName,
@@ -364,9 +409,10 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 			InstanceOnly:         instanceOnly,
 			OptimizedStorage:     req.OptimizedStorage,
 			CompressionAlgorithm: req.CompressionAlgorithm,
+			Target:               req.Target,
 		}
 
-		err := backupCreate(ctx, s, args, inst, req.Version, op)
+		err := backupCreate(ctx, s, args, inst, parentSnapshot, req.Version, op)
 		if err != nil {
 			return fmt.Errorf("Create backup: %w", err)
 		}
@@ -748,11 +794,15 @@ func instanceBackupExportGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	s.Events.SendLifecycle(projectName, lifecycle.InstanceBackupRetrieved.Event(r.Context(), fullName, backup.Instance(), nil))
+
+	if backup.Target() != "" {
+		return backupTargetExportResponse(r.Context(), s, backup.Target(), backup.TargetLocation())
+	}
+
 	ent := response.FileResponseEntry{
 		Path: filepath.Join(d.State().BackupsStoragePath(backup.Instance().Project().Name), "instances", project.Instance(projectName, backup.Name())),
 	}
 
-	s.Events.SendLifecycle(projectName, lifecycle.InstanceBackupRetrieved.Event(r.Context(), fullName, backup.Instance(), nil))
-
 	return response.FileResponse([]response.FileResponseEntry{ent}, nil)
 }
@@ -21,6 +21,7 @@ import (
 	liblxc "github.com/lxc/go-lxc"
 	"golang.org/x/sys/unix"
 
+	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/db/operationtype"
 	"github.com/canonical/lxd/lxd/instance"
@@ -31,6 +32,7 @@ import (
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/cancel"
+	"github.com/canonical/lxd/shared/entity"
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/version"
 	"github.com/canonical/lxd/shared/ws"
@@ -64,7 +66,7 @@ type consoleWs struct {
 	// terminal height
 	height int
 
-	// channel type (either console or vga)
+	// channel type (either console, vga or vnc)
 	protocol string
 
 	// track either server or client disconnected
@@ -95,7 +97,7 @@ func (s *consoleWs) Connect(op *operations.Operation, r *http.Request, w http.Re
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
 		return s.connectConsole(r, w)
-	case instance.ConsoleTypeVGA:
+	case instance.ConsoleTypeVGA, instance.ConsoleTypeVNC:
 		return s.connectVGA(r, w)
 	default:
 		return fmt.Errorf("Unknown protocol %q", s.protocol)
@@ -180,7 +182,7 @@ func (s *consoleWs) connectVGA(r *http.Request, w http.ResponseWriter) error {
 
 		logger.Debug("VGA dynamic websocket connected")
 
-		console, _, err := s.instance.Console(r.Context(), "vga")
+		console, _, err := s.instance.Console(r.Context(), s.protocol)
 		if err != nil {
 			_ = conn.Close()
 			return err
@@ -226,7 +228,7 @@ func (s *consoleWs) Do(ctx context.Context, _ *operations.Operation) error {
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
 		return s.doConsole(ctx)
-	case instance.ConsoleTypeVGA:
+	case instance.ConsoleTypeVGA, instance.ConsoleTypeVNC:
 		return s.doVGA(ctx)
 	default:
 		return fmt.Errorf("Unknown protocol %q", s.protocol)
@@ -503,7 +505,7 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Basic parameter validation.
-	if !slices.Contains([]string{instance.ConsoleTypeConsole, instance.ConsoleTypeVGA}, post.Type) {
+	if !slices.Contains([]string{instance.ConsoleTypeConsole, instance.ConsoleTypeVGA, instance.ConsoleTypeVNC}, post.Type) {
 		return response.BadRequest(fmt.Errorf("Unknown console type %q", post.Type))
 	}
 
@@ -516,6 +518,14 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("VGA console is only supported by virtual machines"))
 	}
 
+	if post.Type == instance.ConsoleTypeVNC && inst.Type() != instancetype.VM {
+		return response.BadRequest(errors.New("VNC console is only supported by virtual machines"))
+	}
+
+	if post.Type == instance.ConsoleTypeVNC && shared.IsFalseOrEmpty(inst.ExpandedConfig()["security.vnc"]) {
+		return response.BadRequest(errors.New("VNC console requires security.vnc to be enabled"))
+	}
+
 	if !inst.IsRunning() {
 		return response.BadRequest(errors.New("Instance is not running"))
 	}
@@ -569,19 +579,26 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 //	Get console log
 //
 //	Gets the console log for the instance.
+//	With the `screenshot` type, gets a PNG image of the VGA display of a running virtual machine instead.
 //
 //	---
 //	produces:
 //	  - application/json
+//	  - application/octet-stream
 //	parameters:
 //	  - in: query
 //	    name: project
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: type
+//	    description: Type of content to retrieve (empty for the console log, or screenshot)
+//	    type: string
+//	    example: screenshot
 //	responses:
 //	  "200":
-//	     description: Raw console log
+//	     description: Raw console log or PNG image
 //	     content:
 //	       application/octet-stream:
 //	         schema:
@@ -613,6 +630,19 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("Invalid instance name"))
 	}
 
+	consoleType := request.QueryParam(r, "type")
+	if consoleType != "" && consoleType != instance.ConsoleTypeScreenshot {
+		return response.BadRequest(fmt.Errorf("Unknown console type %q", consoleType))
+	}
+
+	// Screenshots give the same access to the instance as the VGA console.
+	if consoleType == instance.ConsoleTypeScreenshot {
+		err = s.Authorizer.CheckPermission(r.Context(), entity.InstanceURL(projectName, name), auth.EntitlementCanAccessConsole)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Forward the request if the container is remote.
 	resp, err := forwardedResponseIfInstanceIsRemote(r.Context(), s, projectName, name, instanceType)
 	if err != nil {
@@ -628,6 +658,10 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	if consoleType == instance.ConsoleTypeScreenshot {
+		return instanceConsoleScreenshot(inst)
+	}
+
 	if inst.Type() != instancetype.Container {
 		return response.SmartError(errors.New("Instance is not container type"))
 	}
@@ -676,6 +710,32 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 	return response.FileResponse([]response.FileResponseEntry{ent}, nil)
 }
 
+// instanceConsoleScreenshot returns a PNG image of the VGA display of a running virtual machine.
+func instanceConsoleScreenshot(inst instance.Instance) response.Response {
+	vm, ok := inst.(instance.VM)
+	if !ok {
+		return response.BadRequest(errors.New("Screenshots are only supported by virtual machines"))
+	}
+
+	if !vm.IsRunning() {
+		return response.BadRequest(errors.New("Instance is not running"))
+	}
+
+	screenshot, err := vm.Screenshot()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Filename:     "screenshot.png",
+		File:         bytes.NewReader(screenshot),
+		FileModified: time.Now(),
+		FileSize:     int64(len(screenshot)),
+	}
+
+	return response.FileResponse([]response.FileResponseEntry{ent}, nil)
+}
+
 // swagger:operation DELETE /1.0/instances/{name}/console instances instance_console_delete
 //
 //	Clear the console log
@@ -818,6 +818,17 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 		return response.BadRequest(errors.New("Instance definition in backup config is missing"))
 	}
 
+	// Incremental backups are applied on top of the existing instance they were taken from.
+	if bInfo.Parent != "" {
+		bInfo.Project = projectName
+		if instanceName != "" {
+			bInfo.Name = instanceName
+		}
+
+		revert.Success() // The backup file is handed over.
+		return instanceRefreshFromBackup(s, r, bInfo, backupFile)
+	}
+
 	// Initialise the devices maps.
 	if bInfo.Config.Instance.Devices == nil {
 		bInfo.Config.Instance.Devices = make(map[string]map[string]string, 0)
@@ -1014,6 +1025,189 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 	return operations.OperationResponse(op)
 }
 
+// instanceRefreshFromBackup applies an incremental backup on top of the stopped instance it was taken from.
+// The snapshots included in the backup are added to the instance and its root volume is brought to the state
+// it was in when the backup was taken. The configuration of the existing instance is left untouched.
+func instanceRefreshFromBackup(s *state.State, r *http.Request, bInfo *backup.Info, backupFile *os.File) response.Response {
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() { _ = backupFile.Close() })
+
+	// The backup overwrites the root volume of an existing instance so the caller must be able to edit it.
+	err := s.Authorizer.CheckPermission(r.Context(), entity.InstanceURL(bInfo.Project, bInfo.Name), auth.EntitlementCanEdit)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Forward the backup to the cluster member hosting the instance.
+	client, err := cluster.ConnectIfInstanceIsRemote(r.Context(), s, bInfo.Project, bInfo.Name, instancetype.Any)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if client != nil {
+		_, err = backupFile.Seek(0, io.SeekStart)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		logger.Debug("Forward incremental backup request", logger.Ctx{"project": bInfo.Project, "instance": bInfo.Name})
+		op, err := client.UseProject(bInfo.Project).CreateInstanceFromBackup(lxd.InstanceBackupArgs{
+			BackupFile: backupFile,
+			Name:       bInfo.Name,
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		opAPI := op.Get()
+		return operations.ForwardedOperationResponse(&opAPI)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, bInfo.Project, bInfo.Name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading instance %q to apply incremental backup to: %w", bInfo.Name, err))
+	}
+
+	if inst.IsRunning() {
+		return response.BadRequest(errors.New("Incremental backups can only be applied to stopped instances"))
+	}
+
+	if bInfo.Config.Instance.Type != inst.Type().String() {
+		return response.BadRequest(fmt.Errorf("Backup of instance type %q cannot be applied to instance of type %q", bInfo.Config.Instance.Type, inst.Type().String()))
+	}
+
+	pool, err := storagePools.LoadByInstance(s, inst)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !pool.Driver().Info().IncrementalBackups {
+		return response.BadRequest(fmt.Errorf("Incremental backups aren't supported by storage driver %q", pool.Driver().Info().Name))
+	}
+
+	logger.Debug("Incremental backup file info loaded", logger.Ctx{
+		"type":      bInfo.Type,
+		"name":      bInfo.Name,
+		"project":   bInfo.Project,
+		"backend":   bInfo.Backend,
+		"parent":    bInfo.Parent,
+		"snapshots": bInfo.Snapshots,
+	})
+
+	// Copy reverter so far so we can use it inside run after this function has finished.
+	runRevert := revert.Clone()
+
+	run := func(ctx context.Context, op *operations.Operation) (err error) {
+		defer func() { _ = backupFile.Close() }()
+		defer runRevert.Fail()
+
+		// Complete the snapshot operations, which hold the snapshot locks, once done.
+		snapOps := []*operationlock.InstanceOperation{}
+		defer func() {
+			for _, snapInstOp := range snapOps {
+				snapInstOp.Done(err)
+			}
+		}()
+
+		// Create the records of the snapshots included in the backup.
+		for _, snapName := range bInfo.Snapshots {
+			err := instancetype.ValidSnapName(snapName)
+			if err != nil {
+				return fmt.Errorf("Invalid snapshot name %q: %w", snapName, err)
+			}
+
+			i := slices.IndexFunc(bInfo.Config.Snapshots, func(snap *api.InstanceSnapshot) bool { return snap.Name == snapName })
+			if i < 0 {
+				return fmt.Errorf("Snapshot %q definition in backup config is missing", snapName)
+			}
+
+			snap := bInfo.Config.Snapshots[i]
+
+			arch, err := osarch.ArchitectureId(snap.Architecture)
+			if err != nil {
+				return err
+			}
+
+			var profiles []api.Profile
+			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				profiles, err = tx.GetProfiles(ctx, bInfo.Project, snap.Profiles)
+
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("Failed loading profiles for instance snapshot %q: %w", snapName, err)
+			}
+
+			if snap.Devices == nil {
+				snap.Devices = make(map[string]map[string]string, 0)
+			}
+
+			if snap.ExpandedDevices == nil {
+				snap.ExpandedDevices = make(map[string]map[string]string, 0)
+			}
+
+			internalImportRootDevicePopulate(pool.Name(), snap.Devices, snap.ExpandedDevices, profiles)
+
+			_, snapInstOp, cleanup, err := instance.CreateInternal(ctx, s, db.InstanceArgs{
+				Project:      bInfo.Project,
+				Architecture: arch,
+				BaseImage:    snap.Config["volatile.base_image"],
+				Config:       snap.Config,
+				CreationDate: snap.CreatedAt,
+				Type:         inst.Type(),
+				Snapshot:     true,
+				Devices:      deviceConfig.NewDevices(snap.Devices),
+				Ephemeral:    snap.Ephemeral,
+				ExpiryDate:   snap.ExpiresAt,
+				LastUsedDate: snap.LastUsedAt,
+				Name:         inst.Name() + shared.SnapshotDelimiter + snapName,
+				Profiles:     profiles,
+				Stateful:     snap.Stateful,
+			}, true)
+			if err != nil {
+				return fmt.Errorf("Failed creating instance snapshot record %q: %w", snapName, err)
+			}
+
+			runRevert.Add(cleanup)
+			snapOps = append(snapOps, snapInstOp)
+		}
+
+		err = pool.RefreshInstanceFromBackup(inst, *bInfo, backupFile, op)
+		if err != nil {
+			return fmt.Errorf("Apply incremental backup to instance: %w", err)
+		}
+
+		err = inst.UpdateBackupFile()
+		if err != nil {
+			return err
+		}
+
+		runRevert.Success()
+		return nil
+	}
+
+	args := operations.OperationArgs{
+		ProjectName: bInfo.Project,
+		EntityURL:   api.NewURL().Path(version.APIVersion, "instances", bInfo.Name).Project(bInfo.Project),
+		Type:        operationtype.BackupRestore,
+		Class:       operations.OperationClassTask,
+		RunHook:     run,
+		Metadata: map[string]any{
+			api.MetadataEntityURL: api.NewURL().Path(version.APIVersion, "instances", bInfo.Name).Project(bInfo.Project).String(),
+		},
+	}
+
+	op, err := operations.ScheduleUserOperationFromRequest(s, r, args)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	revert.Success()
+	return operations.OperationResponse(op)
+}
+
 // setupInstanceArgs sets the database instance arguments and determines the storage pool to use.
 func setupInstanceArgs(s *state.State, instType instancetype.Type, projectName string, profiles []api.Profile, req *api.InstancesPost) (storagePool string, instArgs *db.InstanceArgs, resp response.Response) {
 	// Parse the architecture name
@@ -1159,7 +1353,24 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
-		return createFromBackup(s, r, targetProjectName, r.Body, r.Header.Get("X-LXD-pool"), r.Header.Get("X-LXD-name"), deviceMap)
+		// The backup can be fetched from a backup target rather than being uploaded.
+		data := io.Reader(r.Body)
+		if r.Header.Get("X-LXD-backup-target") != "" {
+			err := backup.ValidateInstanceTargetLocation(targetProjectName, r.Header.Get("X-LXD-backup-location"))
+			if err != nil {
+				return response.BadRequest(err)
+			}
+
+			reader, _, err := backupTargetReader(r.Context(), s, r.Header.Get("X-LXD-backup-target"), r.Header.Get("X-LXD-backup-location"))
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			defer func() { _ = reader.Close() }()
+			data = reader
+		}
+
+		return createFromBackup(s, r, targetProjectName, data, r.Header.Get("X-LXD-pool"), r.Header.Get("X-LXD-name"), deviceMap)
 	}
 
 	// Parse the request
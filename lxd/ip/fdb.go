@@ -0,0 +1,121 @@
+package ip
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+)
+
+// FDB represents arguments for bridge forwarding database manipulation.
+type FDB struct {
+	DevName   string
+	Master    string
+	MAC       net.HardwareAddr
+	Dst       net.IP
+	Permanent bool
+}
+
+// Show lists the forwarding database entries of DevName or, if Master is set, of all the ports of the bridge.
+// Entries are optionally filtered by MAC address.
+func (f *FDB) Show() ([]FDB, error) {
+	cmd := []string{"fdb", "show"}
+	if f.Master != "" {
+		cmd = append(cmd, "br", f.Master)
+	}
+
+	if f.DevName != "" {
+		cmd = append(cmd, "dev", f.DevName)
+	}
+
+	out, err := shared.RunCommand(context.TODO(), "bridge", cmd...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []FDB{}
+
+	for _, line := range shared.SplitNTrimSpace(out, "\n", -1, true) {
+		fields := strings.Fields(line)
+		if len(fields) < 1 {
+			continue
+		}
+
+		mac, err := net.ParseMAC(fields[0])
+		if err != nil {
+			continue
+		}
+
+		// Check entry matches desired MAC address if specified.
+		if f.MAC != nil && !bytes.Equal(f.MAC, mac) {
+			continue
+		}
+
+		entry := FDB{
+			DevName:   f.DevName,
+			MAC:       mac,
+			Permanent: slices.Contains(fields, "permanent"),
+		}
+
+		for i := 1; i < len(fields)-1; i++ {
+			switch fields[i] {
+			case "dev":
+				entry.DevName = fields[i+1]
+			case "dst":
+				entry.Dst = net.ParseIP(fields[i+1])
+			case "master":
+				entry.Master = fields[i+1]
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Append adds a permanent forwarding database entry towards Dst to the device, alongside any existing entry
+// for the same MAC address.
+func (f *FDB) Append() error {
+	_, err := shared.RunCommand(context.TODO(), "bridge", "fdb", "append", f.MAC.String(), "dev", f.DevName, "dst", f.Dst.String(), "self", "permanent")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Replace adds or replaces the static forwarding database entry towards Dst of the device.
+func (f *FDB) Replace() error {
+	_, err := shared.RunCommand(context.TODO(), "bridge", "fdb", "replace", f.MAC.String(), "dev", f.DevName, "dst", f.Dst.String(), "self", "static")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes a forwarding database entry. The entry of the device itself is removed unless Master is set,
+// in which case the entry learned by the bridge is removed.
+func (f *FDB) Delete() error {
+	cmd := []string{"fdb", "del", f.MAC.String(), "dev", f.DevName}
+	if f.Dst != nil {
+		cmd = append(cmd, "dst", f.Dst.String())
+	}
+
+	if f.Master != "" {
+		cmd = append(cmd, "master")
+	} else {
+		cmd = append(cmd, "self")
+	}
+
+	_, err := shared.RunCommand(context.TODO(), "bridge", cmd...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,14 +3,15 @@ package ip
 // Vxlan represents arguments for link of type vxlan.
 type Vxlan struct {
 	Link
-	VxlanID string
-	DevName string
-	Local   string
-	Remote  string
-	Group   string
-	DstPort string
-	TTL     string
-	FanMap  string
+	VxlanID    string
+	DevName    string
+	Local      string
+	Remote     string
+	Group      string
+	DstPort    string
+	TTL        string
+	FanMap     string
+	NoLearning bool
 }
 
 // additionalArgs generates vxlan specific arguments.
@@ -45,6 +46,10 @@ func (vxlan *Vxlan) additionalArgs() []string {
 		args = append(args, "fan-map", vxlan.FanMap)
 	}
 
+	if vxlan.NoLearning {
+		args = append(args, "nolearning")
+	}
+
 	return args
 }
 
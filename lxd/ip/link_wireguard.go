@@ -0,0 +1,11 @@
+package ip
+
+// WireGuard represents arguments for link device of type wireguard.
+type WireGuard struct {
+	Link
+}
+
+// Add adds new virtual link.
+func (w *WireGuard) Add() error {
+	return w.add("wireguard", nil)
+}
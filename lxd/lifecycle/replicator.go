@@ -10,11 +10,19 @@ type ReplicatorAction string
 
 // All supported lifecycle events for replicators.
 const (
-	ReplicatorCreated = ReplicatorAction(api.EventLifecycleReplicatorCreated)
-	ReplicatorDeleted = ReplicatorAction(api.EventLifecycleReplicatorDeleted)
-	ReplicatorRenamed = ReplicatorAction(api.EventLifecycleReplicatorRenamed)
-	ReplicatorRun     = ReplicatorAction(api.EventLifecycleReplicatorRun)
-	ReplicatorUpdated = ReplicatorAction(api.EventLifecycleReplicatorUpdated)
+	ReplicatorCreated          = ReplicatorAction(api.EventLifecycleReplicatorCreated)
+	ReplicatorDeleted          = ReplicatorAction(api.EventLifecycleReplicatorDeleted)
+	ReplicatorFailback         = ReplicatorAction(api.EventLifecycleReplicatorFailback)
+	ReplicatorFailbackFailed   = ReplicatorAction(api.EventLifecycleReplicatorFailbackFailed)
+	ReplicatorFailover         = ReplicatorAction(api.EventLifecycleReplicatorFailover)
+	ReplicatorFailoverFailed   = ReplicatorAction(api.EventLifecycleReplicatorFailoverFailed)
+	ReplicatorInstancesStarted = ReplicatorAction(api.EventLifecycleReplicatorInstancesStarted)
+	ReplicatorProjectDemoted   = ReplicatorAction(api.EventLifecycleReplicatorProjectDemoted)
+	ReplicatorProjectPromoted  = ReplicatorAction(api.EventLifecycleReplicatorProjectPromoted)
+	ReplicatorRenamed          = ReplicatorAction(api.EventLifecycleReplicatorRenamed)
+	ReplicatorRun              = ReplicatorAction(api.EventLifecycleReplicatorRun)
+	ReplicatorSynced           = ReplicatorAction(api.EventLifecycleReplicatorSynced)
+	ReplicatorUpdated          = ReplicatorAction(api.EventLifecycleReplicatorUpdated)
 )
 
 // Event creates the lifecycle event for an action on a replicator.
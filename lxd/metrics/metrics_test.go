@@ -56,3 +56,15 @@ func TestMetricSet_FilterSamples(t *testing.T) {
 		require.Contains(t, hasKeys, "project")
 	}
 }
+
+func TestMetricTypes(t *testing.T) {
+	for metricType := APICompletedRequests; metricType <= WarningsTotal; metricType++ {
+		require.Contains(t, MetricNames, metricType)
+		require.Contains(t, MetricHeaders, metricType)
+	}
+
+	m := NewMetricSet(nil)
+	m.AddSamples(ReplicatorTransferredBytesTotal, Sample{Value: 10, Labels: map[string]string{"project": "default", "replicator": "r1", "name": "c1"}})
+	require.Contains(t, m.String(), "# TYPE lxd_replicator_transferred_bytes_total counter\n")
+	require.Contains(t, m.String(), `lxd_replicator_transferred_bytes_total{name="c1",project="default",replicator="r1"} 10`)
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"sync"
+)
+
+type replicatorMetricsLabeling struct {
+	project    string
+	replicator string
+	instance   string
+}
+
+var replicatorTransferredBytes = map[replicatorMetricsLabeling]int64{}
+var replicatorTransferredBytesMu sync.Mutex
+
+// AddReplicatorTransferredBytes increments the number of bytes transferred when replicating the given instance.
+func AddReplicatorTransferredBytes(projectName string, replicatorName string, instanceName string, bytes int64) {
+	replicatorTransferredBytesMu.Lock()
+	defer replicatorTransferredBytesMu.Unlock()
+
+	replicatorTransferredBytes[replicatorMetricsLabeling{project: projectName, replicator: replicatorName, instance: instanceName}] += bytes
+}
+
+// GetReplicatorMetrics returns the replicator metrics tracked by this cluster member.
+func GetReplicatorMetrics() *MetricSet {
+	out := NewMetricSet(nil)
+
+	replicatorTransferredBytesMu.Lock()
+	defer replicatorTransferredBytesMu.Unlock()
+
+	for labeling, bytes := range replicatorTransferredBytes {
+		out.AddSamples(ReplicatorTransferredBytesTotal, Sample{
+			Labels: map[string]string{"project": labeling.project, "replicator": labeling.replicator, "name": labeling.instance},
+			Value:  float64(bytes),
+		})
+	}
+
+	return out
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -69,6 +70,7 @@ type migrationConn struct {
 	conn           *websocket.Conn
 	connected      chan struct{}
 	disconnected   bool
+	transferred    atomic.Int64
 }
 
 // Secret returns the secret for this connection.
@@ -161,7 +163,43 @@ func (c *migrationConn) WebsocketIO(ctx context.Context) (io.ReadWriteCloser, er
 		return nil, err
 	}
 
-	return ws.NewWrapper(wsConn), nil
+	return &migrationConnIO{ReadWriteCloser: ws.NewWrapper(wsConn), transferred: &c.transferred}, nil
+}
+
+// BytesTransferred returns the number of bytes sent and received through [migrationConn.WebsocketIO].
+func (c *migrationConn) BytesTransferred() int64 {
+	return c.transferred.Load()
+}
+
+// migrationConnIO wraps an [io.ReadWriteCloser] to count the bytes sent and received through it.
+type migrationConnIO struct {
+	io.ReadWriteCloser
+
+	transferred *atomic.Int64
+}
+
+// Read implements [io.Reader].
+func (c *migrationConnIO) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	c.transferred.Add(int64(n))
+	return n, err
+}
+
+// Write implements [io.Writer].
+func (c *migrationConnIO) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	c.transferred.Add(int64(n))
+	return n, err
+}
+
+// migrationBytesTransferred returns the total number of bytes transferred through the given migration connections.
+func migrationBytesTransferred(conns map[string]*migrationConn) int64 {
+	var total int64
+	for _, conn := range conns {
+		total += conn.BytesTransferred()
+	}
+
+	return total
 }
 
 // Close closes the connection (if established) and marks it as disconnected so that it cannot be used again.
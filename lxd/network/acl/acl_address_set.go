@@ -0,0 +1,133 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/lxd/db"
+	firewallDrivers "github.com/canonical/lxd/lxd/firewall/drivers"
+	"github.com/canonical/lxd/lxd/network/openvswitch"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// AddressSetRefPrefix is the prefix used to reference a network address set in the subjects of ACL rules.
+const AddressSetRefPrefix = "$"
+
+// ovnAddressSetPrefix prefix used when naming the OVN address sets of network address sets.
+const ovnAddressSetPrefix = "lxd_addrset"
+
+// OVNAddressSetPrefix returns the OVN address set prefix for a network address set ID.
+// The OVN address sets are named "<prefix>_ip4" and "<prefix>_ip6".
+func OVNAddressSetPrefix(addressSetID int64) openvswitch.OVNAddressSet {
+	return openvswitch.OVNAddressSet(fmt.Sprintf("%s%d", ovnAddressSetPrefix, addressSetID))
+}
+
+// ReferencedAddressSets returns the names of the network address sets referenced in the rules of the ACL.
+func ReferencedAddressSets(info *api.NetworkACL) []string {
+	setNames := []string{}
+
+	addSetNamesFrom := func(ruleSubjects []string) {
+		for _, subject := range ruleSubjects {
+			setName, isSet := strings.CutPrefix(subject, AddressSetRefPrefix)
+			if isSet && !slices.Contains(setNames, setName) {
+				setNames = append(setNames, setName)
+			}
+		}
+	}
+
+	for _, rules := range [][]api.NetworkACLRule{info.Ingress, info.Egress} {
+		for _, rule := range rules {
+			addSetNamesFrom(shared.SplitNTrimSpace(rule.Source, ",", -1, true))
+			addSetNamesFrom(shared.SplitNTrimSpace(rule.Destination, ",", -1, true))
+		}
+	}
+
+	return setNames
+}
+
+// firewallAddressSets loads the specified network address sets for use by the firewall.
+func firewallAddressSets(ctx context.Context, s *state.State, projectName string, setNames []string) ([]firewallDrivers.AddressSet, error) {
+	addressSets := make([]firewallDrivers.AddressSet, 0, len(setNames))
+
+	if len(setNames) == 0 {
+		return addressSets, nil
+	}
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, setName := range setNames {
+			setID, setInfo, err := tx.GetNetworkAddressSet(ctx, projectName, setName)
+			if err != nil {
+				return fmt.Errorf("Failed loading network address set %q: %w", setName, err)
+			}
+
+			addressSets = append(addressSets, firewallDrivers.AddressSet{
+				ID:        setID,
+				Name:      setName,
+				Addresses: setInfo.Addresses,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addressSets, nil
+}
+
+// OVNAddressSetApply sets the addresses of the OVN address sets of a network address set, creating them if needed.
+func OVNAddressSetApply(client *openvswitch.OVN, addressSetID int64, addresses []string) error {
+	ipNets := make([]net.IPNet, 0, len(addresses))
+	for _, address := range addresses {
+		if strings.Contains(address, "/") {
+			_, ipNet, err := net.ParseCIDR(address)
+			if err != nil {
+				return fmt.Errorf("Invalid address %q: %w", address, err)
+			}
+
+			ipNets = append(ipNets, *ipNet)
+			continue
+		}
+
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return fmt.Errorf("Invalid address %q", address)
+		}
+
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+
+		ipNets = append(ipNets, net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+
+	return client.AddressSetReplace(OVNAddressSetPrefix(addressSetID), ipNets...)
+}
+
+// ovnEnsureAddressSets creates or updates the OVN address sets of the specified network address sets.
+// Returns a map of the network address set names to their IDs.
+func ovnEnsureAddressSets(ctx context.Context, s *state.State, client *openvswitch.OVN, projectName string, setNames []string) (map[string]int64, error) {
+	addressSets, err := firewallAddressSets(ctx, s, projectName, setNames)
+	if err != nil {
+		return nil, err
+	}
+
+	addressSetIDs := make(map[string]int64, len(addressSets))
+	for _, addressSet := range addressSets {
+		err = OVNAddressSetApply(client, addressSet.ID, addressSet.Addresses)
+		if err != nil {
+			return nil, fmt.Errorf("Failed applying OVN address set for network address set %q: %w", addressSet.Name, err)
+		}
+
+		addressSetIDs[addressSet.Name] = addressSet.ID
+	}
+
+	return addressSetIDs, nil
+}
@@ -1,8 +1,15 @@
 package acl
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/canonical/lxd/lxd/db"
 	firewallDrivers "github.com/canonical/lxd/lxd/firewall/drivers"
@@ -18,13 +25,14 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 	var allowRules []firewallDrivers.ACLRule
 
 	// convertACLRules converts the ACL rules to Firewall ACL rules.
-	convertACLRules := func(direction string, logPrefix string, rules ...api.NetworkACLRule) error {
+	convertACLRules := func(aclID int64, direction string, rules ...api.NetworkACLRule) error {
 		for ruleIndex, rule := range rules {
 			if rule.State == "disabled" {
 				continue
 			}
 
 			firewallACLRule := firewallDrivers.ACLRule{
+				Name:            ruleName(aclID, direction, ruleIndex),
 				Direction:       direction,
 				Action:          rule.Action,
 				Source:          rule.Source,
@@ -39,7 +47,7 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 			if rule.State == "logged" {
 				firewallACLRule.Log = true
 				// Max 29 chars.
-				firewallACLRule.LogName = fmt.Sprintf("%s-%s-%d", logPrefix, direction, ruleIndex)
+				firewallACLRule.LogName = firewallACLRule.Name
 			}
 
 			switch rule.Action {
@@ -58,15 +66,17 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 	}
 
 	logPrefix := aclNet.Name
+	var addressSetNames []string
 
 	// Load ACLs specified by network.
 	for _, aclName := range shared.SplitNTrimSpace(aclNet.Config["security.acls"], ",", -1, true) {
+		var aclID int64
 		var aclInfo *api.NetworkACL
 
 		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			var err error
 
-			_, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
+			aclID, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
 
 			return err
 		})
@@ -74,12 +84,18 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 			return fmt.Errorf("Failed loading ACL %q for network %q: %w", aclName, aclNet.Name, err)
 		}
 
-		err = convertACLRules("ingress", logPrefix, aclInfo.Ingress...)
+		for _, setName := range ReferencedAddressSets(aclInfo) {
+			if !slices.Contains(addressSetNames, setName) {
+				addressSetNames = append(addressSetNames, setName)
+			}
+		}
+
+		err = convertACLRules(aclID, "ingress", aclInfo.Ingress...)
 		if err != nil {
 			return fmt.Errorf("Failed converting ACL %q ingress rules for network %q: %w", aclInfo.Name, aclNet.Name, err)
 		}
 
-		err = convertACLRules("egress", logPrefix, aclInfo.Egress...)
+		err = convertACLRules(aclID, "egress", aclInfo.Egress...)
 		if err != nil {
 			return fmt.Errorf("Failed converting ACL %q egress rules for network %q: %w", aclInfo.Name, aclNet.Name, err)
 		}
@@ -108,7 +124,13 @@ func FirewallApplyACLRules(ctx context.Context, s *state.State, aclProjectName s
 		LogName:   logPrefix + "-ingress",
 	})
 
-	return s.Firewall.NetworkApplyACLRules(aclNet.Name, rules)
+	// Load the network address sets referenced by the rules.
+	addressSets, err := firewallAddressSets(ctx, s, aclProjectName, addressSetNames)
+	if err != nil {
+		return fmt.Errorf("Failed loading address sets for network %q: %w", aclNet.Name, err)
+	}
+
+	return s.Firewall.NetworkApplyACLRules(aclNet.Name, rules, addressSets)
 }
 
 // firewallACLDefaults returns the action and logging mode to use for the specified direction's default rule.
@@ -128,3 +150,137 @@ func firewallACLDefaults(netConfig map[string]string, direction string) (string,
 
 	return defaults[fmt.Sprintf("security.acls.default.%s.action", direction)], shared.IsTrue(defaults[fmt.Sprintf("security.acls.default.%s.logged", direction)])
 }
+
+// firewallParseLogEntriesFromJournald reads the entries logged by the firewall rules of the ACL from the kernel log
+// in the systemd journal and returns them as a list of string entries.
+func firewallParseLogEntriesFromJournald(ctx context.Context, aclID int64, info *api.NetworkACL) ([]string, error) {
+	prefix := string(OVNACLPortGroupName(aclID)) + "-"
+
+	cmd := []string{
+		"journalctl",
+		"--dmesg",
+		"--no-pager",
+		"--boot", "0",
+		"--case-sensitive",
+		"--grep", prefix,
+		"--output-fields", "MESSAGE",
+		"-n", "1000",
+		"-o", "json",
+	}
+
+	stdout := bytes.Buffer{}
+	err := shared.RunCommandWithFds(ctx, nil, &stdout, cmd[0], cmd[1:]...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed running journalctl to fetch firewall ACL logs: %w", err)
+	}
+
+	var logEntries []string
+	decoder := json.NewDecoder(&stdout)
+	for {
+		var sdLogEntry map[string]any
+		err = decoder.Decode(&sdLogEntry)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed parsing log entry: %w", err)
+		}
+
+		message, ok := sdLogEntry["MESSAGE"].(string)
+		if !ok {
+			continue
+		}
+
+		timestamp, ok := sdLogEntry["__REALTIME_TIMESTAMP"].(string)
+		if !ok {
+			continue
+		}
+
+		logEntry := firewallParseLogEntry(message, timestamp, prefix, info)
+		if logEntry == "" {
+			continue
+		}
+
+		logEntries = append(logEntries, logEntry)
+	}
+
+	return logEntries, nil
+}
+
+// firewallParseLogEntry takes a kernel log line of a logged firewall ACL rule and returns it as a JSON log entry.
+// The log line starts with the rule name used as log prefix, e.g.
+// "lxd_acl1-ingress-0 IN=eth0 OUT=lxdbr0 ... SRC=192.0.2.2 DST=192.0.2.3 ... PROTO=TCP SPT=42312 DPT=22 ...".
+// Returns an empty string if the log line doesn't belong to the ACL or can't be parsed.
+func firewallParseLogEntry(logline string, syslogTimestamp string, prefix string, info *api.NetworkACL) string {
+	fields := strings.Fields(logline)
+	if len(fields) < 2 {
+		return ""
+	}
+
+	// Filter for our ACL and find the rule that logged the packet.
+	ruleRef, found := strings.CutPrefix(fields[0], prefix)
+	if !found {
+		return ""
+	}
+
+	direction, ruleIndexStr, found := strings.Cut(ruleRef, "-")
+	if !found {
+		return ""
+	}
+
+	ruleIndex, err := strconv.Atoi(ruleIndexStr)
+	if err != nil {
+		return ""
+	}
+
+	rules := info.Ingress
+	if direction == "egress" {
+		rules = info.Egress
+	}
+
+	if ruleIndex < 0 || ruleIndex >= len(rules) {
+		return ""
+	}
+
+	// The provided timestamp is in microseconds and need to be converted to nanoseconds.
+	tsInt, err := strconv.ParseInt(syslogTimestamp, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	logEntry := map[string]string{}
+	for _, field := range fields[1:] {
+		key, value, found := strings.Cut(field, "=")
+		if found {
+			logEntry[key] = value
+		}
+	}
+
+	if logEntry["SRC"] == "" || logEntry["DST"] == "" {
+		return ""
+	}
+
+	// Use the same protocol names as in the OVN log entries.
+	protocol := strings.ToLower(logEntry["PROTO"])
+	if protocol == "icmpv6" {
+		protocol = "icmp6"
+	}
+
+	newEntry := ovnLogEntry{
+		Time:     time.Unix(0, tsInt*1000).UTC().Format(time.RFC3339),
+		Proto:    protocol,
+		Src:      logEntry["SRC"],
+		Dst:      logEntry["DST"],
+		SrcPort:  logEntry["SPT"],
+		DstPort:  logEntry["DPT"],
+		ICMPType: logEntry["TYPE"],
+		ICMPCode: logEntry["CODE"],
+		Action:   rules[ruleIndex].Action,
+	}
+
+	out, err := json.Marshal(&newEntry)
+	if err != nil {
+		return ""
+	}
+
+	return string(out)
+}
@@ -0,0 +1,51 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+func Test_firewallParseLogEntry(t *testing.T) {
+	info := &api.NetworkACL{
+		Ingress: []api.NetworkACLRule{{Action: "allow", State: "logged"}},
+		Egress:  []api.NetworkACLRule{{Action: "allow"}, {Action: "drop", State: "logged"}},
+	}
+
+	tests := []struct {
+		name     string
+		logline  string
+		expected string
+	}{
+		{
+			name:     "TCP ingress entry",
+			logline:  "lxd_acl1-ingress-0 IN=eth0 OUT=lxdbr0 MAC=00:16:3e:00:00:01 SRC=192.0.2.2 DST=192.0.2.3 LEN=60 TOS=0x00 PREC=0x00 TTL=63 ID=1 DF PROTO=TCP SPT=42312 DPT=22 WINDOW=64240 RES=0x00 SYN URGP=0",
+			expected: `{"time":"2025-01-01T00:00:00Z","proto":"tcp","src":"192.0.2.2","dst":"192.0.2.3","src_port":"42312","dst_port":"22","action":"allow"}`,
+		},
+		{
+			name:     "ICMPv6 egress entry",
+			logline:  "lxd_acl1-egress-1 IN=lxdbr0 OUT=eth0 SRC=2001:db8::2 DST=2001:db8::3 LEN=104 PROTO=ICMPv6 TYPE=128 CODE=0 ID=1 SEQ=1",
+			expected: `{"time":"2025-01-01T00:00:00Z","proto":"icmp6","src":"2001:db8::2","dst":"2001:db8::3","icmp_type":"128","icmp_code":"0","action":"drop"}`,
+		},
+		{
+			name:     "Entry of another ACL",
+			logline:  "lxd_acl10-ingress-0 IN=eth0 OUT=lxdbr0 SRC=192.0.2.2 DST=192.0.2.3 PROTO=TCP SPT=42312 DPT=22",
+			expected: "",
+		},
+		{
+			name:     "Entry of an unknown rule",
+			logline:  "lxd_acl1-ingress-5 IN=eth0 OUT=lxdbr0 SRC=192.0.2.2 DST=192.0.2.3 PROTO=TCP SPT=42312 DPT=22",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 2025-01-01T00:00:00Z in microseconds.
+			result := firewallParseLogEntry(tt.logline, "1735689600000000", "lxd_acl1-", info)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
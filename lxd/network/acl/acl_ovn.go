@@ -236,6 +236,26 @@ func OVNEnsureACLs(ctx context.Context, s *state.State, l logger.Logger, client
 		}
 	}
 
+	// Create or update the OVN address sets for any network address sets referenced in the rules we are going
+	// to apply, so that they exist with the current addresses before the rules referencing them are added.
+	addressSetNames := []string{}
+	for _, aclStatus := range append(createACLPortGroups, existingACLPortGroups...) {
+		if aclStatus.aclInfo == nil {
+			continue
+		}
+
+		for _, setName := range ReferencedAddressSets(aclStatus.aclInfo) {
+			if !slices.Contains(addressSetNames, setName) {
+				addressSetNames = append(addressSetNames, setName)
+			}
+		}
+	}
+
+	addressSetIDs, err := ovnEnsureAddressSets(ctx, s, client, aclProjectName, addressSetNames)
+	if err != nil {
+		return nil, fmt.Errorf("Failed ensuring OVN address sets for security ACL setup: %w", err)
+	}
+
 	// Create the needed port groups and then apply ACL rules to new port groups.
 	for _, aclStatus := range createACLPortGroups {
 		portGroupName := OVNACLPortGroupName(aclNameIDs[aclStatus.name])
@@ -263,7 +283,7 @@ func OVNEnsureACLs(ctx context.Context, s *state.State, l logger.Logger, client
 		}
 
 		// Now apply our ACL rules to port group (and any per-ACL-per-network port groups needed).
-		err = ovnApplyToPortGroup(l, client, aclStatus.aclInfo, portGroupName, aclNameIDs, aclNets, peerTargetNetIDs)
+		err = ovnApplyToPortGroup(l, client, aclStatus.aclInfo, portGroupName, aclNameIDs, addressSetIDs, aclNets, peerTargetNetIDs)
 		if err != nil {
 			return nil, fmt.Errorf("Failed applying ACL rules to port group %q for security ACL %q setup: %w", portGroupName, aclStatus.name, err)
 		}
@@ -293,7 +313,7 @@ func OVNEnsureACLs(ctx context.Context, s *state.State, l logger.Logger, client
 		if aclStatus.aclInfo != nil {
 			l.Debug("Applying ACL rules to OVN port group", logger.Ctx{"networkACL": aclStatus.name, "portGroup": portGroupName})
 
-			err := ovnApplyToPortGroup(l, client, aclStatus.aclInfo, portGroupName, aclNameIDs, aclNets, peerTargetNetIDs)
+			err := ovnApplyToPortGroup(l, client, aclStatus.aclInfo, portGroupName, aclNameIDs, addressSetIDs, aclNets, peerTargetNetIDs)
 			if err != nil {
 				return nil, fmt.Errorf("Failed applying ACL rules to port group %q for security ACL %q setup: %w", portGroupName, aclStatus.name, err)
 			}
@@ -322,6 +342,10 @@ func ovnAddReferencedACLs(info *api.NetworkACL, referencedACLNames map[string]st
 				continue // Skip if the subject is an IP CIDR or IP range.
 			}
 
+			if strings.HasPrefix(subject, AddressSetRefPrefix) {
+				continue // Skip if the subject is a network address set reference.
+			}
+
 			// Anything else must be a referenced ACL name.
 			// Record newly seen referenced ACL into authoritative list.
 			referencedACLNames[subject] = struct{}{}
@@ -338,7 +362,7 @@ func ovnAddReferencedACLs(info *api.NetworkACL, referencedACLNames map[string]st
 }
 
 // ovnApplyToPortGroup applies the rules in the specified ACL to the specified port group.
-func ovnApplyToPortGroup(l logger.Logger, client *openvswitch.OVN, aclInfo *api.NetworkACL, portGroupName openvswitch.OVNPortGroup, aclNameIDs map[string]int64, aclNets map[string]NetworkACLUsage, peerTargetNetIDs map[db.NetworkPeer]int64) error {
+func ovnApplyToPortGroup(l logger.Logger, client *openvswitch.OVN, aclInfo *api.NetworkACL, portGroupName openvswitch.OVNPortGroup, aclNameIDs map[string]int64, addressSetIDs map[string]int64, aclNets map[string]NetworkACLUsage, peerTargetNetIDs map[db.NetworkPeer]int64) error {
 	// Create slice for port group rules that has the capacity for ingress and egress rules, plus default rule.
 	portGroupRules := make([]openvswitch.OVNACLRule, 0, len(aclInfo.Ingress)+len(aclInfo.Egress)+1)
 	networkRules := make([]openvswitch.OVNACLRule, 0)
@@ -351,14 +375,16 @@ func ovnApplyToPortGroup(l logger.Logger, client *openvswitch.OVN, aclInfo *api.
 				continue
 			}
 
-			ovnACLRule, networkSpecific, networkPeers, err := ovnRuleCriteriaToOVNACLRule(direction, &rule, portGroupName, aclNameIDs, peerTargetNetIDs)
+			ovnACLRule, networkSpecific, networkPeers, err := ovnRuleCriteriaToOVNACLRule(direction, &rule, portGroupName, aclNameIDs, addressSetIDs, peerTargetNetIDs)
 			if err != nil {
 				return err
 			}
 
+			ovnACLRule.Name = fmt.Sprintf("%s-%s-%d", portGroupName, direction, ruleIndex)
+
 			if rule.State == "logged" {
 				ovnACLRule.Log = true
-				ovnACLRule.LogName = fmt.Sprintf("%s-%s-%d", portGroupName, direction, ruleIndex)
+				ovnACLRule.LogName = ovnACLRule.Name
 			}
 
 			if networkSpecific {
@@ -434,7 +460,7 @@ func ovnApplyToPortGroup(l logger.Logger, client *openvswitch.OVN, aclInfo *api.
 
 // ovnRuleCriteriaToOVNACLRule converts a LXD ACL rule into an OVNACLRule for an OVN port group or network.
 // Returns a bool indicating if any of the rule subjects are network specific.
-func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, portGroupName openvswitch.OVNPortGroup, aclNameIDs map[string]int64, peerTargetNetIDs map[db.NetworkPeer]int64) (openvswitch.OVNACLRule, bool, []db.NetworkPeer, error) {
+func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, portGroupName openvswitch.OVNPortGroup, aclNameIDs map[string]int64, addressSetIDs map[string]int64, peerTargetNetIDs map[db.NetworkPeer]int64) (openvswitch.OVNACLRule, bool, []db.NetworkPeer, error) {
 	networkSpecific := false
 	networkPeersNeeded := make([]db.NetworkPeer, 0)
 	portGroupRule := openvswitch.OVNACLRule{
@@ -468,7 +494,7 @@ func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, por
 
 	// Add subject filters.
 	if rule.Source != "" {
-		match, netSpecificMatch, networkPeers, err := ovnRuleSubjectToOVNACLMatch("src", aclNameIDs, peerTargetNetIDs, shared.SplitNTrimSpace(rule.Source, ",", -1, false)...)
+		match, netSpecificMatch, networkPeers, err := ovnRuleSubjectToOVNACLMatch("src", aclNameIDs, addressSetIDs, peerTargetNetIDs, shared.SplitNTrimSpace(rule.Source, ",", -1, false)...)
 		if err != nil {
 			return openvswitch.OVNACLRule{}, false, nil, err
 		}
@@ -482,7 +508,7 @@ func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, por
 	}
 
 	if rule.Destination != "" {
-		match, netSpecificMatch, networkPeers, err := ovnRuleSubjectToOVNACLMatch("dst", aclNameIDs, peerTargetNetIDs, shared.SplitNTrimSpace(rule.Destination, ",", -1, false)...)
+		match, netSpecificMatch, networkPeers, err := ovnRuleSubjectToOVNACLMatch("dst", aclNameIDs, addressSetIDs, peerTargetNetIDs, shared.SplitNTrimSpace(rule.Destination, ",", -1, false)...)
 		if err != nil {
 			return openvswitch.OVNACLRule{}, false, nil, err
 		}
@@ -543,7 +569,7 @@ func ovnRulePortToOVNACLMatch(protocol string, direction string, portCriteria ..
 
 // ovnRuleSubjectToOVNACLMatch converts direction (src/dst) and subject criteria list into an OVN match statement.
 // Returns a bool indicating if any of the subjects are network specific.
-func ovnRuleSubjectToOVNACLMatch(direction string, aclNameIDs map[string]int64, peerTargetNetIDs map[db.NetworkPeer]int64, subjectCriteria ...string) (string, bool, []db.NetworkPeer, error) {
+func ovnRuleSubjectToOVNACLMatch(direction string, aclNameIDs map[string]int64, addressSetIDs map[string]int64, peerTargetNetIDs map[db.NetworkPeer]int64, subjectCriteria ...string) (string, bool, []db.NetworkPeer, error) {
 	fieldParts := make([]string, 0, len(subjectCriteria))
 	networkSpecific := false
 	networkPeersNeeded := make([]db.NetworkPeer, 0)
@@ -583,6 +609,7 @@ func ovnRuleSubjectToOVNACLMatch(direction string, aclNameIDs map[string]int64,
 				// If not valid IP subnet, check if subject is ACL name or network peer name.
 				var subjectPortSelector openvswitch.OVNPortGroup
 				peerRef, hasPeerRef := strings.CutPrefix(subjectCriterion, "@")
+				setRef, hasSetRef := strings.CutPrefix(subjectCriterion, AddressSetRefPrefix)
 				if slices.Contains(ruleSubjectInternalAliases, subjectCriterion) {
 					// Use pseudo port group name for special reserved port selector types.
 					// These will be expanded later for each network specific rule.
@@ -617,6 +644,18 @@ func ovnRuleSubjectToOVNACLMatch(direction string, aclNameIDs map[string]int64,
 					fieldParts = append(fieldParts, fmt.Sprintf("ip6.%s == $%s_ip6 || ip4.%s == $%s_ip4", direction, addrSetPrefix, direction, addrSetPrefix))
 					networkPeersNeeded = append(networkPeersNeeded, peer)
 
+					continue // Not a port based selector.
+				} else if hasSetRef {
+					// Subject is a network address set name. Convert to address set criteria.
+					addressSetID, found := addressSetIDs[setRef]
+					if !found {
+						return "", false, nil, fmt.Errorf("Cannot find network address set ID for %q", setRef)
+					}
+
+					addrSetPrefix := OVNAddressSetPrefix(addressSetID)
+
+					fieldParts = append(fieldParts, fmt.Sprintf("ip4.%s == $%s_ip4 || ip6.%s == $%s_ip6", direction, addrSetPrefix, direction, addrSetPrefix))
+
 					continue // Not a port based selector.
 				} else {
 					// Assume the bare name is an ACL name and convert to port group.
@@ -0,0 +1,165 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/network/openvswitch"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// ruleName returns the name identifying the firewall and OVN rules generated from an ACL rule.
+// It is used to retrieve the rule counters and as the log prefix of logged rules.
+func ruleName(aclID int64, direction string, ruleIndex int) string {
+	return fmt.Sprintf("%s-%s-%d", OVNACLPortGroupName(aclID), direction, ruleIndex)
+}
+
+// localRuleCounters returns the hit counters of the ACL rules applied on this member to the specified networks,
+// keyed by rule name.
+func localRuleCounters(ctx context.Context, s *state.State, aclNets map[string]NetworkACLUsage) (map[string]api.NetworkACLRuleCounters, error) {
+	counters := make(map[string]api.NetworkACLRuleCounters)
+	hasOVNNetworks := false
+
+	for _, aclNet := range aclNets {
+		if aclNet.Type == "ovn" {
+			hasOVNNetworks = true
+			continue
+		}
+
+		firewallCounters, err := s.Firewall.NetworkACLRuleCounters(aclNet.Name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting ACL rule counters for network %q: %w", aclNet.Name, err)
+		}
+
+		for name, firewallCounter := range firewallCounters {
+			ruleCounters := counters[name]
+			ruleCounters.Packets += firewallCounter.Packets
+			ruleCounters.Bytes += firewallCounter.Bytes
+			counters[name] = ruleCounters
+		}
+	}
+
+	// The OVN ACL rules are turned into OpenFlow flows on each chassis, so only look at the flows of the local
+	// integration bridge, if any.
+	ovs := openvswitch.NewOVS()
+	if !hasOVNNetworks || !ovs.Installed() {
+		return counters, nil
+	}
+
+	client, err := openvswitch.NewOVN(s.GlobalConfig.NetworkOVNNorthboundConnection(), s.GlobalConfig.NetworkOVNSSL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN client: %w", err)
+	}
+
+	ruleCookies, err := client.ACLRuleFlowCookies()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN ACL rule flows: %w", err)
+	}
+
+	flowCounters, err := ovs.FlowCounters(ctx, s.GlobalConfig.NetworkOVNIntegrationBridge())
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN integration bridge flow counters: %w", err)
+	}
+
+	for name, cookies := range ruleCookies {
+		ruleCounters := counters[name]
+		for _, cookie := range cookies {
+			ruleCounters.Packets += flowCounters[cookie].Packets
+			ruleCounters.Bytes += flowCounters[cookie].Bytes
+		}
+
+		counters[name] = ruleCounters
+	}
+
+	return counters, nil
+}
+
+// ruleCountersToState returns the state of the ACL from the counters of its rules.
+// Returns whether any of the ACL rules has counters, which indicates the ACL is applied on this member.
+func ruleCountersToState(aclID int64, info *api.NetworkACL, counters map[string]api.NetworkACLRuleCounters) (*api.NetworkACLState, bool) {
+	applied := false
+
+	rulesCounters := func(direction string, rules []api.NetworkACLRule) []api.NetworkACLRuleCounters {
+		directionCounters := make([]api.NetworkACLRuleCounters, len(rules))
+		for ruleIndex := range rules {
+			ruleCounters, found := counters[ruleName(aclID, direction, ruleIndex)]
+			if found {
+				applied = true
+				directionCounters[ruleIndex] = ruleCounters
+			}
+		}
+
+		return directionCounters
+	}
+
+	aclState := &api.NetworkACLState{
+		Ingress: rulesCounters("ingress", info.Ingress),
+		Egress:  rulesCounters("egress", info.Egress),
+	}
+
+	return aclState, applied
+}
+
+// ProjectRuleCounters returns the state of the ACLs of the project applied on this member, keyed by ACL name.
+func ProjectRuleCounters(ctx context.Context, s *state.State, projectName string) (map[string]*api.NetworkACLState, error) {
+	aclIDs := map[string]int64{}
+	aclInfos := map[string]*api.NetworkACL{}
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		aclNames, err := tx.GetNetworkACLs(ctx, projectName)
+		if err != nil {
+			return err
+		}
+
+		for _, aclName := range aclNames {
+			aclID, aclInfo, err := tx.GetNetworkACL(ctx, projectName, aclName)
+			if err != nil {
+				return err
+			}
+
+			aclIDs[aclName] = aclID
+			aclInfos[aclName] = aclInfo
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading network ACLs: %w", err)
+	}
+
+	aclStates := make(map[string]*api.NetworkACLState)
+	if len(aclInfos) == 0 {
+		return aclStates, nil
+	}
+
+	aclNames := make([]string, 0, len(aclInfos))
+	for aclName := range aclInfos {
+		aclNames = append(aclNames, aclName)
+	}
+
+	aclNets := map[string]NetworkACLUsage{}
+	err = NetworkUsage(ctx, s, projectName, aclNames, aclNets)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting ACL network usage: %w", err)
+	}
+
+	if len(aclNets) == 0 {
+		return aclStates, nil
+	}
+
+	counters, err := localRuleCounters(ctx, s, aclNets)
+	if err != nil {
+		return nil, err
+	}
+
+	for aclName, aclInfo := range aclInfos {
+		aclState, applied := ruleCountersToState(aclIDs[aclName], aclInfo, counters)
+		if applied {
+			aclStates[aclName] = aclState
+		}
+	}
+
+	return aclStates, nil
+}
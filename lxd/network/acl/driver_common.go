@@ -284,12 +284,18 @@ func (d *common) validateRule(ctx context.Context, direction ruleDirection, rule
 	}
 
 	var acls map[string]int64
+	var addressSetNames []string
 
 	err := d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
 
 		// Get map of ACL names to DB IDs (used for generating OVN port group names).
 		acls, err = tx.GetNetworkACLIDsByNames(ctx, d.Project())
+		if err != nil {
+			return err
+		}
+
+		addressSetNames, err = tx.GetNetworkAddressSets(ctx, d.Project())
 
 		return err
 	})
@@ -297,7 +303,7 @@ func (d *common) validateRule(ctx context.Context, direction ruleDirection, rule
 		return fmt.Errorf("Failed getting network ACLs for security ACL subject validation: %w", err)
 	}
 
-	validSubjectNames := make([]string, 0, len(acls)+len(ruleSubjectInternalAliases)+len(ruleSubjectExternalAliases))
+	validSubjectNames := make([]string, 0, len(acls)+len(addressSetNames)+len(ruleSubjectInternalAliases)+len(ruleSubjectExternalAliases))
 	validSubjectNames = append(validSubjectNames, ruleSubjectInternalAliases...)
 	validSubjectNames = append(validSubjectNames, ruleSubjectExternalAliases...)
 
@@ -305,6 +311,10 @@ func (d *common) validateRule(ctx context.Context, direction ruleDirection, rule
 		validSubjectNames = append(validSubjectNames, aclName)
 	}
 
+	for _, setName := range addressSetNames {
+		validSubjectNames = append(validSubjectNames, AddressSetRefPrefix+setName)
+	}
+
 	var srcHasName, srcHasIPv4, srcHasIPv6 bool
 	var dstHasName, dstHasIPv4, dstHasIPv6 bool
 
@@ -433,7 +443,7 @@ func (d *common) validateRule(ctx context.Context, direction ruleDirection, rule
 }
 
 // validateRuleSubjects checks that the source or destination subjects for a rule are valid.
-// Accepts a validSubjectNames list of valid ACL or special classifier names.
+// Accepts a validSubjectNames list of valid ACL, address set references or special classifier names.
 // Returns whether the subjects include names, IPv4 and IPv6 addresses respectively.
 func (d *common) validateRuleSubjects(fieldName string, direction ruleDirection, subjects []string, validSubjectNames []string) (hasName bool, hasIPv4 bool, hasIPv6 bool, err error) {
 	// Check if named subjects are allowed in field/direction combination.
@@ -503,6 +513,16 @@ func (d *common) validateRuleSubjects(fieldName string, direction ruleDirection,
 			}
 		}
 
+		// Check if it references a network address set. These are allowed in any field/direction as they
+		// only contain addresses, but the IP families are only known when the rules are applied.
+		if strings.HasPrefix(subject, AddressSetRefPrefix) {
+			if slices.Contains(validSubjectNames, subject) {
+				return 0, nil // Found valid subject.
+			}
+
+			return 0, fmt.Errorf("Network address set %q does not exist", strings.TrimPrefix(subject, AddressSetRefPrefix))
+		}
+
 		// Check if it is one of the valid subject names.
 		for _, n := range validSubjectNames {
 			if subject == n {
@@ -738,20 +758,44 @@ func (d *common) Delete(ctx context.Context) error {
 
 // GetLog gets the ACL log.
 func (d *common) GetLog(ctx context.Context, clientType request.ClientType) (string, error) {
-	// ACLs aren't specific to a particular network type but the log only works with OVN.
-	var logEntries []string
-	var err error
+	// Get a list of networks that are using this ACL.
+	aclNets := map[string]NetworkACLUsage{}
+	err := NetworkUsage(ctx, d.state, d.projectName, []string{d.info.Name}, aclNets)
+	if err != nil {
+		return "", fmt.Errorf("Failed getting ACL network usage: %w", err)
+	}
 
-	if shared.IsMicroOVNUsed() {
+	hasFirewallNetworks := false
+	hasOVNNetworks := false
+	for _, aclNet := range aclNets {
+		if aclNet.Type == "ovn" {
+			hasOVNNetworks = true
+		} else {
+			hasFirewallNetworks = true
+		}
+	}
+
+	logEntries := []string{}
+
+	// The entries logged by the firewall rules of bridge networks are in the kernel log.
+	if hasFirewallNetworks {
+		logEntries, err = firewallParseLogEntriesFromJournald(ctx, d.id, d.info)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if hasOVNNetworks && shared.IsMicroOVNUsed() {
 		prefix := fmt.Sprintf("lxd_acl%d-", d.id)
-		logEntries, err = ovnParseLogEntriesFromJournald(ctx, "snap.microovn.chassis.service", prefix)
+		ovnLogEntries, err := ovnParseLogEntriesFromJournald(ctx, "snap.microovn.chassis.service", prefix)
 		if err != nil {
 			return "", fmt.Errorf("Failed getting OVN log entries from syslog: %w", err)
 		}
-	} else {
+
+		logEntries = append(logEntries, ovnLogEntries...)
+	} else if hasOVNNetworks {
 		// Else, if the current LXD deployment does not use MicroOVN,
 		// then try to read the OVN controller log file directly (a standalone OVN controller might be built-in with LXD).
-		logEntries = []string{}
 		prefix := fmt.Sprintf("lxd_acl%d-", d.id)
 		logPath := shared.HostPath("/var/log/ovn/ovn-controller.log")
 
@@ -759,7 +803,7 @@ func (d *common) GetLog(ctx context.Context, clientType request.ClientType) (str
 		logFile, err := os.Open(logPath)
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
-				return "", errors.New("Only OVN log entries may be retrieved at this time")
+				return "", errors.New("OVN controller log file not found")
 			}
 
 			return "", fmt.Errorf("Failed opening OVN log file: %w", err)
@@ -838,3 +882,59 @@ func (d *common) GetLog(ctx context.Context, clientType request.ClientType) (str
 
 	return strings.Join(logEntries, "\n") + "\n", nil
 }
+
+// GetState returns the hit counters of the ACL rules, summed across the cluster members.
+func (d *common) GetState(ctx context.Context, clientType request.ClientType) (*api.NetworkACLState, error) {
+	// Get a list of networks that are using this ACL.
+	aclNets := map[string]NetworkACLUsage{}
+	err := NetworkUsage(ctx, d.state, d.projectName, []string{d.info.Name}, aclNets)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting ACL network usage: %w", err)
+	}
+
+	counters, err := localRuleCounters(ctx, d.state, aclNets)
+	if err != nil {
+		return nil, err
+	}
+
+	aclState, _ := ruleCountersToState(d.id, d.info, counters)
+
+	// Aggregates the counters from the rest of the cluster.
+	if clientType == request.ClientTypeNormal && len(aclNets) > 0 {
+		// Setup notifier to reach the rest of the cluster.
+		notifier, err := cluster.NewNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return nil, err
+		}
+
+		// addCounters adds the member counters to the counters of the same rules.
+		addCounters := func(counters []api.NetworkACLRuleCounters, memberCounters []api.NetworkACLRuleCounters) {
+			for i := range min(len(counters), len(memberCounters)) {
+				counters[i].Packets += memberCounters[i].Packets
+				counters[i].Bytes += memberCounters[i].Bytes
+			}
+		}
+
+		mu := sync.Mutex{}
+		err = notifier(func(member db.NodeInfo, client lxd.InstanceServer) error {
+			memberState, err := client.UseProject(d.projectName).GetNetworkACLState(d.info.Name)
+			if err != nil {
+				return err
+			}
+
+			// Prevent concurrent writes to the counters.
+			mu.Lock()
+			defer mu.Unlock()
+
+			addCounters(aclState.Ingress, memberState.Ingress)
+			addCounters(aclState.Egress, memberState.Egress)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aclState, nil
+}
@@ -0,0 +1,349 @@
+package addressset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/client"
+	"github.com/canonical/lxd/lxd/cluster"
+	"github.com/canonical/lxd/lxd/config"
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/network/acl"
+	"github.com/canonical/lxd/lxd/network/openvswitch"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/revert"
+	"github.com/canonical/lxd/shared/version"
+)
+
+// common represents a Network address set.
+type common struct {
+	logger      logger.Logger
+	state       *state.State
+	id          int64
+	projectName string
+	info        *api.NetworkAddressSet
+}
+
+// init initialise internal variables.
+func (d *common) init(state *state.State, id int64, projectName string, info *api.NetworkAddressSet) {
+	if info == nil {
+		d.info = &api.NetworkAddressSet{}
+	} else {
+		d.info = info
+	}
+
+	d.logger = logger.AddContext(logger.Ctx{"project": projectName, "networkAddressSet": d.info.Name})
+	d.id = id
+	d.projectName = projectName
+	d.state = state
+
+	if d.info.Addresses == nil {
+		d.info.Addresses = []string{}
+	}
+
+	if d.info.Config == nil {
+		d.info.Config = make(map[string]string)
+	}
+}
+
+// ID returns the Network address set ID.
+func (d *common) ID() int64 {
+	return d.id
+}
+
+// Project returns the project name.
+func (d *common) Project() string {
+	return d.projectName
+}
+
+// Info returns copy of internal info for the Network address set.
+func (d *common) Info() *api.NetworkAddressSet {
+	// Copy internal info to prevent modification externally.
+	info := api.NetworkAddressSet{}
+	info.Name = d.info.Name
+	info.Description = d.info.Description
+	info.Addresses = append(make([]string, 0, len(d.info.Addresses)), d.info.Addresses...)
+	info.Config = util.CopyConfig(d.info.Config)
+	info.UsedBy = nil // To indicate its not populated (use UsedBy() function to populate).
+	info.Project = d.projectName
+
+	return &info
+}
+
+// Etag returns the values used for etag generation.
+func (d *common) Etag() []any {
+	return []any{d.info.Name, d.info.Description, d.info.Addresses, d.info.Config}
+}
+
+// usingACLs returns the names of the network ACLs whose rules reference this address set.
+func (d *common) usingACLs(ctx context.Context) ([]string, error) {
+	aclNames := []string{}
+
+	err := d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		projectACLNames, err := tx.GetNetworkACLs(ctx, d.projectName)
+		if err != nil {
+			return err
+		}
+
+		for _, aclName := range projectACLNames {
+			_, aclInfo, err := tx.GetNetworkACL(ctx, d.projectName, aclName)
+			if err != nil {
+				return err
+			}
+
+			if slices.Contains(acl.ReferencedAddressSets(aclInfo), d.info.Name) {
+				aclNames = append(aclNames, aclName)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting address set usage: %w", err)
+	}
+
+	return aclNames, nil
+}
+
+// UsedBy returns a list of API endpoints referencing this address set.
+func (d *common) UsedBy(ctx context.Context) ([]string, error) {
+	aclNames, err := d.usingACLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usedBy := make([]string, 0, len(aclNames))
+	for _, aclName := range aclNames {
+		usedBy = append(usedBy, api.NewURL().Path(version.APIVersion, "network-acls", aclName).Project(d.projectName).String())
+	}
+
+	return usedBy, nil
+}
+
+// validateName checks name is valid.
+func (d *common) validateName(name string) error {
+	return ValidName(name)
+}
+
+// validateConfig checks the config and addresses are valid.
+func (d *common) validateConfig(info *api.NetworkAddressSetPut) error {
+	for k := range info.Config {
+		// User keys are not validated.
+		if config.IsUserConfig(k) {
+			continue
+		}
+
+		return fmt.Errorf("Invalid config option %q", k)
+	}
+
+	for i, address := range info.Addresses {
+		if strings.Contains(address, "/") {
+			_, _, err := net.ParseCIDR(address)
+			if err != nil {
+				return fmt.Errorf("Invalid address %q: %w", address, err)
+			}
+		} else if net.ParseIP(address) == nil {
+			return fmt.Errorf("Invalid address %q", address)
+		}
+
+		if slices.Contains(info.Addresses[:i], address) {
+			return fmt.Errorf("Duplicate address %q", address)
+		}
+	}
+
+	return nil
+}
+
+// Update applies the supplied config to the address set and to the firewall of the networks using it.
+func (d *common) Update(ctx context.Context, config *api.NetworkAddressSetPut, clientType request.ClientType) error {
+	err := d.validateConfig(config)
+	if err != nil {
+		return err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		oldConfig := d.info.Writable()
+
+		err = d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			// Update database. Its important this occurs before we attempt to apply to networks using the
+			// address set as the ACL rules are loaded from the database.
+			return tx.UpdateNetworkAddressSet(ctx, d.id, *config)
+		})
+		if err != nil {
+			return err
+		}
+
+		// Apply changes internally and reinitialise.
+		d.info.SetWritable(*config)
+		d.init(d.state, d.id, d.projectName, d.info)
+
+		revert.Add(func() {
+			_ = d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpdateNetworkAddressSet(ctx, d.id, oldConfig)
+			})
+
+			d.info.SetWritable(oldConfig)
+			d.init(d.state, d.id, d.projectName, d.info)
+		})
+	}
+
+	aclNames, err := d.usingACLs(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Get a list of networks that are using the ACLs referencing this address set.
+	aclNets := map[string]acl.NetworkACLUsage{}
+	err = acl.NetworkUsage(ctx, d.state, d.projectName, aclNames, aclNets)
+	if err != nil {
+		return fmt.Errorf("Failed getting ACL network usage: %w", err)
+	}
+
+	// Separate out OVN networks from non-OVN networks. This is because OVN address sets are shared by all
+	// the networks and cluster members, whereas the firewall address sets are per-network on each member.
+	aclOVNNets := map[string]acl.NetworkACLUsage{}
+	for k, v := range aclNets {
+		if v.Type == "ovn" {
+			delete(aclNets, k)
+			aclOVNNets[k] = v
+		}
+	}
+
+	// Apply address set changes to non-OVN networks on this member.
+	for _, aclNet := range aclNets {
+		err = acl.FirewallApplyACLRules(ctx, d.state, d.projectName, aclNet)
+		if err != nil {
+			return err
+		}
+	}
+
+	// If there are affected OVN networks, then update the OVN address sets in place, but only if the request
+	// type is normal. This way we won't apply the same changes multiple times for each LXD cluster member.
+	if len(aclOVNNets) > 0 && clientType == request.ClientTypeNormal {
+		client, err := openvswitch.NewOVN(d.state.GlobalConfig.NetworkOVNNorthboundConnection(), d.state.GlobalConfig.NetworkOVNSSL)
+		if err != nil {
+			return fmt.Errorf("Failed getting OVN client: %w", err)
+		}
+
+		err = acl.OVNAddressSetApply(client, d.id, d.info.Addresses)
+		if err != nil {
+			return fmt.Errorf("Failed updating OVN address sets: %w", err)
+		}
+	}
+
+	// Apply address set changes to non-OVN networks on cluster members.
+	if clientType == request.ClientTypeNormal && len(aclNets) > 0 {
+		// Notify all other nodes to update the address set synchronously.
+		notifier, err := cluster.NewOperationNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(member db.NodeInfo, client lxd.InstanceServer) error {
+			op, err := client.UseProject(d.projectName).UpdateNetworkAddressSet(d.info.Name, d.info.Writable(), "")
+			if err == nil {
+				err = op.WaitContext(ctx)
+			}
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
+// Rename renames the address set if not in use.
+func (d *common) Rename(ctx context.Context, newName string) error {
+	_, err := LoadByName(ctx, d.state, d.projectName, newName)
+	if err == nil {
+		return errors.New("An address set by that name exists already")
+	}
+
+	usedBy, err := d.usingACLs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(usedBy) > 0 {
+		return errors.New("Cannot rename an address set that is in use")
+	}
+
+	err = d.validateName(newName)
+	if err != nil {
+		return err
+	}
+
+	err = d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.RenameNetworkAddressSet(ctx, d.id, newName)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Apply changes internally.
+	d.info.Name = newName
+
+	return nil
+}
+
+// Delete deletes the address set.
+func (d *common) Delete(ctx context.Context) error {
+	usedBy, err := d.usingACLs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(usedBy) > 0 {
+		return errors.New("Cannot delete an address set that is in use")
+	}
+
+	hasOVNNetworks := false
+	err = d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		networks, err := tx.GetCreatedNetworksByProject(ctx, d.projectName)
+		if err != nil {
+			return err
+		}
+
+		for _, network := range networks {
+			if network.Type == "ovn" {
+				hasOVNNetworks = true
+				break
+			}
+		}
+
+		return tx.DeleteNetworkAddressSet(ctx, d.id)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Remove the OVN address sets that may have been created for ACLs formerly using the address set.
+	if hasOVNNetworks {
+		client, err := openvswitch.NewOVN(d.state.GlobalConfig.NetworkOVNNorthboundConnection(), d.state.GlobalConfig.NetworkOVNSSL)
+		if err != nil {
+			return fmt.Errorf("Failed getting OVN client: %w", err)
+		}
+
+		err = client.AddressSetDelete(acl.OVNAddressSetPrefix(d.id))
+		if err != nil {
+			return fmt.Errorf("Failed deleting OVN address sets: %w", err)
+		}
+	}
+
+	return nil
+}
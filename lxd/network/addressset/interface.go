@@ -0,0 +1,31 @@
+package addressset
+
+import (
+	"context"
+
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// NetworkAddressSet represents a Network address set.
+type NetworkAddressSet interface {
+	// Initialise.
+	init(state *state.State, id int64, projectName string, setInfo *api.NetworkAddressSet)
+
+	// Info.
+	ID() int64
+	Project() string
+	Info() *api.NetworkAddressSet
+	Etag() []any
+	UsedBy(ctx context.Context) ([]string, error)
+
+	// Internal validation.
+	validateName(name string) error
+	validateConfig(config *api.NetworkAddressSetPut) error
+
+	// Modifications.
+	Update(ctx context.Context, config *api.NetworkAddressSetPut, clientType request.ClientType) error
+	Rename(ctx context.Context, newName string) error
+	Delete(ctx context.Context) error
+}
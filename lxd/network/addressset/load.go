@@ -0,0 +1,66 @@
+package addressset
+
+import (
+	"context"
+	"errors"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/validate"
+)
+
+// LoadByName loads and initialises a Network address set from the database by project and name.
+func LoadByName(ctx context.Context, s *state.State, projectName string, name string) (NetworkAddressSet, error) {
+	var id int64
+	var setInfo *api.NetworkAddressSet
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		id, setInfo, err = tx.GetNetworkAddressSet(ctx, projectName, name)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var set NetworkAddressSet = &common{} // Only a single driver currently.
+	set.init(s, id, projectName, setInfo)
+
+	return set, nil
+}
+
+// Create validates supplied record and creates new Network address set record in the database.
+func Create(ctx context.Context, s *state.State, projectName string, setInfo *api.NetworkAddressSetsPost) error {
+	var set NetworkAddressSet = &common{} // Only a single driver currently.
+	set.init(s, -1, projectName, nil)
+
+	err := set.validateName(setInfo.Name)
+	if err != nil {
+		return err
+	}
+
+	err = set.validateConfig(&setInfo.NetworkAddressSetPut)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Insert DB record.
+		_, err := tx.CreateNetworkAddressSet(ctx, projectName, setInfo)
+
+		return err
+	})
+}
+
+// ValidName checks the address set name is valid.
+func ValidName(name string) error {
+	if name == "" {
+		return errors.New("Name is required")
+	}
+
+	// Ensures the name can't contain characters used by the syntax of the ACL rule subjects referencing it.
+	return validate.IsHostname(name)
+}
@@ -54,6 +54,9 @@ const ForkdnsServersListFile = "servers.conf"
 
 var forkdnsServersLock sync.Mutex
 
+// evpnSyncLock serializes the updates of the EVPN overlays.
+var evpnSyncLock sync.Mutex
+
 // Default MTU for bridge interface.
 const bridgeMTUDefault = 1500
 
@@ -71,6 +74,7 @@ func (n *bridge) DBType() db.NetworkType {
 func (n *bridge) Info() Info {
 	info := n.common.Info()
 	info.AddressForwards = true
+	info.LoadBalancers = true
 
 	return info
 }
@@ -234,6 +238,29 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: Peer session hold time
 		//  scope: global
 
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=bgp.peers.NAME.import_prefixes)
+		// Specify a comma-separated list of subnets in CIDR notation.
+		// Routes received from the peer for prefixes within these subnets are added to the host through the bridge.
+		// See {ref}`network-bgp-import`.
+		// ---
+		//  type: string
+		//  condition: BGP server
+		//  defaultdesc: (no route import)
+		//  required: no
+		//  shortdesc: Subnets to import routes for from the peer
+		//  scope: global
+
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=bgp.peers.NAME.bfd)
+		// When enabled, the BGP session with the peer is shut down as soon as BFD detects that the peer is unreachable.
+		// See {ref}`network-bgp-bfd`.
+		// ---
+		//  type: bool
+		//  condition: BGP server
+		//  defaultdesc: `false`
+		//  required: no
+		//  shortdesc: Whether to use BFD to detect peer failures
+		//  scope: global
+
 		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=bgp.ipv4.nexthop)
 		//
 		// ---
@@ -288,18 +315,54 @@ func (n *bridge) Validate(config map[string]string) error {
 		// The default value varies depending on whether the bridge uses a tunnel or a fan setup.
 		// ---
 		//  type: integer
-		//  defaultdesc: `1400` when tunnels are configured, otherwise `1500` if `bridge.mode=standard` or `1450` if `bridge.mode=fan`
+		//  defaultdesc: `1400` when tunnels are configured, otherwise `1500` if `bridge.mode=standard` or `1450` if `bridge.mode=fan` or `bridge.mode=evpn`
 		//  shortdesc: Bridge MTU
 		//  scope: global
 		"bridge.mtu": validate.Optional(validate.IsNetworkMTU),
 		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=bridge.mode)
-		// Possible values are `standard` and `fan`.
+		// Possible values are `standard`, `fan` and `evpn`.
 		// ---
 		//  type: string
 		//  defaultdesc: `standard`
 		//  shortdesc: Bridge operation mode
 		//  scope: global
-		"bridge.mode": validate.Optional(validate.IsOneOf("standard", "fan")),
+		"bridge.mode": validate.Optional(validate.IsOneOf("standard", "fan", "evpn")),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=evpn.vni)
+		// All the VTEPs of the network must use the same VXLAN network identifier.
+		// ---
+		//  type: integer
+		//  condition: EVPN mode
+		//  defaultdesc: network ID
+		//  shortdesc: VXLAN network identifier of the overlay
+		//  scope: global
+		"evpn.vni": validate.Optional(validate.IsInRange(1, 16777215)),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=evpn.port)
+		//
+		// ---
+		//  type: integer
+		//  condition: EVPN mode
+		//  defaultdesc: `4789`
+		//  shortdesc: UDP port of the VXLAN overlay
+		//  scope: global
+		"evpn.port": validate.Optional(validate.IsNetworkPort),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=evpn.local_address)
+		// This address is advertised to the other VTEPs as the next hop of the local instances.
+		// ---
+		//  type: string
+		//  condition: EVPN mode
+		//  defaultdesc: cluster address of the member
+		//  shortdesc: Local VTEP address of the overlay
+		//  scope: local
+		"evpn.local_address": validate.Optional(validate.IsNetworkAddress),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=evpn.peers)
+		// The BGP server exchanges the EVPN routes with all the other cluster members as well as with these addresses.
+		// Specify a comma-separated list of IP addresses.
+		// ---
+		//  type: string
+		//  condition: EVPN mode
+		//  shortdesc: Additional VTEPs to exchange EVPN routes with
+		//  scope: global
+		"evpn.peers": validate.Optional(validate.IsListOf(validate.IsNetworkAddress)),
 		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=fan.overlay_subnet)
 		// Use CIDR notation.
 		// ---
@@ -647,6 +710,13 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  shortdesc: Whether to log egress traffic that doesn’t match any ACL rule
 		//  scope: global
 		"security.acls.default.egress.logged": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=qos.ingress.min)
+		// Default value of the `limits.ingress.min` option of the NICs connected to the network.
+		// ---
+		//  type: string
+		//  shortdesc: Default minimum guaranteed bandwidth for incoming traffic of NICs
+		//  scope: global
+		"qos.ingress.min": validate.Optional(validate.IsBitSize),
 
 		// lxdmeta:generate(entities=network-bridge; group=network-conf; key=user.*)
 		//
@@ -746,6 +816,39 @@ func (n *bridge) Validate(config map[string]string) error {
 				rules[k] = validate.Optional(validate.IsUint8)
 			}
 		}
+
+		// DHCP option keys have the option code in their name.
+		if strings.HasPrefix(k, "ipv4.dhcp.options.") {
+			// lxdmeta:generate(entities=network-bridge; group=network-conf; key=ipv4.dhcp.options.NNN)
+			// Replace `NNN` with the code of the DHCP option, between 1 and 254.
+			// The value is given to `dnsmasq` as is and the option is sent to all clients.
+			// ---
+			//  type: string
+			//  condition: IPv4 DHCP
+			//  shortdesc: Value of a DHCP option to send to clients
+			//  scope: global
+			err := validate.IsInRange(1, 254)(strings.TrimPrefix(k, "ipv4.dhcp.options."))
+			if err != nil {
+				return fmt.Errorf("Invalid DHCP option code in configuration key %q: %w", k, err)
+			}
+
+			rules[k] = validateDHCPOptionValue
+		} else if strings.HasPrefix(k, "ipv6.dhcp.options.") {
+			// lxdmeta:generate(entities=network-bridge; group=network-conf; key=ipv6.dhcp.options.NNN)
+			// Replace `NNN` with the code of the DHCPv6 option, between 1 and 65535.
+			// The value is given to `dnsmasq` as is and the option is sent to all clients.
+			// ---
+			//  type: string
+			//  condition: IPv6 DHCP
+			//  shortdesc: Value of a DHCPv6 option to send to clients
+			//  scope: global
+			err := validate.IsInRange(1, 65535)(strings.TrimPrefix(k, "ipv6.dhcp.options."))
+			if err != nil {
+				return fmt.Errorf("Invalid DHCPv6 option code in configuration key %q: %w", k, err)
+			}
+
+			rules[k] = validateDHCPOptionValue
+		}
 	}
 
 	// Add the BGP validation rules.
@@ -755,6 +858,7 @@ func (n *bridge) Validate(config map[string]string) error {
 	}
 
 	maps.Copy(rules, bgpRules)
+	maps.Copy(rules, n.qosValidationRules())
 
 	// Validate the configuration.
 	err = n.validate(config, rules)
@@ -783,6 +887,17 @@ func (n *bridge) Validate(config map[string]string) error {
 		return errors.New("Network name too long to use with the FAN (must be 11 characters or less)")
 	}
 
+	// Validate network name and driver when used in EVPN mode.
+	if bridgeMode == "evpn" {
+		if len(n.name) > 10 {
+			return errors.New("Network name too long to use with EVPN (must be 10 characters or less)")
+		}
+
+		if config["bridge.driver"] == "openvswitch" {
+			return errors.New("EVPN mode cannot be used with the openvswitch bridge driver")
+		}
+	}
+
 	bridgeModeOptions := []string{"ipv4.dhcp.expiry", "ipv4.firewall", "ipv4.nat", "ipv4.nat.order"}
 	for k, v := range config {
 		key := k
@@ -799,6 +914,10 @@ func (n *bridge) Validate(config map[string]string) error {
 			return errors.New("FAN configuration may only be set when in 'fan' mode")
 		}
 
+		if bridgeMode != "evpn" && strings.HasPrefix(key, "evpn.") && v != "" {
+			return errors.New("EVPN configuration may only be set when in 'evpn' mode")
+		}
+
 		// MTU checks
 		if key == "bridge.mtu" && v != "" {
 			mtu, err := strconv.ParseInt(v, 10, 64)
@@ -818,6 +937,8 @@ func (n *bridge) Validate(config map[string]string) error {
 
 			if config["bridge.mode"] == "fan" && mtu > 1450 {
 				return errors.New("Maximum MTU for a FAN bridge is 1450")
+			} else if config["bridge.mode"] == "evpn" && mtu > 1450 {
+				return errors.New("Maximum MTU for an EVPN bridge is 1450")
 			} else if n.hasTunnels(config) && mtu > 1400 {
 				return errors.New("Maximum MTU for a bridge with tunnels is 1400")
 			}
@@ -1084,6 +1205,8 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-option-force=119,"+strings.Trim(dnsSearch, " "))
 			}
 
+			dnsmasqCmd = append(dnsmasqCmd, n.dhcpOptionArgs(4)...)
+
 			expiry := "1h"
 			if n.config["ipv4.dhcp.expiry"] != "" {
 				expiry = n.config["ipv4.dhcp.expiry"]
@@ -1118,6 +1241,8 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 				dnsmasqCmd = append(dnsmasqCmd, "--dhcp-no-override", "--dhcp-authoritative", "--dhcp-leasefile="+leasefile, "--dhcp-hostsdir="+hostsDir)
 			}
 
+			dnsmasqCmd = append(dnsmasqCmd, n.dhcpOptionArgs(6)...)
+
 			expiry := "1h"
 			if n.config["ipv6.dhcp.expiry"] != "" {
 				expiry = n.config["ipv6.dhcp.expiry"]
@@ -1143,6 +1268,28 @@ func (n *bridge) getDnsmasqArgs(bridge *ip.Bridge) ([]string, error) {
 	return dnsmasqCmd, nil
 }
 
+// dhcpOptionArgs returns the dnsmasq arguments sending the DHCP options set in the ipv{ipVersion}.dhcp.options.NNN
+// configuration keys, ordered by key.
+func (n *bridge) dhcpOptionArgs(ipVersion uint) []string {
+	args := []string{}
+	prefix := fmt.Sprintf("ipv%d.dhcp.options.", ipVersion)
+
+	for _, k := range slices.Sorted(maps.Keys(n.config)) {
+		code, found := strings.CutPrefix(k, prefix)
+		if !found || n.config[k] == "" {
+			continue
+		}
+
+		if ipVersion == 6 {
+			code = "option6:" + code
+		}
+
+		args = append(args, fmt.Sprintf("--dhcp-option-force=%s,%s", code, n.config[k]))
+	}
+
+	return args
+}
+
 func (n *bridge) addDnsmasqFanArgs(args []string, address string, fanMTU uint32) ([]string, error) {
 	// Parse the host subnet.
 	_, hostSubnet, err := net.ParseCIDR(address + "/24")
@@ -1227,6 +1374,14 @@ func (n *bridge) startDnsmasq(dnsmasqCmd []string, dnsClustered bool, dnsCluster
 		return err
 	}
 
+	// Add the DHCP reservations (not written above when dnsmasq isn't running yet).
+	dnsmasq.ConfigMutex.Lock()
+	err = updateDNSMasqReservations(n.state, n)
+	dnsmasq.ConfigMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
 	// Create subprocess object dnsmasq.
 	command := "dnsmasq"
 	dnsmasqLogPath := shared.LogPath(fmt.Sprintf("dnsmasq.%s.log", n.name))
@@ -1325,7 +1480,7 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		bridge.MTU = uint32(mtuInt)
 	} else if len(tunnels) > 0 {
 		bridge.MTU = 1400
-	} else if n.config["bridge.mode"] == "fan" {
+	} else if slices.Contains([]string{"fan", "evpn"}, n.config["bridge.mode"]) {
 		bridge.MTU = 1450
 	}
 
@@ -1761,41 +1916,11 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 
 		// Allow forwarding.
 		if shared.IsTrueOrEmpty(n.config["ipv6.routing"]) {
-			// Get a list of proc entries.
-			entries, err := os.ReadDir("/proc/sys/net/ipv6/conf/")
+			err = enableIPv6Forwarding()
 			if err != nil {
 				return err
 			}
 
-			// First set accept_ra to 2 for all interfaces (if not disabled).
-			// This ensures that the host can still receive IPv6 router advertisements even with
-			// forwarding enabled (which enable below), as the default is to ignore router adverts
-			// when forward is enabled, and this could render the host unreachable if it uses
-			// SLAAC generated IPs.
-			for _, entry := range entries {
-				// Check that IPv6 router advertisement acceptance is enabled currently.
-				// If its set to 0 then we don't want to enable, and if its already set to 2 then
-				// we don't need to do anything.
-				content, err := os.ReadFile(fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/accept_ra", entry.Name()))
-				if err == nil && string(content) != "1\n" {
-					continue
-				}
-
-				// If IPv6 router acceptance is enabled (set to 1) then we now set it to 2.
-				err = util.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/accept_ra", entry.Name()), "2")
-				if err != nil && !os.IsNotExist(err) {
-					return err
-				}
-			}
-
-			// Then set forwarding for all of them.
-			for _, entry := range entries {
-				err = util.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/forwarding", entry.Name()), "1")
-				if err != nil && !os.IsNotExist(err) {
-					return err
-				}
-			}
-
 			if n.hasIPv6Firewall() {
 				fwOpts.FeaturesV6.ForwardingAllow = true
 			}
@@ -1993,6 +2118,48 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		dnsClusteredAddress, _, _ = strings.Cut(fanAddress, "/")
 	}
 
+	// Configure the EVPN overlay.
+	if n.config["bridge.mode"] == "evpn" {
+		vtep, err := n.evpnLocalAddress()
+		if err != nil {
+			return err
+		}
+
+		// Remote MAC addresses are added from the EVPN routes rather than learned.
+		vxlan := &ip.Vxlan{
+			Link:       ip.Link{Name: n.evpnTunnelName()},
+			VxlanID:    strconv.FormatUint(uint64(n.evpnVNI()), 10),
+			Local:      vtep.String(),
+			DstPort:    n.evpnPort(),
+			NoLearning: true,
+		}
+
+		err = vxlan.Add()
+		if err != nil {
+			return err
+		}
+
+		err = AttachInterface(n.name, vxlan.Name)
+		if err != nil {
+			return err
+		}
+
+		err = vxlan.SetMTU(bridge.MTU)
+		if err != nil {
+			return err
+		}
+
+		err = vxlan.SetUp()
+		if err != nil {
+			return err
+		}
+
+		err = bridge.SetUp()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Configure tunnels.
 	for _, tunnel := range tunnels {
 		getConfig := func(key string) string {
@@ -2197,6 +2364,12 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
+	// Setup network load balancers.
+	err = n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
 	nodeEvacuated := n.state.DB.Cluster.LocalNodeIsEvacuated()
 
 	// Setup BGP.
@@ -2207,6 +2380,19 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 	}
 
+	// Setup EVPN.
+	if n.config["bridge.mode"] == "evpn" {
+		err = n.evpnSetup()
+		if err != nil {
+			return fmt.Errorf("Failed setting up EVPN: %w", err)
+		}
+	} else {
+		err = n.evpnClear()
+		if err != nil {
+			return err
+		}
+	}
+
 	revert.Success()
 	return nil
 }
@@ -2225,6 +2411,12 @@ func (n *bridge) Stop() error {
 		return err
 	}
 
+	// Clear EVPN.
+	err = n.evpnClear()
+	if err != nil {
+		return err
+	}
+
 	// Kill any existing dnsmasq and forkdns daemon for this network
 	err = dnsmasq.Kill(n.name, false)
 	if err != nil {
@@ -2456,7 +2648,21 @@ func (n *bridge) spawnForkDNS(listenAddress string) error {
 
 // HandleHeartbeat refreshes forkdns servers. Retrieves the IPv4 address of each cluster node (excluding ourselves)
 // for this network. It then updates the forkdns server list file if there are changes.
+// In EVPN mode, it refreshes the VTEPs to exchange EVPN routes with instead.
 func (n *bridge) HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error {
+	if n.config["bridge.mode"] == "evpn" {
+		if !n.isRunning() {
+			return nil
+		}
+
+		memberAddresses := make([]string, 0, len(heartbeatData.Members))
+		for _, member := range heartbeatData.Members {
+			memberAddresses = append(memberAddresses, member.Address)
+		}
+
+		return n.evpnSetupPeers(memberAddresses)
+	}
+
 	// Make sure forkdns has been setup.
 	if !shared.PathExists(shared.VarPath("networks", n.name, "forkdns.pid")) {
 		return nil
@@ -3030,7 +3236,7 @@ func (n *bridge) bridgedNICExternalRoutes(bridgeProjectNetworks map[string][]*ap
 func (n *bridge) getExternalSubnetInUse() ([]externalSubnetUsage, error) {
 	var err error
 	var projectNetworks map[string]map[int64]api.Network
-	var projectNetworksForwardsOnUplink map[string]map[int64][]string
+	var projectNetworksForwardsOnUplink, projectNetworksLoadBalancersOnUplink map[string]map[int64][]string
 	var externalSubnets []externalSubnetUsage
 
 	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -3046,6 +3252,12 @@ func (n *bridge) getExternalSubnetInUse() ([]externalSubnetUsage, error) {
 			return fmt.Errorf("Failed loading network forward listen addresses: %w", err)
 		}
 
+		// Get all network load balancer listen addresses for load balancers assigned to this specific cluster member.
+		projectNetworksLoadBalancersOnUplink, err = tx.GetProjectNetworkLoadBalancerListenAddressesOnMember(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed loading network load balancer listen addresses: %w", err)
+		}
+
 		externalSubnets, err = n.common.getExternalSubnetInUse(ctx, tx, n.name, true)
 		if err != nil {
 			return fmt.Errorf("Failed getting external subnets in use: %w", err)
@@ -3134,9 +3346,143 @@ func (n *bridge) getExternalSubnetInUse() ([]externalSubnetUsage, error) {
 		}
 	}
 
+	// Add load balancer listen addresses to this list.
+	for projectName, networks := range projectNetworksLoadBalancersOnUplink {
+		for networkID, listenAddresses := range networks {
+			for _, listenAddress := range listenAddresses {
+				// Convert listen address to subnet.
+				listenAddressNet, err := ParseIPToNet(listenAddress)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid existing load balancer listen address %q", listenAddress)
+				}
+
+				externalSubnets = append(externalSubnets, externalSubnetUsage{
+					subnet:         *listenAddressNet,
+					networkProject: projectName,
+					networkName:    projectNetworks[projectName][networkID].Name,
+					usageType:      subnetUsageNetworkLoadBalancer,
+				})
+			}
+		}
+	}
+
 	return externalSubnets, nil
 }
 
+// checkListenAddressNotInUse checks that a forward or load balancer listen address doesn't overlap with the
+// external subnets used by networks, NICs, forwards and load balancers on this member.
+func (n *bridge) checkListenAddressNotInUse(listenAddressNet *net.IPNet) (bool, error) {
+	externalSubnetsInUse, err := n.getExternalSubnetInUse()
+	if err != nil {
+		return false, err
+	}
+
+	for _, externalSubnetUser := range externalSubnetsInUse {
+		// Check if usage is from our own network.
+		if externalSubnetUser.networkProject == n.project && externalSubnetUser.networkName == n.name {
+			// Skip checking conflict with our own network's subnet or SNAT address.
+			// But do not allow other conflict with other usage types within our own network.
+			if externalSubnetUser.usageType == subnetUsageNetwork || externalSubnetUser.usageType == subnetUsageNetworkSNAT {
+				continue
+			}
+		}
+
+		if SubnetContains(&externalSubnetUser.subnet, listenAddressNet) || SubnetContains(listenAddressNet, &externalSubnetUser.subnet) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// setupNICHairpinMode enables hairpin mode on the bridge ports of the active NICs connected to the network when
+// br_netfilter is enabled. This is needed for instances to connect to the forwards and load balancers targeting
+// them. Without hairpin mode the target will not be able to connect to the listener.
+func (n *bridge) setupNICHairpinMode() error {
+	if n.config["bridge.driver"] == "openvswitch" {
+		return nil
+	}
+
+	brNetfilterEnabled := false
+	for _, ipVersion := range []uint{4, 6} {
+		if BridgeNetfilterEnabled(ipVersion) == nil {
+			brNetfilterEnabled = true
+			break
+		}
+	}
+
+	if !brNetfilterEnabled {
+		return nil
+	}
+
+	filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
+
+	return n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
+			// Get the instance's effective network project name.
+			instNetworkProject := project.NetworkProjectFromRecord(&p)
+
+			if instNetworkProject != api.ProjectDefaultName {
+				return nil // Managed bridge networks can only exist in default project.
+			}
+
+			devices := instancetype.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
+
+			// Iterate through each of the instance's devices, looking for bridged NICs
+			// that are linked to this network.
+			for devName, devConfig := range devices {
+				if devConfig["type"] != "nic" {
+					continue
+				}
+
+				// Check whether the NIC device references our network..
+				if !NICUsesNetwork(devConfig, &api.Network{Name: n.Name()}) {
+					continue
+				}
+
+				hostName := inst.Config[fmt.Sprintf("volatile.%s.host_name", devName)]
+				if InterfaceExists(hostName) {
+					link := &ip.Link{Name: hostName}
+					err := link.BridgeLinkSetHairpin(true)
+					if err != nil {
+						return fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
+					}
+
+					n.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": link.Name})
+				}
+			}
+
+			return nil
+		}, filter)
+	})
+}
+
+// natListenAddressesCount returns the number of forwards and load balancers on this member.
+func (n *bridge) natListenAddressesCount() (int, error) {
+	var forwardListenAddresses, loadBalancerListenAddresses map[int64]string
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		forwardListenAddresses, err = tx.GetNetworkForwardListenAddresses(ctx, n.ID(), true)
+		if err != nil {
+			return fmt.Errorf("Failed loading network forwards: %w", err)
+		}
+
+		loadBalancerListenAddresses, err = tx.GetNetworkLoadBalancerListenAddresses(ctx, n.ID(), true)
+		if err != nil {
+			return fmt.Errorf("Failed loading network load balancers: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return len(forwardListenAddresses) + len(loadBalancerListenAddresses), nil
+}
+
 // forwardValidate validates the forward request.
 func (n *bridge) forwardValidate(listenAddress net.IP, forward api.NetworkForwardPut) ([]*forwardPortMap, error) {
 	err := n.checkAddressNotInOVNRange(listenAddress)
@@ -3176,32 +3522,7 @@ func (n *bridge) ForwardCreate(forward api.NetworkForwardsPost, clientType reque
 		return nil, err
 	}
 
-	externalSubnetsInUse, err := n.getExternalSubnetInUse()
-	if err != nil {
-		return nil, err
-	}
-
-	checkAddressNotInUse := func(netip *net.IPNet) (bool, error) {
-		// Check the listen address subnet doesn't fall within any existing network external subnets.
-		for _, externalSubnetUser := range externalSubnetsInUse {
-			// Check if usage is from our own network.
-			if externalSubnetUser.networkProject == n.project && externalSubnetUser.networkName == n.name {
-				// Skip checking conflict with our own network's subnet or SNAT address.
-				// But do not allow other conflict with other usage types within our own network.
-				if externalSubnetUser.usageType == subnetUsageNetwork || externalSubnetUser.usageType == subnetUsageNetworkSNAT {
-					continue
-				}
-			}
-
-			if SubnetContains(&externalSubnetUser.subnet, netip) || SubnetContains(netip, &externalSubnetUser.subnet) {
-				return false, nil
-			}
-		}
-
-		return true, nil
-	}
-
-	isValid, err := checkAddressNotInUse(listenAddressNet)
+	isValid, err := n.checkListenAddressNotInUse(listenAddressNet)
 	if err != nil {
 		return nil, err
 	} else if !isValid {
@@ -3238,78 +3559,16 @@ func (n *bridge) ForwardCreate(forward api.NetworkForwardsPost, clientType reque
 		return nil, err
 	}
 
-	// Check if hairpin mode needs to be enabled on active NIC bridge ports.
-	if n.config["bridge.driver"] != "openvswitch" {
-		brNetfilterEnabled := false
-		for _, ipVersion := range []uint{4, 6} {
-			if BridgeNetfilterEnabled(ipVersion) == nil {
-				brNetfilterEnabled = true
-				break
-			}
-		}
-
-		// If br_netfilter is enabled and bridge has forwards, we enable hairpin mode on each NIC's bridge
-		// port in case any of the forwards target the NIC and the instance attempts to connect to the
-		// forward's listener. Without hairpin mode on the target of the forward will not be able to
-		// connect to the listener.
-		if brNetfilterEnabled {
-			var listenAddresses map[int64]string
-
-			err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-				listenAddresses, err = tx.GetNetworkForwardListenAddresses(ctx, n.ID(), true)
-
-				return err
-			})
-			if err != nil {
-				return nil, fmt.Errorf("Failed loading network forwards: %w", err)
-			}
-
-			// If we are the first forward on this bridge, enable hairpin mode on active NIC ports.
-			if len(listenAddresses) <= 1 {
-				filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
-
-				err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-					return tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
-						// Get the instance's effective network project name.
-						instNetworkProject := project.NetworkProjectFromRecord(&p)
-
-						if instNetworkProject != api.ProjectDefaultName {
-							return nil // Managed bridge networks can only exist in default project.
-						}
-
-						devices := instancetype.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
-
-						// Iterate through each of the instance's devices, looking for bridged NICs
-						// that are linked to this network.
-						for devName, devConfig := range devices {
-							if devConfig["type"] != "nic" {
-								continue
-							}
-
-							// Check whether the NIC device references our network..
-							if !NICUsesNetwork(devConfig, &api.Network{Name: n.Name()}) {
-								continue
-							}
-
-							hostName := inst.Config[fmt.Sprintf("volatile.%s.host_name", devName)]
-							if InterfaceExists(hostName) {
-								link := &ip.Link{Name: hostName}
-								err = link.BridgeLinkSetHairpin(true)
-								if err != nil {
-									return fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
-								}
-
-								n.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"inst": inst.Name, "project": inst.Project, "device": devName, "dev": link.Name})
-							}
-						}
+	// If we are the first forward or load balancer on this bridge, enable hairpin mode on active NIC ports.
+	listenAddressesCount, err := n.natListenAddressesCount()
+	if err != nil {
+		return nil, err
+	}
 
-						return nil
-					}, filter)
-				})
-				if err != nil {
-					return nil, err
-				}
-			}
+	if listenAddressesCount <= 1 {
+		err = n.setupNICHairpinMode()
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -3528,6 +3787,320 @@ func (n *bridge) forwardSetupFirewall() error {
 	return nil
 }
 
+// loadBalancerValidate validates the load balancer request.
+func (n *bridge) loadBalancerValidate(listenAddress net.IP, loadBalancer api.NetworkLoadBalancerPut) ([]*loadBalancerPortMap, error) {
+	err := n.checkAddressNotInOVNRange(listenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.common.loadBalancerValidate(listenAddress, loadBalancer)
+}
+
+// loadBalancerConvertToFirewallLoadBalancers converts load balancer port maps into format compatible with the
+// firewall package.
+func (n *bridge) loadBalancerConvertToFirewallLoadBalancers(listenAddress net.IP, portMaps []*loadBalancerPortMap) []firewallDrivers.LoadBalancer {
+	var loadBalancers []firewallDrivers.LoadBalancer
+	for _, portMap := range portMaps {
+		for i, lp := range portMap.listenPorts {
+			loadBalancer := firewallDrivers.LoadBalancer{
+				ListenAddress: listenAddress,
+				Protocol:      portMap.protocol,
+				ListenPort:    lp,
+				Targets:       make([]firewallDrivers.LoadBalancerTarget, 0, len(portMap.targets)),
+			}
+
+			for _, target := range portMap.targets {
+				targetPort := lp // Default to using same port as listen port for target port.
+				targetPortsLen := len(target.ports)
+
+				if targetPortsLen == 1 {
+					// If a single target port is specified, forward all listen ports to it.
+					targetPort = target.ports[0]
+				} else if targetPortsLen > 1 {
+					// If more than 1 target port specified, use listen port index to get the
+					// target port to use.
+					targetPort = target.ports[i]
+				}
+
+				loadBalancer.Targets = append(loadBalancer.Targets, firewallDrivers.LoadBalancerTarget{
+					Address: target.address,
+					Port:    targetPort,
+				})
+			}
+
+			loadBalancers = append(loadBalancers, loadBalancer)
+		}
+	}
+
+	return loadBalancers
+}
+
+// LoadBalancerCreate creates a network load balancer.
+func (n *bridge) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) (net.IP, error) {
+	memberSpecific := true // bridge supports per-member load balancers.
+
+	// Convert listen address to subnet so we can check its valid and can be used.
+	listenAddressNet, err := ParseIPToNet(loadBalancer.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing load balancer listen address %q: %w", loadBalancer.ListenAddress, err)
+	}
+
+	if listenAddressNet.IP.IsUnspecified() {
+		return nil, api.StatusErrorf(http.StatusNotImplemented, "Automatic listen address allocation not supported for drivers of type %q", n.netType)
+	}
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check if there is an existing load balancer using the same listen address.
+		_, _, err := tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, loadBalancer.ListenAddress)
+
+		return err
+	})
+	if err == nil {
+		return nil, api.StatusErrorf(http.StatusConflict, "A load balancer for that listen address already exists")
+	}
+
+	_, err = n.loadBalancerValidate(listenAddressNet.IP, loadBalancer.NetworkLoadBalancerPut)
+	if err != nil {
+		return nil, err
+	}
+
+	isValid, err := n.checkListenAddressNotInUse(listenAddressNet)
+	if err != nil {
+		return nil, err
+	} else if !isValid {
+		// This error is purposefully vague so that it doesn't reveal any names of
+		// resources potentially outside of the network.
+		return nil, fmt.Errorf("Load balancer listen address %q overlaps with another network or NIC", listenAddressNet.String())
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	var loadBalancerID int64
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Create load balancer DB record.
+		loadBalancerID, err = tx.CreateNetworkLoadBalancer(ctx, n.ID(), memberSpecific, &loadBalancer)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revert.Add(func() {
+		_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.DeleteNetworkLoadBalancer(ctx, n.ID(), loadBalancerID)
+		})
+		_ = n.loadBalancerSetupFirewall()
+		_ = n.loadBalancerBGPSetupPrefixes()
+	})
+
+	err = n.loadBalancerSetupFirewall()
+	if err != nil {
+		return nil, err
+	}
+
+	// If we are the first forward or load balancer on this bridge, enable hairpin mode on active NIC ports.
+	listenAddressesCount, err := n.natListenAddressesCount()
+	if err != nil {
+		return nil, err
+	}
+
+	if listenAddressesCount <= 1 {
+		err = n.setupNICHairpinMode()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Refresh exported BGP prefixes on local member.
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	revert.Success()
+	return listenAddressNet.IP, nil
+}
+
+// LoadBalancerUpdate updates a network load balancer.
+func (n *bridge) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalancerPut, clientType request.ClientType) error {
+	memberSpecific := true // bridge supports per-member load balancers.
+
+	var curLoadBalancerID int64
+	var curLoadBalancer *api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		curLoadBalancerID, curLoadBalancer, err = tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, listenAddress)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), req)
+	if err != nil {
+		return err
+	}
+
+	curLoadBalancerEtagHash, err := util.EtagHash(curLoadBalancer.Etag())
+	if err != nil {
+		return err
+	}
+
+	newLoadBalancer := api.NetworkLoadBalancer{
+		ListenAddress: curLoadBalancer.ListenAddress,
+	}
+
+	newLoadBalancer.SetWritable(req)
+
+	newLoadBalancerEtagHash, err := util.EtagHash(newLoadBalancer.Etag())
+	if err != nil {
+		return err
+	}
+
+	if curLoadBalancerEtagHash == newLoadBalancerEtagHash {
+		return nil // Nothing has changed.
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateNetworkLoadBalancer(ctx, n.ID(), curLoadBalancerID, newLoadBalancer.Writable())
+	})
+	if err != nil {
+		return err
+	}
+
+	revert.Add(func() {
+		_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpdateNetworkLoadBalancer(ctx, n.ID(), curLoadBalancerID, curLoadBalancer.Writable())
+		})
+		_ = n.loadBalancerSetupFirewall()
+	})
+
+	err = n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
+// LoadBalancerDelete deletes a network load balancer.
+func (n *bridge) LoadBalancerDelete(listenAddress string, clientType request.ClientType) error {
+	memberSpecific := true // bridge supports per-member load balancers.
+	var loadBalancerID int64
+	var loadBalancer *api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		loadBalancerID, loadBalancer, err = tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, listenAddress)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.DeleteNetworkLoadBalancer(ctx, n.ID(), loadBalancerID)
+	})
+	if err != nil {
+		return err
+	}
+
+	revert.Add(func() {
+		newLoadBalancer := api.NetworkLoadBalancersPost{
+			NetworkLoadBalancerPut: loadBalancer.Writable(),
+			ListenAddress:          loadBalancer.ListenAddress,
+		}
+
+		_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			_, _ = tx.CreateNetworkLoadBalancer(ctx, n.ID(), memberSpecific, &newLoadBalancer)
+
+			return nil
+		})
+
+		_ = n.loadBalancerSetupFirewall()
+		_ = n.loadBalancerBGPSetupPrefixes()
+	})
+
+	err = n.loadBalancerSetupFirewall()
+	if err != nil {
+		return err
+	}
+
+	// Refresh exported BGP prefixes on local member.
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
+	revert.Success()
+	return nil
+}
+
+// loadBalancerSetupFirewall applies all network load balancers defined for this network and this member.
+func (n *bridge) loadBalancerSetupFirewall() error {
+	memberSpecific := true // Get all load balancers for this cluster member.
+
+	var loadBalancers map[int64]*api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		loadBalancers, err = tx.GetNetworkLoadBalancers(ctx, n.ID(), memberSpecific)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading network load balancers: %w", err)
+	}
+
+	var fwLoadBalancers []firewallDrivers.LoadBalancer
+
+	for loadBalancerID, loadBalancer := range loadBalancers {
+		listenAddress := net.ParseIP(loadBalancer.ListenAddress)
+
+		// Don't send traffic to the backends that failed their health checks.
+		portMaps, err := n.loadBalancerValidate(listenAddress, loadBalancerHealthyBackends(loadBalancerID, loadBalancer.Writable()))
+		if err != nil {
+			return fmt.Errorf("Failed validating firewall load balancer for listen address %q: %w", loadBalancer.ListenAddress, err)
+		}
+
+		fwLoadBalancers = append(fwLoadBalancers, n.loadBalancerConvertToFirewallLoadBalancers(listenAddress, portMaps)...)
+	}
+
+	err = n.state.Firewall.NetworkApplyLoadBalancers(n.name, fwLoadBalancers)
+	if err != nil {
+		return fmt.Errorf("Failed applying firewall load balancers: %w", err)
+	}
+
+	return nil
+}
+
+// loadBalancerRefresh applies the load balancers again to remove the backends that failed their health checks.
+func (n *bridge) loadBalancerRefresh(loadBalancerID int64, loadBalancer *api.NetworkLoadBalancer) error {
+	return n.loadBalancerSetupFirewall()
+}
+
+// BGPState returns the state of the BGP sessions with the peers of the network.
+func (n *bridge) BGPState() (*api.NetworkBGP, error) {
+	return n.bgpState()
+}
+
 // Leases returns a list of leases for the bridged network. It will reach out to other cluster members as needed.
 // The projectName passed here refers to the initial project from the API request which may differ from the network's project.
 // If projectName is empty, get leases from all projects.
@@ -3555,14 +4128,31 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 
 			// Include downstream OVN routers using the network as an uplink.
 			var projectNetworks map[string]map[int64]api.Network
+			var reservations []api.NetworkLeasesPost
 			err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 				projectNetworks, err = tx.GetCreatedNetworks(ctx)
+				if err != nil {
+					return err
+				}
+
+				reservations, err = tx.GetNetworkDHCPReservations(ctx, n.ID())
+
 				return err
 			})
 			if err != nil {
 				return nil, err
 			}
 
+			// Add the DHCP reservations.
+			for _, reservation := range reservations {
+				leases = append(leases, api.NetworkLease{
+					Hostname: reservation.Hostname,
+					Address:  reservation.Address,
+					Hwaddr:   reservation.Hwaddr,
+					Type:     "reserved",
+				})
+			}
+
 			// Look for networks using the current network as an uplink.
 			for projectName, networks := range projectNetworks {
 				for _, network := range networks {
@@ -3759,6 +4349,227 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 	return leases, nil
 }
 
+// LeaseCreate reserves an address of the network for a MAC address. It will notify other cluster members as needed.
+func (n *bridge) LeaseCreate(req api.NetworkLeasesPost, clientType request.ClientType) error {
+	if clientType == request.ClientTypeNormal {
+		err := n.leaseValidate(&req)
+		if err != nil {
+			return err
+		}
+
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			_, _, err := tx.GetNetworkDHCPReservation(ctx, n.ID(), req.Address)
+			if err == nil {
+				return api.StatusErrorf(http.StatusConflict, "A DHCP reservation for address %q already exists", req.Address)
+			} else if !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return err
+			}
+
+			// Only a single reservation per MAC address and address family is allowed.
+			reservations, err := tx.GetNetworkDHCPReservations(ctx, n.ID())
+			if err != nil {
+				return err
+			}
+
+			isIPv4 := net.ParseIP(req.Address).To4() != nil
+			for _, reservation := range reservations {
+				if reservation.Hwaddr == req.Hwaddr && (net.ParseIP(reservation.Address).To4() != nil) == isIPv4 {
+					return api.StatusErrorf(http.StatusConflict, "MAC address %q already has a DHCP reservation for address %q", req.Hwaddr, reservation.Address)
+				}
+			}
+
+			_, err = tx.CreateNetworkDHCPReservation(ctx, n.ID(), req)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		revert := revert.New()
+		defer revert.Fail()
+
+		revert.Add(func() {
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				id, _, err := tx.GetNetworkDHCPReservation(ctx, n.ID(), req.Address)
+				if err != nil {
+					return err
+				}
+
+				return tx.DeleteNetworkDHCPReservation(ctx, id)
+			})
+		})
+
+		// Notify all other members to refresh their dnsmasq host entries.
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(member db.NodeInfo, client lxd.InstanceServer) error {
+			return client.UseProject(n.project).CreateNetworkLease(n.name, req)
+		})
+		if err != nil {
+			return err
+		}
+
+		revert.Success()
+	}
+
+	return n.leaseRefresh(nil)
+}
+
+// LeaseDelete removes the DHCP reservation of an address or releases its dynamic lease.
+// It will notify other cluster members as needed.
+func (n *bridge) LeaseDelete(address string, clientType request.ClientType) error {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid address %q", address)
+	}
+
+	if clientType == request.ClientTypeNormal {
+		var reservationID int64
+
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			reservationID, _, err = tx.GetNetworkDHCPReservation(ctx, n.ID(), ip.String())
+
+			return err
+		})
+		if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		if err == nil {
+			err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.DeleteNetworkDHCPReservation(ctx, reservationID)
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			// Without a reservation, only addresses with an active lease can be released.
+			leases, err := n.Leases("", clientType)
+			if err != nil {
+				return err
+			}
+
+			found := slices.ContainsFunc(leases, func(lease api.NetworkLease) bool {
+				return lease.Type == "dynamic" && ip.Equal(net.ParseIP(lease.Address))
+			})
+
+			if !found {
+				return api.StatusErrorf(http.StatusNotFound, "Network lease not found")
+			}
+		}
+
+		// Notify all other members to release the lease and refresh their dnsmasq host entries.
+		notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
+
+		err = notifier(func(member db.NodeInfo, client lxd.InstanceServer) error {
+			return client.UseProject(n.project).DeleteNetworkLease(n.name, ip.String())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return n.leaseRefresh(ip)
+}
+
+// leaseValidate validates and normalizes a DHCP reservation request.
+func (n *bridge) leaseValidate(req *api.NetworkLeasesPost) error {
+	hwaddr, err := net.ParseMAC(req.Hwaddr)
+	if err != nil || validate.IsNetworkMAC(req.Hwaddr) != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid MAC address %q", req.Hwaddr)
+	}
+
+	req.Hwaddr = hwaddr.String()
+
+	if req.Hostname != "" {
+		err = validate.IsHostname(req.Hostname)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid hostname %q: %v", req.Hostname, err)
+		}
+	}
+
+	ip := net.ParseIP(req.Address)
+	if ip == nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid address %q", req.Address)
+	}
+
+	req.Address = ip.String()
+
+	// Check the address is part of the DHCP enabled subnet of its family.
+	ipVersion := 4
+	if ip.To4() == nil {
+		ipVersion = 6
+	}
+
+	routerIP, subnet, _ := net.ParseCIDR(n.config[fmt.Sprintf("ipv%d.address", ipVersion)])
+	if subnet == nil || !subnet.Contains(ip) {
+		return api.StatusErrorf(http.StatusBadRequest, "Address %q isn't part of the IPv%d subnet of the network", req.Address, ipVersion)
+	}
+
+	if ip.Equal(routerIP) {
+		return api.StatusErrorf(http.StatusBadRequest, "Address %q is the network gateway", req.Address)
+	}
+
+	if shared.IsFalse(n.config[fmt.Sprintf("ipv%d.dhcp", ipVersion)]) {
+		return api.StatusErrorf(http.StatusBadRequest, "DHCP is disabled for IPv%d on the network", ipVersion)
+	}
+
+	if ipVersion == 6 && shared.IsFalseOrEmpty(n.config["ipv6.dhcp.stateful"]) {
+		return api.StatusErrorf(http.StatusBadRequest, "IPv6 reservations require stateful DHCPv6 (ipv6.dhcp.stateful)")
+	}
+
+	// Check the MAC and address aren't already used by an instance NIC.
+	return UsedByInstanceDevices(n.state, n.Project(), n.Name(), n.Type(), func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		nicHwaddr := nicConfig["hwaddr"]
+		if nicHwaddr == "" {
+			nicHwaddr = inst.Config[fmt.Sprintf("volatile.%s.hwaddr", nicName)]
+		}
+
+		nicMAC, _ := net.ParseMAC(nicHwaddr)
+		if nicMAC != nil && nicMAC.String() == req.Hwaddr {
+			return api.StatusErrorf(http.StatusConflict, "MAC address %q is used by an instance NIC", req.Hwaddr)
+		}
+
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			if ip.Equal(net.ParseIP(nicConfig[key])) {
+				return api.StatusErrorf(http.StatusConflict, "Address %q is used by an instance NIC", req.Address)
+			}
+		}
+
+		return nil
+	})
+}
+
+// leaseRefresh rebuilds the local dnsmasq host entries and releases the dynamic lease of the given address if any.
+func (n *bridge) leaseRefresh(releaseIP net.IP) error {
+	if !n.UsesDNSMasq() {
+		return nil
+	}
+
+	err := UpdateDNSMasqStatic(n.state, n.name)
+	if err != nil {
+		return err
+	}
+
+	if releaseIP != nil {
+		_, err = dnsmasq.ReleaseLease(n.name, releaseIP)
+		if err != nil {
+			return fmt.Errorf("Failed releasing lease for %q: %w", releaseIP.String(), err)
+		}
+	}
+
+	return nil
+}
+
 // UsesDNSMasq indicates if network's config indicates if it needs to use dnsmasq.
 func (n *bridge) UsesDNSMasq() bool {
 	return n.config["bridge.mode"] == "fan" || !slices.Contains([]string{"", "none"}, n.config["ipv4.address"]) || !slices.Contains([]string{"", "none"}, n.config["ipv6.address"])
@@ -3795,3 +4606,279 @@ func (n *bridge) checkAddressNotInOVNRange(addr net.IP) error {
 
 	return nil
 }
+
+// evpnTunnelName returns the name of the VXLAN interface of the EVPN overlay.
+func (n *bridge) evpnTunnelName() string {
+	return n.name + "-evpn"
+}
+
+// evpnOwner returns the owner of the EVPN routes, peers and handler of the network in the BGP server.
+func (n *bridge) evpnOwner() string {
+	return fmt.Sprintf("network_%d_evpn", n.id)
+}
+
+// evpnVNI returns the VXLAN network identifier of the EVPN overlay.
+func (n *bridge) evpnVNI() uint32 {
+	vni, err := strconv.ParseUint(n.config["evpn.vni"], 10, 32)
+	if err != nil {
+		return uint32(n.id)
+	}
+
+	return uint32(vni)
+}
+
+// evpnPort returns the UDP port of the EVPN overlay.
+func (n *bridge) evpnPort() string {
+	if n.config["evpn.port"] == "" {
+		return "4789"
+	}
+
+	return n.config["evpn.port"]
+}
+
+// evpnLocalAddress returns the address of the local VTEP of the EVPN overlay.
+func (n *bridge) evpnLocalAddress() (net.IP, error) {
+	address := n.config["evpn.local_address"]
+	if address == "" {
+		clusterAddress := n.state.LocalConfig.ClusterAddress()
+		if clusterAddress == "" {
+			return nil, errors.New(`The "evpn.local_address" setting is required on standalone servers`)
+		}
+
+		var err error
+		address, _, err = net.SplitHostPort(clusterAddress)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing cluster address %q: %w", clusterAddress, err)
+		}
+	}
+
+	vtep := net.ParseIP(address)
+	if vtep == nil {
+		return nil, fmt.Errorf("Invalid EVPN local address %q", address)
+	}
+
+	return vtep, nil
+}
+
+// evpnSetup sets up the EVPN peers and routes of the network.
+func (n *bridge) evpnSetup() error {
+	var members []db.NodeInfo
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		members, err = tx.GetNodes(ctx)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	memberAddresses := make([]string, 0, len(members))
+	for _, member := range members {
+		memberAddresses = append(memberAddresses, member.Address)
+	}
+
+	err = n.evpnSetupPeers(memberAddresses)
+	if err != nil {
+		return err
+	}
+
+	// Refresh the overlay whenever the routes received from the other VTEPs change.
+	n.state.BGP.SetEVPNHandler(n.evpnOwner(), func() {
+		err := n.evpnSync()
+		if err != nil {
+			n.logger.Warn("Failed refreshing EVPN overlay", logger.Ctx{"err": err})
+		}
+	})
+
+	return n.evpnSync()
+}
+
+// evpnSetupPeers sets the VTEPs to exchange EVPN routes with to the other cluster members and the
+// "evpn.peers" addresses.
+func (n *bridge) evpnSetupPeers(memberAddresses []string) error {
+	localClusterAddress := n.state.LocalConfig.ClusterAddress()
+
+	peers := []net.IP{}
+	for _, memberAddress := range memberAddresses {
+		if memberAddress == localClusterAddress {
+			continue
+		}
+
+		host, _, err := net.SplitHostPort(memberAddress)
+		if err != nil {
+			continue
+		}
+
+		peer := net.ParseIP(host)
+		if peer == nil || peer.IsUnspecified() {
+			continue
+		}
+
+		peers = append(peers, peer)
+	}
+
+	for address := range strings.SplitSeq(n.config["evpn.peers"], ",") {
+		peer := net.ParseIP(strings.TrimSpace(address))
+		if peer == nil || slices.ContainsFunc(peers, peer.Equal) {
+			continue
+		}
+
+		peers = append(peers, peer)
+	}
+
+	err := n.state.BGP.SetEVPNPeers(peers, n.evpnOwner())
+	if err != nil {
+		return fmt.Errorf("Failed setting EVPN peers: %w", err)
+	}
+
+	return nil
+}
+
+// evpnClear removes the EVPN peers and routes of the network.
+func (n *bridge) evpnClear() error {
+	return n.state.BGP.RemoveEVPNByOwner(n.evpnOwner())
+}
+
+// evpnSync advertises the MAC addresses of the instances connected to the local member and updates the
+// forwarding entries of the overlay from the EVPN routes of the other VTEPs.
+func (n *bridge) evpnSync() error {
+	evpnSyncLock.Lock()
+	defer evpnSyncLock.Unlock()
+
+	tunName := n.evpnTunnelName()
+	if !InterfaceExists(tunName) {
+		return nil
+	}
+
+	vtep, err := n.evpnLocalAddress()
+	if err != nil {
+		return err
+	}
+
+	// Get the MAC addresses learned on the local ports of the bridge.
+	learned, err := (&ip.FDB{Master: n.name}).Show()
+	if err != nil {
+		return fmt.Errorf("Failed getting forwarding entries of %q: %w", n.name, err)
+	}
+
+	localPorts := map[string]string{}
+	macs := []net.HardwareAddr{}
+	for _, entry := range learned {
+		if entry.Permanent || entry.Master != n.name || entry.DevName == tunName {
+			continue
+		}
+
+		_, found := localPorts[entry.MAC.String()]
+		if found {
+			continue
+		}
+
+		localPorts[entry.MAC.String()] = entry.DevName
+		macs = append(macs, entry.MAC)
+	}
+
+	// Get the IP addresses of the local MAC addresses from the neighbour table of the bridge.
+	neighbours, err := (&ip.Neigh{DevName: n.name}).Show()
+	if err != nil {
+		return fmt.Errorf("Failed getting neighbours of %q: %w", n.name, err)
+	}
+
+	ips := map[string][]net.IP{}
+	for _, neighbour := range neighbours {
+		if neighbour.MAC == nil || neighbour.State == ip.NeighbourIPStateFailed {
+			continue
+		}
+
+		_, found := localPorts[neighbour.MAC.String()]
+		if !found {
+			continue
+		}
+
+		ips[neighbour.MAC.String()] = append(ips[neighbour.MAC.String()], neighbour.Addr)
+	}
+
+	state, err := n.state.BGP.SyncEVPN(n.evpnVNI(), vtep, macs, ips, n.evpnOwner())
+	if err != nil {
+		return fmt.Errorf("Failed syncing EVPN routes: %w", err)
+	}
+
+	// Forget the local ports of the MAC addresses that moved to another VTEP.
+	for _, mac := range state.Moved {
+		entry := &ip.FDB{DevName: localPorts[mac.String()], Master: n.name, MAC: mac}
+		err = entry.Delete()
+		if err != nil {
+			return fmt.Errorf("Failed removing forwarding entry of moved MAC address %q: %w", mac.String(), err)
+		}
+	}
+
+	// Get the current forwarding entries of the overlay.
+	current, err := (&ip.FDB{DevName: tunName}).Show()
+	if err != nil {
+		return fmt.Errorf("Failed getting forwarding entries of %q: %w", tunName, err)
+	}
+
+	floodMAC := net.HardwareAddr{0, 0, 0, 0, 0, 0}
+	flood := map[string]net.IP{}
+	remote := map[string]ip.FDB{}
+	for _, entry := range current {
+		if entry.Dst == nil {
+			continue
+		}
+
+		if entry.MAC.String() == floodMAC.String() {
+			flood[entry.Dst.String()] = entry.Dst
+		} else {
+			remote[entry.MAC.String()] = entry
+		}
+	}
+
+	// Send the broadcast, unknown unicast and multicast traffic to all the other VTEPs.
+	for _, dst := range state.Flood {
+		_, found := flood[dst.String()]
+		if found {
+			delete(flood, dst.String())
+			continue
+		}
+
+		err = (&ip.FDB{DevName: tunName, MAC: floodMAC, Dst: dst}).Append()
+		if err != nil {
+			return fmt.Errorf("Failed adding flood entry for VTEP %q: %w", dst.String(), err)
+		}
+	}
+
+	for _, dst := range flood {
+		err = (&ip.FDB{DevName: tunName, MAC: floodMAC, Dst: dst}).Delete()
+		if err != nil {
+			return fmt.Errorf("Failed removing flood entry for VTEP %q: %w", dst.String(), err)
+		}
+	}
+
+	// Send the unicast traffic to the VTEP of the destination MAC address.
+	for macStr, dst := range state.MACs {
+		entry, found := remote[macStr]
+		delete(remote, macStr)
+		if found && entry.Dst.Equal(dst) {
+			continue
+		}
+
+		mac, err := net.ParseMAC(macStr)
+		if err != nil {
+			return err
+		}
+
+		err = (&ip.FDB{DevName: tunName, MAC: mac, Dst: dst}).Replace()
+		if err != nil {
+			return fmt.Errorf("Failed adding forwarding entry for MAC address %q: %w", macStr, err)
+		}
+	}
+
+	for _, entry := range remote {
+		err = entry.Delete()
+		if err != nil {
+			return fmt.Errorf("Failed removing forwarding entry for MAC address %q: %w", entry.MAC.String(), err)
+		}
+	}
+
+	return nil
+}
@@ -736,12 +736,54 @@ func (n *common) bgpValidationRules(config map[string]string) (map[string]func(v
 			rules[k] = validate.IsAny
 		case "holdtime":
 			rules[k] = validate.Optional(validate.IsInRange(9, 65535))
+		case "import_prefixes":
+			rules[k] = validate.Optional(validate.IsListOf(validate.IsNetwork))
+		case "bfd":
+			rules[k] = validate.Optional(validate.IsBool)
 		}
 	}
 
 	return rules, nil
 }
 
+// qosValidationRules returns the validation rules for the QoS defaults of the NICs connected to the network.
+func (n *common) qosValidationRules() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// lxdmeta:generate(entities=network-{bridge+ovn}; group=network-conf; key=qos.ingress)
+		// Default value of the `limits.ingress` option of the NICs connected to the network.
+		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// ---
+		//  type: string
+		//  shortdesc: Default I/O limit for incoming traffic of NICs
+		"qos.ingress": validate.Optional(validate.IsBitSize),
+		// lxdmeta:generate(entities=network-{bridge+ovn}; group=network-conf; key=qos.egress)
+		// Default value of the `limits.egress` option of the NICs connected to the network.
+		// Specify the limit in bit/s. Various suffixes are supported (see {ref}`instances-limit-units`).
+		// ---
+		//  type: string
+		//  shortdesc: Default I/O limit for outgoing traffic of NICs
+		"qos.egress": validate.Optional(validate.IsBitSize),
+		// lxdmeta:generate(entities=network-{bridge+ovn}; group=network-conf; key=qos.ingress.burst)
+		// Default value of the `limits.ingress.burst` option of the NICs connected to the network.
+		// ---
+		//  type: string
+		//  shortdesc: Default burst size for incoming traffic of NICs
+		"qos.ingress.burst": validate.Optional(validate.IsSize),
+		// lxdmeta:generate(entities=network-{bridge+ovn}; group=network-conf; key=qos.egress.burst)
+		// Default value of the `limits.egress.burst` option of the NICs connected to the network.
+		// ---
+		//  type: string
+		//  shortdesc: Default burst size for outgoing traffic of NICs
+		"qos.egress.burst": validate.Optional(validate.IsSize),
+		// lxdmeta:generate(entities=network-{bridge+ovn}; group=network-conf; key=qos.dscp)
+		// Default value of the `limits.dscp` option of the NICs connected to the network.
+		// ---
+		//  type: integer
+		//  shortdesc: Default DSCP value for outgoing traffic of NICs
+		"qos.dscp": validate.Optional(validate.IsInRange(0, 63)),
+	}
+}
+
 // bgpSetup initializes BGP peers and prefixes.
 func (n *common) bgpSetup(oldConfig map[string]string) error {
 	err := n.bgpSetupPeers(oldConfig)
@@ -749,6 +791,11 @@ func (n *common) bgpSetup(oldConfig map[string]string) error {
 		return fmt.Errorf("Failed setting up BGP peers: %w", err)
 	}
 
+	err = n.bgpSetupImports(oldConfig)
+	if err != nil {
+		return fmt.Errorf("Failed setting up BGP route imports: %w", err)
+	}
+
 	err = n.bgpSetupPrefixes(oldConfig)
 	if err != nil {
 		return fmt.Errorf("Failed setting up BGP prefixes: %w", err)
@@ -760,13 +807,29 @@ func (n *common) bgpSetup(oldConfig map[string]string) error {
 		return fmt.Errorf("Failed applying BGP prefixes for address forwards: %w", err)
 	}
 
+	err = n.loadBalancerBGPSetupPrefixes()
+	if err != nil {
+		return fmt.Errorf("Failed applying BGP prefixes for load balancers: %w", err)
+	}
+
 	return nil
 }
 
 // bgpClear initializes BGP peers and prefixes.
 func (n *common) bgpClear(config map[string]string) error {
+	// Clear all route imports and BFD sessions.
+	err := n.state.BGP.RemoveImportByOwner(fmt.Sprintf("network_%d", n.id))
+	if err != nil {
+		return err
+	}
+
+	err = n.state.BGP.RemoveBFDByOwner(fmt.Sprintf("network_%d", n.id))
+	if err != nil {
+		return err
+	}
+
 	// Clear all peers.
-	err := n.bgpClearPeers(config)
+	err = n.bgpClearPeers(config)
 	if err != nil {
 		return err
 	}
@@ -783,6 +846,12 @@ func (n *common) bgpClear(config map[string]string) error {
 		return err
 	}
 
+	// Clear existing load balancer prefixes for network.
+	err = n.state.BGP.RemovePrefixByOwner(fmt.Sprintf("network_%d_load_balancer", n.id))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -851,6 +920,182 @@ func (n *common) bgpSetupPeers(oldConfig map[string]string) error {
 	return nil
 }
 
+// bgpSetupImports refreshes the route imports and BFD sessions for the BGP peers of the network.
+func (n *common) bgpSetupImports(oldConfig map[string]string) error {
+	bgpOwner := fmt.Sprintf("network_%d", n.id)
+
+	newImports, newBFD, err := n.bgpGetPeerOptions(n.config)
+	if err != nil {
+		return err
+	}
+
+	oldImports, oldBFD, err := n.bgpGetPeerOptions(oldConfig)
+	if err != nil {
+		return err
+	}
+
+	// Remove old route imports and BFD sessions.
+	for address := range oldImports {
+		_, found := newImports[address]
+		if found {
+			continue
+		}
+
+		err := n.state.BGP.RemoveImport(net.ParseIP(address), bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, address := range oldBFD {
+		if slices.Contains(newBFD, address) {
+			continue
+		}
+
+		err := n.state.BGP.RemoveBFD(net.ParseIP(address), bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Add new route imports and BFD sessions.
+	for address, prefixes := range newImports {
+		err := n.state.BGP.AddImport(net.ParseIP(address), prefixes, n.bgpImportDevice(), bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, address := range newBFD {
+		err := n.state.BGP.AddBFD(net.ParseIP(address), bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bgpImportDevice returns the host interface that the routes imported from BGP peers go through.
+func (n *common) bgpImportDevice() string {
+	if n.netType == "physical" {
+		return GetHostDevice(n.config["parent"], n.config["vlan"])
+	}
+
+	return n.name
+}
+
+// bgpGetPeerOptions returns the subnets to import routes for, keyed by peer address, and the addresses of the peers using BFD.
+func (n *common) bgpGetPeerOptions(config map[string]string) (map[string][]net.IPNet, []string, error) {
+	imports := map[string][]net.IPNet{}
+	bfdPeers := []string{}
+
+	for _, peerName := range n.bgpGetPeerNames(config) {
+		peerAddress := config[fmt.Sprintf("bgp.peers.%s.address", peerName)]
+		if peerAddress == "" || config[fmt.Sprintf("bgp.peers.%s.asn", peerName)] == "" {
+			continue
+		}
+
+		importPrefixes := config[fmt.Sprintf("bgp.peers.%s.import_prefixes", peerName)]
+		if importPrefixes != "" {
+			subnets := []net.IPNet{}
+			for _, importPrefix := range shared.SplitNTrimSpace(importPrefixes, ",", -1, true) {
+				_, subnet, err := net.ParseCIDR(importPrefix)
+				if err != nil {
+					return nil, nil, fmt.Errorf("Failed parsing BGP import prefix %q: %w", importPrefix, err)
+				}
+
+				subnets = append(subnets, *subnet)
+			}
+
+			imports[peerAddress] = subnets
+		}
+
+		if shared.IsTrue(config[fmt.Sprintf("bgp.peers.%s.bfd", peerName)]) {
+			bfdPeers = append(bfdPeers, peerAddress)
+		}
+	}
+
+	return imports, bfdPeers, nil
+}
+
+// bgpGetPeerNames returns the sorted names of the BGP peers in the config.
+func (n *common) bgpGetPeerNames(config map[string]string) []string {
+	peerNames := []string{}
+	for k := range config {
+		if !strings.HasPrefix(k, "bgp.peers.") {
+			continue
+		}
+
+		fields := strings.Split(k, ".")
+		if len(fields) == 4 && !slices.Contains(peerNames, fields[2]) {
+			peerNames = append(peerNames, fields[2])
+		}
+	}
+
+	slices.Sort(peerNames)
+
+	return peerNames
+}
+
+// bgpState returns the state of the BGP sessions with the peers of the network on the local member.
+func (n *common) bgpState() (*api.NetworkBGP, error) {
+	state := &api.NetworkBGP{
+		Peers: []api.NetworkBGPPeer{},
+	}
+
+	for _, peerName := range n.bgpGetPeerNames(n.config) {
+		peerAddress := n.config[fmt.Sprintf("bgp.peers.%s.address", peerName)]
+		peerASN := n.config[fmt.Sprintf("bgp.peers.%s.asn", peerName)]
+		if peerAddress == "" || peerASN == "" {
+			continue
+		}
+
+		asn, err := strconv.ParseUint(peerASN, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		peer := api.NetworkBGPPeer{
+			Name:               peerName,
+			Address:            peerAddress,
+			ASN:                uint32(asn),
+			State:              "idle",
+			ReceivedPrefixes:   []api.NetworkBGPPrefix{},
+			AdvertisedPrefixes: []api.NetworkBGPPrefix{},
+		}
+
+		info, err := n.state.BGP.PeerInfo(net.ParseIP(peerAddress))
+		if err != nil && !errors.Is(err, bgp.ErrPeerNotFound) {
+			return nil, fmt.Errorf("Failed getting state of BGP peer %q: %w", peerName, err)
+		}
+
+		if info != nil {
+			peer.State = info.State
+			peer.BFDState = info.BFDState
+
+			for _, prefix := range info.Received {
+				peer.ReceivedPrefixes = append(peer.ReceivedPrefixes, api.NetworkBGPPrefix{
+					Prefix:   prefix.Prefix.String(),
+					Nexthop:  prefix.Nexthop.String(),
+					Imported: prefix.Imported,
+				})
+			}
+
+			for _, prefix := range info.Advertised {
+				peer.AdvertisedPrefixes = append(peer.AdvertisedPrefixes, api.NetworkBGPPrefix{
+					Prefix:  prefix.Prefix.String(),
+					Nexthop: prefix.Nexthop.String(),
+				})
+			}
+		}
+
+		state.Peers = append(state.Peers, peer)
+	}
+
+	return state, nil
+}
+
 // bgpNextHopAddress parses nexthop configuration and returns next hop address to use for BGP routes.
 // Uses first of bgp.ipv{ipVersion}.nexthop or volatile.network.ipv{ipVersion}.address or wildcard address.
 func (n *common) bgpNextHopAddress(ipVersion uint) net.IP {
@@ -923,22 +1168,9 @@ func (n *common) bgpSetupPrefixes(oldConfig map[string]string) error {
 
 // bgpGetPeers returns a list of strings representing the BGP peers.
 func (n *common) bgpGetPeers(config map[string]string) []string {
-	// Get a list of peer names.
-	peerNames := []string{}
-	for k := range config {
-		if !strings.HasPrefix(k, "bgp.peers.") {
-			continue
-		}
-
-		fields := strings.Split(k, ".")
-		if !slices.Contains(peerNames, fields[2]) {
-			peerNames = append(peerNames, fields[2])
-		}
-	}
-
 	// Build up a list of peer strings.
 	peers := []string{}
-	for _, peerName := range peerNames {
+	for _, peerName := range n.bgpGetPeerNames(config) {
 		peerAddress := config[fmt.Sprintf("bgp.peers.%s.address", peerName)]
 		peerASN := config[fmt.Sprintf("bgp.peers.%s.asn", peerName)]
 		peerPassword := config[fmt.Sprintf("bgp.peers.%s.password", peerName)]
@@ -1335,14 +1567,23 @@ func (n *common) loadBalancerValidate(listenAddress net.IP, forward api.NetworkL
 		}
 	}
 
-	// Look for any unknown config fields.
-	for k := range forward.Config {
+	// Validate config fields.
+	healthCheckRules := loadBalancerHealthCheckRules()
+	for k, v := range forward.Config {
 		// User keys are not validated.
 		if config.IsUserConfig(k) {
 			continue
 		}
 
-		return nil, fmt.Errorf("Invalid option %q", k)
+		validator, found := healthCheckRules[k]
+		if !found {
+			return nil, fmt.Errorf("Invalid option %q", k)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for option %q: %w", k, err)
+		}
 	}
 
 	// Validate port rules.
@@ -1562,11 +1803,26 @@ func (n *common) loadBalancerBGPSetupPrefixes() error {
 	return nil
 }
 
+// BGPState returns ErrNotImplemented for drivers that don't support BGP peers.
+func (n *common) BGPState() (*api.NetworkBGP, error) {
+	return nil, ErrNotImplemented
+}
+
 // Leases returns ErrNotImplemented for drivers that don't support address leases.
 func (n *common) Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error) {
 	return nil, ErrNotImplemented
 }
 
+// LeaseCreate returns ErrNotImplemented for drivers that don't support DHCP reservations.
+func (n *common) LeaseCreate(req api.NetworkLeasesPost, clientType request.ClientType) error {
+	return ErrNotImplemented
+}
+
+// LeaseDelete returns ErrNotImplemented for drivers that don't support DHCP reservations.
+func (n *common) LeaseDelete(address string, clientType request.ClientType) error {
+	return ErrNotImplemented
+}
+
 // PeerCreate returns ErrNotImplemented for drivers that do not support forwards.
 func (n *common) PeerCreate(forward api.NetworkPeersPost) error {
 	return ErrNotImplemented
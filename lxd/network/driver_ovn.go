@@ -37,6 +37,7 @@ import (
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/revert"
+	"github.com/canonical/lxd/shared/units"
 	"github.com/canonical/lxd/shared/validate"
 )
 
@@ -46,6 +47,7 @@ const ovnVolatileUplinkIPv6 = "volatile.network.ipv6.address"
 
 const ovnRouterPolicyPeerAllowPriority = 600
 const ovnRouterPolicyPeerDropPriority = 500
+const ovnQoSPriority = 100
 
 // ovnUplinkVars OVN object variables derived from uplink network.
 type ovnUplinkVars struct {
@@ -604,6 +606,12 @@ func (n *ovn) Validate(config map[string]string) error {
 		//  defaultdesc: `false`
 		//  shortdesc: Whether to log egress traffic that doesn’t match any ACL rule
 		"security.acls.default.egress.logged": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=network-ovn; group=network-conf; key=qos.egress.min)
+		// Default value of the `limits.egress.min` option of the NICs connected to the network.
+		// ---
+		//  type: string
+		//  shortdesc: Default minimum guaranteed bandwidth for outgoing traffic of NICs
+		"qos.egress.min": validate.Optional(validate.IsBitSize),
 
 		// lxdmeta:generate(entities=network-ovn; group=network-conf; key=user.*)
 		//
@@ -616,6 +624,8 @@ func (n *ovn) Validate(config map[string]string) error {
 		ovnVolatileUplinkIPv6: validate.Optional(validate.IsNetworkAddressV6),
 	}
 
+	maps.Copy(rules, n.qosValidationRules())
+
 	err := n.validate(config, rules)
 	if err != nil {
 		return err
@@ -3489,9 +3499,12 @@ func (n *ovn) Update(newNetwork api.NetworkPut, targetNode string, clientType re
 
 		aclConfigChanged := len(addedACLs) > 0 || len(removedACLs) > 0 || len(changedDefaultRuleKeys) > 0
 
+		// Detect if the network QoS defaults have changed.
+		qosConfigChanged := slices.ContainsFunc(changedKeys, func(k string) bool { return strings.HasPrefix(k, "qos.") })
+
 		var localNICRoutes []net.IPNet
 
-		// Apply ACL changes to running instance NICs that use this network.
+		// Apply ACL and QoS changes to running instance NICs that use this network.
 		err = UsedByInstanceDevices(n.state, n.Project(), n.Name(), n.Type(), func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
 			nicACLs := shared.SplitNTrimSpace(nicConfig["security.acls"], ",", -1, true)
 
@@ -3503,6 +3516,14 @@ func (n *ovn) Update(newNetwork api.NetworkPut, targetNode string, clientType re
 				return nil // No need to update a port that isn't started yet.
 			}
 
+			// Apply the new QoS defaults.
+			if qosConfigChanged {
+				err = n.InstanceDevicePortSetQoS(inst.Config["volatile.uuid"], nicName, nicConfig)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Apply security ACL and default rule changes.
 			if aclConfigChanged {
 				// Check whether we need to add any of the new ACLs to the NIC.
@@ -4333,10 +4354,132 @@ func (n *ovn) InstanceDevicePortStart(opts *OVNInstanceNICSetupOpts, securityACL
 		n.logger.Debug("Cleared NIC default rule", logger.Ctx{"port": instancePortName})
 	}
 
+	err = n.InstanceDevicePortSetQoS(opts.InstanceUUID, opts.DeviceName, opts.DeviceConfig)
+	if err != nil {
+		return "", err
+	}
+
 	revert.Success()
 	return instancePortName, nil
 }
 
+// InstanceDevicePortSetQoS applies the QoS settings of the NIC device (or the network defaults) to its logical
+// switch port. The limits are applied by OVN from the perspective of the instance: egress is the traffic sent by
+// the instance (from-lport) and ingress is the traffic it receives (to-lport).
+func (n *ovn) InstanceDevicePortSetQoS(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error {
+	if instanceUUID == "" {
+		return errors.New("Instance UUID is required")
+	}
+
+	qosConfig := NICQoSConfig(deviceConfig, n.config)
+
+	// Converts a rate in bit/s to kbit/s.
+	rateKbit := func(key string) (uint64, error) {
+		if qosConfig[key] == "" {
+			return 0, nil
+		}
+
+		rate, err := units.ParseBitSizeString(qosConfig[key])
+		if err != nil {
+			return 0, fmt.Errorf("Invalid %q value: %w", key, err)
+		}
+
+		return uint64(rate) / 1000, nil
+	}
+
+	// Converts a burst size in bytes to kbit.
+	burstKbit := func(key string) (uint64, error) {
+		if qosConfig[key] == "" {
+			return 0, nil
+		}
+
+		burst, err := units.ParseByteSizeString(qosConfig[key])
+		if err != nil {
+			return 0, fmt.Errorf("Invalid %q value: %w", key, err)
+		}
+
+		return uint64(burst) * 8 / 1000, nil
+	}
+
+	instancePortName := n.getInstanceDevicePortName(instanceUUID, deviceName)
+
+	egressRule := openvswitch.OVNQoSRule{
+		Direction: "from-lport",
+		Match:     fmt.Sprintf(`inport == "%s"`, instancePortName),
+		Priority:  ovnQoSPriority,
+		DSCP:      -1,
+	}
+
+	ingressRule := openvswitch.OVNQoSRule{
+		Direction: "to-lport",
+		Match:     fmt.Sprintf(`outport == "%s"`, instancePortName),
+		Priority:  ovnQoSPriority,
+		DSCP:      -1,
+	}
+
+	var err error
+
+	egressRule.Rate, err = rateKbit("limits.egress")
+	if err != nil {
+		return err
+	}
+
+	egressRule.Burst, err = burstKbit("limits.egress.burst")
+	if err != nil {
+		return err
+	}
+
+	ingressRule.Rate, err = rateKbit("limits.ingress")
+	if err != nil {
+		return err
+	}
+
+	ingressRule.Burst, err = burstKbit("limits.ingress.burst")
+	if err != nil {
+		return err
+	}
+
+	if qosConfig["limits.dscp"] != "" {
+		egressRule.DSCP, err = strconv.Atoi(qosConfig["limits.dscp"])
+		if err != nil {
+			return fmt.Errorf("Invalid %q value: %w", "limits.dscp", err)
+		}
+	}
+
+	var minRate uint64
+	if qosConfig["limits.egress.min"] != "" {
+		rate, err := units.ParseBitSizeString(qosConfig["limits.egress.min"])
+		if err != nil {
+			return fmt.Errorf("Invalid %q value: %w", "limits.egress.min", err)
+		}
+
+		minRate = uint64(rate)
+	}
+
+	qosRules := []openvswitch.OVNQoSRule{}
+	if egressRule.Rate > 0 || egressRule.DSCP >= 0 {
+		qosRules = append(qosRules, egressRule)
+	}
+
+	if ingressRule.Rate > 0 {
+		qosRules = append(qosRules, ingressRule)
+	}
+
+	client, err := openvswitch.NewOVN(n.state.GlobalConfig.NetworkOVNNorthboundConnection(), n.state.GlobalConfig.NetworkOVNSSL)
+	if err != nil {
+		return fmt.Errorf("Failed getting OVN client: %w", err)
+	}
+
+	err = client.LogicalSwitchPortSetQoS(n.getIntSwitchName(), instancePortName, minRate, qosRules...)
+	if err != nil {
+		return fmt.Errorf("Failed applying OVN QoS rules for instance NIC: %w", err)
+	}
+
+	n.logger.Debug("Set NIC QoS rules", logger.Ctx{"port": instancePortName, "rules": len(qosRules), "minRate": minRate})
+
+	return nil
+}
+
 // instanceDeviceACLDefaults returns the action and logging mode to use for the specified direction's default rule.
 // If the security.acls.default.{in,e}gress.action or security.acls.default.{in,e}gress.logged settings are not
 // specified in the NIC device config, then the settings on the network are used, and if not specified there then
@@ -5304,9 +5447,87 @@ func (n *ovn) loadBalancerValidate(listenAddress net.IP, forward api.NetworkLoad
 		return nil, err
 	}
 
+	// OVN checks the target ports of the backends with TCP connections or UDP probes.
+	if forward.Config["healthcheck"] == "http" {
+		return nil, errors.New("HTTP health checks aren't supported on OVN networks")
+	}
+
+	for _, k := range []string{"healthcheck.port", "healthcheck.http.path", "healthcheck.http.status"} {
+		if forward.Config[k] != "" {
+			return nil, fmt.Errorf("Option %q isn't supported on OVN networks", k)
+		}
+	}
+
 	return n.common.loadBalancerValidate(listenAddress, forward)
 }
 
+// loadBalancerHealthCheck returns the OVN health check of the load balancer, or nil if health checks are disabled.
+// The backends connected to the network are checked through their logical switch port.
+func (n *ovn) loadBalancerHealthCheck(client *openvswitch.OVN, config map[string]string) (*openvswitch.OVNLoadBalancerHealthCheck, error) {
+	if config["healthcheck"] == "" {
+		return nil, nil
+	}
+
+	portIPs, err := client.LogicalSwitchIPs(n.getIntSwitchName())
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting logical switch port IPs: %w", err)
+	}
+
+	// The checks are sent from the router address of the network.
+	routerIPv4, _, _ := net.ParseCIDR(n.config["ipv4.address"])
+	routerIPv6, _, _ := net.ParseCIDR(n.config["ipv6.address"])
+
+	healthCheck := &openvswitch.OVNLoadBalancerHealthCheck{
+		Interval:     loadBalancerHealthCheckConfigInt(config, "healthcheck.interval", 10),
+		Timeout:      loadBalancerHealthCheckConfigInt(config, "healthcheck.timeout", 5),
+		SuccessCount: loadBalancerHealthCheckConfigInt(config, "healthcheck.success_threshold", 2),
+		FailureCount: loadBalancerHealthCheckConfigInt(config, "healthcheck.failure_threshold", 3),
+		TargetPorts:  map[string]openvswitch.OVNLoadBalancerHealthCheckPort{},
+	}
+
+	for portName, ips := range portIPs {
+		for _, ip := range ips {
+			sourceIP := routerIPv4
+			if ip.To4() == nil {
+				sourceIP = routerIPv6
+			}
+
+			if sourceIP == nil {
+				continue
+			}
+
+			healthCheck.TargetPorts[ip.String()] = openvswitch.OVNLoadBalancerHealthCheckPort{
+				Port:          portName,
+				SourceAddress: sourceIP,
+			}
+		}
+	}
+
+	return healthCheck, nil
+}
+
+// loadBalancerApply applies the load balancer VIPs along with their health check to OVN.
+func (n *ovn) loadBalancerApply(client *openvswitch.OVN, listenAddress string, config map[string]string, portMaps []*loadBalancerPortMap) error {
+	vips := n.loadBalancerFlattenVIPs(net.ParseIP(listenAddress), portMaps)
+
+	err := client.LoadBalancerApply(n.getLoadBalancerName(listenAddress), []openvswitch.OVNRouter{n.getRouterName()}, []openvswitch.OVNSwitch{n.getIntSwitchName()}, vips...)
+	if err != nil {
+		return fmt.Errorf("Failed applying OVN load balancer: %w", err)
+	}
+
+	healthCheck, err := n.loadBalancerHealthCheck(client, config)
+	if err != nil {
+		return err
+	}
+
+	err = client.LoadBalancerHealthCheckApply(n.getLoadBalancerName(listenAddress), healthCheck, vips...)
+	if err != nil {
+		return fmt.Errorf("Failed applying OVN load balancer health check: %w", err)
+	}
+
+	return nil
+}
+
 // LoadBalancerCreate creates a network load balancer.
 func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) (net.IP, error) {
 	revert := revert.New()
@@ -5362,11 +5583,9 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 			_ = n.loadBalancerBGPSetupPrefixes()
 		})
 
-		vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
-
-		err = client.LoadBalancerApply(n.getLoadBalancerName(loadBalancer.ListenAddress), []openvswitch.OVNRouter{n.getRouterName()}, []openvswitch.OVNSwitch{n.getIntSwitchName()}, vips...)
+		err = n.loadBalancerApply(client, loadBalancer.ListenAddress, loadBalancer.Config, portMaps)
 		if err != nil {
-			return nil, fmt.Errorf("Failed applying OVN load balancer: %w", err)
+			return nil, err
 		}
 
 		// Notify all other members to refresh their BGP prefixes.
@@ -5450,19 +5669,17 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 			return fmt.Errorf("Failed getting OVN client: %w", err)
 		}
 
-		vips := n.loadBalancerFlattenVIPs(net.ParseIP(newLoadBalancer.ListenAddress), portMaps)
-
-		err = client.LoadBalancerApply(n.getLoadBalancerName(newLoadBalancer.ListenAddress), []openvswitch.OVNRouter{n.getRouterName()}, []openvswitch.OVNSwitch{n.getIntSwitchName()}, vips...)
+		// OVN stops sending traffic to the backends that fail their health checks.
+		err = n.loadBalancerApply(client, newLoadBalancer.ListenAddress, req.Config, portMaps)
 		if err != nil {
-			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
+			return err
 		}
 
 		revert.Add(func() {
 			// Apply old settings to OVN on failure.
 			portMaps, err := n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), curLoadBalancer.Writable())
 			if err == nil {
-				vips := n.loadBalancerFlattenVIPs(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
-				_ = client.LoadBalancerApply(n.getLoadBalancerName(curLoadBalancer.ListenAddress), []openvswitch.OVNRouter{n.getRouterName()}, []openvswitch.OVNSwitch{n.getIntSwitchName()}, vips...)
+				_ = n.loadBalancerApply(client, curLoadBalancer.ListenAddress, curLoadBalancer.Config, portMaps)
 				_ = n.forwardBGPSetupPrefixes()
 			}
 		})
@@ -5573,6 +5790,79 @@ func (n *ovn) LoadBalancerDelete(listenAddress string, clientType request.Client
 	return nil
 }
 
+// loadBalancerBackendChecker returns a checker reporting the health of the load balancer backends as seen by the
+// OVN health checks. The backends aren't reachable from the host so they can't be checked by LXD itself.
+// The health check is applied again if backends connected to the network since it was configured aren't checked.
+func (n *ovn) loadBalancerBackendChecker(loadBalancer *api.NetworkLoadBalancer) (loadBalancerBackendChecker, error) {
+	client, err := openvswitch.NewOVN(n.state.GlobalConfig.NetworkOVNNorthboundConnection(), n.state.GlobalConfig.NetworkOVNSSL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN client: %w", err)
+	}
+
+	statuses, err := client.LoadBalancerHealthCheckStatus()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN load balancer health check status: %w", err)
+	}
+
+	healthCheck, err := n.loadBalancerHealthCheck(client, loadBalancer.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if healthCheck != nil {
+		portMaps, err := n.loadBalancerValidate(net.ParseIP(loadBalancer.ListenAddress), loadBalancer.Writable())
+		if err != nil {
+			return nil, err
+		}
+
+		vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
+
+		unchecked := false
+		for _, vip := range vips {
+			protocol := vip.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+
+			for _, target := range vip.Targets {
+				_, hasPort := healthCheck.TargetPorts[target.Address.String()]
+				_, checked := statuses[protocol+":"+net.JoinHostPort(target.Address.String(), strconv.FormatUint(target.Port, 10))]
+				if vip.ListenPort > 0 && hasPort && !checked {
+					unchecked = true
+				}
+			}
+		}
+
+		if unchecked {
+			err = client.LoadBalancerHealthCheckApply(n.getLoadBalancerName(loadBalancer.ListenAddress), healthCheck, vips...)
+			if err != nil {
+				return nil, fmt.Errorf("Failed applying OVN load balancer health check: %w", err)
+			}
+		}
+	}
+
+	checker := func(ctx context.Context, address string, port uint64) error {
+		status := ""
+		for _, protocol := range []string{"tcp", "udp"} {
+			status = statuses[protocol+":"+net.JoinHostPort(address, strconv.FormatUint(port, 10))]
+			if status != "" {
+				break
+			}
+		}
+
+		switch status {
+		case "online":
+			return nil
+		case "":
+			return errLoadBalancerBackendHealthUnknown
+		default:
+			return fmt.Errorf("OVN reports the backend as %s", status)
+		}
+	}
+
+	return checker, nil
+}
+
 // Leases returns a list of leases for the OVN network. Those are directly extracted from the OVN database.
 // If projectName is empty, get leases from all projects.
 func (n *ovn) Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error) {
@@ -191,6 +191,29 @@ func (n *physical) Validate(config map[string]string) error {
 	//  required: no
 	//  shortdesc: Peer session hold time
 	//  scope: global
+
+	// lxdmeta:generate(entities=network-physical; group=network-conf; key=bgp.peers.NAME.import_prefixes)
+	// Specify a comma-separated list of subnets in CIDR notation.
+	// Routes received from the peer for prefixes within these subnets are added to the host through the parent interface.
+	// See {ref}`network-bgp-import`.
+	// ---
+	//  type: string
+	//  condition: BGP server
+	//  defaultdesc: (no route import)
+	//  required: no
+	//  shortdesc: Subnets to import routes for from the peer
+	//  scope: global
+
+	// lxdmeta:generate(entities=network-physical; group=network-conf; key=bgp.peers.NAME.bfd)
+	// When enabled, the BGP session with the peer is shut down as soon as BFD detects that the peer is unreachable.
+	// See {ref}`network-bgp-bfd`.
+	// ---
+	//  type: bool
+	//  condition: BGP server
+	//  defaultdesc: `false`
+	//  required: no
+	//  shortdesc: Whether to use BFD to detect peer failures
+	//  scope: global
 	bgpRules, err := n.bgpValidationRules(config)
 	if err != nil {
 		return err
@@ -599,3 +622,8 @@ func (n *physical) State() (*api.NetworkState, error) {
 
 	return state, nil
 }
+
+// BGPState returns the state of the BGP sessions with the peers of the network.
+func (n *physical) BGPState() (*api.NetworkBGP, error) {
+	return n.bgpState()
+}
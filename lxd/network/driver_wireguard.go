@@ -0,0 +1,673 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/ip"
+	"github.com/canonical/lxd/lxd/request"
+	"github.com/canonical/lxd/lxd/resources"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/revert"
+	"github.com/canonical/lxd/shared/validate"
+)
+
+// wireGuardDefaultMTU is the default MTU of WireGuard interfaces, leaving room for the encapsulation overhead.
+const wireGuardDefaultMTU = 1420
+
+// wireGuardDefaultListenPort is the default UDP port WireGuard interfaces listen on.
+const wireGuardDefaultListenPort = 51820
+
+// wireguard represents a LXD WireGuard network.
+type wireguard struct {
+	common
+}
+
+// DBType returns the network type DB ID.
+func (n *wireguard) DBType() db.NetworkType {
+	return db.NetworkTypeWireGuard
+}
+
+// ValidateName validates network name.
+func (n *wireguard) ValidateName(name string) error {
+	err := validate.IsInterfaceName(name)
+	if err != nil {
+		return err
+	}
+
+	// Apply common name validation that applies to all network types.
+	return n.common.ValidateName(name)
+}
+
+// Validate network config.
+func (n *wireguard) Validate(config map[string]string) error {
+	rules := map[string]func(value string) error{
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=ipv4.address)
+		// Use CIDR notation.
+		// The subnet of the address is routed through the tunnel.
+		// ---
+		//  type: string
+		//  shortdesc: IPv4 address of the tunnel interface
+		//  scope: global
+		"ipv4.address": validate.Optional(validate.IsNetworkAddressCIDRV4),
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=ipv6.address)
+		// Use CIDR notation.
+		// The subnet of the address is routed through the tunnel.
+		// ---
+		//  type: string
+		//  shortdesc: IPv6 address of the tunnel interface
+		//  scope: global
+		"ipv6.address": validate.Optional(validate.IsNetworkAddressCIDRV6),
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=mtu)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `1420`
+		//  shortdesc: MTU of the tunnel interface
+		//  scope: global
+		"mtu": validate.Optional(validate.IsNetworkMTU),
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=wireguard.listen_port)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `51820`
+		//  shortdesc: UDP port to listen on for the tunnel traffic
+		//  scope: global
+		"wireguard.listen_port": validate.Optional(validate.IsNetworkPort),
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=wireguard.networks)
+		// Specify a comma-separated list of managed networks.
+		// The subnets of those networks are included in the allowed IPs of the peer configuration exported with `lxc network wireguard export`.
+		// ---
+		//  type: string
+		//  shortdesc: Local networks to make reachable by the peers
+		//  scope: global
+		"wireguard.networks": validate.Optional(validate.IsListOf(validate.IsInterfaceName)),
+
+		// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=user.*)
+		//
+		// ---
+		//  type: string
+		//  shortdesc: User-provided free-form key/value pairs
+		//  scope: global
+	}
+
+	// Add dynamic validation rules.
+	for k := range config {
+		// Peer keys have the peer name in their name, extract the suffix.
+		if !strings.HasPrefix(k, "peers.") {
+			continue
+		}
+
+		// Validate peer name in key.
+		fields := strings.Split(k, ".")
+		if len(fields) != 3 || fields[1] == "" {
+			return fmt.Errorf("Invalid network configuration key: %q", k)
+		}
+
+		peerKey := fields[2]
+
+		// Add the correct validation rule for the dynamic field based on last part of key.
+		switch peerKey {
+		case "public_key":
+			// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=peers.NAME.public_key)
+			// This key is required for every peer.
+			// ---
+			//  type: string
+			//  shortdesc: Public key of the peer
+			//  scope: global
+			rules[k] = validate.Required(validWireGuardKey)
+		case "endpoint":
+			// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=peers.NAME.endpoint)
+			// Specify the address or host name and the port, for example, `198.51.100.10:51820`.
+			// If not set, the peer must initiate the connection.
+			// ---
+			//  type: string
+			//  shortdesc: Endpoint of the peer
+			//  scope: global
+			rules[k] = validate.Optional(validate.IsListenAddress(true, false, true))
+		case "allowed_ips":
+			// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=peers.NAME.allowed_ips)
+			// Specify a comma-separated list of IPv4 and IPv6 CIDR subnets.
+			// Traffic to those subnets is routed to the peer, and traffic from those subnets is accepted from the peer.
+			// This key is required for every peer.
+			// ---
+			//  type: string
+			//  shortdesc: Subnets reachable through the peer
+			//  scope: global
+			rules[k] = validate.Required(validate.IsListOf(validate.IsNetwork))
+		case "persistent_keepalive":
+			// lxdmeta:generate(entities=network-wireguard; group=network-conf; key=peers.NAME.persistent_keepalive)
+			// Specify the interval in seconds.
+			// Set this option to keep the tunnel open through NAT and stateful firewalls.
+			// ---
+			//  type: integer
+			//  defaultdesc: `0` (disabled)
+			//  shortdesc: Interval between keepalive packets sent to the peer
+			//  scope: global
+			rules[k] = validate.Optional(validate.IsUint16)
+		}
+	}
+
+	// Validate the configuration.
+	err := n.validate(config, rules)
+	if err != nil {
+		return err
+	}
+
+	// Perform composite key checks after per-key validation.
+	publicKeys := map[string]string{}
+	var allowedNets []*net.IPNet
+	for _, peerName := range n.peers(config) {
+		publicKey := config["peers."+peerName+".public_key"]
+		if publicKey == "" {
+			return fmt.Errorf("Missing public key for peer %q", peerName)
+		}
+
+		otherPeerName, found := publicKeys[publicKey]
+		if found {
+			return fmt.Errorf("Peers %q and %q have the same public key", otherPeerName, peerName)
+		}
+
+		publicKeys[publicKey] = peerName
+
+		if config["peers."+peerName+".allowed_ips"] == "" {
+			return fmt.Errorf("Missing allowed IPs for peer %q", peerName)
+		}
+
+		// WireGuard routes each subnet to a single peer, so the allowed IPs of the peers must not overlap.
+		peerNets, err := n.peerAllowedNets(config, peerName)
+		if err != nil {
+			return err
+		}
+
+		for _, peerNet := range peerNets {
+			for _, allowedNet := range allowedNets {
+				if SubnetContains(peerNet, allowedNet) || SubnetContains(allowedNet, peerNet) {
+					return fmt.Errorf("Allowed IP %q of peer %q overlaps with the allowed IPs of another peer", peerNet.String(), peerName)
+				}
+			}
+		}
+
+		allowedNets = append(allowedNets, peerNets...)
+	}
+
+	return nil
+}
+
+// peers returns the sorted names of the peers defined in the config.
+func (n *wireguard) peers(config map[string]string) []string {
+	peerNames := []string{}
+	for k := range config {
+		if !strings.HasPrefix(k, "peers.") {
+			continue
+		}
+
+		fields := strings.Split(k, ".")
+		if len(fields) != 3 {
+			continue
+		}
+
+		if !slices.Contains(peerNames, fields[1]) {
+			peerNames = append(peerNames, fields[1])
+		}
+	}
+
+	slices.Sort(peerNames)
+
+	return peerNames
+}
+
+// peerAllowedNets returns the parsed allowed IPs of a peer.
+func (n *wireguard) peerAllowedNets(config map[string]string, peerName string) ([]*net.IPNet, error) {
+	var allowedNets []*net.IPNet
+	for allowedIP := range strings.SplitSeq(config["peers."+peerName+".allowed_ips"], ",") {
+		_, allowedNet, err := net.ParseCIDR(strings.TrimSpace(allowedIP))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid allowed IP %q for peer %q: %w", allowedIP, peerName, err)
+		}
+
+		allowedNets = append(allowedNets, allowedNet)
+	}
+
+	return allowedNets, nil
+}
+
+// isRunning returns whether the network is up.
+func (n *wireguard) isRunning() bool {
+	return InterfaceExists(n.name)
+}
+
+// Create checks the network can be created on this server.
+func (n *wireguard) Create(clientType request.ClientType) error {
+	n.logger.Debug("Create", logger.Ctx{"clientType": clientType, "config": n.config})
+
+	// A WireGuard network connects the local server to remote sites using a single key pair and set of routes,
+	// which can't be shared by the members of a cluster.
+	if n.state.ServerClustered {
+		return errors.New("WireGuard networks are not supported on clustered servers")
+	}
+
+	if InterfaceExists(n.name) {
+		return fmt.Errorf("Network interface %q already exists", n.name)
+	}
+
+	return nil
+}
+
+// Delete deletes a network.
+func (n *wireguard) Delete(clientType request.ClientType) error {
+	n.logger.Debug("Delete", logger.Ctx{"clientType": clientType})
+
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Removes the network directory, including the private key.
+	return n.delete()
+}
+
+// Rename renames a network.
+func (n *wireguard) Rename(newName string) error {
+	n.logger.Debug("Rename", logger.Ctx{"newName": newName})
+
+	if InterfaceExists(newName) {
+		return fmt.Errorf("Network interface %q already exists", newName)
+	}
+
+	// Bring the network down.
+	if n.isRunning() {
+		err := n.Stop()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Rename common steps.
+	err := n.rename(newName)
+	if err != nil {
+		return err
+	}
+
+	// Bring the network up.
+	err = n.Start()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Start starts the network.
+func (n *wireguard) Start() error {
+	n.logger.Debug("Start")
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() { n.setUnavailable() })
+
+	err := n.setup()
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+
+	// Ensure network is marked as available now its started.
+	n.setAvailable()
+
+	return nil
+}
+
+// privateKey returns the private key of the network, generating it on first use.
+// The key is kept on the local server only and never exposed through the API.
+func (n *wireguard) privateKey() (string, error) {
+	keyPath := shared.VarPath("networks", n.name, "wireguard.key")
+
+	content, err := os.ReadFile(keyPath)
+	if err == nil {
+		return strings.TrimSpace(string(content)), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("Failed reading WireGuard private key: %w", err)
+	}
+
+	privateKey, err := wireGuardGenerateKey()
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(keyPath, []byte(privateKey+"\n"), 0600)
+	if err != nil {
+		return "", fmt.Errorf("Failed writing WireGuard private key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// wgConfig returns the configuration of the WireGuard interface in the format used by "wg setconf".
+func (n *wireguard) wgConfig(privateKey string) string {
+	var sb strings.Builder
+
+	listenPort := n.config["wireguard.listen_port"]
+	if listenPort == "" {
+		listenPort = strconv.Itoa(wireGuardDefaultListenPort)
+	}
+
+	sb.WriteString("[Interface]\n")
+	sb.WriteString("PrivateKey = " + privateKey + "\n")
+	sb.WriteString("ListenPort = " + listenPort + "\n")
+
+	for _, peerName := range n.peers(n.config) {
+		peerKey := func(key string) string {
+			return n.config["peers."+peerName+"."+key]
+		}
+
+		sb.WriteString("\n[Peer]\n")
+		sb.WriteString("PublicKey = " + peerKey("public_key") + "\n")
+
+		if peerKey("endpoint") != "" {
+			sb.WriteString("Endpoint = " + peerKey("endpoint") + "\n")
+		}
+
+		sb.WriteString("AllowedIPs = " + peerKey("allowed_ips") + "\n")
+
+		if peerKey("persistent_keepalive") != "" {
+			sb.WriteString("PersistentKeepalive = " + peerKey("persistent_keepalive") + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// setup creates the WireGuard interface if needed and applies the config to it.
+func (n *wireguard) setup() error {
+	n.logger.Debug("Setting up network")
+
+	_, err := exec.LookPath("wg")
+	if err != nil {
+		return errors.New(`The "wg" tool is required to use WireGuard networks`)
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Create directory.
+	err = os.MkdirAll(shared.VarPath("networks", n.name), 0711)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := n.privateKey()
+	if err != nil {
+		return err
+	}
+
+	// Create the interface if needed.
+	if !n.isRunning() {
+		link := &ip.WireGuard{Link: ip.Link{Name: n.name}}
+		err = link.Add()
+		if err != nil {
+			return fmt.Errorf("Failed creating WireGuard interface (is the wireguard kernel module available?): %w", err)
+		}
+
+		revert.Add(func() { _ = link.Delete() })
+	}
+
+	// Set the MTU.
+	mtu := uint64(wireGuardDefaultMTU)
+	if n.config["mtu"] != "" {
+		mtu, err = strconv.ParseUint(n.config["mtu"], 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid MTU %q: %w", n.config["mtu"], err)
+		}
+	}
+
+	link := &ip.Link{Name: n.name}
+	err = link.SetMTU(uint32(mtu))
+	if err != nil {
+		return fmt.Errorf("Failed setting MTU %d on %q: %w", mtu, n.name, err)
+	}
+
+	// Apply the keys and peers. The config is replaced entirely so that removed peers are dropped.
+	configPath := shared.VarPath("networks", n.name, "wireguard.conf")
+	err = os.WriteFile(configPath, []byte(n.wgConfig(privateKey)), 0600)
+	if err != nil {
+		return fmt.Errorf("Failed writing WireGuard config: %w", err)
+	}
+
+	_, err = shared.RunCommand(context.TODO(), "wg", "setconf", n.name, configPath)
+	if err != nil {
+		return fmt.Errorf("Failed applying WireGuard config: %w", err)
+	}
+
+	// Configure the addresses, this also removes any route previously added to the interface.
+	var interfaceNets []*net.IPNet
+	for _, family := range []string{ip.FamilyV4, ip.FamilyV6} {
+		addr := &ip.Addr{
+			DevName: n.name,
+			Family:  family,
+		}
+
+		err = addr.Flush()
+		if err != nil {
+			return err
+		}
+
+		key := "ipv4.address"
+		if family == ip.FamilyV6 {
+			key = "ipv6.address"
+
+			if n.config[key] != "" {
+				err = util.SysctlSet("net/ipv6/conf/"+n.name+"/disable_ipv6", "0")
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if n.config[key] == "" {
+			continue
+		}
+
+		addr.Address = n.config[key]
+		err = addr.Add()
+		if err != nil {
+			return fmt.Errorf("Failed adding address %q to %q: %w", n.config[key], n.name, err)
+		}
+
+		_, interfaceNet, err := net.ParseCIDR(n.config[key])
+		if err != nil {
+			return err
+		}
+
+		interfaceNets = append(interfaceNets, interfaceNet)
+	}
+
+	err = link.SetUp()
+	if err != nil {
+		return err
+	}
+
+	// Route the allowed IPs of the peers through the tunnel.
+	hasIPv4Routes := false
+	hasIPv6Routes := false
+	for _, peerName := range n.peers(n.config) {
+		peerNets, err := n.peerAllowedNets(n.config, peerName)
+		if err != nil {
+			return err
+		}
+
+		for _, peerNet := range peerNets {
+			// Subnets of the interface are already routed through it.
+			if slices.ContainsFunc(interfaceNets, func(interfaceNet *net.IPNet) bool { return SubnetContains(interfaceNet, peerNet) }) {
+				continue
+			}
+
+			family := ip.FamilyV4
+			if peerNet.IP.To4() == nil {
+				family = ip.FamilyV6
+				hasIPv6Routes = true
+			} else {
+				hasIPv4Routes = true
+			}
+
+			r := &ip.Route{
+				DevName: n.name,
+				Route:   peerNet.String(),
+				Proto:   "static",
+				Family:  family,
+			}
+
+			err = r.Add()
+			if err != nil {
+				return fmt.Errorf("Failed adding route %q for peer %q: %w", peerNet.String(), peerName, err)
+			}
+		}
+	}
+
+	// Allow forwarding between the tunnel and the local networks.
+	if hasIPv4Routes || n.config["ipv4.address"] != "" {
+		err = util.SysctlSet("net/ipv4/ip_forward", "1")
+		if err != nil {
+			return err
+		}
+	}
+
+	if hasIPv6Routes || n.config["ipv6.address"] != "" {
+		err = enableIPv6Forwarding()
+		if err != nil {
+			return err
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
+// Stop stops the network.
+func (n *wireguard) Stop() error {
+	n.logger.Debug("Stop")
+
+	if !n.isRunning() {
+		return nil
+	}
+
+	// Removing the interface also removes its addresses and routes.
+	link := &ip.Link{Name: n.name}
+	err := link.Delete()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update updates the network. Accepts notification boolean indicating if this update request is coming from a
+// cluster notification, in which case do not update the database, just apply local changes needed.
+func (n *wireguard) Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error {
+	n.logger.Debug("Update", logger.Ctx{"clientType": clientType, "newNetwork": newNetwork})
+
+	dbUpdateNeeded, changedKeys, oldNetwork, err := n.configChanged(newNetwork)
+	if err != nil {
+		return err
+	}
+
+	if !dbUpdateNeeded {
+		return nil // Nothing changed.
+	}
+
+	// If the network as a whole has not had any previous creation attempts, or the node itself is still
+	// pending, then don't apply the new settings to the node, just to the database record (ready for the
+	// actual global create request to be initiated).
+	if n.Status() == api.NetworkStatusPending || n.LocalStatus() == api.NetworkStatusPending {
+		return n.update(newNetwork, targetNode, clientType)
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Define a function which reverts everything.
+	revert.Add(func() {
+		// Reset changes to all nodes and database.
+		err := n.update(oldNetwork, targetNode, clientType)
+		if err != nil {
+			n.logger.Warn("Failed reverting network update", logger.Ctx{"err": err})
+		}
+
+		// Reset any change that was made to the local interface.
+		_ = n.setup()
+	})
+
+	// Apply changes to all nodes and database.
+	err = n.update(newNetwork, targetNode, clientType)
+	if err != nil {
+		return err
+	}
+
+	// Apply the new config to the interface if needed.
+	if len(changedKeys) > 0 {
+		err = n.setup()
+		if err != nil {
+			return err
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
+// State returns the network state, including the WireGuard keys and peers.
+func (n *wireguard) State() (*api.NetworkState, error) {
+	state, err := resources.GetNetworkState(n.name)
+	if err != nil {
+		// If the interface is not found, return a response indicating the network is unavailable.
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return &api.NetworkState{
+				State: "unavailable",
+				Type:  "unknown",
+			}, nil
+		}
+
+		// In all other cases, return the original error.
+		return nil, err
+	}
+
+	output, err := shared.RunCommand(context.TODO(), "wg", "show", n.name, "dump")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting WireGuard state: %w", err)
+	}
+
+	wgState, err := wireGuardParseDump(output)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name the peers after their config.
+	for i, peer := range wgState.Peers {
+		for _, peerName := range n.peers(n.config) {
+			if n.config["peers."+peerName+".public_key"] == peer.PublicKey {
+				wgState.Peers[i].Name = peerName
+				break
+			}
+		}
+	}
+
+	state.WireGuard = wgState
+
+	return state, nil
+}
@@ -59,6 +59,11 @@ type Network interface {
 	// Status.
 	State() (*api.NetworkState, error)
 	Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error)
+	BGPState() (*api.NetworkBGP, error)
+
+	// DHCP leases.
+	LeaseCreate(req api.NetworkLeasesPost, clientType request.ClientType) error
+	LeaseDelete(address string, clientType request.ClientType) error
 
 	// Address Forwards.
 	ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) (net.IP, error)
@@ -69,6 +74,7 @@ type Network interface {
 	LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) (net.IP, error)
 	LoadBalancerUpdate(listenAddress string, newLoadBalancer api.NetworkLoadBalancerPut, clientType request.ClientType) error
 	LoadBalancerDelete(listenAddress string, clientType request.ClientType) error
+	LoadBalancerState(listenAddress string) (*api.NetworkLoadBalancerState, error)
 
 	// Peerings.
 	PeerCreate(forward api.NetworkPeersPost) error
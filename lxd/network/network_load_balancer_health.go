@@ -0,0 +1,632 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/validate"
+)
+
+// Load balancer backend health statuses.
+const (
+	loadBalancerBackendStatusUnknown = "unknown"
+	loadBalancerBackendStatusOnline  = "online"
+	loadBalancerBackendStatusOffline = "offline"
+)
+
+// loadBalancerHealthCheckRules returns the validation rules of the load balancer health check config keys.
+func loadBalancerHealthCheckRules() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck)
+		// Possible values are `tcp` (a TCP connection to the backend must succeed) and `http` (an HTTP `GET` request
+		// to the backend must return the expected status code).
+		// Health checks are disabled if not set.
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: Type of health check run against the backends
+		"healthcheck": validate.Optional(validate.IsOneOf("tcp", "http")),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.interval)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `10`
+		//  required: no
+		//  shortdesc: Number of seconds between two health checks of a backend
+		"healthcheck.interval": validate.Optional(validate.IsInRange(5, 3600)),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.timeout)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `5`
+		//  required: no
+		//  shortdesc: Number of seconds after which a health check is considered failed
+		"healthcheck.timeout": validate.Optional(validate.IsInRange(1, 60)),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.failure_threshold)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `3`
+		//  required: no
+		//  shortdesc: Number of consecutive failed checks after which a backend is considered offline
+		"healthcheck.failure_threshold": validate.Optional(validate.IsInRange(1, 100)),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.success_threshold)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `2`
+		//  required: no
+		//  shortdesc: Number of consecutive successful checks after which an offline backend is considered online again
+		"healthcheck.success_threshold": validate.Optional(validate.IsInRange(1, 100)),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.port)
+		// If not set, the first target port of the backend is used, or the first listen port of the first port
+		// specification using the backend if the backend has no target port.
+		// ---
+		//  type: integer
+		//  required: no
+		//  shortdesc: Port of the backends to run the health checks against
+		"healthcheck.port": validate.Optional(validate.IsNetworkPort),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.http.path)
+		//
+		// ---
+		//  type: string
+		//  defaultdesc: `/`
+		//  condition: `healthcheck` set to `http`
+		//  required: no
+		//  shortdesc: Path requested by the HTTP health check
+		"healthcheck.http.path": validate.Optional(func(value string) error {
+			if value[0] != '/' {
+				return errors.New("Path must start with /")
+			}
+
+			return nil
+		}),
+
+		// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-health-check-properties; key=healthcheck.http.status)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `200`
+		//  condition: `healthcheck` set to `http`
+		//  required: no
+		//  shortdesc: HTTP status code expected from a healthy backend
+		"healthcheck.http.status": validate.Optional(validate.IsInRange(100, 599)),
+	}
+}
+
+// loadBalancerBackendHealth is the health of a load balancer backend as seen by the local member.
+type loadBalancerBackendHealth struct {
+	address       string
+	port          uint64
+	status        string
+	successes     int
+	failures      int
+	lastCheckedAt time.Time
+	err           string
+}
+
+// loadBalancerHealth is the health of the backends of a load balancer checked by the local member.
+type loadBalancerHealth struct {
+	lastCheckedAt time.Time
+	appliedEtag   string // Etag of the load balancer when unhealthy backends were last removed.
+	backends      map[string]*loadBalancerBackendHealth
+}
+
+// loadBalancerHealthMu protects loadBalancerHealthByID.
+var loadBalancerHealthMu sync.Mutex
+
+// loadBalancerHealthByID holds the health of the load balancers checked by the local member keyed by load balancer ID.
+var loadBalancerHealthByID = map[int64]*loadBalancerHealth{}
+
+// loadBalancerHealthCheckConfigInt returns the integer value of the given health check config key or its default.
+func loadBalancerHealthCheckConfigInt(config map[string]string, key string, defaultValue int) int {
+	value, err := strconv.Atoi(config[key])
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// loadBalancerHealthyBackends returns the load balancer with its offline backends removed from its port
+// specifications. Port specifications whose backends are all offline keep their configured backends rather than
+// being left without any target. Backends that haven't been checked yet are considered healthy.
+func loadBalancerHealthyBackends(loadBalancerID int64, loadBalancer api.NetworkLoadBalancerPut) api.NetworkLoadBalancerPut {
+	if loadBalancer.Config["healthcheck"] == "" {
+		return loadBalancer
+	}
+
+	loadBalancerHealthMu.Lock()
+	defer loadBalancerHealthMu.Unlock()
+
+	health, found := loadBalancerHealthByID[loadBalancerID]
+	if !found {
+		return loadBalancer
+	}
+
+	ports := make([]api.NetworkLoadBalancerPort, 0, len(loadBalancer.Ports))
+	for _, port := range loadBalancer.Ports {
+		backends := make([]string, 0, len(port.TargetBackend))
+		for _, backendName := range port.TargetBackend {
+			backendHealth, found := health.backends[backendName]
+			if found && backendHealth.status == loadBalancerBackendStatusOffline {
+				continue
+			}
+
+			backends = append(backends, backendName)
+		}
+
+		if len(backends) > 0 {
+			port.TargetBackend = backends
+		}
+
+		ports = append(ports, port)
+	}
+
+	loadBalancer.Ports = ports
+
+	return loadBalancer
+}
+
+// loadBalancerBackendHealthCheckPort returns the port of the backend that is health checked.
+func loadBalancerBackendHealthCheckPort(loadBalancer *api.NetworkLoadBalancer, backend api.NetworkLoadBalancerBackend) (uint64, error) {
+	port := loadBalancer.Config["healthcheck.port"]
+
+	if port == "" {
+		ports := shared.SplitNTrimSpace(backend.TargetPort, ",", -1, true)
+		if len(ports) > 0 {
+			port = ports[0]
+		}
+	}
+
+	if port == "" {
+		for _, portSpec := range loadBalancer.Ports {
+			ports := shared.SplitNTrimSpace(portSpec.ListenPort, ",", -1, true)
+			if len(ports) > 0 && slices.Contains(portSpec.TargetBackend, backend.Name) {
+				port = ports[0]
+				break
+			}
+		}
+	}
+
+	if port == "" {
+		return 0, errors.New("No port to check")
+	}
+
+	portFirst, _, err := ParsePortRange(port)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(portFirst), nil
+}
+
+// errLoadBalancerBackendHealthUnknown is returned by a backend health check whose result isn't available yet.
+var errLoadBalancerBackendHealthUnknown = errors.New("Backend health unknown")
+
+// loadBalancerBackendChecker is a function checking the health of a load balancer backend.
+type loadBalancerBackendChecker func(ctx context.Context, address string, port uint64) error
+
+// loadBalancerBackendHealthCheck checks the health of a load balancer backend.
+func loadBalancerBackendHealthCheck(ctx context.Context, config map[string]string, address string, port uint64) error {
+	timeout := time.Duration(loadBalancerHealthCheckConfigInt(config, "healthcheck.timeout", 5)) * time.Second
+	hostPort := net.JoinHostPort(address, strconv.FormatUint(port, 10))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if config["healthcheck"] == "http" {
+		path := config["healthcheck.http.path"]
+		if path == "" {
+			path = "/"
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+hostPort+path, nil)
+		if err != nil {
+			return err
+		}
+
+		// Use a dedicated transport so that no proxy is used and connections aren't kept between checks.
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		_ = resp.Body.Close()
+
+		expectedStatus := loadBalancerHealthCheckConfigInt(config, "healthcheck.http.status", http.StatusOK)
+		if resp.StatusCode != expectedStatus {
+			return fmt.Errorf("Unexpected HTTP status %d (expected %d)", resp.StatusCode, expectedStatus)
+		}
+
+		return nil
+	}
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.Close()
+
+	return nil
+}
+
+// loadBalancerHealthCheck checks the backends of a load balancer with the given checker if its interval has elapsed
+// and updates their health. Returns true if the set of offline backends changed since it was last applied.
+func loadBalancerHealthCheck(ctx context.Context, loadBalancerID int64, loadBalancer *api.NetworkLoadBalancer, checker loadBalancerBackendChecker) (bool, error) {
+	interval := time.Duration(loadBalancerHealthCheckConfigInt(loadBalancer.Config, "healthcheck.interval", 10)) * time.Second
+	failureThreshold := loadBalancerHealthCheckConfigInt(loadBalancer.Config, "healthcheck.failure_threshold", 3)
+	successThreshold := loadBalancerHealthCheckConfigInt(loadBalancer.Config, "healthcheck.success_threshold", 2)
+
+	etagHash, err := util.EtagHash(loadBalancer.Etag())
+	if err != nil {
+		return false, err
+	}
+
+	loadBalancerHealthMu.Lock()
+	health, found := loadBalancerHealthByID[loadBalancerID]
+	if !found {
+		health = &loadBalancerHealth{backends: map[string]*loadBalancerBackendHealth{}}
+		loadBalancerHealthByID[loadBalancerID] = health
+	}
+
+	if time.Since(health.lastCheckedAt) < interval {
+		loadBalancerHealthMu.Unlock()
+		return false, nil
+	}
+
+	health.lastCheckedAt = time.Now()
+
+	// Prepare the backends to check, resetting the health of the backends whose target changed.
+	toCheck := make(map[string]loadBalancerBackendHealth, len(loadBalancer.Backends))
+	for _, backend := range loadBalancer.Backends {
+		port, err := loadBalancerBackendHealthCheckPort(loadBalancer, backend)
+		if err != nil {
+			logger.Warn("Skipping health check of load balancer backend", logger.Ctx{"listenAddress": loadBalancer.ListenAddress, "backend": backend.Name, "err": err})
+			continue
+		}
+
+		backendHealth, found := health.backends[backend.Name]
+		if !found || backendHealth.address != backend.TargetAddress || backendHealth.port != port {
+			backendHealth = &loadBalancerBackendHealth{
+				address: backend.TargetAddress,
+				port:    port,
+				status:  loadBalancerBackendStatusUnknown,
+			}
+
+			health.backends[backend.Name] = backendHealth
+		}
+
+		toCheck[backend.Name] = *backendHealth
+	}
+
+	// Forget the backends that were removed from the load balancer.
+	for name := range health.backends {
+		_, found := toCheck[name]
+		if !found {
+			delete(health.backends, name)
+		}
+	}
+
+	loadBalancerHealthMu.Unlock()
+
+	// Run the checks in parallel.
+	results := make(map[string]error, len(toCheck))
+	resultsMu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for name, backendHealth := range toCheck {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := checker(ctx, backendHealth.address, backendHealth.port)
+
+			resultsMu.Lock()
+			results[name] = err
+			resultsMu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	loadBalancerHealthMu.Lock()
+	defer loadBalancerHealthMu.Unlock()
+
+	changed := false
+	for name, err := range results {
+		backendHealth, found := health.backends[name]
+		if !found || errors.Is(err, errLoadBalancerBackendHealthUnknown) {
+			continue
+		}
+
+		backendHealth.lastCheckedAt = time.Now()
+
+		if err != nil {
+			backendHealth.err = err.Error()
+			backendHealth.successes = 0
+			backendHealth.failures++
+
+			if backendHealth.status != loadBalancerBackendStatusOffline && backendHealth.failures >= failureThreshold {
+				logger.Warn("Load balancer backend is offline", logger.Ctx{"listenAddress": loadBalancer.ListenAddress, "backend": name, "err": err})
+				backendHealth.status = loadBalancerBackendStatusOffline
+				changed = true
+			}
+		} else {
+			backendHealth.err = ""
+			backendHealth.failures = 0
+			backendHealth.successes++
+
+			if backendHealth.status == loadBalancerBackendStatusUnknown || (backendHealth.status == loadBalancerBackendStatusOffline && backendHealth.successes >= successThreshold) {
+				if backendHealth.status == loadBalancerBackendStatusOffline {
+					logger.Info("Load balancer backend is online", logger.Ctx{"listenAddress": loadBalancer.ListenAddress, "backend": name})
+					changed = true
+				}
+
+				backendHealth.status = loadBalancerBackendStatusOnline
+			}
+		}
+	}
+
+	// The load balancer needs to be applied again if its config changed since unhealthy backends were last
+	// removed, as the update of the load balancer may have been done by another member unaware of their health.
+	if health.appliedEtag != etagHash {
+		for _, backendHealth := range health.backends {
+			if backendHealth.status == loadBalancerBackendStatusOffline {
+				changed = true
+				break
+			}
+		}
+	}
+
+	if changed {
+		health.appliedEtag = etagHash
+	}
+
+	return changed, nil
+}
+
+// loadBalancerHealthCheckMember returns the ID of the cluster member running the health checks of a load balancer
+// that doesn't belong to a specific member.
+func loadBalancerHealthCheckMember(loadBalancerID int64, onlineMemberIDs []int64) (int64, error) {
+	return util.GetStableRandomInt64FromList(loadBalancerID, onlineMemberIDs)
+}
+
+// loadBalancerOnlineMemberIDs returns the IDs of the online cluster members.
+func loadBalancerOnlineMemberIDs(ctx context.Context, s *state.State, tx *db.ClusterTx) ([]db.NodeInfo, []int64, error) {
+	members, err := tx.GetNodes(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	onlineMemberIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+			continue
+		}
+
+		onlineMemberIDs = append(onlineMemberIDs, member.ID)
+	}
+
+	return members, onlineMemberIDs, nil
+}
+
+// LoadBalancerHealthCheckMemberName returns the name of the cluster member running the health checks of the
+// given load balancer that doesn't belong to a specific member.
+func LoadBalancerHealthCheckMemberName(ctx context.Context, s *state.State, tx *db.ClusterTx, loadBalancerID int64) (string, error) {
+	members, onlineMemberIDs, err := loadBalancerOnlineMemberIDs(ctx, s, tx)
+	if err != nil {
+		return "", err
+	}
+
+	memberID, err := loadBalancerHealthCheckMember(loadBalancerID, onlineMemberIDs)
+	if err != nil {
+		return "", err
+	}
+
+	for _, member := range members {
+		if member.ID == memberID {
+			return member.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("Cluster member with ID %d not found", memberID)
+}
+
+// loadBalancerRefresher is implemented by the drivers able to remove unhealthy backends from a load balancer.
+type loadBalancerRefresher interface {
+	loadBalancerRefresh(loadBalancerID int64, loadBalancer *api.NetworkLoadBalancer) error
+}
+
+// loadBalancerNativeHealthChecker is implemented by the drivers whose load balancers check the health of their
+// backends themselves. The returned checker reports the health of the backends as seen by the load balancer.
+type loadBalancerNativeHealthChecker interface {
+	loadBalancerBackendChecker(loadBalancer *api.NetworkLoadBalancer) (loadBalancerBackendChecker, error)
+}
+
+// HealthCheckLoadBalancers runs the health checks of the load balancers checked by the local member and removes
+// unhealthy backends from them.
+func HealthCheckLoadBalancers(ctx context.Context, s *state.State) error {
+	var loadBalancers []db.NetworkLoadBalancerHealthCheck
+	var onlineMemberIDs []int64
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		loadBalancers, err = tx.GetNetworkLoadBalancersWithHealthCheck(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting load balancers with health checks: %w", err)
+		}
+
+		for _, lb := range loadBalancers {
+			if lb.NodeID < 0 {
+				_, onlineMemberIDs, err = loadBalancerOnlineMemberIDs(ctx, s, tx)
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	localMemberID := s.DB.Cluster.GetNodeID()
+	checked := make(map[int64]struct{}, len(loadBalancers))
+
+	for _, lb := range loadBalancers {
+		if lb.NodeID >= 0 && lb.NodeID != localMemberID {
+			continue
+		}
+
+		// Load balancers not specific to a member are checked by a stable random online member.
+		if lb.NodeID < 0 && len(onlineMemberIDs) > 0 {
+			memberID, err := loadBalancerHealthCheckMember(lb.ID, onlineMemberIDs)
+			if err != nil || memberID != localMemberID {
+				continue
+			}
+		}
+
+		checked[lb.ID] = struct{}{}
+
+		n, err := LoadByName(s, lb.ProjectName, lb.NetworkName)
+		if err != nil {
+			logger.Error("Failed loading network for load balancer health check", logger.Ctx{"project": lb.ProjectName, "network": lb.NetworkName, "err": err})
+			continue
+		}
+
+		var loadBalancer *api.NetworkLoadBalancer
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			loadBalancers, err := tx.GetNetworkLoadBalancers(ctx, n.ID(), false, lb.ListenAddress)
+			if err != nil {
+				return err
+			}
+
+			loadBalancer = loadBalancers[lb.ID]
+
+			return nil
+		})
+		if err != nil || loadBalancer == nil {
+			continue // The load balancer was deleted in the meantime.
+		}
+
+		var checker loadBalancerBackendChecker = func(ctx context.Context, address string, port uint64) error {
+			return loadBalancerBackendHealthCheck(ctx, loadBalancer.Config, address, port)
+		}
+
+		nativeChecker, ok := n.(loadBalancerNativeHealthChecker)
+		if ok {
+			checker, err = nativeChecker.loadBalancerBackendChecker(loadBalancer)
+			if err != nil {
+				logger.Error("Failed getting load balancer backend health", logger.Ctx{"project": lb.ProjectName, "network": lb.NetworkName, "listenAddress": lb.ListenAddress, "err": err})
+				continue
+			}
+		}
+
+		changed, err := loadBalancerHealthCheck(ctx, lb.ID, loadBalancer, checker)
+		if err != nil {
+			logger.Error("Failed checking load balancer health", logger.Ctx{"project": lb.ProjectName, "network": lb.NetworkName, "listenAddress": lb.ListenAddress, "err": err})
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		refresher, ok := n.(loadBalancerRefresher)
+		if !ok {
+			continue
+		}
+
+		err = refresher.loadBalancerRefresh(lb.ID, loadBalancer)
+		if err != nil {
+			logger.Error("Failed removing unhealthy load balancer backends", logger.Ctx{"project": lb.ProjectName, "network": lb.NetworkName, "listenAddress": lb.ListenAddress, "err": err})
+		}
+	}
+
+	// Forget the health of the load balancers that aren't checked by this member anymore.
+	loadBalancerHealthMu.Lock()
+	for id := range loadBalancerHealthByID {
+		_, found := checked[id]
+		if !found {
+			delete(loadBalancerHealthByID, id)
+		}
+	}
+
+	loadBalancerHealthMu.Unlock()
+
+	return nil
+}
+
+// LoadBalancerState returns the health of the backends of the load balancer as seen by the local member.
+func (n *common) LoadBalancerState(listenAddress string) (*api.NetworkLoadBalancerState, error) {
+	memberSpecific := true // Get the load balancer of this member or of all members.
+
+	var loadBalancerID int64
+	var loadBalancer *api.NetworkLoadBalancer
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		loadBalancerID, loadBalancer, err = tx.GetNetworkLoadBalancer(ctx, n.ID(), memberSpecific, listenAddress)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancerHealthMu.Lock()
+	defer loadBalancerHealthMu.Unlock()
+
+	health := loadBalancerHealthByID[loadBalancerID]
+
+	state := &api.NetworkLoadBalancerState{
+		BackendHealth: make(map[string]api.NetworkLoadBalancerStateBackendHealth, len(loadBalancer.Backends)),
+	}
+
+	for _, backend := range loadBalancer.Backends {
+		backendState := api.NetworkLoadBalancerStateBackendHealth{
+			Address: backend.TargetAddress,
+			Status:  loadBalancerBackendStatusUnknown,
+		}
+
+		if health != nil && loadBalancer.Config["healthcheck"] != "" {
+			backendHealth, found := health.backends[backend.Name]
+			if found && backendHealth.address == backend.TargetAddress {
+				backendState.Port = backendHealth.port
+				backendState.Status = backendHealth.status
+				backendState.LastCheckedAt = backendHealth.lastCheckedAt
+				backendState.Error = backendHealth.err
+			}
+		}
+
+		state.BackendHealth[backend.Name] = backendState
+	}
+
+	return state, nil
+}
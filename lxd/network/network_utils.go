@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"math/rand"
 	"net"
@@ -57,6 +58,52 @@ func networkValidPort(value string) error {
 	return nil
 }
 
+// validateDHCPOptionValue checks that a DHCP option value can be passed to dnsmasq.
+func validateDHCPOptionValue(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New("DHCP option value cannot contain line breaks")
+	}
+
+	return nil
+}
+
+// nicQoSDefaultKeys maps the QoS configuration keys of NICs to the network configuration keys holding their default.
+var nicQoSDefaultKeys = map[string]string{
+	"limits.ingress":       "qos.ingress",
+	"limits.egress":        "qos.egress",
+	"limits.ingress.burst": "qos.ingress.burst",
+	"limits.egress.burst":  "qos.egress.burst",
+	"limits.ingress.min":   "qos.ingress.min",
+	"limits.egress.min":    "qos.egress.min",
+	"limits.dscp":          "qos.dscp",
+}
+
+// NICQoSConfig returns a copy of the NIC configuration with its unset QoS keys filled in with the defaults from
+// the configuration of its network. The limits.max key is expanded into limits.ingress and limits.egress.
+func NICQoSConfig(nicConfig map[string]string, netConfig map[string]string) map[string]string {
+	config := maps.Clone(nicConfig)
+	if config == nil {
+		config = map[string]string{}
+	}
+
+	if config["limits.max"] != "" {
+		config["limits.ingress"] = config["limits.max"]
+		config["limits.egress"] = config["limits.max"]
+	}
+
+	for nicKey, netKey := range nicQoSDefaultKeys {
+		if config[nicKey] == "" && netConfig[netKey] != "" {
+			config[nicKey] = netConfig[netKey]
+		}
+	}
+
+	return config
+}
+
 // RandomDevName returns a random device name with prefix.
 // If the random string combined with the prefix exceeds 13 characters then empty string is returned.
 // This is to ensure we support buggy dhclient applications: https://bugs.debian.org/cgi-bin/bugreport.cgi?bug=858580
@@ -574,6 +621,12 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 			}
 		}
 
+		// Add the DHCP reservations.
+		err = updateDNSMasqReservations(s, n)
+		if err != nil {
+			return err
+		}
+
 		// Signal dnsmasq.
 		err = dnsmasq.Kill(network, true)
 		if err != nil {
@@ -584,6 +637,62 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 	return nil
 }
 
+// updateDNSMasqReservations writes the dnsmasq host entries of the DHCP reservations of a network.
+// The caller is expected to hold dnsmasq.ConfigMutex.
+func updateDNSMasqReservations(s *state.State, n Network) error {
+	var reservations []api.NetworkLeasesPost
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		reservations, err = tx.GetNetworkDHCPReservations(ctx, n.ID())
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading DHCP reservations of network %q: %w", n.Name(), err)
+	}
+
+	// Combine the IPv4 and IPv6 reservations of each MAC address into a single entry.
+	type reservationEntry struct {
+		ipv4Address string
+		ipv6Address string
+		hostname    string
+	}
+
+	hwaddrs := []string{}
+	entries := map[string]*reservationEntry{}
+	for _, reservation := range reservations {
+		entry, ok := entries[reservation.Hwaddr]
+		if !ok {
+			entry = &reservationEntry{}
+			entries[reservation.Hwaddr] = entry
+			hwaddrs = append(hwaddrs, reservation.Hwaddr)
+		}
+
+		if net.ParseIP(reservation.Address).To4() != nil {
+			entry.ipv4Address = reservation.Address
+		} else {
+			entry.ipv6Address = reservation.Address
+		}
+
+		if reservation.Hostname != "" {
+			entry.hostname = reservation.Hostname
+		}
+	}
+
+	for _, hwaddr := range hwaddrs {
+		entry := entries[hwaddr]
+
+		err = dnsmasq.UpdateReservationEntry(n.Name(), hwaddr, entry.ipv4Address, entry.ipv6Address, entry.hostname)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ForkdnsServersList reads the server list file and returns the list as a slice.
 func ForkdnsServersList(networkName string) ([]string, error) {
 	servers := []string{}
@@ -1627,3 +1736,43 @@ func ipInRanges(ipAddr net.IP, ipRanges []shared.IPRange) bool {
 
 	return false
 }
+
+// enableIPv6Forwarding enables IPv6 forwarding on all interfaces.
+func enableIPv6Forwarding() error {
+	// Get a list of proc entries.
+	entries, err := os.ReadDir("/proc/sys/net/ipv6/conf/")
+	if err != nil {
+		return err
+	}
+
+	// First set accept_ra to 2 for all interfaces (if not disabled).
+	// This ensures that the host can still receive IPv6 router advertisements even with
+	// forwarding enabled (which enable below), as the default is to ignore router adverts
+	// when forward is enabled, and this could render the host unreachable if it uses
+	// SLAAC generated IPs.
+	for _, entry := range entries {
+		// Check that IPv6 router advertisement acceptance is enabled currently.
+		// If its set to 0 then we don't want to enable, and if its already set to 2 then
+		// we don't need to do anything.
+		content, err := os.ReadFile(fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/accept_ra", entry.Name()))
+		if err == nil && string(content) != "1\n" {
+			continue
+		}
+
+		// If IPv6 router acceptance is enabled (set to 1) then we now set it to 2.
+		err = util.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/accept_ra", entry.Name()), "2")
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	// Then set forwarding for all of them.
+	for _, entry := range entries {
+		err = util.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/forwarding", entry.Name()), "1")
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
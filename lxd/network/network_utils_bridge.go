@@ -1,13 +1,18 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/ip"
 	"github.com/canonical/lxd/lxd/network/openvswitch"
+	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
 )
 
 // BridgeVLANFilteringStatus returns whether VLAN filtering is enabled on a bridge interface.
@@ -82,3 +87,44 @@ func DetachInterface(bridgeName string, devName string) error {
 
 	return nil
 }
+
+// SyncEVPN advertises the MAC addresses of the local instances of the running EVPN bridge networks and updates
+// the forwarding entries of their overlays.
+func SyncEVPN(ctx context.Context, s *state.State) error {
+	var networks map[int64]api.Network
+
+	// Use api.ProjectDefaultName here as bridge networks don't support projects.
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		networks, err = tx.GetCreatedNetworksByProject(ctx, api.ProjectDefaultName)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading networks: %w", err)
+	}
+
+	for _, netInfo := range networks {
+		if netInfo.Type != "bridge" || netInfo.Config["bridge.mode"] != "evpn" {
+			continue
+		}
+
+		n, err := LoadByName(s, api.ProjectDefaultName, netInfo.Name)
+		if err != nil {
+			logger.Error("Failed loading EVPN network", logger.Ctx{"network": netInfo.Name, "err": err})
+			continue
+		}
+
+		b, ok := n.(*bridge)
+		if !ok || !b.isRunning() {
+			continue
+		}
+
+		err = b.evpnSync()
+		if err != nil {
+			logger.Warn("Failed syncing EVPN network", logger.Ctx{"network": netInfo.Name, "err": err})
+		}
+	}
+
+	return nil
+}
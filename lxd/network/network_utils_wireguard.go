@@ -0,0 +1,124 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+// wireGuardGenerateKey generates a new base64 encoded WireGuard private key.
+func wireGuardGenerateKey() (string, error) {
+	key := make([]byte, curve25519.ScalarSize)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", fmt.Errorf("Failed generating WireGuard private key: %w", err)
+	}
+
+	// Clamp the key the same way as "wg genkey" does.
+	key[0] &= 248
+	key[31] = (key[31] & 127) | 64
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// wireGuardPublicKey derives the base64 encoded WireGuard public key from a base64 encoded private key.
+func wireGuardPublicKey(privateKey string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("Failed decoding WireGuard private key: %w", err)
+	}
+
+	publicKey, err := curve25519.X25519(key, curve25519.Basepoint)
+	if err != nil {
+		return "", fmt.Errorf("Failed deriving WireGuard public key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// validWireGuardKey validates a base64 encoded WireGuard key.
+func validWireGuardKey(value string) error {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != curve25519.PointSize {
+		return fmt.Errorf("Invalid WireGuard key %q", value)
+	}
+
+	return nil
+}
+
+// wireGuardParseDump parses the output of "wg show <interface> dump" into the WireGuard network state.
+// The peers are left unnamed as WireGuard only knows them by their public key.
+func wireGuardParseDump(output string) (*api.NetworkStateWireGuard, error) {
+	state := &api.NetworkStateWireGuard{
+		Peers: []api.NetworkStateWireGuardPeer{},
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	// The first line describes the interface: private-key, public-key, listen-port and fwmark.
+	fields := strings.Split(lines[0], "\t")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("Invalid WireGuard interface line %q", lines[0])
+	}
+
+	state.PublicKey = fields[1]
+
+	listenPort, err := strconv.ParseUint(fields[2], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid WireGuard listen port %q: %w", fields[2], err)
+	}
+
+	state.ListenPort = listenPort
+
+	// The other lines describe the peers: public-key, preshared-key, endpoint, allowed-ips, latest-handshake,
+	// transfer-rx, transfer-tx and persistent-keepalive.
+	for _, line := range lines[1:] {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("Invalid WireGuard peer line %q", line)
+		}
+
+		peer := api.NetworkStateWireGuardPeer{
+			PublicKey:  fields[0],
+			AllowedIPs: []string{},
+		}
+
+		if fields[2] != "(none)" {
+			peer.Endpoint = fields[2]
+		}
+
+		if fields[3] != "(none)" {
+			peer.AllowedIPs = strings.Split(fields[3], ",")
+		}
+
+		latestHandshake, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard latest handshake %q: %w", fields[4], err)
+		}
+
+		if latestHandshake > 0 {
+			peer.LatestHandshake = time.Unix(latestHandshake, 0).UTC()
+		}
+
+		peer.BytesReceived, err = strconv.ParseUint(fields[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard received bytes %q: %w", fields[5], err)
+		}
+
+		peer.BytesSent, err = strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WireGuard sent bytes %q: %w", fields[6], err)
+		}
+
+		state.Peers = append(state.Peers, peer)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/canonical/lxd/shared/api"
+)
+
+func Test_wireGuardPublicKey(t *testing.T) {
+	// X25519 test vector from RFC 7748 section 6.1.
+	publicKey, err := wireGuardPublicKey("dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=")
+	require.NoError(t, err)
+	assert.Equal(t, "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=", publicKey)
+
+	// Generated keys are valid and have a valid public key.
+	privateKey, err := wireGuardGenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, validWireGuardKey(privateKey))
+
+	publicKey, err = wireGuardPublicKey(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, validWireGuardKey(publicKey))
+
+	assert.Error(t, validWireGuardKey("foo"))
+	assert.Error(t, validWireGuardKey("Zm9v"))
+}
+
+func Test_wireGuardParseDump(t *testing.T) {
+	output := "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=\thSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=\t51820\toff\n" +
+		"HIgo9xNzJMWLKASShiTqIybxZ0U3wGLiUeJ1PKf8ykw=\t(none)\t198.51.100.10:51820\t10.99.0.2/32,10.170.45.0/24\t1735689600\t1024\t2048\t25\n" +
+		"xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=\t(none)\t(none)\t(none)\t0\t0\t0\toff\n"
+
+	state, err := wireGuardParseDump(output)
+	require.NoError(t, err)
+
+	expected := &api.NetworkStateWireGuard{
+		PublicKey:  "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=",
+		ListenPort: 51820,
+		Peers: []api.NetworkStateWireGuardPeer{
+			{
+				PublicKey:       "HIgo9xNzJMWLKASShiTqIybxZ0U3wGLiUeJ1PKf8ykw=",
+				Endpoint:        "198.51.100.10:51820",
+				AllowedIPs:      []string{"10.99.0.2/32", "10.170.45.0/24"},
+				LatestHandshake: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BytesReceived:   1024,
+				BytesSent:       2048,
+			},
+			{
+				PublicKey:  "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=",
+				AllowedIPs: []string{},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, state)
+
+	_, err = wireGuardParseDump("foo")
+	assert.Error(t, err)
+}
@@ -81,6 +81,7 @@ const ovnExtIDLXDSwitchPort = "lxd_switch_port"
 const ovnExtIDLXDProjectID = "lxd_project_id"
 const ovnExtIDLXDPortGroup = "lxd_port_group"
 const ovnExtIDLXDLocation = "lxd_location"
+const ovnExtIDLXDACLRule = "lxd_acl_rule"
 
 // OVNIPv6RAOpts IPv6 router advertisements options that can be applied to a router.
 type OVNIPv6RAOpts struct {
@@ -132,6 +133,7 @@ type OVNSwitchPortOpts struct {
 
 // OVNACLRule represents an ACL rule that can be added to a logical switch or port group.
 type OVNACLRule struct {
+	Name      string // Name identifying the rule counters (optional).
 	Direction string // Either "from-lport" or "to-lport".
 	Action    string // Either "allow-related", "allow", "drop", or "reject".
 	Match     string // Match criteria. See OVN Southbound database's Logical_Flow table match column usage.
@@ -140,6 +142,16 @@ type OVNACLRule struct {
 	LogName   string // Log label name (requires Log be true).
 }
 
+// OVNQoSRule represents a QoS rule that can be added to a logical switch.
+type OVNQoSRule struct {
+	Direction string // Either "from-lport" or "to-lport".
+	Match     string // Match criteria. See OVN Southbound database's Logical_Flow table match column usage.
+	Priority  int    // Priority (between 0 and 32767, inclusive). Higher values take precedence.
+	Rate      uint64 // Rate limit in kbit/s (0 for no limit).
+	Burst     uint64 // Burst size in kbit (0 for the default burst size).
+	DSCP      int    // DSCP value to set on matching packets (-1 to leave it unchanged).
+}
+
 // OVNLoadBalancerTarget represents an OVN load balancer Virtual IP target.
 type OVNLoadBalancerTarget struct {
 	Address net.IP
@@ -154,6 +166,24 @@ type OVNLoadBalancerVIP struct {
 	Targets       []OVNLoadBalancerTarget
 }
 
+// OVNLoadBalancerHealthCheck represents the health check of the targets of an OVN load balancer.
+type OVNLoadBalancerHealthCheck struct {
+	Interval     int // Seconds between two checks of a target.
+	Timeout      int // Seconds after which a check is considered failed.
+	SuccessCount int // Consecutive successful checks after which a target is considered online.
+	FailureCount int // Consecutive failed checks after which a target is considered offline.
+
+	// Logical switch port of each target address and the address the checks are sent from.
+	// Targets without a port aren't checked.
+	TargetPorts map[string]OVNLoadBalancerHealthCheckPort
+}
+
+// OVNLoadBalancerHealthCheckPort represents the logical switch port through which a load balancer target is checked.
+type OVNLoadBalancerHealthCheckPort struct {
+	Port          OVNSwitchPort
+	SourceAddress net.IP
+}
+
 // OVNRouterRoute represents a static route added to a logical router.
 type OVNRouterRoute struct {
 	Prefix  net.IPNet
@@ -1097,6 +1127,89 @@ func (o *OVN) logicalSwitchPortACLRules(portName OVNSwitchPort) ([]string, error
 	return ruleUUIDs, nil
 }
 
+// logicalSwitchPortQoSRules returns the QoS rule UUIDs belonging to a logical switch port.
+func (o *OVN) logicalSwitchPortQoSRules(portName OVNSwitchPort) ([]string, error) {
+	output, err := o.nbctl("--format=csv", "--no-headings", "--data=bare", "--columns=_uuid", "find", "qos", "external_ids:"+string(ovnExtIDLXDSwitchPort)+"="+string(portName))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleUUIDs := shared.SplitNTrimSpace(strings.TrimSpace(output), "\n", -1, true)
+
+	return ruleUUIDs, nil
+}
+
+// qosRuleDeleteAppendArgs adds the commands to remove QoS rules from a logical switch to the arguments provided.
+func (o *OVN) qosRuleDeleteAppendArgs(args []string, switchName OVNSwitch, qosRuleUUIDs []string) []string {
+	for _, qosRuleUUID := range qosRuleUUIDs {
+		if len(args) > 0 {
+			args = append(args, "--")
+		}
+
+		args = append(args, "remove", "logical_switch", string(switchName), "qos_rules", qosRuleUUID)
+	}
+
+	return args
+}
+
+// LogicalSwitchPortSetQoS replaces the QoS rules of a logical switch port and sets its minimum guaranteed egress rate
+// in bit/s (0 for none). The rules are tagged with the port name so that they are removed along with the port.
+func (o *OVN) LogicalSwitchPortSetQoS(switchName OVNSwitch, portName OVNSwitchPort, minRate uint64, qosRules ...OVNQoSRule) error {
+	removeQoSRuleUUIDs, err := o.logicalSwitchPortQoSRules(portName)
+	if err != nil {
+		return err
+	}
+
+	args := o.qosRuleDeleteAppendArgs(nil, switchName, removeQoSRuleUUIDs)
+
+	for i, rule := range qosRules {
+		if len(args) > 0 {
+			args = append(args, "--")
+		}
+
+		// Add command to create QoS rule.
+		args = append(args, "--id=@qos"+strconv.Itoa(i), "create", "qos",
+			"direction="+rule.Direction,
+			"priority="+strconv.Itoa(rule.Priority),
+			"match="+strconv.Quote(rule.Match),
+			"external_ids:"+ovnExtIDLXDSwitchPort+"="+string(portName),
+		)
+
+		if rule.Rate > 0 {
+			args = append(args, "bandwidth:rate="+strconv.FormatUint(rule.Rate, 10))
+
+			if rule.Burst > 0 {
+				args = append(args, "bandwidth:burst="+strconv.FormatUint(rule.Burst, 10))
+			}
+		}
+
+		if rule.DSCP >= 0 {
+			args = append(args, "action:dscp="+strconv.Itoa(rule.DSCP))
+		}
+
+		// Add command to assign QoS rule to switch.
+		args = append(args, "--", "add", "logical_switch", string(switchName), "qos_rules", "@qos"+strconv.Itoa(i))
+	}
+
+	if len(args) > 0 {
+		args = append(args, "--")
+	}
+
+	// The minimum rate is enforced by ovn-controller on the egress interface of the chassis.
+	if minRate > 0 {
+		args = append(args, "set", "logical_switch_port", string(portName), "options:qos_min_rate="+strconv.FormatUint(minRate, 10))
+	} else {
+		args = append(args, "remove", "logical_switch_port", string(portName), "options", "qos_min_rate")
+	}
+
+	_, err = o.nbctl(args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // LogicalSwitchPorts returns a map of logical switch ports (name and UUID) for a switch.
 // Includes non-instance ports, such as the router port.
 func (o *OVN) LogicalSwitchPorts(switchName OVNSwitch) (map[OVNSwitchPort]OVNSwitchPortUUID, error) {
@@ -1490,6 +1603,14 @@ func (o *OVN) LogicalSwitchPortCleanup(portName OVNSwitchPort, switchName OVNSwi
 
 	args := o.aclRuleDeleteAppendArgs(nil, "port_group", string(switchPortGroupName), removeACLRuleUUIDs)
 
+	// Remove any QoS rules of the port.
+	removeQoSRuleUUIDs, err := o.logicalSwitchPortQoSRules(portName)
+	if err != nil {
+		return err
+	}
+
+	args = o.qosRuleDeleteAppendArgs(args, switchName, removeQoSRuleUUIDs)
+
 	// Remove logical switch port.
 	args = o.logicalSwitchPortDeleteAppendArgs(args, portName)
 
@@ -1810,6 +1931,10 @@ func (o *OVN) aclRuleAddAppendArgs(args []string, entityTable string, entityName
 			args = append(args, "external_ids:"+k+"="+v)
 		}
 
+		if rule.Name != "" {
+			args = append(args, "external_ids:"+ovnExtIDLXDACLRule+"="+rule.Name)
+		}
+
 		// Add command to assign ACL rule to entity.
 		args = append(args, "--", "add", entityTable, entityName, "acl", "@id"+strconv.Itoa(i))
 	}
@@ -1817,6 +1942,76 @@ func (o *OVN) aclRuleAddAppendArgs(args []string, entityTable string, entityName
 	return args
 }
 
+// ACLRuleFlowCookies returns the OpenFlow cookies of the flows generated from the named ACL rules, keyed by rule name.
+// The flows generated by ovn-controller from a logical flow use the first 32 bits of the logical flow UUID as
+// cookie, and the logical flows generated from an ACL reference the first 32 bits of the ACL UUID as stage hint.
+func (o *OVN) ACLRuleFlowCookies() (map[string][]uint64, error) {
+	// parseExternalIDs returns the UUID and external IDs of the records in the CSV output.
+	parseExternalIDs := func(output string) map[string]map[string]string {
+		records := make(map[string]map[string]string)
+		for _, line := range shared.SplitNTrimSpace(strings.TrimSpace(output), "\n", -1, true) {
+			recordUUID, rawExternalIDs, found := strings.Cut(line, ",")
+			if !found {
+				continue
+			}
+
+			externalIDs := make(map[string]string)
+			for _, externalID := range strings.Fields(strings.Trim(rawExternalIDs, `"`)) {
+				key, value, found := strings.Cut(externalID, "=")
+				if found {
+					externalIDs[key] = value
+				}
+			}
+
+			records[recordUUID] = externalIDs
+		}
+
+		return records
+	}
+
+	output, err := o.nbctl("--format=csv", "--no-headings", "--data=bare", "--columns=_uuid,external_ids", "find", "acl")
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the stage hints of the named ACL rules to their names.
+	ruleNames := make(map[string]string)
+	for aclUUID, externalIDs := range parseExternalIDs(output) {
+		ruleName := externalIDs[ovnExtIDLXDACLRule]
+		if ruleName == "" || len(aclUUID) < 8 {
+			continue
+		}
+
+		ruleNames[aclUUID[:8]] = ruleName
+	}
+
+	cookies := make(map[string][]uint64)
+	if len(ruleNames) == 0 {
+		return cookies, nil
+	}
+
+	output, err = o.sbctl("--format=csv", "--no-headings", "--data=bare", "--columns=_uuid,external_ids", "find", "logical_flow")
+	if err != nil {
+		return nil, err
+	}
+
+	for flowUUID, externalIDs := range parseExternalIDs(output) {
+		ruleName, found := ruleNames[externalIDs["stage-hint"]]
+		if !found || len(flowUUID) < 8 {
+			continue
+		}
+
+		cookie, err := strconv.ParseUint(flowUUID[:8], 16, 64)
+		if err != nil {
+			continue
+		}
+
+		cookies[ruleName] = append(cookies[ruleName], cookie)
+	}
+
+	return cookies, nil
+}
+
 // aclRuleDeleteAppendArgs adds the commands to args that delete the provided ACL rules from the specified OVN entity.
 // Returns args with the ACL rule delete commands added to it.
 func (o *OVN) aclRuleDeleteAppendArgs(args []string, entityTable string, entityName string, aclRuleUUIDs []string) []string {
@@ -2168,6 +2363,123 @@ func (o *OVN) LoadBalancerDelete(loadBalancerNames ...OVNLoadBalancer) error {
 	return nil
 }
 
+// LoadBalancerHealthCheckApply configures the health check of the port based VIPs of the specified load balancer.
+// OVN stops sending traffic to the targets that fail their checks. Providing a nil health check removes it.
+func (o *OVN) LoadBalancerHealthCheckApply(loadBalancerName OVNLoadBalancer, healthCheck *OVNLoadBalancerHealthCheck, vips ...OVNLoadBalancerVIP) error {
+	// ipToString wraps IPv6 addresses in square brackets.
+	ipToString := func(ip net.IP) string {
+		if ip.To4() == nil {
+			return "[" + ip.String() + "]"
+		}
+
+		return ip.String()
+	}
+
+	lbUUIDs, err := o.loadBalancerUUIDs(loadBalancerName)
+	if err != nil {
+		return fmt.Errorf("Failed getting UUIDs for load balancer %q: %w", loadBalancerName, err)
+	}
+
+	args := []string{}
+
+	for _, protocol := range []string{"tcp", "udp"} {
+		if len(lbUUIDs[protocol]) == 0 {
+			continue
+		}
+
+		lbUUID := lbUUIDs[protocol][0]
+
+		// Unreferenced health check records are garbage collected.
+		if len(args) > 0 {
+			args = append(args, "--")
+		}
+
+		args = append(args, "clear", "load_balancer", lbUUID, "health_check", "--", "clear", "load_balancer", lbUUID, "ip_port_mappings")
+
+		if healthCheck == nil {
+			continue
+		}
+
+		mappings := map[string]string{}
+		for i, vip := range vips {
+			vipProtocol := vip.Protocol
+			if vipProtocol == "" {
+				vipProtocol = "tcp"
+			}
+
+			// OVN only checks the targets of port based VIPs.
+			if vipProtocol != protocol || vip.ListenPort <= 0 {
+				continue
+			}
+
+			hcID := "@hc" + strconv.Itoa(i)
+			vipStr := ipToString(vip.ListenAddress) + ":" + strconv.FormatUint(vip.ListenPort, 10)
+
+			args = append(args, "--", "--id="+hcID, "create", "load_balancer_health_check",
+				`vip="`+vipStr+`"`,
+				"options:interval="+strconv.Itoa(healthCheck.Interval),
+				"options:timeout="+strconv.Itoa(healthCheck.Timeout),
+				"options:success_count="+strconv.Itoa(healthCheck.SuccessCount),
+				"options:failure_count="+strconv.Itoa(healthCheck.FailureCount),
+			)
+
+			args = append(args, "--", "add", "load_balancer", lbUUID, "health_check", hcID)
+
+			for _, target := range vip.Targets {
+				targetPort, found := healthCheck.TargetPorts[target.Address.String()]
+				if !found {
+					continue
+				}
+
+				mappings[ipToString(target.Address)] = string(targetPort.Port) + ":" + ipToString(targetPort.SourceAddress)
+			}
+		}
+
+		for address, mapping := range mappings {
+			args = append(args, "--", "set", "load_balancer", lbUUID, fmt.Sprintf(`ip_port_mappings:"%s"="%s"`, address, mapping))
+		}
+	}
+
+	if len(args) > 0 {
+		_, err := o.nbctl(args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadBalancerHealthCheckStatus returns the status ("online", "offline" or "error") of the load balancer targets
+// checked by OVN, keyed by protocol and target address and port (e.g. "tcp:10.0.0.2:80").
+// Targets that haven't been checked yet have an empty status.
+func (o *OVN) LoadBalancerHealthCheckStatus() (map[string]string, error) {
+	output, err := o.sbctl("--format=csv", "--no-headings", "--data=bare", "--columns=protocol,ip,port,status", "list", "service_monitor")
+	if err != nil {
+		return nil, err
+	}
+
+	lines := shared.SplitNTrimSpace(strings.TrimSpace(output), "\n", -1, true)
+	statuses := make(map[string]string, len(lines))
+
+	for _, line := range lines {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("Unrecognised service monitor output %q", line)
+		}
+
+		// In case no protocol is set, default to TCP as OVN does.
+		protocol := fields[0]
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		statuses[protocol+":"+net.JoinHostPort(fields[1], fields[2])] = fields[3]
+	}
+
+	return statuses, nil
+}
+
 // AddressSetCreate creates address sets for IP versions 4 and 6 in the format "<addressSetPrefix>_ip<IP version>".
 // Populates them with the relevant addresses supplied.
 func (o *OVN) AddressSetCreate(addressSetPrefix OVNAddressSet, addresses ...net.IPNet) error {
@@ -2244,6 +2556,55 @@ func (o *OVN) AddressSetAdd(addressSetPrefix OVNAddressSet, addresses ...net.IPN
 	return nil
 }
 
+// AddressSetReplace sets the addresses of the address sets to the supplied addresses, or creates new address sets
+// if needed. Any address not supplied is removed from the address sets.
+// The address set names used are "<addressSetPrefix>_ip<IP version>", e.g. "foo_ip4".
+func (o *OVN) AddressSetReplace(addressSetPrefix OVNAddressSet, addresses ...net.IPNet) error {
+	ipVersionAddresses := map[uint][]string{4: {}, 6: {}}
+
+	for _, address := range addresses {
+		var ipVersion uint = 4
+		if address.IP.To4() == nil {
+			ipVersion = 6
+		}
+
+		ipVersionAddresses[ipVersion] = append(ipVersionAddresses[ipVersion], fmt.Sprintf(`"%s"`, address.String()))
+	}
+
+	args := make([]string, 0, 9)
+	for _, ipVersion := range []uint{4, 6} {
+		if len(args) > 0 {
+			args = append(args, "--")
+		}
+
+		addressSetName := fmt.Sprintf("%s_ip%d", addressSetPrefix, ipVersion)
+		if len(ipVersionAddresses[ipVersion]) > 0 {
+			args = append(args, "set", "address_set", addressSetName, "addresses="+strings.Join(ipVersionAddresses[ipVersion], ","))
+		} else {
+			args = append(args, "clear", "address_set", addressSetName, "addresses")
+		}
+	}
+
+	// Optimistically assume the address sets exist (they normally will).
+	_, err := o.nbctl(args...)
+	if err != nil {
+		// Try creating the address sets one at a time, but ignore errors here in case some of the
+		// address sets already exist. If there was a problem creating the address set it will be
+		// revealead when we run the original command again next.
+		for _, ipVersion := range []uint{4, 6} {
+			_, _ = o.nbctl("create", "address_set", fmt.Sprintf("name=%s_ip%d", addressSetPrefix, ipVersion))
+		}
+
+		// Try original command again.
+		_, err := o.nbctl(args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AddressSetRemove removes the supplied addresses from the address set.
 // The address set name used is "<addressSetPrefix>_ip<IP version>", e.g. "foo_ip4".
 func (o *OVN) AddressSetRemove(addressSetPrefix OVNAddressSet, addresses ...net.IPNet) error {
@@ -503,3 +503,53 @@ func (o *OVS) GetVLANPVID(ctx context.Context, bridgeName string) (uint64, error
 
 	return pvid, nil
 }
+
+// OVSFlowCounters represents the hit counters of OpenFlow flows.
+type OVSFlowCounters struct {
+	Packets uint64
+	Bytes   uint64
+}
+
+// FlowCounters returns the hit counters of the OpenFlow flows of the bridge summed by flow cookie.
+func (o *OVS) FlowCounters(ctx context.Context, bridgeName string) (map[uint64]OVSFlowCounters, error) {
+	output, err := shared.RunCommand(ctx, "ovs-ofctl", "dump-flows", bridgeName)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make(map[uint64]OVSFlowCounters)
+	for line := range strings.SplitSeq(output, "\n") {
+		// E.g. " cookie=0x3f9b6f4c, duration=12.345s, table=44, n_packets=3, n_bytes=294, priority=2002,ip actions=next"
+		var cookie uint64
+		var flowCounters OVSFlowCounters
+		var foundCookie bool
+
+		for _, field := range strings.Fields(line) {
+			key, value, found := strings.Cut(strings.TrimSuffix(field, ","), "=")
+			if !found {
+				continue
+			}
+
+			switch key {
+			case "cookie":
+				cookie, err = strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+				foundCookie = err == nil
+			case "n_packets":
+				flowCounters.Packets, _ = strconv.ParseUint(value, 10, 64)
+			case "n_bytes":
+				flowCounters.Bytes, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+
+		if !foundCookie {
+			continue
+		}
+
+		cookieCounters := counters[cookie]
+		cookieCounters.Packets += flowCounters.Packets
+		cookieCounters.Bytes += flowCounters.Bytes
+		counters[cookie] = cookieCounters
+	}
+
+	return counters, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/canonical/lxd/lxd/dns"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/state"
 	"github.com/canonical/lxd/shared/api"
@@ -18,6 +19,7 @@ type NetworkZone interface {
 	ID() int64
 	Project() string
 	Info() *api.NetworkZone
+	DNSSECKey() string
 	Etag() []any
 	UsedBy(ctx context.Context) ([]string, error)
 	Content(ctx context.Context) (*strings.Builder, error)
@@ -29,6 +31,7 @@ type NetworkZone interface {
 	GetRecord(ctx context.Context, name string) (*api.NetworkZoneRecord, error)
 	UpdateRecord(ctx context.Context, name string, req api.NetworkZoneRecordPut) error
 	DeleteRecord(ctx context.Context, name string) error
+	ApplyUpdate(ctx context.Context, changes []dns.ZoneChange) error
 
 	// Internal validation.
 	validateName(name string) error
@@ -106,6 +106,20 @@ func Create(ctx context.Context, s *state.State, projectName string, zoneInfo *a
 		}
 	}
 
+	if zoneInfo.Config == nil {
+		zoneInfo.Config = map[string]string{}
+	}
+
+	err = checkDNSSECConfig(nil, zoneInfo.Config)
+	if err != nil {
+		return err
+	}
+
+	err = setupDNSSEC(zoneInfo.Name, zoneInfo.Config)
+	if err != nil {
+		return err
+	}
+
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		// Insert DB record.
 		_, err = tx.CreateNetworkZone(ctx, projectName, zoneInfo)
@@ -0,0 +1,46 @@
+package zone
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// notifyPeers sends a DNS NOTIFY message to the peers of the zone which have an address so that they transfer
+// the updated zone. Messages to peers with a key are signed with it using HMAC-SHA256.
+func (d *zone) notifyPeers() {
+	for k, address := range d.info.Config {
+		suffix, found := strings.CutPrefix(k, "peers.")
+		if !found {
+			continue
+		}
+
+		peerName, found := strings.CutSuffix(suffix, ".address")
+		if !found || address == "" {
+			continue
+		}
+
+		m := new(dns.Msg)
+		m.SetNotify(dns.Fqdn(d.info.Name))
+
+		client := &dns.Client{Timeout: 5 * time.Second}
+
+		key := d.info.Config["peers."+peerName+".key"]
+		if key != "" {
+			keyName := d.info.Name + "_" + peerName + "."
+			client.TsigSecret = map[string]string{keyName: key}
+			m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+		}
+
+		go func() {
+			_, _, err := client.Exchange(m, net.JoinHostPort(address, "53"))
+			if err != nil {
+				d.logger.Warn("Failed sending DNS NOTIFY to zone peer", logger.Ctx{"peer": peerName, "address": address, "err": err})
+			}
+		}()
+	}
+}
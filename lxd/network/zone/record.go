@@ -3,11 +3,14 @@ package zone
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/miekg/dns"
 
 	"github.com/canonical/lxd/lxd/db"
+	lxdDNS "github.com/canonical/lxd/lxd/dns"
 	"github.com/canonical/lxd/shared/api"
 )
 
@@ -63,6 +66,8 @@ func (d *zone) AddRecord(ctx context.Context, req api.NetworkZoneRecordsPost) er
 		return err
 	}
 
+	d.notifyPeers()
+
 	return nil
 }
 
@@ -156,6 +161,8 @@ func (d *zone) UpdateRecord(ctx context.Context, name string, req api.NetworkZon
 		return err
 	}
 
+	d.notifyPeers()
+
 	return nil
 }
 
@@ -182,6 +189,97 @@ func (d *zone) DeleteRecord(ctx context.Context, name string) error {
 		return err
 	}
 
+	d.notifyPeers()
+
+	return nil
+}
+
+// ApplyUpdate applies the changes of a dynamic DNS update to the zone records.
+// All the changes are applied in a single transaction so that the update is atomic.
+func (d *zone) ApplyUpdate(ctx context.Context, changes []lxdDNS.ZoneChange) error {
+	err := d.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, change := range changes {
+			id, record, err := tx.GetNetworkZoneRecord(ctx, d.id, change.Name)
+			if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return err
+			}
+
+			// Deleting from a missing record is a no-op.
+			if record == nil {
+				if change.Delete {
+					continue
+				}
+
+				req := api.NetworkZoneRecordsPost{
+					Name: change.Name,
+					NetworkZoneRecordPut: api.NetworkZoneRecordPut{
+						Entries: []api.NetworkZoneRecordEntry{{Type: change.Type, TTL: change.TTL, Value: change.Value}},
+						Config:  map[string]string{},
+					},
+				}
+
+				err = d.validateEntries(req.NetworkZoneRecordPut)
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.CreateNetworkZoneRecord(ctx, d.id, req)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			req := record.Writable()
+			if change.Delete {
+				req.Entries = slices.DeleteFunc(req.Entries, func(entry api.NetworkZoneRecordEntry) bool {
+					if change.Type != "" && !strings.EqualFold(entry.Type, change.Type) {
+						return false
+					}
+
+					return change.Value == "" || entry.Value == change.Value
+				})
+
+				// Remove the record once it has no entries left.
+				if len(req.Entries) == 0 {
+					err = tx.DeleteNetworkZoneRecord(ctx, id)
+					if err != nil {
+						return err
+					}
+
+					continue
+				}
+			} else {
+				// Adding an existing entry is a no-op.
+				if slices.ContainsFunc(req.Entries, func(entry api.NetworkZoneRecordEntry) bool {
+					return strings.EqualFold(entry.Type, change.Type) && entry.Value == change.Value
+				}) {
+					continue
+				}
+
+				req.Entries = append(req.Entries, api.NetworkZoneRecordEntry{Type: change.Type, TTL: change.TTL, Value: change.Value})
+			}
+
+			err = d.validateEntries(req)
+			if err != nil {
+				return err
+			}
+
+			err = tx.UpdateNetworkZoneRecord(ctx, id, req)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.notifyPeers()
+
 	return nil
 }
 
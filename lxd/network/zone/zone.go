@@ -14,6 +14,7 @@ import (
 	"github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/config"
 	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/dns"
 	"github.com/canonical/lxd/lxd/network"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
@@ -66,6 +67,7 @@ func (d *zone) Project() string {
 }
 
 // Info returns copy of internal info for the Network zone.
+// The private DNSSEC key of the zone isn't included.
 func (d *zone) Info() *api.NetworkZone {
 	// Copy internal info to prevent modification externally.
 	info := api.NetworkZone{}
@@ -74,9 +76,16 @@ func (d *zone) Info() *api.NetworkZone {
 	info.Config = util.CopyConfig(d.info.Config)
 	info.UsedBy = nil // To indicate its not populated (use Usedby() function to populate).
 
+	delete(info.Config, "volatile.dnssec.key")
+
 	return &info
 }
 
+// DNSSECKey returns the private DNSSEC key of the zone, if any.
+func (d *zone) DNSSECKey() string {
+	return d.info.Config["volatile.dnssec.key"]
+}
+
 // networkUsesZone indicates if the network uses the zone based on its config.
 func (d *zone) networkUsesZone(netConfig map[string]string) bool {
 	for _, key := range []string{"dns.zone.forward", "dns.zone.reverse.ipv4", "dns.zone.reverse.ipv6"} {
@@ -184,6 +193,17 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 	//  required: no
 	//  shortdesc: Whether to generate records for NAT-ed subnets
 	rules["network.nat"] = validate.Optional(validate.IsBool)
+	// lxdmeta:generate(entities=network-zone; group=config-options; key=dnssec)
+	// When enabled, a signing key is generated for the zone and the DS record to add to the parent zone
+	// is exposed in `volatile.dnssec.ds`.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  required: no
+	//  shortdesc: Whether to sign the zone with DNSSEC
+	rules["dnssec"] = validate.Optional(validate.IsBool)
+	rules["volatile.dnssec.key"] = validate.IsAny
+	rules["volatile.dnssec.ds"] = validate.IsAny
 	// lxdmeta:generate(entities=network-zone; group=config-options; key=user.*)
 	//
 	// ---
@@ -206,6 +226,14 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 		//  type: string
 		//  required: no
 		//  shortdesc: TSIG key for the server
+
+		// lxdmeta:generate(entities=network-zone; group=config-options; key=peers.NAME.update)
+		// Dynamic updates (RFC 2136) must be signed with the TSIG key of the peer.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  required: no
+		//  shortdesc: Whether the server can update the zone records
 		suffix, found := strings.CutPrefix(k, "peers.")
 		if !found {
 			continue
@@ -223,6 +251,8 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 			rules[k] = validate.Optional(validate.IsNetworkAddress)
 		case "key":
 			rules[k] = validate.IsAny
+		case "update":
+			rules[k] = validate.Optional(validate.IsBool)
 		default:
 			return fmt.Errorf("Invalid network zone peer configuration key %q (unknown field %q)", k, peerKey)
 		}
@@ -267,6 +297,41 @@ func (d *zone) validateConfigMap(zoneConfig map[string]string, rules map[string]
 	return nil
 }
 
+// checkDNSSECConfig checks that the user supplied config doesn't change the DNSSEC keys generated by LXD.
+func checkDNSSECConfig(oldConfig map[string]string, newConfig map[string]string) error {
+	for _, k := range []string{"volatile.dnssec.key", "volatile.dnssec.ds"} {
+		value, found := newConfig[k]
+		if found && value != oldConfig[k] {
+			return fmt.Errorf("Config option %q can't be set", k)
+		}
+	}
+
+	return nil
+}
+
+// setupDNSSEC generates the DNSSEC key of a zone and its DS record when signing is enabled.
+// The key is kept for as long as the zone exists so that the delegation in the parent zone remains valid.
+func setupDNSSEC(zoneName string, config map[string]string) error {
+	if shared.IsFalseOrEmpty(config["dnssec"]) || config["volatile.dnssec.key"] != "" {
+		return nil
+	}
+
+	key, err := dns.GenerateDNSSECKey()
+	if err != nil {
+		return fmt.Errorf("Failed generating DNSSEC key: %w", err)
+	}
+
+	ds, err := dns.DNSSECDelegation(zoneName, key)
+	if err != nil {
+		return err
+	}
+
+	config["volatile.dnssec.key"] = key
+	config["volatile.dnssec.ds"] = ds
+
+	return nil
+}
+
 // Update applies the supplied config to the zone.
 func (d *zone) Update(config *api.NetworkZonePut, clientType request.ClientType) error {
 	err := d.validateConfig(config)
@@ -281,6 +346,28 @@ func (d *zone) Update(config *api.NetworkZonePut, clientType request.ClientType)
 	if clientType == request.ClientTypeNormal {
 		oldConfig := d.info.Writable()
 
+		// Keep the existing DNSSEC key.
+		if config.Config == nil {
+			config.Config = map[string]string{}
+		}
+
+		err = checkDNSSECConfig(oldConfig.Config, config.Config)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range []string{"volatile.dnssec.key", "volatile.dnssec.ds"} {
+			_, found := config.Config[k]
+			if !found && oldConfig.Config[k] != "" {
+				config.Config[k] = oldConfig.Config[k]
+			}
+		}
+
+		err = setupDNSSEC(d.info.Name, config.Config)
+		if err != nil {
+			return err
+		}
+
 		// Update database.
 		err = d.state.DB.Cluster.UpdateNetworkZone(d.id, config)
 		if err != nil {
@@ -322,6 +409,11 @@ func (d *zone) Update(config *api.NetworkZonePut, clientType request.ClientType)
 		return err
 	}
 
+	// Let the peers transfer the updated zone.
+	if clientType == request.ClientTypeNormal {
+		d.notifyPeers()
+	}
+
 	revert.Success()
 	return nil
 }
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/network"
 	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/logger"
 )
@@ -39,11 +41,11 @@ func networkAutoAttach(cluster *db.Cluster, devName string) error {
 	return nil
 }
 
-// networkUpdateForkdnsServersTask runs every 30s and refreshes the forkdns servers list.
+// networkUpdateForkdnsServersTask runs every 30s and refreshes the forkdns servers list and the EVPN peers.
 func networkUpdateForkdnsServersTask(s *state.State, heartbeatData *cluster.APIHeartbeat) error {
 	logger.Debug("Refreshing forkdns servers")
 
-	// Use api.ProjectDefaultName here as forkdns (fan bridge) networks don't support projects.
+	// Use api.ProjectDefaultName here as forkdns (fan bridge) and EVPN bridge networks don't support projects.
 	projectName := api.ProjectDefaultName
 
 	// Get a list of managed networks
@@ -65,7 +67,7 @@ func networkUpdateForkdnsServersTask(s *state.State, heartbeatData *cluster.APIH
 			continue
 		}
 
-		if n.Type() == "bridge" && n.Config()["bridge.mode"] == "fan" {
+		if n.Type() == "bridge" && slices.Contains([]string{"fan", "evpn"}, n.Config()["bridge.mode"]) {
 			err := n.HandleHeartbeat(heartbeatData)
 			if err != nil {
 				return err
@@ -103,3 +105,16 @@ func networkUpdateOVNChassis(s *state.State, heartbeatData *cluster.APIHeartbeat
 	networkOVNChassis = &runChassis
 	return nil
 }
+
+// syncEVPNNetworksTask advertises the MAC addresses of the local instances of EVPN bridge networks and updates
+// their overlays.
+func syncEVPNNetworksTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := network.SyncEVPN(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed syncing EVPN bridge networks", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(5 * time.Second)
+}
@@ -2361,8 +2361,9 @@ func patchRemoveMAASConfigKeys(_ string, d *Daemon) error {
 	return nil
 }
 
-// patchStorageRemoveLocalBuckets removes the orphaned "buckets/" directories from local storage pools
-// and the core.storage_buckets_address config key since local storage drivers no longer support storage buckets.
+// patchStorageRemoveLocalBuckets removes the leftovers of local storage buckets created by older versions from the
+// "buckets/" directories of local storage pools, as well as the cluster-wide core.storage_buckets_address config key
+// that was used to configure their S3 listener. The directories are recreated empty for the built-in S3 server.
 func patchStorageRemoveLocalBuckets(_ string, d *Daemon) error {
 	s := d.State()
 
@@ -2385,18 +2386,25 @@ func patchStorageRemoveLocalBuckets(_ string, d *Daemon) error {
 
 	for _, pool := range pools {
 		path := filepath.Join(storageDrivers.GetPoolMountPath(pool), "buckets")
+		if !shared.PathExists(path) {
+			continue
+		}
 
-		// The upgrade is blocked by checkNoLocalStorageBuckets if any local
-		// buckets still exist in the database, so the "buckets/" directory is
-		// guaranteed to be empty here and os.Remove is safe (would fail if the
-		// directory is not empty).
-		err := os.Remove(path)
-		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		// The upgrade is blocked by checkNoLocalStorageBuckets if any local buckets created by older
+		// versions still exist in the database, so anything left in the "buckets/" directory is stale.
+		err := os.RemoveAll(path)
+		if err != nil {
 			return fmt.Errorf("Failed removing bucket directory %q: %w", path, err)
 		}
+
+		err = os.Mkdir(path, storageDrivers.BaseDirectories[storageDrivers.VolumeTypeBucket].Mode)
+		if err != nil {
+			return fmt.Errorf("Failed creating bucket directory %q: %w", path, err)
+		}
 	}
 
 	// Remove the core.storage_buckets_address config key which was used to configure the S3 listener.
+	// The key is now a member-specific server setting.
 	_, err = s.DB.Cluster.DB().ExecContext(d.shutdownCtx, `DELETE FROM config WHERE key = 'core.storage_buckets_address'`)
 	if err != nil {
 		return fmt.Errorf("Failed removing core.storage_buckets_address config key: %w", err)
@@ -0,0 +1,290 @@
+package placement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/shared/api"
+)
+
+// migration represents the move of a single instance between two cluster members.
+type migration struct {
+	instanceID int64
+	sourceID   int64
+	targetID   int64
+}
+
+// Compliance checks whether the current placement of the instances in the provided [api.PlacementGroup] complies
+// with its policy and plans the instance migrations needed to restore compliance.
+// Only the provided candidate cluster members are considered as migration sources and targets.
+func Compliance(ctx context.Context, tx *db.ClusterTx, candidates []db.NodeInfo, apiPlacementGroup api.PlacementGroup) (*api.PlacementGroupCompliance, error) {
+	policy := apiPlacementGroup.Config["policy"]
+	rigor := apiPlacementGroup.Config["rigor"]
+	scope := apiPlacementGroup.Config["scope"]
+
+	memberToInst, err := cluster.GetInstancesInPlacementGroup(ctx, tx.Tx(), apiPlacementGroup.Name, apiPlacementGroup.Project, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	memberToTarget, err := getMemberTargets(ctx, tx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	compliance := &api.PlacementGroupCompliance{
+		Compliant:  true,
+		Migrations: []api.PlacementGroupMigration{},
+	}
+
+	compliance.Reason = getViolation(policy, rigor, candidates, memberToInst, memberToTarget)
+	if compliance.Reason == "" {
+		return compliance, nil
+	}
+
+	compliance.Compliant = false
+
+	migrations := planMigrations(policy, rigor, candidates, memberToInst, memberToTarget)
+	if len(migrations) == 0 {
+		return compliance, nil
+	}
+
+	// Resolve the instance and cluster member names for the planned migrations.
+	members, err := tx.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	memberNames := make(map[int64]string, len(members))
+	for _, member := range members {
+		memberNames[member.ID] = member.Name
+	}
+
+	instances, err := cluster.GetInstances(ctx, tx.Tx(), cluster.InstanceFilter{Project: &apiPlacementGroup.Project})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting instances: %w", err)
+	}
+
+	instanceNames := make(map[int64]string, len(instances))
+	for _, inst := range instances {
+		instanceNames[int64(inst.ID)] = inst.Name
+	}
+
+	for _, m := range migrations {
+		compliance.Migrations = append(compliance.Migrations, api.PlacementGroupMigration{
+			Instance: instanceNames[m.instanceID],
+			Source:   memberNames[m.sourceID],
+			Target:   memberNames[m.targetID],
+		})
+	}
+
+	return compliance, nil
+}
+
+// getViolation returns the reason why the placement of the instances violates the given placement policy and rigor.
+// An empty string is returned if the placement complies with the policy.
+func getViolation(policy string, rigor string, candidates []db.NodeInfo, memberToInst map[int64][]int64, memberToTarget map[int64]int64) string {
+	targetOf := newTargetOf(memberToTarget)
+	targetToInst := getTargetInstances(memberToInst, targetOf)
+
+	scopeName := "cluster member"
+	if memberToTarget != nil {
+		scopeName = "failure domain"
+	}
+
+	switch {
+	case policy == api.PlacementPolicySpread && rigor == api.PlacementRigorStrict:
+		// At most one instance per cluster member (or failure domain).
+		for _, instances := range targetToInst {
+			if len(instances) > 1 {
+				return fmt.Sprintf("Multiple instances are placed on the same %s", scopeName)
+			}
+		}
+
+	case policy == api.PlacementPolicySpread && rigor == api.PlacementRigorPermissive:
+		// The number of instances per cluster member (or failure domain) differs by at most one.
+		maxInstances := 0
+		for _, instances := range targetToInst {
+			maxInstances = max(maxInstances, len(instances))
+		}
+
+		minInstances := maxInstances
+		for _, c := range candidates {
+			minInstances = min(minInstances, len(targetToInst[targetOf(c.ID)]))
+		}
+
+		if maxInstances-minInstances > 1 {
+			return fmt.Sprintf("Instances are not evenly spread across %ss", scopeName)
+		}
+
+	case policy == api.PlacementPolicyCompact:
+		// All instances on the same cluster member (or failure domain).
+		if len(targetToInst) > 1 {
+			return fmt.Sprintf("Instances are placed on multiple %ss", scopeName)
+		}
+	}
+
+	return ""
+}
+
+// planMigrations returns the instance migrations needed for the placement of the instances to comply with the given
+// placement policy and rigor. Migrations are planned one instance at a time and planning stops as soon as the placement
+// complies with the policy or no further progress can be made.
+func planMigrations(policy string, rigor string, candidates []db.NodeInfo, memberToInst map[int64][]int64, memberToTarget map[int64]int64) []migration {
+	// Work on a copy so that the provided map is left untouched.
+	placement := make(map[int64][]int64, len(memberToInst))
+	total := 0
+	for memberID, instances := range memberToInst {
+		placement[memberID] = slices.Clone(instances)
+		total += len(instances)
+	}
+
+	var migrations []migration
+	for range total {
+		if getViolation(policy, rigor, candidates, placement, memberToTarget) == "" {
+			break
+		}
+
+		var m *migration
+		switch policy {
+		case api.PlacementPolicySpread:
+			m = planSpreadMigration(rigor, candidates, placement, memberToTarget)
+		case api.PlacementPolicyCompact:
+			m = planCompactMigration(candidates, placement, memberToTarget)
+		}
+
+		if m == nil {
+			break
+		}
+
+		placement[m.sourceID] = slices.DeleteFunc(placement[m.sourceID], func(instanceID int64) bool { return instanceID == m.instanceID })
+		placement[m.targetID] = append(placement[m.targetID], m.instanceID)
+		migrations = append(migrations, *m)
+	}
+
+	return migrations
+}
+
+// planSpreadMigration plans the migration of one instance from the most loaded cluster member (or failure domain)
+// to the least loaded compliant cluster member. Returns nil if no such migration exists.
+func planSpreadMigration(rigor string, candidates []db.NodeInfo, placement map[int64][]int64, memberToTarget map[int64]int64) *migration {
+	targetOf := newTargetOf(memberToTarget)
+	targetToInst := getTargetInstances(placement, targetOf)
+
+	// Pick the source among the candidates, preferring the most loaded target and then the most loaded member.
+	var source *db.NodeInfo
+	for i, c := range candidates {
+		if len(placement[c.ID]) == 0 {
+			continue
+		}
+
+		if source == nil {
+			source = &candidates[i]
+			continue
+		}
+
+		cTarget := len(targetToInst[targetOf(c.ID)])
+		sourceTarget := len(targetToInst[targetOf(source.ID)])
+		if cTarget > sourceTarget || (cTarget == sourceTarget && len(placement[c.ID]) > len(placement[source.ID])) {
+			source = &candidates[i]
+		}
+	}
+
+	if source == nil {
+		return nil
+	}
+
+	sourceTarget := targetOf(source.ID)
+	instanceID := slices.Max(placement[source.ID])
+
+	// Determine the compliant cluster members as if the instance was not placed yet.
+	remaining := make(map[int64][]int64, len(placement))
+	for memberID, instances := range placement {
+		if memberID == source.ID {
+			instances = slices.DeleteFunc(slices.Clone(instances), func(id int64) bool { return id == instanceID })
+		}
+
+		remaining[memberID] = instances
+	}
+
+	eligible, err := getCompliantMembers(api.PlacementPolicySpread, rigor, candidates, remaining, memberToTarget)
+	if err != nil {
+		return nil
+	}
+
+	// Pick the least loaded eligible member outside of the source target.
+	var target *db.NodeInfo
+	for i, c := range eligible {
+		if targetOf(c.ID) == sourceTarget {
+			continue
+		}
+
+		if target == nil {
+			target = &eligible[i]
+			continue
+		}
+
+		cTarget := len(targetToInst[targetOf(c.ID)])
+		targetTarget := len(targetToInst[targetOf(target.ID)])
+		if cTarget < targetTarget || (cTarget == targetTarget && len(placement[c.ID]) < len(placement[target.ID])) {
+			target = &eligible[i]
+		}
+	}
+
+	// Only migrate if it reduces the imbalance between the source and target.
+	if target == nil || len(targetToInst[targetOf(target.ID)])+1 >= len(targetToInst[sourceTarget]) {
+		return nil
+	}
+
+	return &migration{instanceID: instanceID, sourceID: source.ID, targetID: target.ID}
+}
+
+// planCompactMigration plans the migration of one instance located outside of the preferred cluster member
+// (or failure domain) onto it. Returns nil if no such migration exists.
+func planCompactMigration(candidates []db.NodeInfo, placement map[int64][]int64, memberToTarget map[int64]int64) *migration {
+	targetOf := newTargetOf(memberToTarget)
+	targetToInst := getTargetInstances(placement, targetOf)
+
+	// Only targets with at least one candidate member can receive instances.
+	reachable := make(map[int64][]int64, len(targetToInst))
+	for _, c := range candidates {
+		target := targetOf(c.ID)
+		instances, ok := targetToInst[target]
+		if ok {
+			reachable[target] = instances
+		}
+	}
+
+	if len(reachable) == 0 {
+		return nil
+	}
+
+	preferredID := getPreferredTarget(reachable)
+
+	// Pick the candidate member of the preferred target hosting the most instances as destination,
+	// and any other candidate member hosting instances as source.
+	var source *db.NodeInfo
+	var target *db.NodeInfo
+	for i, c := range candidates {
+		if targetOf(c.ID) != preferredID {
+			if source == nil && len(placement[c.ID]) > 0 {
+				source = &candidates[i]
+			}
+
+			continue
+		}
+
+		if target == nil || len(placement[c.ID]) > len(placement[target.ID]) {
+			target = &candidates[i]
+		}
+	}
+
+	if source == nil || target == nil {
+		return nil
+	}
+
+	return &migration{instanceID: slices.Max(placement[source.ID]), sourceID: source.ID, targetID: target.ID}
+}
@@ -0,0 +1,112 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/shared/api"
+)
+
+type complianceSuite struct {
+	suite.Suite
+}
+
+func TestComplianceSuite(t *testing.T) {
+	suite.Run(t, new(complianceSuite))
+}
+
+func (s *complianceSuite) TestPlanMigrations() {
+	candidates := []db.NodeInfo{{ID: 1, Name: "member01"}, {ID: 2, Name: "member02"}, {ID: 3, Name: "member03"}}
+
+	tests := []struct {
+		name           string
+		policy         string
+		rigor          string
+		memberToInst   map[int64][]int64
+		memberToTarget map[int64]int64
+		wantViolation  bool
+		want           []migration
+	}{
+		{
+			name:          "spread/strict: compliant",
+			policy:        api.PlacementPolicySpread,
+			rigor:         api.PlacementRigorStrict,
+			memberToInst:  map[int64][]int64{1: {10}, 2: {11}},
+			wantViolation: false,
+		},
+		{
+			name:          "spread/strict: two instances on the same member after restore",
+			policy:        api.PlacementPolicySpread,
+			rigor:         api.PlacementRigorStrict,
+			memberToInst:  map[int64][]int64{1: {10, 11}, 2: {12}},
+			wantViolation: true,
+			want:          []migration{{instanceID: 11, sourceID: 1, targetID: 3}},
+		},
+		{
+			name:          "spread/strict: no spare member",
+			policy:        api.PlacementPolicySpread,
+			rigor:         api.PlacementRigorStrict,
+			memberToInst:  map[int64][]int64{1: {10, 11}, 2: {12}, 3: {13}},
+			wantViolation: true,
+		},
+		{
+			name:          "spread/permissive: new member joined",
+			policy:        api.PlacementPolicySpread,
+			rigor:         api.PlacementRigorPermissive,
+			memberToInst:  map[int64][]int64{1: {10, 11}, 2: {12, 13}},
+			wantViolation: true,
+			want:          []migration{{instanceID: 11, sourceID: 1, targetID: 3}},
+		},
+		{
+			name:          "spread/permissive: uneven by one is compliant",
+			policy:        api.PlacementPolicySpread,
+			rigor:         api.PlacementRigorPermissive,
+			memberToInst:  map[int64][]int64{1: {10, 11}, 2: {12}, 3: {13}},
+			wantViolation: false,
+		},
+		{
+			name:           "spread/strict/failure-domain: two instances in the same failure domain",
+			policy:         api.PlacementPolicySpread,
+			rigor:          api.PlacementRigorStrict,
+			memberToInst:   map[int64][]int64{1: {10}, 2: {11}},
+			memberToTarget: map[int64]int64{1: 100, 2: 100, 3: 200},
+			wantViolation:  true,
+			want:           []migration{{instanceID: 10, sourceID: 1, targetID: 3}},
+		},
+		{
+			name:          "compact/strict: instances on multiple members",
+			policy:        api.PlacementPolicyCompact,
+			rigor:         api.PlacementRigorStrict,
+			memberToInst:  map[int64][]int64{1: {10}, 2: {11, 12}},
+			wantViolation: true,
+			want:          []migration{{instanceID: 10, sourceID: 1, targetID: 2}},
+		},
+		{
+			name:           "compact/strict/failure-domain: instances within the same failure domain",
+			policy:         api.PlacementPolicyCompact,
+			rigor:          api.PlacementRigorStrict,
+			memberToInst:   map[int64][]int64{1: {10}, 2: {11, 12}},
+			memberToTarget: map[int64]int64{1: 100, 2: 100, 3: 200},
+			wantViolation:  false,
+		},
+		{
+			name:          "compact/permissive: instance on an unavailable member",
+			policy:        api.PlacementPolicyCompact,
+			rigor:         api.PlacementRigorPermissive,
+			memberToInst:  map[int64][]int64{2: {11}, 4: {12}},
+			wantViolation: true,
+		},
+	}
+
+	for i, tt := range tests {
+		s.T().Logf("Case %d: %s", i, tt.name)
+
+		violation := getViolation(tt.policy, tt.rigor, candidates, tt.memberToInst, tt.memberToTarget)
+		s.Equal(tt.wantViolation, violation != "")
+
+		got := planMigrations(tt.policy, tt.rigor, candidates, tt.memberToInst, tt.memberToTarget)
+		s.Equal(tt.want, got)
+	}
+}
@@ -3,6 +3,7 @@ package placement
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"slices"
 
@@ -13,9 +14,10 @@ import (
 
 // Filter filters the provided slice of candidate cluster members using the provided [api.PlacementGroup].
 func Filter(ctx context.Context, tx *db.ClusterTx, candidates []db.NodeInfo, apiPlacementGroup api.PlacementGroup, evacuation bool) ([]db.NodeInfo, error) {
-	// Get policy and rigor from config.
+	// Get policy, rigor and scope from config.
 	policy := apiPlacementGroup.Config["policy"]
 	rigor := apiPlacementGroup.Config["rigor"]
+	scope := apiPlacementGroup.Config["scope"]
 
 	// If this is an evacuation request, exclude instances on the source cluster member.
 	// This allows placement decisions to be made based on where instances will be, not where they currently are.
@@ -30,8 +32,14 @@ func Filter(ctx context.Context, tx *db.ClusterTx, candidates []db.NodeInfo, api
 		return nil, err
 	}
 
+	// Resolve the placement target of each cluster member according to the scope.
+	memberToTarget, err := getMemberTargets(ctx, tx, scope)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get compliant cluster members using the placement group.
-	filteredCandidates, err := getCompliantMembers(policy, rigor, candidates, memberToInst)
+	filteredCandidates, err := getCompliantMembers(policy, rigor, candidates, memberToInst, memberToTarget)
 	if err != nil {
 		return nil, api.StatusErrorf(http.StatusConflict, "Failed filtering candidate cluster members using placement group %q with %q policy and %q rigor: %w", apiPlacementGroup.Name, policy, rigor, err)
 	}
@@ -39,16 +47,51 @@ func Filter(ctx context.Context, tx *db.ClusterTx, candidates []db.NodeInfo, api
 	return filteredCandidates, nil
 }
 
+// getMemberTargets returns a map of cluster member IDs to the placement target they belong to for the given scope.
+// With the default member scope, each cluster member is its own target and a nil map is returned.
+// With the failure domain scope, cluster members in the same failure domain share the failure domain ID as target.
+func getMemberTargets(ctx context.Context, tx *db.ClusterTx, scope string) (map[int64]int64, error) {
+	if scope == "" || scope == api.PlacementScopeMember {
+		return nil, nil
+	}
+
+	if scope != api.PlacementScopeFailureDomain {
+		return nil, fmt.Errorf("Invalid placement scope %q", scope)
+	}
+
+	members, err := tx.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	memberFailureDomains, err := tx.GetNodesFailureDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster member failure domains: %w", err)
+	}
+
+	memberToTarget := make(map[int64]int64, len(members))
+	for _, member := range members {
+		memberToTarget[member.ID] = int64(memberFailureDomains[member.Address])
+	}
+
+	return memberToTarget, nil
+}
+
 // getCompliantMembers gets compliant cluster members from the provided candidates based on the given placement policy and rigor.
-func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo, memberToInst map[int64][]int64) ([]db.NodeInfo, error) {
+// If memberToTarget is not nil, the policy is applied to the placement targets (e.g. failure domains) the cluster members belong to
+// rather than to the individual cluster members.
+func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo, memberToInst map[int64][]int64, memberToTarget map[int64]int64) ([]db.NodeInfo, error) {
 	var compliantCandidates []db.NodeInfo
 
+	targetOf := newTargetOf(memberToTarget)
+	targetToInst := getTargetInstances(memberToInst, targetOf)
+
 	switch {
 	case policy == api.PlacementPolicySpread && rigor == api.PlacementRigorStrict:
-		// Spread + Strict: Place at most one instance per cluster member.
+		// Spread + Strict: Place at most one instance per cluster member (or failure domain).
 		// Filter out candidates that already have instances.
 		for _, c := range candidates {
-			_, hasInst := memberToInst[c.ID]
+			_, hasInst := targetToInst[targetOf(c.ID)]
 			if !hasInst {
 				compliantCandidates = append(compliantCandidates, c)
 			}
@@ -61,13 +104,13 @@ func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo,
 		return compliantCandidates, nil
 
 	case policy == api.PlacementPolicySpread && rigor == api.PlacementRigorPermissive:
-		// Spread + Permissive: Prefer spreading instances evenly across cluster members.
-		// The number of instances per cluster member differs by at most one.
+		// Spread + Permissive: Prefer spreading instances evenly across cluster members (or failure domains).
+		// The number of instances per cluster member (or failure domain) differs by at most one.
 
 		// Find the minimum instance count among candidates.
 		counts := make([]int, 0, len(candidates))
 		for _, c := range candidates {
-			counts = append(counts, len(memberToInst[c.ID]))
+			counts = append(counts, len(targetToInst[targetOf(c.ID)]))
 		}
 
 		minInstances := 0
@@ -78,7 +121,7 @@ func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo,
 		// Filter candidates to only those with at most minInstances instances.
 		// This ensures the number of instances per cluster member differs by at most one.
 		for _, c := range candidates {
-			instanceCount := len(memberToInst[c.ID])
+			instanceCount := len(targetToInst[targetOf(c.ID)])
 			if instanceCount <= minInstances {
 				compliantCandidates = append(compliantCandidates, c)
 			}
@@ -91,29 +134,21 @@ func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo,
 		return compliantCandidates, nil
 
 	case policy == api.PlacementPolicyCompact && rigor == api.PlacementRigorStrict:
-		// Compact + Strict: Place all instances on the same cluster member.
+		// Compact + Strict: Place all instances on the same cluster member (or failure domain).
 		// The member with the most instances determines the cluster member.
-		if len(memberToInst) == 0 {
+		if len(targetToInst) == 0 {
 			// No instances yet.
 			// All candidates are valid (first instance determines the member).
 			return candidates, nil
 		}
 
-		// Find which member has the most instances from this placement group.
-		var targetMemberID int64
-		maxInstances := -1
-		for memberID, instances := range memberToInst {
-			if len(instances) > maxInstances {
-				maxInstances = len(instances)
-				targetMemberID = memberID
-			}
-		}
+		// Find which member (or failure domain) has the most instances from this placement group.
+		targetID := getPreferredTarget(targetToInst)
 
-		// Filter candidates to only include the node with the most instances.
+		// Filter candidates to only include the member(s) of the target with the most instances.
 		for _, c := range candidates {
-			if c.ID == targetMemberID {
+			if targetOf(c.ID) == targetID {
 				compliantCandidates = append(compliantCandidates, c)
-				break
 			}
 		}
 
@@ -124,31 +159,27 @@ func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo,
 		return compliantCandidates, nil
 
 	case policy == api.PlacementPolicyCompact && rigor == api.PlacementRigorPermissive:
-		// Compact + Permissive: Prefer to place all instances on the same cluster member.
-		if len(memberToInst) == 0 {
+		// Compact + Permissive: Prefer to place all instances on the same cluster member (or failure domain).
+		if len(targetToInst) == 0 {
 			// No instances yet.
 			// All candidates are valid (first instance determines preferred member).
 			return candidates, nil
 		}
 
-		// Find which member has the most instances from this placement group.
-		var preferredMemberID int64
-		maxInstances := -1
-		for memberID, instances := range memberToInst {
-			if len(instances) > maxInstances {
-				maxInstances = len(instances)
-				preferredMemberID = memberID
-			}
-		}
+		// Find which member (or failure domain) has the most instances from this placement group.
+		preferredID := getPreferredTarget(targetToInst)
 
-		// Check if preferred member is in candidates.
+		// Check if the preferred member (or members of the preferred failure domain) are in candidates.
 		for _, c := range candidates {
-			if c.ID == preferredMemberID {
-				// Preferred member is available.
-				return []db.NodeInfo{c}, nil
+			if targetOf(c.ID) == preferredID {
+				compliantCandidates = append(compliantCandidates, c)
 			}
 		}
 
+		if len(compliantCandidates) > 0 {
+			return compliantCandidates, nil
+		}
+
 		// Preferred node is not available - fall back to all candidates.
 		return candidates, nil
 
@@ -156,3 +187,46 @@ func getCompliantMembers(policy string, rigor string, candidates []db.NodeInfo,
 		return nil, errors.New("Invalid placement group")
 	}
 }
+
+// newTargetOf returns a function resolving the placement target of a cluster member.
+// If memberToTarget is nil, each cluster member is its own placement target.
+func newTargetOf(memberToTarget map[int64]int64) func(memberID int64) int64 {
+	return func(memberID int64) int64 {
+		if memberToTarget == nil {
+			return memberID
+		}
+
+		return memberToTarget[memberID]
+	}
+}
+
+// getTargetInstances aggregates the instances of the placement group per placement target.
+// Cluster members without instances are skipped.
+func getTargetInstances(memberToInst map[int64][]int64, targetOf func(memberID int64) int64) map[int64][]int64 {
+	targetToInst := make(map[int64][]int64, len(memberToInst))
+	for memberID, instances := range memberToInst {
+		if len(instances) == 0 {
+			continue
+		}
+
+		target := targetOf(memberID)
+		targetToInst[target] = append(targetToInst[target], instances...)
+	}
+
+	return targetToInst
+}
+
+// getPreferredTarget returns the placement target with the most instances from the placement group.
+// Ties are broken by picking the lowest target ID so that the result is deterministic.
+func getPreferredTarget(targetToInst map[int64][]int64) int64 {
+	var preferredID int64
+	maxInstances := -1
+	for target, instances := range targetToInst {
+		if len(instances) > maxInstances || (len(instances) == maxInstances && target < preferredID) {
+			maxInstances = len(instances)
+			preferredID = target
+		}
+	}
+
+	return preferredID
+}
@@ -597,6 +597,130 @@ func (s *filteringSuite) TestFilter() {
 				})
 			},
 		},
+
+		// Scope: failure-domain
+		{
+			name: "spread/strict/failure-domain: excludes members sharing a failure domain",
+			caseSetup: func() {
+				_ = testCluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+					// Assign failure domains: rack1=member01,member02, rack2=member03,member04, rack3=member05.
+					for i, domain := range []string{"rack1", "rack1", "rack2", "rack2", "rack3"} {
+						err := tx.UpdateNodeFailureDomain(ctx, candidates[i].ID, domain)
+						s.Require().NoError(err)
+					}
+
+					pgID, err := query.Create(ctx, tx.Tx(), cluster.PlacementGroupsRow{
+						ProjectID:   1,
+						Name:        "pg-spread-strict-fd",
+						Description: "Spread strict failure domain placement group",
+					})
+					s.Require().NoError(err)
+
+					err = cluster.CreatePlacementGroupConfig(ctx, tx.Tx(), pgID, map[string]string{
+						"policy": api.PlacementPolicySpread,
+						"rigor":  api.PlacementRigorStrict,
+						"scope":  api.PlacementScopeFailureDomain,
+					})
+					s.Require().NoError(err)
+
+					// Create instance on member01.
+					instanceID, err := cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+						Name:    "c1",
+						Node:    "member01",
+						Project: "default",
+						Type:    instancetype.Container,
+					})
+					s.Require().NoError(err)
+
+					err = cluster.CreateInstanceConfig(ctx, tx.Tx(), instanceID, map[string]string{
+						"placement.group": "pg-spread-strict-fd",
+					})
+					s.Require().NoError(err)
+
+					return nil
+				})
+			},
+			args: args{
+				candidates: candidates,
+				project:    "default",
+				placementGroup: cluster.PlacementGroup{
+					ProjectName: "default",
+					Row: cluster.PlacementGroupsRow{
+						Name:        "pg-spread-strict-fd",
+						Description: "Spread strict failure domain placement group",
+					},
+				},
+			},
+			want:    candidatesWithout("member01", "member02"), // rack1 has an instance, exclude all its members.
+			wantErr: false,
+			caseTearDown: func() {
+				_ = testCluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+					_ = cluster.DeleteInstance(ctx, tx.Tx(), "default", "c1")
+					return nil
+				})
+			},
+		},
+		{
+			name: "compact/strict/failure-domain: keeps instances within the same failure domain",
+			caseSetup: func() {
+				_ = testCluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+					pgID, err := query.Create(ctx, tx.Tx(), cluster.PlacementGroupsRow{
+						ProjectID:   1,
+						Name:        "pg-compact-strict-fd",
+						Description: "Compact strict failure domain placement group",
+					})
+					s.Require().NoError(err)
+
+					err = cluster.CreatePlacementGroupConfig(ctx, tx.Tx(), pgID, map[string]string{
+						"policy": api.PlacementPolicyCompact,
+						"rigor":  api.PlacementRigorStrict,
+						"scope":  api.PlacementScopeFailureDomain,
+					})
+					s.Require().NoError(err)
+
+					// Create instance on member03.
+					instanceID, err := cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+						Name:    "c1",
+						Node:    "member03",
+						Project: "default",
+						Type:    instancetype.Container,
+					})
+					s.Require().NoError(err)
+
+					err = cluster.CreateInstanceConfig(ctx, tx.Tx(), instanceID, map[string]string{
+						"placement.group": "pg-compact-strict-fd",
+					})
+					s.Require().NoError(err)
+
+					return nil
+				})
+			},
+			args: args{
+				candidates: candidates,
+				project:    "default",
+				placementGroup: cluster.PlacementGroup{
+					ProjectName: "default",
+					Row: cluster.PlacementGroupsRow{
+						Name:        "pg-compact-strict-fd",
+						Description: "Compact strict failure domain placement group",
+					},
+				},
+			},
+			want:    candidatesOnly("member03", "member04"), // Both members of rack2 are eligible.
+			wantErr: false,
+			caseTearDown: func() {
+				_ = testCluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+					_ = cluster.DeleteInstance(ctx, tx.Tx(), "default", "c1")
+
+					// Reset failure domains.
+					for _, candidate := range candidates {
+						_ = tx.UpdateNodeFailureDomain(ctx, candidate.ID, "default")
+					}
+
+					return nil
+				})
+			},
+		},
 	}
 
 	// Prepare a placement group cache to avoid reloading the same group repeatedly.
@@ -8,21 +8,30 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/canonical/lxd/lxd/auth"
+	lxdCluster "github.com/canonical/lxd/lxd/cluster"
 	"github.com/canonical/lxd/lxd/db"
 	"github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/operationtype"
 	"github.com/canonical/lxd/lxd/db/query"
+	"github.com/canonical/lxd/lxd/db/warningtype"
 	"github.com/canonical/lxd/lxd/lifecycle"
+	"github.com/canonical/lxd/lxd/operations"
+	"github.com/canonical/lxd/lxd/placement"
 	"github.com/canonical/lxd/lxd/project"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/lxd/state"
+	"github.com/canonical/lxd/lxd/task"
 	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/lxd/warnings"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/entity"
+	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/validate"
 )
 
@@ -45,6 +54,13 @@ var placementGroupCmd = APIEndpoint{
 	Post:   APIEndpointAction{Handler: placementGroupPost, AccessHandler: allowPermission(entity.TypePlacementGroup, auth.EntitlementCanEdit, "name")},
 }
 
+var placementGroupComplianceCmd = APIEndpoint{
+	Path:        "placement-groups/{name}/compliance",
+	MetricsType: entity.TypePlacementGroup,
+
+	Get: APIEndpointAction{Handler: placementGroupComplianceGet, AccessHandler: allowPermission(entity.TypePlacementGroup, auth.EntitlementCanView, "name")},
+}
+
 // API endpoints.
 
 // swagger:operation GET /1.0/placement-groups placement-groups placement_groups_get
@@ -737,6 +753,89 @@ func placementGroupPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, entity.PlacementGroupURL(projectName, placementGroupName).String())
 }
 
+// swagger:operation GET /1.0/placement-groups/{name}/compliance placement-groups placement_group_compliance_get
+//
+//	Get the placement group compliance
+//
+//	Checks whether the current placement of the instances in the placement group complies with its policy.
+//	If not, the instance migrations that would restore compliance are returned without being performed.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Placement group compliance
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/PlacementGroupCompliance"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func placementGroupComplianceGet(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+	placementGroupName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	var compliance *api.PlacementGroupCompliance
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbGroup, err := cluster.GetPlacementGroup(ctx, tx.Tx(), placementGroupName, projectName)
+		if err != nil {
+			return err
+		}
+
+		apiGroup, err := dbGroup.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		candidates, err := placementGroupRebalanceCandidates(ctx, s, tx)
+		if err != nil {
+			return err
+		}
+
+		compliance, err = placement.Compliance(ctx, tx, candidates, *apiGroup)
+		if err != nil {
+			return fmt.Errorf("Failed checking placement group compliance: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, compliance)
+}
+
 // placementGroupValidateConfig validates the configuration keys/values for placement groups.
 func placementGroupValidateConfig(config map[string]string) error {
 	placementGroupConfigKeys := map[string]func(value string) error{
@@ -762,6 +861,35 @@ func placementGroupValidateConfig(config map[string]string) error {
 		//  required: "yes"
 		//  shortdesc: Enforcement level of the placement policy
 		"rigor": validate.IsOneOf(api.PlacementRigorStrict, api.PlacementRigorPermissive),
+
+		// lxdmeta:generate(entities=placement-group; group=placement-group; key=scope)
+		// Determines whether the policy applies to individual cluster members or
+		// to cluster failure domains.
+		//
+		// Possible values are `member` and `failure-domain`.
+		// With `failure-domain`, all cluster members in the same failure domain
+		// are treated as one placement target.
+		// See {ref}`clustering-instance-placement` for more information.
+		// ---
+		//  type: string
+		//  defaultdesc: `member`
+		//  required: no
+		//  shortdesc: Scope of the placement policy
+		"scope": validate.Optional(validate.IsOneOf(api.PlacementScopeMember, api.PlacementScopeFailureDomain)),
+
+		// lxdmeta:generate(entities=placement-group; group=placement-group; key=rebalance)
+		// Determines whether LXD migrates instances to restore compliance with the placement policy.
+		//
+		// Possible values are `manual` and `auto`.
+		// LXD regularly checks placement groups for compliance (for example, after a cluster member
+		// is evacuated, restored or added) and raises a warning for non-compliant placement groups.
+		// With `auto`, LXD also migrates the affected instances, using live migration for running instances.
+		// ---
+		//  type: string
+		//  defaultdesc: `manual`
+		//  required: no
+		//  shortdesc: Automatic rebalancing of the placement group
+		"rebalance": validate.Optional(validate.IsOneOf(api.PlacementRebalanceManual, api.PlacementRebalanceAuto)),
 	}
 
 	for k, v := range config {
@@ -795,3 +923,219 @@ func placementGroupValidateConfig(config map[string]string) error {
 
 	return nil
 }
+
+// placementGroupRebalanceCandidates returns the cluster members that instances can be rebalanced onto.
+// Evacuated and offline cluster members are excluded.
+func placementGroupRebalanceCandidates(ctx context.Context, s *state.State, tx *db.ClusterTx) ([]db.NodeInfo, error) {
+	members, err := tx.GetNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting cluster members: %w", err)
+	}
+
+	candidates := make([]db.NodeInfo, 0, len(members))
+	for _, member := range members {
+		if member.State == db.ClusterMemberStateEvacuated || member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+			continue
+		}
+
+		candidates = append(candidates, member)
+	}
+
+	return candidates, nil
+}
+
+// autoRebalancePlacementGroupsTask returns a background task that checks placement groups for compliance with their policy.
+func autoRebalancePlacementGroupsTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := autoRebalancePlacementGroups(ctx, stateFunc())
+		if err != nil {
+			logger.Error("Failed rebalancing placement groups", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(5 * time.Minute)
+}
+
+// autoRebalancePlacementGroups raises a warning for each placement group that does not comply with its policy
+// and resolves it once the placement group is compliant again. The warnings aren't tied to a cluster member so that
+// they can be resolved by whichever member is leader. Placement groups with rebalance set to auto are brought back
+// into compliance by migrating their instances.
+func autoRebalancePlacementGroups(ctx context.Context, s *state.State) error {
+	leaderInfo, err := s.LeaderInfo()
+	if err != nil {
+		return fmt.Errorf("Failed determining cluster leader: %w", err)
+	}
+
+	// Only run on the cluster leader.
+	if !leaderInfo.Clustered || !leaderInfo.Leader {
+		return nil
+	}
+
+	// Don't interfere with cluster members being evacuated or restored.
+	for _, opType := range []operationtype.Type{operationtype.ClusterMemberEvacuate, operationtype.ClusterMemberRestore} {
+		ops, err := operationsGetByType(ctx, s, "", opType)
+		if err != nil {
+			return err
+		}
+
+		for _, op := range ops {
+			if !op.StatusCode.IsFinal() {
+				logger.Debug("Skipping placement group rebalancing while a cluster member is being evacuated or restored")
+				return nil
+			}
+		}
+	}
+
+	type placementGroupCompliance struct {
+		id             int64
+		placementGroup *api.PlacementGroup
+		compliance     *api.PlacementGroupCompliance
+	}
+
+	var results []placementGroupCompliance
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		placementGroups, _, err := cluster.GetPlacementGroupsAndURLs(ctx, tx.Tx(), nil, nil)
+		if err != nil {
+			return fmt.Errorf("Failed loading placement groups: %w", err)
+		}
+
+		candidates, err := placementGroupRebalanceCandidates(ctx, s, tx)
+		if err != nil {
+			return err
+		}
+
+		// Transaction local variable to prevent appending duplicates in case of transaction retry on sqlite.ErrBusy.
+		resultsTx := make([]placementGroupCompliance, 0, len(placementGroups))
+		for _, placementGroup := range placementGroups {
+			apiGroup, err := placementGroup.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			compliance, err := placement.Compliance(ctx, tx, candidates, *apiGroup)
+			if err != nil {
+				return fmt.Errorf("Failed checking compliance of placement group %q in project %q: %w", apiGroup.Name, apiGroup.Project, err)
+			}
+
+			resultsTx = append(resultsTx, placementGroupCompliance{id: placementGroup.Row.ID, placementGroup: apiGroup, compliance: compliance})
+		}
+
+		results = resultsTx
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		l := logger.AddContext(logger.Ctx{"project": result.placementGroup.Project, "placementGroup": result.placementGroup.Name})
+
+		if result.compliance.Compliant {
+			err := warnings.ResolveWarningsByNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", result.placementGroup.Project, warningtype.PlacementGroupNonCompliant, entity.TypePlacementGroup, int(result.id))
+			if err != nil {
+				l.Warn("Failed resolving placement group compliance warning", logger.Ctx{"err": err})
+			}
+
+			continue
+		}
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarning(ctx, "", result.placementGroup.Project, entity.TypePlacementGroup, int(result.id), warningtype.PlacementGroupNonCompliant, result.compliance.Reason)
+		})
+		if err != nil {
+			l.Warn("Failed creating placement group compliance warning", logger.Ctx{"err": err})
+		}
+
+		if result.placementGroup.Config["rebalance"] != api.PlacementRebalanceAuto || len(result.compliance.Migrations) == 0 {
+			continue
+		}
+
+		err = rebalancePlacementGroup(ctx, s, result.placementGroup, result.compliance.Migrations)
+		if err != nil {
+			l.Error("Failed rebalancing placement group", logger.Ctx{"err": err})
+		}
+	}
+
+	return nil
+}
+
+// rebalancePlacementGroup performs the provided instance migrations within a background operation and waits for it to complete.
+func rebalancePlacementGroup(ctx context.Context, s *state.State, placementGroup *api.PlacementGroup, migrations []api.PlacementGroupMigration) error {
+	placementGroupURL := entity.PlacementGroupURL(placementGroup.Project, placementGroup.Name)
+
+	run := func(ctx context.Context, op *operations.Operation) error {
+		for _, migration := range migrations {
+			logger.Info("Migrating instance to rebalance placement group", logger.Ctx{"project": placementGroup.Project, "placementGroup": placementGroup.Name, "instance": migration.Instance, "source": migration.Source, "target": migration.Target})
+
+			err := rebalancePlacementGroupMigrateInstance(ctx, s, placementGroup.Project, migration)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	args := operations.OperationArgs{
+		ProjectName:       placementGroup.Project,
+		EntityURL:         placementGroupURL,
+		Type:              operationtype.PlacementGroupRebalance,
+		Class:             operations.OperationClassTask,
+		ConflictReference: placementGroupURL.String(), // Prevents concurrent rebalancing of the same placement group.
+		RunHook:           run,
+	}
+
+	op, err := operations.ScheduleServerOperation(s, args)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusConflict) {
+			return nil
+		}
+
+		return fmt.Errorf("Failed scheduling placement group rebalance operation: %w", err)
+	}
+
+	return op.Wait(ctx)
+}
+
+// rebalancePlacementGroupMigrateInstance migrates an instance between cluster members, live if the instance is running.
+func rebalancePlacementGroupMigrateInstance(ctx context.Context, s *state.State, projectName string, migration api.PlacementGroupMigration) error {
+	var sourceMember db.NodeInfo
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		sourceMember, err = tx.GetNodeByName(ctx, migration.Source)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed getting cluster member %q: %w", migration.Source, err)
+	}
+
+	client, err := lxdCluster.Connect(ctx, sourceMember.Address, s.Endpoints.NetworkCert(), s.ServerCert(), true)
+	if err != nil {
+		return fmt.Errorf("Failed connecting to cluster member %q: %w", migration.Source, err)
+	}
+
+	client = client.UseProject(projectName)
+
+	inst, _, err := client.GetInstance(migration.Instance)
+	if err != nil {
+		return fmt.Errorf("Failed getting instance %q: %w", migration.Instance, err)
+	}
+
+	req := api.InstancePost{
+		Name:      migration.Instance,
+		Migration: true,
+		Live:      inst.StatusCode == api.Running,
+	}
+
+	op, err := client.UseTarget(migration.Target).MigrateInstance(migration.Instance, req)
+	if err != nil {
+		return fmt.Errorf("Failed migrating instance %q to %q: %w", migration.Instance, migration.Target, err)
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return fmt.Errorf("Failed migrating instance %q to %q: %w", migration.Instance, migration.Target, err)
+	}
+
+	return nil
+}
@@ -3337,11 +3337,16 @@ func (b *lxdBackend) CleanupInstancePaths(inst instance.Instance, _ ioprogress.P
 }
 
 // BackupInstance creates an instance backup.
-func (b *lxdBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, version uint32, progressReporter ioprogress.ProgressReporter) error {
-	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "optimized": optimized, "snapshots": snapshots})
+// If parentSnapshot is set, only the changes made since that snapshot are included in the backup.
+func (b *lxdBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parentSnapshot string, version uint32, progressReporter ioprogress.ProgressReporter) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "optimized": optimized, "snapshots": snapshots, "parentSnapshot": parentSnapshot})
 	l.Debug("BackupInstance started")
 	defer l.Debug("BackupInstance finished")
 
+	if parentSnapshot != "" && (!optimized || !snapshots) {
+		return errors.New("Incremental backups must use optimized storage and include snapshots")
+	}
+
 	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
 		return err
@@ -3390,6 +3395,10 @@ func (b *lxdBackend) BackupInstance(inst instance.Instance, tarWriter *instancew
 
 	volCopy := drivers.NewVolumeCopy(vol, sourceSnapshots...)
 
+	if parentSnapshot != "" {
+		return b.backupVolumeIncremental(volCopy, inst.Project().Name, tarWriter, parentSnapshot, snapNames, progressReporter)
+	}
+
 	err = b.driver.BackupVolume(volCopy, inst.Project().Name, tarWriter, optimized, snapNames, progressReporter)
 	if err != nil {
 		return err
@@ -3398,6 +3407,155 @@ func (b *lxdBackend) BackupInstance(inst instance.Instance, tarWriter *instancew
 	return nil
 }
 
+// backupVolumeIncremental writes the changes made to a volume since the parent snapshot into a backup file.
+// Only the snapshots taken after the parent snapshot are included.
+func (b *lxdBackend) backupVolumeIncremental(vol drivers.VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapNames []string, progressReporter ioprogress.ProgressReporter) error {
+	parentIndex := slices.Index(snapNames, parentSnapshot)
+	if parentIndex < 0 {
+		return api.StatusErrorf(http.StatusNotFound, "Parent snapshot %q not found", parentSnapshot)
+	}
+
+	err := b.driver.BackupVolumeIncremental(vol, projectName, tarWriter, parentSnapshot, snapNames[parentIndex+1:], progressReporter)
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return fmt.Errorf("Incremental backups aren't supported by storage driver %q", b.driver.Info().Name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RefreshInstanceFromBackup applies an incremental backup on top of the root volume of an existing instance.
+// The instance records of the snapshots included in the backup must already exist.
+func (b *lxdBackend) RefreshInstanceFromBackup(inst instance.Instance, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "parent": srcBackup.Parent, "snapshots": srcBackup.Snapshots})
+	l.Debug("RefreshInstanceFromBackup started")
+	defer l.Debug("RefreshInstanceFromBackup finished")
+
+	if inst.IsRunning() {
+		return errors.New("Incremental backups can only be applied to stopped instances")
+	}
+
+	if srcBackup.Config == nil {
+		return errors.New("Valid instance config not found in index")
+	}
+
+	rootVol, err := srcBackup.Config.RootVolume()
+	if err != nil {
+		return fmt.Errorf("Failed getting the root volume: %w", err)
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Load storage volume from database.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return err
+	}
+
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+	err = b.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Create database entries for the new storage volume snapshots.
+	for _, snapName := range srcBackup.Snapshots {
+		i := slices.IndexFunc(rootVol.Snapshots, func(snap *api.StorageVolumeSnapshot) bool { return snap.Name == snapName })
+		if i < 0 {
+			return fmt.Errorf("Valid config for snapshot %q not found in index", snapName)
+		}
+
+		newSnapshotName := drivers.GetSnapshotVolumeName(inst.Name(), snapName)
+
+		var volumeSnapExpiryDate time.Time
+		if rootVol.Snapshots[i].ExpiresAt != nil {
+			volumeSnapExpiryDate = *rootVol.Snapshots[i].ExpiresAt
+		}
+
+		// Create a new snapshot volume with its own config and UUID.
+		snapVol := b.GetNewVolume(volType, contentType, newSnapshotName, rootVol.Snapshots[i].Config)
+
+		// Validate config and create database entry for new storage volume.
+		err = VolumeDBCreate(b, inst.Project().Name, newSnapshotName, rootVol.Snapshots[i].Description, volType, true, snapVol.Config(), rootVol.Snapshots[i].CreatedAt, volumeSnapExpiryDate, contentType, false, true)
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() { _ = VolumeDBDelete(b, inst.Project().Name, newSnapshotName, volType) })
+	}
+
+	// Get all of the instance's snapshots, including the ones from the backup.
+	instSnapshots, err := inst.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	targetSnapshots := make([]drivers.Volume, 0, len(instSnapshots))
+	for _, instSnapshot := range instSnapshots {
+		snap, err := VolumeDBGet(b, inst.Project().Name, instSnapshot.Name(), volType)
+		if err != nil {
+			return err
+		}
+
+		snapshotStorageName := project.Instance(inst.Project().Name, snap.Name)
+		targetSnapshots = append(targetSnapshots, b.GetVolume(volType, contentType, snapshotStorageName, snap.Config))
+	}
+
+	err = b.refreshVolumeFromBackup(drivers.NewVolumeCopy(vol, targetSnapshots...), srcBackup, srcData, progressReporter)
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
+// refreshVolumeFromBackup applies an incremental backup on top of an existing volume after checking
+// that the backup's parent snapshot is the volume's most recent snapshot prior to the backup.
+func (b *lxdBackend) refreshVolumeFromBackup(vol drivers.VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	if srcBackup.OptimizedStorage == nil || !*srcBackup.OptimizedStorage {
+		return errors.New("Incremental backups must use optimized storage")
+	}
+
+	if srcBackup.Backend != b.driver.Info().Name {
+		return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q", srcBackup.Backend, b.driver.Info().Name)
+	}
+
+	// The snapshots from the backup are expected to directly follow the parent snapshot.
+	parentIndex := len(vol.Snapshots) - len(srcBackup.Snapshots) - 1
+	if parentIndex < 0 {
+		return fmt.Errorf("Parent snapshot %q of the incremental backup not found", srcBackup.Parent)
+	}
+
+	_, parentName, _ := api.GetParentAndSnapshotName(vol.Snapshots[parentIndex].Name())
+	if parentName != srcBackup.Parent {
+		return fmt.Errorf("Incremental backup must be applied on top of snapshot %q but the most recent snapshot is %q", srcBackup.Parent, parentName)
+	}
+
+	err := b.driver.RefreshVolumeFromBackup(vol, srcBackup, srcData, progressReporter)
+	if err != nil {
+		if errors.Is(err, drivers.ErrNotSupported) {
+			return fmt.Errorf("Incremental backups aren't supported by storage driver %q", b.driver.Info().Name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 // GetInstanceUsage returns the disk usage of the instance's root volume.
 func (b *lxdBackend) GetInstanceUsage(inst instance.Instance) (*VolumeUsage, error) {
 	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
@@ -4502,17 +4660,30 @@ func (b *lxdBackend) CreateBucket(projectName string, bucket api.StorageBucketsP
 	// Set the new bucket volume's UUID.
 	bucket.Config["volatile.uuid"] = bucketVol.Config()["volatile.uuid"]
 
-	bucketID, err := BucketDBCreate(context.TODO(), b, projectName, &bucket)
+	// Buckets on local storage drivers are only available on the member that hosts them.
+	memberSpecific := !b.Driver().Info().Remote
+
+	bucketID, err := BucketDBCreate(context.TODO(), b, projectName, memberSpecific, &bucket)
 	if err != nil {
 		return err
 	}
 
 	revert.Add(func() { _ = BucketDBDelete(context.TODO(), b, bucketID) })
 
-	// Handle per-driver implementation for remote storage drivers.
-	err = b.driver.CreateBucket(bucketVol)
-	if err != nil {
-		return err
+	if memberSpecific {
+		// Local storage drivers store the bucket in a volume served by the built-in S3 server.
+		err = b.driver.CreateVolume(bucketVol, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() { _ = b.driver.DeleteVolume(bucketVol, nil) })
+	} else {
+		// Handle per-driver implementation for remote storage drivers.
+		err = b.driver.CreateBucket(bucketVol)
+		if err != nil {
+			return err
+		}
 	}
 
 	revert.Success()
@@ -4534,10 +4705,12 @@ func (b *lxdBackend) UpdateBucket(projectName string, bucketName string, bucket
 		return errors.New("Storage pool does not support buckets")
 	}
 
+	memberSpecific := !b.Driver().Info().Remote
+
 	// Get current config to compare what has changed.
 	var curBucket *db.StorageBucket
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		curBucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		curBucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
 		return err
 	})
 	if err != nil {
@@ -4583,8 +4756,13 @@ func (b *lxdBackend) UpdateBucket(projectName string, bucketName string, bucket
 
 	changedConfig, userOnly := b.detectChangedConfig(curBucket.Config, bucket.Config)
 	if len(changedConfig) > 0 && !userOnly {
-		// Handle per-driver implementation for remote storage drivers.
-		err = b.driver.UpdateBucket(curBucketVol, changedConfig)
+		if memberSpecific {
+			err = b.driver.UpdateVolume(curBucketVol, changedConfig)
+		} else {
+			// Handle per-driver implementation for remote storage drivers.
+			err = b.driver.UpdateBucket(curBucketVol, changedConfig)
+		}
+
 		if err != nil {
 			return err
 		}
@@ -4616,9 +4794,11 @@ func (b *lxdBackend) DeleteBucket(projectName string, bucketName string) error {
 		return errors.New("Storage pool does not support buckets")
 	}
 
+	memberSpecific := !b.Driver().Info().Remote
+
 	var bucket *db.StorageBucket
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
 		return err
 	})
 	if err != nil {
@@ -4628,8 +4808,18 @@ func (b *lxdBackend) DeleteBucket(projectName string, bucketName string) error {
 	bucketVolName := project.StorageVolume(projectName, bucket.Name)
 	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
 
-	// Handle per-driver implementation for remote storage drivers.
-	err = b.driver.DeleteBucket(bucketVol)
+	if memberSpecific {
+		_, err = b.driver.UnmountVolume(bucketVol, false, nil)
+		if err != nil {
+			return err
+		}
+
+		err = b.driver.DeleteVolume(bucketVol, nil)
+	} else {
+		// Handle per-driver implementation for remote storage drivers.
+		err = b.driver.DeleteBucket(bucketVol)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -4655,9 +4845,11 @@ func (b *lxdBackend) CreateBucketKey(projectName string, bucketName string, key
 	revert := revert.New()
 	defer revert.Fail()
 
+	memberSpecific := !b.Driver().Info().Remote
+
 	var bucket *db.StorageBucket
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
 		return err
 	})
 	if err != nil {
@@ -4678,16 +4870,26 @@ func (b *lxdBackend) CreateBucketKey(projectName string, bucketName string, key
 		return nil, err
 	}
 
-	// Handle per-driver implementation for remote storage drivers.
-	newCreds, err := b.driver.CreateBucketKey(bucketVol, key.Name, creds, key.Role)
-	if err != nil {
-		return nil, err
-	}
+	if memberSpecific {
+		// Keys for buckets served by the built-in S3 server only live in the database.
+		err = generateBucketCredentials(&creds)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Handle per-driver implementation for remote storage drivers.
+		newCreds, err := b.driver.CreateBucketKey(bucketVol, key.Name, creds, key.Role)
+		if err != nil {
+			return nil, err
+		}
 
-	revert.Add(func() { _ = b.driver.DeleteBucketKey(bucketVol, key.Name) })
+		revert.Add(func() { _ = b.driver.DeleteBucketKey(bucketVol, key.Name) })
 
-	key.AccessKey = newCreds.AccessKey
-	key.SecretKey = newCreds.SecretKey
+		creds = *newCreds
+	}
+
+	key.AccessKey = creds.AccessKey
+	key.SecretKey = creds.SecretKey
 
 	newKey := api.StorageBucketKey{
 		Name:        key.Name,
@@ -4725,11 +4927,13 @@ func (b *lxdBackend) UpdateBucketKey(projectName string, bucketName string, keyN
 		return errors.New("Storage pool does not support buckets")
 	}
 
+	memberSpecific := !b.Driver().Info().Remote
+
 	// Get current config to compare what has changed.
 	var bucket *db.StorageBucket
 	var curBucketKey *db.StorageBucketKey
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
 		if err != nil {
 			return err
 		}
@@ -4780,14 +4984,23 @@ func (b *lxdBackend) UpdateBucketKey(projectName string, bucketName string, keyN
 		return err
 	}
 
-	// Handle per-driver implementation for remote storage drivers.
-	newCreds, err := b.driver.UpdateBucketKey(bucketVol, keyName, creds, key.Role)
-	if err != nil {
-		return err
+	if memberSpecific {
+		err = generateBucketCredentials(&creds)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Handle per-driver implementation for remote storage drivers.
+		newCreds, err := b.driver.UpdateBucketKey(bucketVol, keyName, creds, key.Role)
+		if err != nil {
+			return err
+		}
+
+		creds = *newCreds
 	}
 
-	key.AccessKey = newCreds.AccessKey
-	key.SecretKey = newCreds.SecretKey
+	key.AccessKey = creds.AccessKey
+	key.SecretKey = creds.SecretKey
 
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// Update the database record.
@@ -4815,10 +5028,12 @@ func (b *lxdBackend) DeleteBucketKey(projectName string, bucketName string, keyN
 		return errors.New("Storage pool does not support buckets")
 	}
 
+	memberSpecific := !b.Driver().Info().Remote
+
 	var bucket *db.StorageBucket
 	var bucketKey *db.StorageBucketKey
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, false, bucketName)
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, memberSpecific, bucketName)
 		if err != nil {
 			return err
 		}
@@ -4834,14 +5049,16 @@ func (b *lxdBackend) DeleteBucketKey(projectName string, bucketName string, keyN
 		return err
 	}
 
-	// Handle per-driver implementation for remote storage drivers.
-	bucketVolName := project.StorageVolume(projectName, bucket.Name)
-	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
+	if !memberSpecific {
+		// Handle per-driver implementation for remote storage drivers.
+		bucketVolName := project.StorageVolume(projectName, bucket.Name)
+		bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
 
-	// Delete the bucket key from the storage device.
-	err = b.driver.DeleteBucketKey(bucketVol, keyName)
-	if err != nil {
-		return err
+		// Delete the bucket key from the storage device.
+		err = b.driver.DeleteBucketKey(bucketVol, keyName)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -4865,10 +5082,83 @@ func (b *lxdBackend) GetBucketURL(bucketName string) *url.URL {
 		return nil
 	}
 
+	if !b.Driver().Info().Remote {
+		// Buckets on local storage drivers are served by the built-in S3 server, if enabled.
+		address := b.state.LocalConfig.StorageBucketsAddress()
+		if address == "" {
+			return nil
+		}
+
+		return &api.NewURL().Scheme("https").Host(address).Path(bucketName).URL
+	}
+
 	// Handle per-driver implementation for remote storage drivers.
 	return b.driver.GetBucketURL(bucketName)
 }
 
+// MountBucket mounts the volume of a bucket on a local storage pool and returns its mount path.
+func (b *lxdBackend) MountBucket(projectName string, bucketName string) (string, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucketName": bucketName})
+	l.Debug("MountBucket started")
+	defer l.Debug("MountBucket finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return "", err
+	}
+
+	if b.Driver().Info().Remote {
+		return "", errors.New("Buckets on remote storage pools cannot be mounted")
+	}
+
+	var bucket *db.StorageBucket
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, true, bucketName)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	bucketVolName := project.StorageVolume(projectName, bucket.Name)
+	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
+
+	err = b.driver.MountVolume(bucketVol, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return bucketVol.MountPath(), nil
+}
+
+// UnmountBucket unmounts the volume of a bucket on a local storage pool.
+func (b *lxdBackend) UnmountBucket(projectName string, bucketName string) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "bucketName": bucketName})
+	l.Debug("UnmountBucket started")
+	defer l.Debug("UnmountBucket finished")
+
+	if b.Driver().Info().Remote {
+		return errors.New("Buckets on remote storage pools cannot be unmounted")
+	}
+
+	var bucket *db.StorageBucket
+	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		bucket, err = tx.GetStoragePoolBucket(ctx, b.id, projectName, true, bucketName)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	bucketVolName := project.StorageVolume(projectName, bucket.Name)
+	bucketVol := b.GetVolume(drivers.VolumeTypeBucket, drivers.ContentTypeFS, bucketVolName, bucket.Config)
+
+	_, err = b.driver.UnmountVolume(bucketVol, false, nil)
+
+	return err
+}
+
 // CreateCustomVolume creates an empty custom volume.
 func (b *lxdBackend) CreateCustomVolume(ctx context.Context, projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, progressReporter ioprogress.ProgressReporter) error {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "desc": desc, "config": config, "contentType": contentType})
@@ -4890,6 +5180,11 @@ func (b *lxdBackend) CreateCustomVolume(ctx context.Context, projectName string,
 		return errors.New("Storage pool does not support custom volume type")
 	}
 
+	err = b.validateVolumeReplication(vol.Config())
+	if err != nil {
+		return err
+	}
+
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -4918,6 +5213,39 @@ func (b *lxdBackend) CreateCustomVolume(ctx context.Context, projectName string,
 	return nil
 }
 
+// validateVolumeReplication checks that the replication settings of a custom volume refer to a usable storage pool.
+func (b *lxdBackend) validateVolumeReplication(config map[string]string) error {
+	targetPoolName := config["replication.target_pool"]
+	if targetPoolName == "" {
+		if config["replication.schedule"] != "" {
+			return api.NewStatusError(http.StatusBadRequest, `"replication.schedule" requires "replication.target_pool" to be set`)
+		}
+
+		return nil
+	}
+
+	if targetPoolName == b.name {
+		return api.NewStatusError(http.StatusBadRequest, "Volumes cannot be replicated to their own storage pool")
+	}
+
+	targetPool, err := LoadByName(b.state, targetPoolName)
+	if err != nil {
+		return fmt.Errorf("Failed loading replication target pool %q: %w", targetPoolName, err)
+	}
+
+	if !slices.Contains(targetPool.Driver().Info().VolumeTypes, drivers.VolumeTypeCustom) {
+		return api.StatusErrorf(http.StatusBadRequest, "Replication target pool %q does not support custom volumes", targetPoolName)
+	}
+
+	// Volumes on remote pools can be replicated from any cluster member, so the replica would end up on a
+	// random member when using a local target pool.
+	if b.state.ServerClustered && b.driver.Info().Remote && !targetPool.Driver().Info().Remote {
+		return api.NewStatusError(http.StatusBadRequest, "Volumes on remote storage pools cannot be replicated to local storage pools in a cluster")
+	}
+
+	return nil
+}
+
 // CreateCustomVolumeFromCopy creates a custom volume from an existing custom volume.
 // It copies the snapshots from the source volume by default, but can be disabled if requested.
 func (b *lxdBackend) CreateCustomVolumeFromCopy(ctx context.Context, projectName, srcProjectName, volName, desc string, config map[string]string, srcPoolName, srcVolName string, snapshots bool, progressReporter ioprogress.ProgressReporter) error {
@@ -5610,6 +5938,7 @@ func (b *lxdBackend) RenameCustomVolume(ctx context.Context, projectName string,
 		_, backupName, _ := api.GetParentAndSnapshotName(backupRow.Name)
 		newVolBackupName := drivers.GetSnapshotVolumeName(newVolName, backupName)
 		volBackup := backup.NewVolumeBackup(b.state, projectName, b.name, volName, backupRow.ID, backupRow.Name, backupRow.CreationDate, backupRow.ExpiryDate, backupRow.VolumeOnly, backupRow.OptimizedStorage)
+		volBackup.SetTarget(backupRow.Target, backupRow.TargetLocation)
 		err = volBackup.Rename(newVolBackupName)
 		if err != nil {
 			return fmt.Errorf("Failed renaming backup %q to %q: %w", backupRow.Name, newVolBackupName, err)
@@ -5755,6 +6084,11 @@ func (b *lxdBackend) UpdateCustomVolume(ctx context.Context, projectName string,
 		return err
 	}
 
+	err = b.validateVolumeReplication(newConfig)
+	if err != nil {
+		return err
+	}
+
 	// Apply config changes if there are any.
 	changedConfig, userOnly := b.detectChangedConfig(curVol.Config, newConfig)
 
@@ -7795,11 +8129,16 @@ func (b *lxdBackend) ImportInstance(inst instance.Instance, poolVol *backupConfi
 }
 
 // BackupCustomVolume creates a backup of an existing custom volume.
-func (b *lxdBackend) BackupCustomVolume(projectName string, volName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, progressReporter ioprogress.ProgressReporter) error {
-	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volume": volName, "optimized": optimized, "snapshots": snapshots})
+// If parentSnapshot is set, only the changes made since that snapshot are included in the backup.
+func (b *lxdBackend) BackupCustomVolume(projectName string, volName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parentSnapshot string, progressReporter ioprogress.ProgressReporter) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volume": volName, "optimized": optimized, "snapshots": snapshots, "parentSnapshot": parentSnapshot})
 	l.Debug("BackupCustomVolume started")
 	defer l.Debug("BackupCustomVolume finished")
 
+	if parentSnapshot != "" && (!optimized || !snapshots) {
+		return errors.New("Incremental backups must use optimized storage and include snapshots")
+	}
+
 	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
 	if err != nil {
 		return err
@@ -7843,6 +8182,10 @@ func (b *lxdBackend) BackupCustomVolume(projectName string, volName string, tarW
 
 	volCopy := drivers.NewVolumeCopy(vol, sourceSnapshots...)
 
+	if parentSnapshot != "" {
+		return b.backupVolumeIncremental(volCopy, projectName, tarWriter, parentSnapshot, snapNames, progressReporter)
+	}
+
 	err = b.driver.BackupVolume(volCopy, projectName, tarWriter, optimized, snapNames, progressReporter)
 	if err != nil {
 		return err
@@ -8173,6 +8516,128 @@ func (b *lxdBackend) CreateCustomVolumeFromBackup(ctx context.Context, srcBackup
 	return nil
 }
 
+// RefreshCustomVolumeFromBackup applies an incremental backup on top of an existing custom volume.
+func (b *lxdBackend) RefreshCustomVolumeFromBackup(ctx context.Context, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	l := b.logger.AddContext(logger.Ctx{"project": srcBackup.Project, "volume": srcBackup.Name, "parent": srcBackup.Parent, "snapshots": srcBackup.Snapshots})
+	l.Debug("RefreshCustomVolumeFromBackup started")
+	defer l.Debug("RefreshCustomVolumeFromBackup finished")
+
+	if srcBackup.Config == nil {
+		return errors.New("Valid volume config not found in index")
+	}
+
+	customVol, err := srcBackup.Config.CustomVolume()
+	if err != nil {
+		return fmt.Errorf("Failed getting the custom volume: %w", err)
+	}
+
+	// Validate the names in the index.yaml file as these could be malicious.
+	err = drivers.ValidVolumeName(srcBackup.Name)
+	if err != nil {
+		return fmt.Errorf("Invalid backup name %q: %w", srcBackup.Name, err)
+	}
+
+	for _, snapName := range srcBackup.Snapshots {
+		err = drivers.ValidVolumeName(snapName)
+		if err != nil {
+			return fmt.Errorf("Invalid backup snapshot name %q: %w", snapName, err)
+		}
+	}
+
+	curVol, err := VolumeDBGet(b, srcBackup.Project, srcBackup.Name, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Check that the volume isn't in use by running instances.
+	err = VolumeUsedByInstanceDevices(b.state, b.Name(), srcBackup.Project, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, _ []string) error {
+		inst, err := instance.Load(b.state, dbInst, project)
+		if err != nil {
+			return err
+		}
+
+		if inst.IsRunning() {
+			return errors.New("Cannot apply incremental backup to custom volume used by running instances")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dbContentType, err := cluster.StoragePoolVolumeContentTypeFromName(curVol.ContentType)
+	if err != nil {
+		return err
+	}
+
+	contentType := VolumeDBContentTypeToContentType(dbContentType)
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Create database entries for the new storage volume snapshots.
+	for _, snapName := range srcBackup.Snapshots {
+		i := slices.IndexFunc(customVol.Snapshots, func(snap *api.StorageVolumeSnapshot) bool {
+			// Due to a historical bug, the volume snapshot names were sometimes written in their full form
+			// (<parent>/<snap>) rather than the expected snapshot name only form, so we need to handle both.
+			_, name, _ := strings.Cut(snap.Name, shared.SnapshotDelimiter)
+			return snap.Name == snapName || name == snapName
+		})
+
+		if i < 0 {
+			return fmt.Errorf("Valid config for snapshot %q not found in index", snapName)
+		}
+
+		snapshot := customVol.Snapshots[i]
+		fullSnapName := drivers.GetSnapshotVolumeName(srcBackup.Name, snapName)
+		snapVolStorageName := project.StorageVolume(srcBackup.Project, fullSnapName)
+		snapVol := b.GetNewVolume(drivers.VolumeTypeCustom, contentType, snapVolStorageName, snapshot.Config)
+
+		var expiryDate time.Time
+		if snapshot.ExpiresAt != nil {
+			expiryDate = *snapshot.ExpiresAt
+		}
+
+		err = VolumeDBCreate(b, srcBackup.Project, fullSnapName, snapshot.Description, snapVol.Type(), true, snapVol.Config(), snapshot.CreatedAt, expiryDate, snapVol.ContentType(), true, true)
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() { _ = VolumeDBDelete(b, srcBackup.Project, fullSnapName, snapVol.Type()) })
+	}
+
+	// Get all of the volume's snapshots, including the ones from the backup.
+	volSnaps, err := VolumeDBSnapshotsGet(b, srcBackup.Project, srcBackup.Name, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	targetSnapshots := make([]drivers.Volume, 0, len(volSnaps))
+	for _, volSnap := range volSnaps {
+		snapshotStorageName := project.StorageVolume(srcBackup.Project, volSnap.Name)
+		targetSnapshots = append(targetSnapshots, b.GetVolume(drivers.VolumeTypeCustom, contentType, snapshotStorageName, volSnap.Config))
+	}
+
+	volStorageName := project.StorageVolume(srcBackup.Project, srcBackup.Name)
+	vol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
+
+	err = b.refreshVolumeFromBackup(drivers.NewVolumeCopy(vol, targetSnapshots...), srcBackup, srcData, progressReporter)
+	if err != nil {
+		return err
+	}
+
+	eventCtx := logger.Ctx{"type": vol.Type()}
+	if !b.Driver().Info().Remote {
+		eventCtx["location"] = b.state.ServerName
+	}
+
+	b.state.Events.SendLifecycle(srcBackup.Project, lifecycle.StorageVolumeUpdated.Event(ctx, vol, string(vol.Type()), srcBackup.Project, eventCtx))
+
+	revert.Success()
+	return nil
+}
+
 // getParentVolumeUUID returns the UUID of the parent's volume.
 // If the volume has no parent, an empty string is returned.
 func (b *lxdBackend) getParentVolumeUUID(vol drivers.Volume, projectName string) (string, error) {
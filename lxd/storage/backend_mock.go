@@ -236,7 +236,12 @@ func (b *mockBackend) RefreshInstance(ctx context.Context, inst instance.Instanc
 }
 
 // BackupInstance ...
-func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, version uint32, progressReporter ioprogress.ProgressReporter) error {
+func (b *mockBackend) BackupInstance(inst instance.Instance, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parentSnapshot string, version uint32, progressReporter ioprogress.ProgressReporter) error {
+	return nil
+}
+
+// RefreshInstanceFromBackup ...
+func (b *mockBackend) RefreshInstanceFromBackup(inst instance.Instance, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
 	return nil
 }
 
@@ -345,6 +350,16 @@ func (b *mockBackend) GetBucketURL(bucketName string) *url.URL {
 	return nil
 }
 
+// MountBucket ...
+func (b *mockBackend) MountBucket(projectName string, bucketName string) (string, error) {
+	return "", nil
+}
+
+// UnmountBucket ...
+func (b *mockBackend) UnmountBucket(projectName string, bucketName string) error {
+	return nil
+}
+
 // CreateCustomVolume ...
 func (b *mockBackend) CreateCustomVolume(ctx context.Context, projectName string, volName string, desc string, config map[string]string, contentType drivers.ContentType, progressReporter ioprogress.ProgressReporter) error {
 	return nil
@@ -426,7 +441,7 @@ func (b *mockBackend) RestoreCustomVolume(ctx context.Context, projectName strin
 }
 
 // BackupCustomVolume ...
-func (b *mockBackend) BackupCustomVolume(projectName string, volName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, progressReporter ioprogress.ProgressReporter) error {
+func (b *mockBackend) BackupCustomVolume(projectName string, volName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, parentSnapshot string, progressReporter ioprogress.ProgressReporter) error {
 	return nil
 }
 
@@ -435,6 +450,11 @@ func (b *mockBackend) CreateCustomVolumeFromBackup(ctx context.Context, srcBacku
 	return nil
 }
 
+// RefreshCustomVolumeFromBackup ...
+func (b *mockBackend) RefreshCustomVolumeFromBackup(ctx context.Context, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	return nil
+}
+
 // CreateCustomVolumeFromISO ...
 func (b *mockBackend) CreateCustomVolumeFromISO(ctx context.Context, projectName string, volName string, srcData io.ReadSeeker, size int64, progressReporter ioprogress.ProgressReporter) error {
 	return nil
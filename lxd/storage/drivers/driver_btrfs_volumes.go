@@ -222,17 +222,90 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 		})
 	}
 
+	err = d.unpackOptimizedBackup(vol, srcBackup.Snapshots, optimizedHeader, srcData, unpacker, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revert.Success()
+	return nil, revertHook, nil
+}
+
+// RefreshVolumeFromBackup applies an incremental backup on top of an existing volume.
+// The backup's parent snapshot must be the most recent snapshot of the volume.
+func (d *btrfs) RefreshVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	if !*srcBackup.OptimizedStorage || srcBackup.Parent == "" {
+		return errors.New("Only optimized incremental backups can be applied to an existing volume")
+	}
+
+	if srcBackup.OptimizedHeader == nil || !*srcBackup.OptimizedHeader {
+		return errors.New("Incremental backup is missing the optimized backup header file")
+	}
+
+	parentVol, _ := vol.NewSnapshot(srcBackup.Parent)
+	if !d.isSubvolume(parentVol.MountPath()) {
+		return fmt.Errorf("Parent snapshot %q of the incremental backup doesn't exist", srcBackup.Parent)
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() {
+		for _, snapName := range srcBackup.Snapshots {
+			snapVol, _ := vol.NewSnapshot(snapName)
+			_ = d.DeleteVolumeSnapshot(snapVol, progressReporter)
+		}
+	})
+
+	// Find the compression algorithm used for backup source data.
+	_, err := srcData.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	_, _, unpacker, err := shared.DetectCompressionFile(srcData)
+	if err != nil {
+		return err
+	}
+
+	optimizedHeader, err := d.loadOptimizedBackupHeader(srcData, GetVolumeMountPath(d.name, vol.volType, ""))
+	if err != nil {
+		return err
+	}
+
+	// The subvolumes of the new snapshots and of the main volume are received as differentials from the
+	// parent snapshot and each other, and the existing main volume is replaced once all have been received.
+	err = d.unpackOptimizedBackup(vol, srcBackup.Snapshots, optimizedHeader, srcData, unpacker, true)
+	if err != nil {
+		return err
+	}
+
+	if vol.contentType == ContentTypeFS {
+		// Apply the size limit to the replaced main volume.
+		err = d.SetVolumeQuota(vol.Volume, vol.ConfigSize(), false, progressReporter)
+		if err != nil {
+			return err
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
+// unpackOptimizedBackup receives the subvolumes of the given snapshots and of the main volume from an optimized
+// backup file and moves them into place. If refresh is true, the existing main volume is replaced.
+func (d *btrfs) unpackOptimizedBackup(vol VolumeCopy, snapshots []string, optimizedHeader *BTRFSMetaDataHeader, srcData io.ReadSeeker, unpacker []string, refresh bool) error {
 	// Create a temporary directory to unpack the backup into.
 	tmpUnpackDir, err := os.MkdirTemp(GetVolumeMountPath(d.name, vol.volType, ""), "backup.")
 	if err != nil {
-		return nil, nil, fmt.Errorf("Failed creating temporary directory %q: %w", tmpUnpackDir, err)
+		return fmt.Errorf("Failed creating temporary directory %q: %w", tmpUnpackDir, err)
 	}
 
 	defer func() { _ = os.RemoveAll(tmpUnpackDir) }()
 
 	err = os.Chmod(tmpUnpackDir, 0100)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Failed chmoding temporary directory %q: %w", tmpUnpackDir, err)
+		return fmt.Errorf("Failed chmoding temporary directory %q: %w", tmpUnpackDir, err)
 	}
 
 	// unpackSubVolume unpacks a subvolume file from a backup tarball file.
@@ -269,8 +342,9 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 	}
 
 	type btrfsCopyOp struct {
-		src  string
-		dest string
+		src          string
+		dest         string
+		receivedUUID string
 	}
 
 	var copyOps []btrfsCopyOp
@@ -310,28 +384,43 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 				return err
 			}
 
+			// Subvolumes can't be listed when running in a user namespace.
+			receivedUUID := ""
+			if !d.state.OS.RunningInUserNS {
+				receivedVol := Volume{
+					pool:            d.name,
+					mountCustomPath: unpackedSubVolPath,
+				}
+
+				receivedUUID, err = d.getSubVolumeReceivedUUID(receivedVol)
+				if err != nil {
+					return fmt.Errorf("Failed getting UUID: %w", err)
+				}
+			}
+
 			copyOps = append(copyOps, btrfsCopyOp{
-				src:  unpackedSubVolPath,
-				dest: subVolTargetPath,
+				src:          unpackedSubVolPath,
+				dest:         subVolTargetPath,
+				receivedUUID: receivedUUID,
 			})
 		}
 
 		return nil
 	}
 
-	if len(srcBackup.Snapshots) > 0 {
+	if len(snapshots) > 0 {
 		// Create new snapshots directory.
 		err := createParentSnapshotDirIfMissing(d.name, vol.volType, vol.name)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
 		// Restore backup snapshots from oldest to newest.
-		for _, snapName := range srcBackup.Snapshots {
+		for _, snapName := range snapshots {
 			// Defend against path traversal attacks.
 			err := instancetype.ValidSnapName(snapName)
 			if err != nil {
-				return nil, nil, fmt.Errorf("Invalid snapshot name %q: %w", snapName, err)
+				return fmt.Errorf("Invalid snapshot name %q: %w", snapName, err)
 			}
 
 			snapVol, _ := vol.NewSnapshot(snapName)
@@ -351,7 +440,7 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 			srcFilePrefix = filepath.Join(snapDir, srcFilePrefix)
 			err = unpackVolume(snapVol, srcFilePrefix)
 			if err != nil {
-				return nil, nil, err
+				return err
 			}
 		}
 	}
@@ -372,13 +461,21 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 
 	err = unpackVolume(vol.Volume, srcFilePrefix)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+
+	if refresh {
+		// Delete main volume after receiving it.
+		err = d.deleteSubvolume(vol.MountPath(), true)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, copyOp := range copyOps {
 		err = d.setSubvolumeReadonlyProperty(copyOp.src, false)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
 		// Clear the target for the subvol to use.
@@ -387,7 +484,16 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 		// Move unpacked subvolume into its final location.
 		err = os.Rename(copyOp.src, copyOp.dest)
 		if err != nil {
-			return nil, nil, err
+			return err
+		}
+
+		// Making the received subvolume writable clears its "Received UUID" field, which is needed to find
+		// the parent subvolume when applying an incremental backup on top of it later on.
+		if copyOp.receivedUUID != "" {
+			err = setReceivedUUID(copyOp.dest, copyOp.receivedUUID)
+			if err != nil {
+				return fmt.Errorf("Failed setting received UUID: %w", err)
+			}
 		}
 	}
 
@@ -406,12 +512,11 @@ func (d *btrfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, sr
 		d.logger.Debug("Setting subvolume readonly", logger.Ctx{"name": v.name, "path": path})
 		err = d.setSubvolumeReadonlyProperty(path, true)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 	}
 
-	revert.Success()
-	return nil, revertHook, nil
+	return nil
 }
 
 // createVolumeFromCopy creates a volume from copy by snapshotting the parent volume.
@@ -1441,9 +1546,19 @@ func (d *btrfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *inst
 		return genericVFSBackupVolume(d, vol, tarWriter, snapshots, progressReporter)
 	}
 
-	// Optimized backup.
+	return d.backupVolume(vol, projectName, tarWriter, "", snapshots, progressReporter)
+}
+
+// BackupVolumeIncremental writes the changes made to a volume since the parent snapshot into a backup file.
+// Only the given snapshots, which must be more recent than the parent, are included.
+func (d *btrfs) BackupVolumeIncremental(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error {
+	return d.backupVolume(vol, projectName, tarWriter, parentSnapshot, snapshots, progressReporter)
+}
 
-	if len(snapshots) > 0 {
+// backupVolume writes an optimized backup of a volume into a backup file.
+// If parentSnapshot is set, the subvolumes are sent as differentials from that snapshot.
+func (d *btrfs) backupVolume(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error {
+	if len(vol.Snapshots) > 0 {
 		// Check requested snapshot match those in storage.
 		err := d.CheckVolumeSnapshots(vol.Volume, vol.Snapshots)
 		if err != nil {
@@ -1590,8 +1705,17 @@ func (d *btrfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *inst
 		return nil
 	}
 
-	// Backup snapshots if populated.
 	lastVolPath := "" // Used as parent for differential exports.
+	if parentSnapshot != "" {
+		parentVol, _ := vol.NewSnapshot(parentSnapshot)
+		if !d.isSubvolume(parentVol.MountPath()) {
+			return fmt.Errorf("Parent snapshot %q doesn't exist", parentSnapshot)
+		}
+
+		lastVolPath = parentVol.MountPath()
+	}
+
+	// Backup snapshots if populated.
 	for _, snapName := range snapshots {
 		snapVol, _ := vol.NewSnapshot(snapName)
 
@@ -2,20 +2,71 @@ package drivers
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
 	"net/url"
 	"path"
 
 	"github.com/canonical/lxd/lxd/project"
+	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/revert"
 	"github.com/canonical/lxd/shared/units"
+	"github.com/canonical/lxd/shared/validate"
 )
 
 // ValidateVolume validates the supplied volume config.
 func (d *cephobject) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
-	return d.validateVolume(vol, nil, removeUnknownKeys)
+	var rules map[string]func(value string) error
+	if vol.volType == VolumeTypeBucket {
+		rules = d.bucketRules()
+	}
+
+	return d.validateVolume(vol, rules, removeUnknownKeys)
+}
+
+// bucketRules returns the bucket config rules specific to the cephobject driver.
+func (d *cephobject) bucketRules() map[string]func(value string) error {
+	return map[string]func(value string) error{
+		// lxdmeta:generate(entities=storage-cephobject; group=bucket-conf; key=lifecycle.expiration)
+		// Objects are deleted by the RADOS Gateway once they are older than the given number of days.
+		// When {config:option}`storage-cephobject-bucket-conf:versioning` is enabled, expired objects are
+		// replaced by a delete marker and their previous versions are kept.
+		// ---
+		//  type: integer
+		//  shortdesc: Number of days after which objects expire
+		//  scope: global
+		"lifecycle.expiration": validate.Optional(validate.IsInRange(1, math.MaxInt32)),
+		// lxdmeta:generate(entities=storage-cephobject; group=bucket-conf; key=lifecycle.abort_incomplete_uploads)
+		//
+		// ---
+		//  type: integer
+		//  shortdesc: Number of days after which incomplete multipart uploads are aborted
+		//  scope: global
+		"lifecycle.abort_incomplete_uploads": validate.Optional(validate.IsInRange(1, math.MaxInt32)),
+		// lxdmeta:generate(entities=storage-cephobject; group=bucket-conf; key=versioning)
+		// When enabled, overwriting or deleting an object keeps its previous versions.
+		// Disabling versioning suspends it: existing versions are kept, but no new versions are created.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to keep previous versions of objects
+		//  scope: global
+		"versioning": validate.Optional(validate.IsBool),
+		// lxdmeta:generate(entities=storage-cephobject; group=bucket-conf; key=policy.anonymous_read)
+		// When enabled, objects in the bucket can be downloaded without an access key.
+		// Listing the bucket content still requires an access key.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether to allow anonymous read access to objects
+		//  scope: global
+		"policy.anonymous_read": validate.Optional(validate.IsBool),
+	}
 }
 
 // CreateBucket creates a new bucket.
@@ -72,10 +123,190 @@ func (d *cephobject) CreateBucket(bucket Volume) error {
 		}
 	}
 
+	// Apply the initial lifecycle, versioning and policy settings.
+	if bucket.config["lifecycle.expiration"] != "" || bucket.config["lifecycle.abort_incomplete_uploads"] != "" {
+		err = d.setBucketLifecycle(bucket, bucket.config)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shared.IsTrue(bucket.config["versioning"]) {
+		err = d.setBucketVersioning(bucket, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shared.IsTrue(bucket.config["policy.anonymous_read"]) {
+		err = d.setBucketPolicy(bucket, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	revert.Success()
 	return nil
 }
 
+// bucketOwnerCredentials returns the credentials of the radosgw user owning the bucket.
+func (d *cephobject) bucketOwnerCredentials(bucket Volume) (*S3Credentials, error) {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+
+	creds, _, err := d.radosgwadminGetUser(context.TODO(), d.radosgwBucketName(bucketName))
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting bucket user credentials: %w", err)
+	}
+
+	return creds, nil
+}
+
+// setBucketLifecycle replaces the bucket lifecycle configuration with the rules derived from the supplied config.
+// If no lifecycle rule is configured, the lifecycle configuration is removed from the bucket.
+func (d *cephobject) setBucketLifecycle(bucket Volume, config map[string]string) error {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+	storageBucketName := d.radosgwBucketName(bucketName)
+
+	creds, err := d.bucketOwnerCredentials(bucket)
+	if err != nil {
+		return err
+	}
+
+	type lifecycleRule struct {
+		ID     string `xml:"ID"`
+		Filter struct {
+			Prefix string `xml:"Prefix"`
+		} `xml:"Filter"`
+		Status     string `xml:"Status"`
+		Expiration *struct {
+			Days string `xml:"Days"`
+		} `xml:"Expiration,omitempty"`
+		AbortIncompleteMultipartUpload *struct {
+			DaysAfterInitiation string `xml:"DaysAfterInitiation"`
+		} `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	}
+
+	lifecycle := struct {
+		XMLName xml.Name        `xml:"LifecycleConfiguration"`
+		Rules   []lifecycleRule `xml:"Rule"`
+	}{}
+
+	if config["lifecycle.expiration"] != "" {
+		rule := lifecycleRule{ID: "lxd-expiration", Status: "Enabled"}
+		rule.Expiration = &struct {
+			Days string `xml:"Days"`
+		}{Days: config["lifecycle.expiration"]}
+
+		lifecycle.Rules = append(lifecycle.Rules, rule)
+	}
+
+	if config["lifecycle.abort_incomplete_uploads"] != "" {
+		rule := lifecycleRule{ID: "lxd-abort-incomplete-uploads", Status: "Enabled"}
+		rule.AbortIncompleteMultipartUpload = &struct {
+			DaysAfterInitiation string `xml:"DaysAfterInitiation"`
+		}{DaysAfterInitiation: config["lifecycle.abort_incomplete_uploads"]}
+
+		lifecycle.Rules = append(lifecycle.Rules, rule)
+	}
+
+	if len(lifecycle.Rules) == 0 {
+		err = d.s3BucketRequest(context.TODO(), *creds, http.MethodDelete, storageBucketName, "lifecycle", nil)
+		if err != nil {
+			return fmt.Errorf("Failed removing bucket lifecycle configuration: %w", err)
+		}
+
+		return nil
+	}
+
+	body, err := xml.Marshal(lifecycle)
+	if err != nil {
+		return err
+	}
+
+	err = d.s3BucketRequest(context.TODO(), *creds, http.MethodPut, storageBucketName, "lifecycle", body)
+	if err != nil {
+		return fmt.Errorf("Failed setting bucket lifecycle configuration: %w", err)
+	}
+
+	return nil
+}
+
+// setBucketVersioning enables or suspends object versioning on the bucket.
+func (d *cephobject) setBucketVersioning(bucket Volume, enabled bool) error {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+	storageBucketName := d.radosgwBucketName(bucketName)
+
+	creds, err := d.bucketOwnerCredentials(bucket)
+	if err != nil {
+		return err
+	}
+
+	// Versioning can't be disabled once it has been enabled on a bucket, only suspended.
+	versioning := struct {
+		XMLName xml.Name `xml:"VersioningConfiguration"`
+		Status  string   `xml:"Status"`
+	}{Status: "Suspended"}
+
+	if enabled {
+		versioning.Status = "Enabled"
+	}
+
+	body, err := xml.Marshal(versioning)
+	if err != nil {
+		return err
+	}
+
+	err = d.s3BucketRequest(context.TODO(), *creds, http.MethodPut, storageBucketName, "versioning", body)
+	if err != nil {
+		return fmt.Errorf("Failed setting bucket versioning: %w", err)
+	}
+
+	return nil
+}
+
+// setBucketPolicy sets or removes the bucket policy allowing anonymous read access to the bucket objects.
+func (d *cephobject) setBucketPolicy(bucket Volume, anonymousRead bool) error {
+	_, bucketName := project.StorageVolumeParts(bucket.name)
+	storageBucketName := d.radosgwBucketName(bucketName)
+
+	creds, err := d.bucketOwnerCredentials(bucket)
+	if err != nil {
+		return err
+	}
+
+	if !anonymousRead {
+		err = d.s3BucketRequest(context.TODO(), *creds, http.MethodDelete, storageBucketName, "policy", nil)
+		if err != nil {
+			return fmt.Errorf("Failed removing bucket policy: %w", err)
+		}
+
+		return nil
+	}
+
+	policy := map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []map[string]any{{
+			"Sid":       "lxd-anonymous-read",
+			"Effect":    "Allow",
+			"Principal": map[string]any{"AWS": []string{"*"}},
+			"Action":    []string{"s3:GetObject"},
+			"Resource":  []string{"arn:aws:s3:::" + storageBucketName + "/*"},
+		}},
+	}
+
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	err = d.s3BucketRequest(context.TODO(), *creds, http.MethodPut, storageBucketName, "policy", body)
+	if err != nil {
+		return fmt.Errorf("Failed setting bucket policy: %w", err)
+	}
+
+	return nil
+}
+
 // setBucketQuota sets the bucket quota.
 func (d *cephobject) setBucketQuota(bucket Volume, quotaSize string) error {
 	_, bucketName := project.StorageVolumeParts(bucket.name)
@@ -122,6 +353,35 @@ func (d *cephobject) UpdateBucket(bucket Volume, changedConfig map[string]string
 		}
 	}
 
+	_, expirationChanged := changedConfig["lifecycle.expiration"]
+	_, abortUploadsChanged := changedConfig["lifecycle.abort_incomplete_uploads"]
+	if expirationChanged || abortUploadsChanged {
+		// Both lifecycle rules are applied together, so combine the changes with the current config.
+		newConfig := maps.Clone(bucket.config)
+		maps.Copy(newConfig, changedConfig)
+
+		err := d.setBucketLifecycle(bucket, newConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	newVersioning, versioningChanged := changedConfig["versioning"]
+	if versioningChanged && shared.IsTrue(newVersioning) != shared.IsTrue(bucket.config["versioning"]) {
+		err := d.setBucketVersioning(bucket, shared.IsTrue(newVersioning))
+		if err != nil {
+			return err
+		}
+	}
+
+	newAnonymousRead, anonymousReadChanged := changedConfig["policy.anonymous_read"]
+	if anonymousReadChanged && shared.IsTrue(newAnonymousRead) != shared.IsTrue(bucket.config["policy.anonymous_read"]) {
+		err := d.setBucketPolicy(bucket, shared.IsTrue(newAnonymousRead))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
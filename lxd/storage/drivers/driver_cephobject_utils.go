@@ -1,13 +1,17 @@
 package drivers
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
+	"crypto/md5"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"net/http"
@@ -207,19 +211,40 @@ func (d *cephobject) radosgwadminSubUserDelete(ctx context.Context, user string,
 
 // s3CreateBucket creates a bucket via the S3 API using an HTTP PUT request with AWS Signature V4 authentication.
 func (d *cephobject) s3CreateBucket(ctx context.Context, creds S3Credentials, bucket string) error {
+	err := d.s3BucketRequest(ctx, creds, http.MethodPut, bucket, "", nil)
+	if err != nil {
+		return fmt.Errorf("Failed creating S3 bucket: %w", err)
+	}
+
+	return nil
+}
+
+// s3BucketRequest sends a request signed with AWS Signature V4 to a bucket or one of its sub-resources
+// (such as "lifecycle" or "policy").
+func (d *cephobject) s3BucketRequest(ctx context.Context, creds S3Credentials, method string, bucket string, subresource string, body []byte) error {
 	u, err := url.ParseRequestURI(d.config["cephobject.radosgw.endpoint"])
 	if err != nil {
 		return fmt.Errorf("Failed parsing cephobject.radosgw.endpoint: %w", err)
 	}
 
 	u.Path = path.Join(u.Path, url.PathEscape(bucket))
+	u.RawQuery = subresource
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
 
-	s3SignRequest(req, creds, "")
+	payloadHash := ""
+	if len(body) > 0 {
+		sha256Sum := sha256.Sum256(body)
+		md5Sum := md5.Sum(body)
+
+		payloadHash = hex.EncodeToString(sha256Sum[:])
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+	}
+
+	s3SignRequest(req, creds, payloadHash)
 
 	transport, err := d.s3Transport()
 	if err != nil {
@@ -230,13 +255,22 @@ func (d *cephobject) s3CreateBucket(ctx context.Context, creds S3Credentials, bu
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("Failed sending S3 create bucket request: %w", err)
+		return fmt.Errorf("Failed sending S3 request: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Failed creating S3 bucket (HTTP %d)", resp.StatusCode)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errResp := struct {
+			Code string `xml:"Code"`
+		}{}
+
+		_ = xml.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Code != "" {
+			return fmt.Errorf("S3 request failed (HTTP %d): %s", resp.StatusCode, errResp.Code)
+		}
+
+		return fmt.Errorf("S3 request failed (HTTP %d)", resp.StatusCode)
 	}
 
 	return nil
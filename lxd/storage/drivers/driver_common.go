@@ -371,6 +371,16 @@ func (d *common) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, s
 	return nil, nil, ErrNotSupported
 }
 
+// BackupVolumeIncremental writes the changes made to a volume since the parent snapshot into a backup file.
+func (d *common) BackupVolumeIncremental(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error {
+	return ErrNotSupported
+}
+
+// RefreshVolumeFromBackup applies an incremental backup on top of an existing volume.
+func (d *common) RefreshVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	return ErrNotSupported
+}
+
 // CreateVolumeFromCopy copies an existing storage volume (with or without snapshots) into a new volume.
 func (d *common) CreateVolumeFromCopy(vol VolumeCopy, srcVol VolumeCopy, allowInconsistent bool, progressReporter ioprogress.ProgressReporter) error {
 	return ErrNotSupported
@@ -20,6 +20,24 @@ func (d *dir) withoutGetVolID() Driver {
 	return newDriver
 }
 
+// bucketQuotaVolIDOffset is added to bucket IDs so that their quota project IDs don't collide with those of volumes.
+const bucketQuotaVolIDOffset = int64(1 << 31)
+
+// getQuotaVolID returns the ID used to generate the quota project ID of a volume.
+// Bucket IDs come from a different table than volume IDs and so are offset.
+func (d *dir) getQuotaVolID(vol Volume) (int64, error) {
+	volID, err := d.getVolID(vol.volType, vol.name)
+	if err != nil {
+		return -1, err
+	}
+
+	if vol.volType == VolumeTypeBucket && volID != volIDQuotaSkip {
+		return volID + bucketQuotaVolIDOffset, nil
+	}
+
+	return volID, nil
+}
+
 // setupInitialQuota enables quota on a new volume and sets with an initial quota from config.
 // Returns a revert fail function that can be used to undo this function if a subsequent step fails.
 func (d *dir) setupInitialQuota(vol Volume) (revert.Hook, error) {
@@ -30,7 +48,7 @@ func (d *dir) setupInitialQuota(vol Volume) (revert.Hook, error) {
 	volPath := vol.MountPath()
 
 	// Get the volume ID for the new volume, which is used to set project quota.
-	volID, err := d.getVolID(vol.volType, vol.name)
+	volID, err := d.getQuotaVolID(vol)
 	if err != nil {
 		return nil, err
 	}
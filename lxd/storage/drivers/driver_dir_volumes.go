@@ -197,7 +197,7 @@ func (d *dir) DeleteVolume(vol Volume, progressReporter ioprogress.ProgressRepor
 	}
 
 	// Get the volume ID for the volume, which is used to remove project quota.
-	volID, err := d.getVolID(vol.volType, vol.name)
+	volID, err := d.getQuotaVolID(vol)
 	if err != nil {
 		return err
 	}
@@ -270,7 +270,7 @@ func (d *dir) GetVolumeUsage(vol Volume) (int64, error) {
 	}
 
 	// Get the volume ID for the volume to access quota.
-	volID, err := d.getVolID(vol.volType, vol.name)
+	volID, err := d.getQuotaVolID(vol)
 	if err != nil {
 		return -1, err
 	}
@@ -326,7 +326,7 @@ func (d *dir) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, pr
 	}
 
 	// For non-VM block volumes, set filesystem quota.
-	volID, err := d.getVolID(vol.volType, vol.name)
+	volID, err := d.getQuotaVolID(vol)
 	if err != nil {
 		return err
 	}
@@ -32,6 +32,9 @@ type Info struct {
 	// Whether driver supports optimized volume backups.
 	OptimizedBackups bool
 
+	// Whether driver supports incremental optimized volume backups.
+	IncrementalBackups bool
+
 	// Whether driver generates an optimised backup header file in backup.
 	OptimizedBackupHeader bool
 
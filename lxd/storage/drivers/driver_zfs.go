@@ -143,14 +143,15 @@ func (d *zfs) Info() Info {
 		DefaultVMBlockFilesystemSize: d.defaultVMBlockFilesystemSize(),
 		OptimizedImages:              true,
 		OptimizedBackups:             true,
+		IncrementalBackups:           true,
 		PreservesInodes:              true,
 		Remote:                       d.isRemote(),
-		VolumeTypes:                  []VolumeType{VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
+		VolumeTypes:                  []VolumeType{VolumeTypeBucket, VolumeTypeCustom, VolumeTypeImage, VolumeTypeContainer, VolumeTypeVM},
 		BlockBacking:                 shared.IsTrue(d.config["volume.zfs.block_mode"]),
 		RunningCopyFreeze:            false,
 		DirectIO:                     zfsDirectIO,
 		MountedRoot:                  false,
-		Buckets:                      false,
+		Buckets:                      true,
 		PopulateParentVolumeUUID:     false,
 	}
 
@@ -787,8 +788,9 @@ func (d *zfs) patchDropBlockVolumeFilesystemExtension() error {
 	return nil
 }
 
-// patchRemoveLocalBucketDatasets removes orphaned ZFS datasets left over from before local storage bucket support was
-// dropped. Affected datasets are "<pool>/buckets" and "<pool>/deleted/buckets".
+// patchRemoveLocalBucketDatasets removes the leftovers of local storage buckets created by older versions from the
+// "<pool>/buckets" and "<pool>/deleted/buckets" datasets. The datasets are then recreated empty for the built-in S3
+// server.
 func (d *zfs) patchRemoveLocalBucketDatasets() error {
 	poolName, ok := d.config["zfs.pool_name"]
 	if !ok {
@@ -809,7 +811,7 @@ func (d *zfs) patchRemoveLocalBucketDatasets() error {
 		}
 	}
 
-	return nil
+	return d.ensureInitialDatasets(true)
 }
 
 // roundVolumeBlockSizeBytes returns sizeBytes rounded up to the next multiple
@@ -13,10 +13,12 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/canonical/lxd/lxd/archive"
 	"github.com/canonical/lxd/lxd/migration"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
 	"github.com/canonical/lxd/shared/ioprogress"
+	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/lxd/shared/units"
 )
 
@@ -565,3 +567,129 @@ func (d *zfs) delegateDataset(vol Volume, pid int) error {
 func ZFSSupportsDelegation() bool {
 	return zfsDelegate
 }
+
+// backupVolumeFile returns the path of the main volume's stream within an optimized backup file.
+func (d *zfs) backupVolumeFile(vol Volume) string {
+	fileName := "container.bin"
+	switch vol.volType {
+	case VolumeTypeVM:
+		if vol.contentType == ContentTypeFS {
+			fileName = "virtual-machine-config.bin"
+		} else {
+			fileName = "virtual-machine.bin"
+		}
+
+	case VolumeTypeCustom:
+		fileName = "volume.bin"
+	}
+
+	return "backup/" + fileName
+}
+
+// backupSnapshotFile returns the path of a snapshot's stream within an optimized backup file.
+func (d *zfs) backupSnapshotFile(vol Volume, snapName string) string {
+	prefix := "snapshots"
+	fileName := snapName + ".bin"
+	switch vol.volType {
+	case VolumeTypeVM:
+		prefix = "virtual-machine-snapshots"
+		if vol.contentType == ContentTypeFS {
+			fileName = snapName + "-config.bin"
+		}
+
+	case VolumeTypeCustom:
+		prefix = "volume-snapshots"
+	}
+
+	return "backup/" + prefix + "/" + fileName
+}
+
+// receiveBackupFile finds srcFile in an optimized backup file and receives its stream into the target dataset.
+func (d *zfs) receiveBackupFile(vol Volume, r io.ReadSeeker, unpacker []string, srcFile string, target string) error {
+	d.Logger().Debug("Unpacking optimized volume", logger.Ctx{"source": srcFile, "target": target})
+
+	targetPath := shared.VarPath("storage-pools", target)
+	tr, cancelFunc, err := archive.CompressedTarReader(d.state, context.Background(), r, unpacker, targetPath)
+	if err != nil {
+		return err
+	}
+
+	defer cancelFunc()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break // End of archive.
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if hdr.Name == srcFile {
+			// Extract the backup.
+			if vol.ContentType() == ContentTypeBlock || d.isBlockBacked(vol) {
+				err = shared.RunCommandWithFds(context.TODO(), tr, nil, "zfs", "receive", "-F", target)
+			} else {
+				err = shared.RunCommandWithFds(context.TODO(), tr, nil, "zfs", "receive", "-x", "mountpoint", "-F", target)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			cancelFunc()
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Could not find %q", srcFile)
+}
+
+// finalizeBackupDataset removes the internal snapshots left over from receiving a volume from an optimized
+// backup file and re-applies the base mount options to the volume's dataset.
+func (d *zfs) finalizeBackupDataset(vol Volume) error {
+	// Strip internal snapshots.
+	entries, err := d.getDatasets(d.dataset(vol, false), "snapshot")
+	if err != nil {
+		return err
+	}
+
+	// Remove only the internal snapshots.
+	for _, entry := range entries {
+		if strings.Contains(entry, "@snapshot-") {
+			continue
+		}
+
+		if strings.Contains(entry, "@") {
+			_, err := shared.RunCommand(context.TODO(), "zfs", "destroy", d.dataset(vol, false)+entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Re-apply the base mount options.
+	if vol.contentType == ContentTypeFS {
+		if zfsDelegate {
+			// Unset the zoned property so the mountpoint property can be updated.
+			err := d.setDatasetProperties(d.dataset(vol, false), "zoned=off")
+			if err != nil {
+				return err
+			}
+		}
+
+		err := d.setDatasetProperties(d.dataset(vol, false), "mountpoint=legacy", "canmount=noauto")
+		if err != nil {
+			return err
+		}
+
+		// Apply the blocksize.
+		err = d.setBlocksizeFromConfig(vol)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/sys/unix"
 
-	"github.com/canonical/lxd/lxd/archive"
 	"github.com/canonical/lxd/lxd/backup"
 	"github.com/canonical/lxd/lxd/instance/instancetype"
 	"github.com/canonical/lxd/lxd/instancewriter"
@@ -379,48 +378,6 @@ func (d *zfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcD
 	// Only execute the revert function if we have had an error internally.
 	revert.Add(revertHook)
 
-	// Define function to unpack a volume from a backup tarball file.
-	unpackVolume := func(v Volume, r io.ReadSeeker, unpacker []string, srcFile string, target string) error {
-		d.Logger().Debug("Unpacking optimized volume", logger.Ctx{"source": srcFile, "target": target})
-
-		targetPath := shared.VarPath("storage-pools", target)
-		tr, cancelFunc, err := archive.CompressedTarReader(d.state, context.Background(), r, unpacker, targetPath)
-		if err != nil {
-			return err
-		}
-
-		defer cancelFunc()
-
-		for {
-			hdr, err := tr.Next()
-			if err == io.EOF {
-				break // End of archive.
-			}
-
-			if err != nil {
-				return err
-			}
-
-			if hdr.Name == srcFile {
-				// Extract the backup.
-				if v.ContentType() == ContentTypeBlock || d.isBlockBacked(v) {
-					err = shared.RunCommandWithFds(context.TODO(), tr, nil, "zfs", "receive", "-F", target)
-				} else {
-					err = shared.RunCommandWithFds(context.TODO(), tr, nil, "zfs", "receive", "-x", "mountpoint", "-F", target)
-				}
-
-				if err != nil {
-					return err
-				}
-
-				cancelFunc()
-				return nil
-			}
-		}
-
-		return fmt.Errorf("Could not find %q", srcFile)
-	}
-
 	var postHook VolumePostHook
 
 	// Create a list of actual volumes to unpack.
@@ -459,88 +416,24 @@ func (d *zfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcD
 				return nil, nil, fmt.Errorf("Invalid snapshot name %q: %w", snapName, err)
 			}
 
-			prefix := "snapshots"
-			fileName := snapName + ".bin"
-			switch v.volType {
-			case VolumeTypeVM:
-				prefix = "virtual-machine-snapshots"
-				if v.contentType == ContentTypeFS {
-					fileName = snapName + "-config.bin"
-				}
-
-			case VolumeTypeCustom:
-				prefix = "volume-snapshots"
-			}
-
-			srcFile := "backup/" + prefix + "/" + fileName
 			dstSnapshot := d.dataset(v, false) + "@snapshot-" + snapName
-			err = unpackVolume(v, srcData, unpacker, srcFile, dstSnapshot)
+			err = d.receiveBackupFile(v, srcData, unpacker, d.backupSnapshotFile(v, snapName), dstSnapshot)
 			if err != nil {
 				return nil, nil, err
 			}
 		}
 
 		// Extract main volume.
-		fileName := "container.bin"
-		switch v.volType {
-		case VolumeTypeVM:
-			if v.contentType == ContentTypeFS {
-				fileName = "virtual-machine-config.bin"
-			} else {
-				fileName = "virtual-machine.bin"
-			}
-
-		case VolumeTypeCustom:
-			fileName = "volume.bin"
-		}
-
-		err = unpackVolume(v, srcData, unpacker, "backup/"+fileName, d.dataset(v, false))
+		err = d.receiveBackupFile(v, srcData, unpacker, d.backupVolumeFile(v), d.dataset(v, false))
 		if err != nil {
 			return nil, nil, err
 		}
 
-		// Strip internal snapshots.
-		entries, err := d.getDatasets(d.dataset(v, false), "snapshot")
+		err = d.finalizeBackupDataset(v)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		// Remove only the internal snapshots.
-		for _, entry := range entries {
-			if strings.Contains(entry, "@snapshot-") {
-				continue
-			}
-
-			if strings.Contains(entry, "@") {
-				_, err := shared.RunCommand(context.TODO(), "zfs", "destroy", d.dataset(v, false)+entry)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-		}
-
-		// Re-apply the base mount options.
-		if v.contentType == ContentTypeFS {
-			if zfsDelegate {
-				// Unset the zoned property so the mountpoint property can be updated.
-				err := d.setDatasetProperties(d.dataset(v, false), "zoned=off")
-				if err != nil {
-					return nil, nil, err
-				}
-			}
-
-			err := d.setDatasetProperties(d.dataset(v, false), "mountpoint=legacy", "canmount=noauto")
-			if err != nil {
-				return nil, nil, err
-			}
-
-			// Apply the blocksize.
-			err = d.setBlocksizeFromConfig(v)
-			if err != nil {
-				return nil, nil, err
-			}
-		}
-
 		// Only mount instance filesystem volumes for backup.yaml access.
 		if v.volType != VolumeTypeCustom && v.contentType != ContentTypeBlock {
 			// The import requires a mounted volume, so mount it and have it unmounted as a post hook.
@@ -563,6 +456,80 @@ func (d *zfs) CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcD
 	return postHook, cleanup, nil
 }
 
+// RefreshVolumeFromBackup applies an incremental backup on top of an existing volume.
+// The backup's parent snapshot must be the most recent snapshot of the volume.
+func (d *zfs) RefreshVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error {
+	if !*srcBackup.OptimizedStorage || srcBackup.Parent == "" {
+		return errors.New("Only optimized incremental backups can be applied to an existing volume")
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Create a list of actual volumes to unpack.
+	var vols []Volume
+	if vol.IsVMBlock() {
+		vols = append(vols, vol.NewVMBlockFilesystemVolume())
+	}
+
+	vols = append(vols, vol.Volume)
+
+	for _, v := range vols {
+		exists, err := d.datasetExists(d.dataset(v, false) + "@snapshot-" + srcBackup.Parent)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("Parent snapshot %q of the incremental backup doesn't exist", srcBackup.Parent)
+		}
+
+		// Find the compression algorithm used for backup source data.
+		_, err = srcData.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		_, _, unpacker, err := shared.DetectCompressionFile(srcData)
+		if err != nil {
+			return err
+		}
+
+		// Apply the new snapshots from oldest to newest, the first one being a delta from the parent.
+		for _, snapName := range srcBackup.Snapshots {
+			// Defend against path traversal attacks.
+			err := instancetype.ValidSnapName(snapName)
+			if err != nil {
+				return fmt.Errorf("Invalid snapshot name %q: %w", snapName, err)
+			}
+
+			dstSnapshot := d.dataset(v, false) + "@snapshot-" + snapName
+			err = d.receiveBackupFile(v, srcData, unpacker, d.backupSnapshotFile(v, snapName), dstSnapshot)
+			if err != nil {
+				return err
+			}
+
+			revert.Add(func() {
+				_, _ = shared.RunCommand(context.TODO(), "zfs", "destroy", "-r", dstSnapshot)
+			})
+		}
+
+		// Receiving the main volume rolls it back to its most recent snapshot before applying the changes.
+		err = d.receiveBackupFile(v, srcData, unpacker, d.backupVolumeFile(v), d.dataset(v, false))
+		if err != nil {
+			return err
+		}
+
+		err = d.finalizeBackupDataset(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
 // CreateVolumeFromCopy provides same-pool volume copying functionality.
 func (d *zfs) CreateVolumeFromCopy(vol VolumeCopy, srcVol VolumeCopy, allowInconsistent bool, progressReporter ioprogress.ProgressReporter) error {
 	// Revert handling
@@ -2864,9 +2831,19 @@ func (d *zfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *instan
 		return genericVFSBackupVolume(d, vol, tarWriter, snapshots, progressReporter)
 	}
 
-	// Optimized backup.
+	return d.backupVolume(vol, projectName, tarWriter, "", snapshots, progressReporter)
+}
 
-	if len(snapshots) > 0 {
+// BackupVolumeIncremental writes the changes made to a volume since the parent snapshot into a backup file.
+// Only the given snapshots, which must be more recent than the parent, are included.
+func (d *zfs) BackupVolumeIncremental(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error {
+	return d.backupVolume(vol, projectName, tarWriter, parentSnapshot, snapshots, progressReporter)
+}
+
+// backupVolume writes an optimized backup of a volume into a backup file.
+// If parentSnapshot is set, the streams are generated incrementally from that snapshot.
+func (d *zfs) backupVolume(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error {
+	if len(vol.Snapshots) > 0 {
 		// Check requested snapshot match those in storage.
 		err := d.CheckVolumeSnapshots(vol.Volume, vol.Snapshots)
 		if err != nil {
@@ -2877,7 +2854,7 @@ func (d *zfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *instan
 	// Backup VM config volumes first.
 	if vol.IsVMBlock() {
 		fsVol := NewVolumeCopy(vol.NewVMBlockFilesystemVolume())
-		err := d.BackupVolume(fsVol, projectName, tarWriter, optimized, snapshots, progressReporter)
+		err := d.backupVolume(fsVol, projectName, tarWriter, parentSnapshot, snapshots, progressReporter)
 		if err != nil {
 			return err
 		}
@@ -2935,41 +2912,33 @@ func (d *zfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *instan
 		return tmpFile.Close()
 	}
 
-	// Handle snapshots.
+	// Each stream is generated incrementally from the previous one, starting from the parent snapshot if any.
 	finalParent := ""
-	if len(snapshots) > 0 {
-		for i, snapName := range snapshots {
-			snapshot, _ := vol.NewSnapshot(snapName)
-
-			// Figure out parent and current subvolumes.
-			parent := ""
-			if i > 0 {
-				oldSnapshot, _ := vol.NewSnapshot(snapshots[i-1])
-				parent = d.dataset(oldSnapshot, false)
-			}
-
-			// Make a binary zfs backup.
-			prefix := "snapshots"
-			fileName := snapName + ".bin"
-			switch vol.volType {
-			case VolumeTypeVM:
-				prefix = "virtual-machine-snapshots"
-				if vol.contentType == ContentTypeFS {
-					fileName = snapName + "-config.bin"
-				}
+	if parentSnapshot != "" {
+		parentVol, _ := vol.NewSnapshot(parentSnapshot)
+		finalParent = d.dataset(parentVol, false)
 
-			case VolumeTypeCustom:
-				prefix = "volume-snapshots"
-			}
+		exists, err := d.datasetExists(finalParent)
+		if err != nil {
+			return err
+		}
 
-			target := "backup/" + prefix + "/" + fileName
-			err := sendToFile(d.dataset(snapshot, false), parent, target)
-			if err != nil {
-				return err
-			}
+		if !exists {
+			return fmt.Errorf("Parent snapshot %q doesn't exist", parentSnapshot)
+		}
+	}
 
-			finalParent = d.dataset(snapshot, false)
+	// Handle snapshots.
+	for _, snapName := range snapshots {
+		snapshot, _ := vol.NewSnapshot(snapName)
+
+		// Make a binary zfs backup.
+		err := sendToFile(d.dataset(snapshot, false), finalParent, d.backupSnapshotFile(vol.Volume, snapName))
+		if err != nil {
+			return err
 		}
+
+		finalParent = d.dataset(snapshot, false)
 	}
 
 	// Create a temporary read-only snapshot.
@@ -2988,20 +2957,7 @@ func (d *zfs) BackupVolume(vol VolumeCopy, projectName string, tarWriter *instan
 	}()
 
 	// Dump the container to a file.
-	fileName := "container.bin"
-	switch vol.volType {
-	case VolumeTypeVM:
-		if vol.contentType == ContentTypeFS {
-			fileName = "virtual-machine-config.bin"
-		} else {
-			fileName = "virtual-machine.bin"
-		}
-
-	case VolumeTypeCustom:
-		fileName = "volume.bin"
-	}
-
-	err = sendToFile(srcSnapshot, finalParent, "backup/"+fileName)
+	err = sendToFile(srcSnapshot, finalParent, d.backupVolumeFile(vol.Volume))
 	if err != nil {
 		return err
 	}
@@ -115,4 +115,6 @@ type Driver interface {
 	// Backup.
 	BackupVolume(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots []string, progressReporter ioprogress.ProgressReporter) error
 	CreateVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) (VolumePostHook, revert.Hook, error)
+	BackupVolumeIncremental(vol VolumeCopy, projectName string, tarWriter *instancewriter.InstanceTarWriter, parentSnapshot string, snapshots []string, progressReporter ioprogress.ProgressReporter) error
+	RefreshVolumeFromBackup(vol VolumeCopy, srcBackup backup.Info, srcData io.ReadSeeker, progressReporter ioprogress.ProgressReporter) error
 }
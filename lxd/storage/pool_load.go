@@ -25,6 +25,24 @@ const PoolIDTemporary = -1
 func volIDFuncMake(state *state.State, poolID int64) func(volType drivers.VolumeType, volName string) (int64, error) {
 	// Return a function to retrieve a volume ID for a volume Name for use in driver.
 	return func(volType drivers.VolumeType, volName string) (int64, error) {
+		// Buckets are stored separately from volumes and are only ever local to this member when they
+		// are backed by a volume.
+		if volType == drivers.VolumeTypeBucket {
+			projectName, bucketName := project.StorageVolumeParts(volName)
+
+			var bucket *db.StorageBucket
+			err := state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				var err error
+				bucket, err = tx.GetStoragePoolBucket(ctx, poolID, projectName, true, bucketName)
+				return err
+			})
+			if err != nil {
+				return -1, err
+			}
+
+			return bucket.ID, nil
+		}
+
 		volTypeID, err := VolumeTypeToDBType(volType)
 		if err != nil {
 			return -1, err
@@ -0,0 +1,375 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	timeFormat         = "20060102T150405Z"
+	dateFormat         = "20060102"
+
+	// maxClockSkew is the maximum allowed difference between the request time and the server time.
+	maxClockSkew = 15 * time.Minute
+
+	// maxPresignExpiry is the maximum validity of a presigned request (7 days).
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// Payload hash values with a special meaning.
+const (
+	unsignedPayload          = "UNSIGNED-PAYLOAD"
+	streamingPayload         = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingPayloadTrailer  = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	streamingUnsignedTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+)
+
+// Request headers and query parameters used for authentication.
+const (
+	headerContentSHA256        = "X-Amz-Content-Sha256"
+	headerDate                 = "X-Amz-Date"
+	headerDecodedContentLength = "X-Amz-Decoded-Content-Length"
+
+	queryAlgorithm     = "X-Amz-Algorithm"
+	queryCredential    = "X-Amz-Credential"
+	queryDate          = "X-Amz-Date"
+	queryExpires       = "X-Amz-Expires"
+	querySignedHeaders = "X-Amz-SignedHeaders"
+	querySignature     = "X-Amz-Signature"
+)
+
+// timeNow returns the current time, overridden in tests.
+var timeNow = time.Now
+
+// Auth represents the AWS Signature Version 4 authentication details of a request.
+type Auth struct {
+	// AccessKey is the access key the request was signed with.
+	AccessKey string
+
+	date          time.Time
+	scopeDate     string
+	region        string
+	signedHeaders []string
+	signature     string
+	payloadHash   string
+	presigned     bool
+}
+
+// ParseAuth extracts the AWS Signature Version 4 authentication details from the request,
+// either from the Authorization header or from the query string of a presigned URL.
+// The signature itself isn't checked until Verify is called.
+func ParseAuth(r *http.Request) (*Auth, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" {
+		return parseAuthHeader(r, authHeader)
+	}
+
+	if r.URL.Query().Has(querySignature) {
+		return parseAuthQuery(r)
+	}
+
+	return nil, ErrAccessDenied
+}
+
+// parseAuthHeader parses an Authorization header of the form:
+// AWS4-HMAC-SHA256 Credential=AKID/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=abcd.
+func parseAuthHeader(r *http.Request, authHeader string) (*Auth, error) {
+	if !strings.HasPrefix(authHeader, signatureAlgorithm+" ") {
+		return nil, ErrUnsupportedSignatureAlgo
+	}
+
+	fields := map[string]string{}
+	for field := range strings.SplitSeq(strings.TrimPrefix(authHeader, signatureAlgorithm+" "), ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !found {
+			return nil, ErrAuthorizationHeader
+		}
+
+		fields[key] = value
+	}
+
+	a := &Auth{
+		signature:   fields["Signature"],
+		payloadHash: r.Header.Get(headerContentSHA256),
+	}
+
+	if a.payloadHash == "" {
+		return nil, ErrMissingSecurityHeader
+	}
+
+	err := a.parseCredential(fields["Credential"])
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.parseSignedHeaders(fields["SignedHeaders"])
+	if err != nil {
+		return nil, err
+	}
+
+	dateHeader := r.Header.Get(headerDate)
+	if dateHeader == "" {
+		dateHeader = r.Header.Get("Date")
+	}
+
+	err = a.parseDate(dateHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.signature == "" {
+		return nil, ErrAuthorizationHeader
+	}
+
+	return a, nil
+}
+
+// parseAuthQuery parses the authentication details of a presigned URL.
+func parseAuthQuery(r *http.Request) (*Auth, error) {
+	query := r.URL.Query()
+
+	if query.Get(queryAlgorithm) != signatureAlgorithm {
+		return nil, ErrUnsupportedSignatureAlgo
+	}
+
+	a := &Auth{
+		signature:   query.Get(querySignature),
+		payloadHash: unsignedPayload,
+		presigned:   true,
+	}
+
+	// Presigned requests may still specify the payload hash as a signed header.
+	if r.Header.Get(headerContentSHA256) != "" {
+		a.payloadHash = r.Header.Get(headerContentSHA256)
+	}
+
+	err := a.parseCredential(query.Get(queryCredential))
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.parseSignedHeaders(query.Get(querySignedHeaders))
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.parseDate(query.Get(queryDate))
+	if err != nil {
+		return nil, err
+	}
+
+	expires, err := strconv.ParseInt(query.Get(queryExpires), 10, 64)
+	if err != nil || expires < 0 || time.Duration(expires)*time.Second > maxPresignExpiry {
+		return nil, ErrAuthorizationHeader
+	}
+
+	if timeNow().After(a.date.Add(time.Duration(expires) * time.Second)) {
+		return nil, ErrExpiredPresignRequest
+	}
+
+	return a, nil
+}
+
+// parseCredential parses a credential of the form AKID/20130524/us-east-1/s3/aws4_request.
+func (a *Auth) parseCredential(credential string) error {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[3] != "s3" || parts[4] != "aws4_request" {
+		return ErrAuthorizationHeader
+	}
+
+	a.AccessKey = parts[0]
+	a.scopeDate = parts[1]
+	a.region = parts[2]
+
+	return nil
+}
+
+// parseSignedHeaders parses a semicolon separated list of signed headers.
+func (a *Auth) parseSignedHeaders(signedHeaders string) error {
+	if signedHeaders == "" {
+		return ErrAuthorizationHeader
+	}
+
+	a.signedHeaders = strings.Split(signedHeaders, ";")
+	if !slices.Contains(a.signedHeaders, "host") {
+		return ErrAuthorizationHeader
+	}
+
+	return nil
+}
+
+// parseDate parses the request date and checks it against the credential scope.
+func (a *Auth) parseDate(date string) error {
+	var err error
+
+	a.date, err = time.Parse(timeFormat, date)
+	if err != nil {
+		a.date, err = http.ParseTime(date)
+		if err != nil {
+			return ErrMissingSecurityHeader
+		}
+	}
+
+	if a.date.UTC().Format(dateFormat) != a.scopeDate {
+		return ErrAuthorizationHeader
+	}
+
+	// Presigned requests remain valid until they expire, but neither kind of request may be dated in the future.
+	skew := timeNow().Sub(a.date)
+	if skew < -maxClockSkew || (!a.presigned && skew > maxClockSkew) {
+		return ErrRequestTimeTooSkewed
+	}
+
+	return nil
+}
+
+// Verify checks the request signature using the given secret key.
+// On success the request body is replaced with a reader which verifies the payload hash or decodes and verifies
+// chunked payloads as it is consumed. Consumers must therefore read the body until io.EOF before acting on it.
+func (a *Auth) Verify(r *http.Request, secretKey string) error {
+	signingKey := deriveSigningKey(secretKey, a.scopeDate, a.region)
+
+	canonicalRequest := a.canonicalRequest(r)
+	expected := hex.EncodeToString(hmacSHA256(signingKey, a.stringToSign(canonicalRequest)))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(a.signature)) != 1 {
+		return ErrSignatureDoesNotMatch
+	}
+
+	switch a.payloadHash {
+	case unsignedPayload:
+	case streamingPayload:
+		r.Body = newChunkedReader(r.Body, &chunkSigner{
+			signingKey:    signingKey,
+			timestamp:     a.date.UTC().Format(timeFormat),
+			scope:         a.scope(),
+			prevSignature: a.signature,
+		})
+	case streamingUnsignedTrailer:
+		r.Body = newChunkedReader(r.Body, nil)
+	case streamingPayloadTrailer:
+		return ErrUnsupportedContentEncoded
+	default:
+		expectedHash, err := hex.DecodeString(a.payloadHash)
+		if err != nil || len(expectedHash) != sha256.Size {
+			return ErrContentSHA256Mismatch
+		}
+
+		r.Body = newHashingReader(r.Body, expectedHash)
+	}
+
+	if strings.HasPrefix(a.payloadHash, "STREAMING-") {
+		decodedLength := r.Header.Get(headerDecodedContentLength)
+		if decodedLength == "" {
+			return ErrMissingContentLength
+		}
+
+		r.ContentLength, _ = strconv.ParseInt(decodedLength, 10, 64)
+	}
+
+	return nil
+}
+
+// scope returns the credential scope of the request.
+func (a *Auth) scope() string {
+	return strings.Join([]string{a.scopeDate, a.region, "s3", "aws4_request"}, "/")
+}
+
+// stringToSign returns the string to sign for the given canonical request.
+func (a *Auth) stringToSign(canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+
+	return strings.Join([]string{
+		signatureAlgorithm,
+		a.date.UTC().Format(timeFormat),
+		a.scope(),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+}
+
+// canonicalRequest builds the canonical form of the request as defined by AWS Signature Version 4.
+func (a *Auth) canonicalRequest(r *http.Request) string {
+	var headers strings.Builder
+	for _, name := range a.signedHeaders {
+		var values []string
+		if name == "host" {
+			values = []string{r.Host}
+		} else {
+			for _, value := range r.Header.Values(name) {
+				values = append(values, strings.Join(strings.Fields(value), " "))
+			}
+		}
+
+		headers.WriteString(name + ":" + strings.Join(values, ",") + "\n")
+	}
+
+	return strings.Join([]string{
+		r.Method,
+		uriEncode(r.URL.Path, false),
+		canonicalQuery(r.URL.Query(), a.presigned),
+		headers.String(),
+		strings.Join(a.signedHeaders, ";"),
+		a.payloadHash,
+	}, "\n")
+}
+
+// canonicalQuery returns the canonical query string, excluding the signature of presigned requests.
+func canonicalQuery(query url.Values, presigned bool) string {
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		if presigned && key == querySignature {
+			continue
+		}
+
+		for _, value := range values {
+			params = append(params, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+
+	slices.Sort(params)
+
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes a string as required by AWS Signature Version 4.
+// Only unreserved characters are left as-is, the forward slash is left as-is unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
+
+// deriveSigningKey derives the signing key from the secret key and the credential scope.
+func deriveSigningKey(secretKey string, date string, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+
+	return hmacSHA256(key, "aws4_request")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data using the given key.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
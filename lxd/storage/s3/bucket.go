@@ -0,0 +1,814 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/revert"
+)
+
+// Limits enforced on objects and multipart uploads.
+const (
+	maxKeyLength     = 1024
+	maxPartNumber    = 10000
+	minPartSize      = 5 * 1024 * 1024
+	maxListKeys      = 1000
+	uploadIDLength   = 64
+	objectsDirName   = "objects"
+	uploadsDirName   = "uploads"
+	tmpDirName       = "tmp"
+	uploadMetaName   = "upload.json"
+	objectDataSuffix = ".data"
+	objectMetaSuffix = ".json"
+)
+
+// objectLocks serialises the replacement of an object's data and metadata files against readers opening them.
+// The locks are striped by the first byte of the hash of the object key.
+var objectLocks [256]sync.RWMutex
+
+// Object represents the metadata of an object stored in a bucket.
+type Object struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"etag"`
+	LastModified time.Time         `json:"last_modified"`
+	Headers      map[string]string `json:"headers,omitempty"`
+}
+
+// Upload represents an in-progress multipart upload.
+type Upload struct {
+	UploadID  string            `json:"upload_id"`
+	Key       string            `json:"key"`
+	Initiated time.Time         `json:"initiated"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// Part represents an uploaded part of a multipart upload.
+type Part struct {
+	PartNumber   int       `json:"part_number"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// Bucket stores the objects of a bucket within a directory, usually the mount path of the bucket's volume.
+//
+// Objects are stored under a hash of their key so that arbitrary keys, including keys which are prefixes of
+// other keys, can be represented on any filesystem. Each object has a data file and a JSON metadata file.
+// Writes go to a temporary file first and are moved into place once complete, so readers never see partial objects.
+type Bucket struct {
+	Name string
+	path string
+}
+
+// NewBucket returns a bucket storing its objects in the given directory.
+func NewBucket(name string, path string) *Bucket {
+	return &Bucket{Name: name, path: path}
+}
+
+// Created returns the creation time of the bucket.
+func (b *Bucket) Created() time.Time {
+	fi, err := os.Stat(b.path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return fi.ModTime()
+}
+
+// validateKey checks that the object key is acceptable.
+func validateKey(key string) error {
+	if key == "" || !utf8.ValidString(key) {
+		return ErrInvalidArgument
+	}
+
+	if len(key) > maxKeyLength {
+		return ErrKeyTooLong
+	}
+
+	return nil
+}
+
+// objectHash returns the hash used to locate the given object key on disk.
+func objectHash(key string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(key))
+}
+
+// objectPaths returns the data and metadata file paths of the given object key.
+func (b *Bucket) objectPaths(hash [sha256.Size]byte) (dataPath string, metaPath string) {
+	hexHash := hex.EncodeToString(hash[:])
+	base := filepath.Join(b.path, objectsDirName, hexHash[:2], hexHash)
+
+	return base + objectDataSuffix, base + objectMetaSuffix
+}
+
+// createTemp creates a temporary file within the bucket, on the same filesystem as the objects.
+func (b *Bucket) createTemp() (*os.File, error) {
+	tmpDir := filepath.Join(b.path, tmpDirName)
+
+	err := os.MkdirAll(tmpDir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.CreateTemp(tmpDir, "object-")
+}
+
+// writeTemp copies the body to a new temporary file, returning its path along with the MD5 and size of its content.
+// If size isn't negative then the body must contain exactly that number of bytes.
+// If contentMD5 is set it must match the MD5 of the body.
+func (b *Bucket) writeTemp(body io.Reader, size int64, contentMD5 []byte) (string, []byte, int64, error) {
+	f, err := b.createTemp()
+	if err != nil {
+		return "", nil, -1, err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { _ = os.Remove(f.Name()) })
+
+	defer func() { _ = f.Close() }()
+
+	md5Hash := md5.New()
+
+	written, err := io.Copy(io.MultiWriter(f, md5Hash), body)
+	if err != nil {
+		return "", nil, -1, err
+	}
+
+	if size >= 0 && written != size {
+		return "", nil, -1, ErrIncompleteBody
+	}
+
+	sum := md5Hash.Sum(nil)
+	if contentMD5 != nil && !bytes.Equal(contentMD5, sum) {
+		return "", nil, -1, ErrBadDigest
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return "", nil, -1, err
+	}
+
+	reverter.Success()
+
+	return f.Name(), sum, written, nil
+}
+
+// writeJSON atomically writes the JSON representation of v to the given path.
+func (b *Bucket) writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	f, err := b.createTemp()
+	if err != nil {
+		return err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { _ = os.Remove(f.Name()) })
+
+	_, err = f.Write(data)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// readJSON reads the JSON file at the given path into v.
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// commitObject moves the given temporary data file into place as the content of the object.
+// The temporary file is removed if the object can't be committed.
+func (b *Bucket) commitObject(tmpPath string, obj *Object) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { _ = os.Remove(tmpPath) })
+
+	hash := objectHash(obj.Key)
+	dataPath, metaPath := b.objectPaths(hash)
+
+	metaTmp, err := b.createTemp()
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = os.Remove(metaTmp.Name()) })
+
+	err = json.NewEncoder(metaTmp).Encode(obj)
+	if err != nil {
+		_ = metaTmp.Close()
+		return err
+	}
+
+	err = metaTmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dataPath), 0o700)
+	if err != nil {
+		return err
+	}
+
+	lock := &objectLocks[hash[0]]
+	lock.Lock()
+	defer lock.Unlock()
+
+	err = os.Rename(tmpPath, dataPath)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(metaTmp.Name(), metaPath)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// PutObject stores the object read from body, replacing any existing object with the same key.
+// The headers are stored alongside the object and returned when it is retrieved.
+func (b *Bucket) PutObject(key string, headers map[string]string, body io.Reader, size int64, contentMD5 []byte) (*Object, error) {
+	err := validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPath, sum, written, err := b.writeTemp(body, size, contentMD5)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &Object{
+		Key:          key,
+		Size:         written,
+		ETag:         hex.EncodeToString(sum),
+		LastModified: time.Now().UTC(),
+		Headers:      headers,
+	}
+
+	err = b.commitObject(tmpPath, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// HeadObject returns the metadata of the object.
+func (b *Bucket) HeadObject(key string) (*Object, error) {
+	err := validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := objectHash(key)
+	_, metaPath := b.objectPaths(hash)
+
+	lock := &objectLocks[hash[0]]
+	lock.RLock()
+	defer lock.RUnlock()
+
+	var obj Object
+
+	err = readJSON(metaPath, &obj)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoSuchKey
+		}
+
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// GetObject returns the metadata of the object along with an open file for its content.
+// The caller is responsible for closing the file.
+func (b *Bucket) GetObject(key string) (*Object, *os.File, error) {
+	err := validateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash := objectHash(key)
+	dataPath, metaPath := b.objectPaths(hash)
+
+	lock := &objectLocks[hash[0]]
+	lock.RLock()
+	defer lock.RUnlock()
+
+	var obj Object
+
+	err = readJSON(metaPath, &obj)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrNoSuchKey
+		}
+
+		return nil, nil, err
+	}
+
+	f, err := os.Open(dataPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrNoSuchKey
+		}
+
+		return nil, nil, err
+	}
+
+	return &obj, f, nil
+}
+
+// DeleteObject removes the object. Deleting an object which doesn't exist isn't an error.
+func (b *Bucket) DeleteObject(key string) error {
+	err := validateKey(key)
+	if err != nil {
+		return err
+	}
+
+	hash := objectHash(key)
+	dataPath, metaPath := b.objectPaths(hash)
+
+	lock := &objectLocks[hash[0]]
+	lock.Lock()
+	defer lock.Unlock()
+
+	err = os.Remove(metaPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	err = os.Remove(dataPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// objects returns the metadata of all objects in the bucket sorted by key.
+func (b *Bucket) objects() ([]Object, error) {
+	var objects []Object
+
+	objectsDir := filepath.Join(b.path, objectsDirName)
+	err := filepath.WalkDir(objectsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, objectMetaSuffix) {
+			return nil
+		}
+
+		var obj Object
+
+		err = readJSON(path, &obj)
+		if err != nil {
+			// The object may have been deleted since the directory was read.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return fmt.Errorf("Failed reading object metadata %q: %w", path, err)
+		}
+
+		objects = append(objects, obj)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(objects, func(a Object, b Object) int { return strings.Compare(a.Key, b.Key) })
+
+	return objects, nil
+}
+
+// ListResult contains the result of listing the objects of a bucket.
+type ListResult struct {
+	Objects        []Object
+	CommonPrefixes []string
+	IsTruncated    bool
+
+	// NextMarker is the last key or common prefix returned when the result is truncated.
+	NextMarker string
+}
+
+// ListObjects lists the objects whose keys begin with prefix and sort after marker, in key order.
+// When a delimiter is specified, keys containing the delimiter after the prefix are rolled up into a single
+// common prefix. At most maxKeys objects and common prefixes are returned.
+func (b *Bucket) ListObjects(prefix string, delimiter string, marker string, maxKeys int) (*ListResult, error) {
+	objects, err := b.objects()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{}
+	count := 0
+	lastPrefix := ""
+
+	for _, obj := range objects {
+		if !strings.HasPrefix(obj.Key, prefix) || obj.Key <= marker {
+			continue
+		}
+
+		// Skip the keys belonging to a common prefix which was returned as the marker.
+		if delimiter != "" && strings.HasSuffix(marker, delimiter) && strings.HasPrefix(obj.Key, marker) {
+			continue
+		}
+
+		commonPrefix := ""
+		if delimiter != "" {
+			idx := strings.Index(obj.Key[len(prefix):], delimiter)
+			if idx >= 0 {
+				commonPrefix = obj.Key[:len(prefix)+idx+len(delimiter)]
+			}
+		}
+
+		if commonPrefix != "" && commonPrefix == lastPrefix {
+			continue
+		}
+
+		if count >= maxKeys {
+			result.IsTruncated = true
+			break
+		}
+
+		if commonPrefix != "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix)
+			result.NextMarker = commonPrefix
+			lastPrefix = commonPrefix
+		} else {
+			result.Objects = append(result.Objects, obj)
+			result.NextMarker = obj.Key
+		}
+
+		count++
+	}
+
+	if !result.IsTruncated {
+		result.NextMarker = ""
+	}
+
+	return result, nil
+}
+
+// validateUploadID checks that the upload ID has the expected format, as it is used as a directory name.
+func validateUploadID(uploadID string) error {
+	_, err := hex.DecodeString(uploadID)
+	if err != nil || len(uploadID) != uploadIDLength {
+		return ErrNoSuchUpload
+	}
+
+	return nil
+}
+
+// uploadPath returns the directory used to store the parts of a multipart upload.
+func (b *Bucket) uploadPath(uploadID string) string {
+	return filepath.Join(b.path, uploadsDirName, uploadID)
+}
+
+// CreateMultipartUpload starts a new multipart upload for the given object key.
+func (b *Bucket) CreateMultipartUpload(key string, headers map[string]string) (*Upload, error) {
+	err := validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadID, err := shared.RandomCryptoString()
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &Upload{
+		UploadID:  uploadID,
+		Key:       key,
+		Initiated: time.Now().UTC(),
+		Headers:   headers,
+	}
+
+	err = b.writeJSON(filepath.Join(b.uploadPath(uploadID), uploadMetaName), upload)
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// GetMultipartUpload returns the multipart upload with the given ID.
+func (b *Bucket) GetMultipartUpload(uploadID string) (*Upload, error) {
+	err := validateUploadID(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	var upload Upload
+
+	err = readJSON(filepath.Join(b.uploadPath(uploadID), uploadMetaName), &upload)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoSuchUpload
+		}
+
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// ListMultipartUploads returns the in-progress multipart uploads for keys beginning with prefix, sorted by key.
+func (b *Bucket) ListMultipartUploads(prefix string) ([]Upload, error) {
+	entries, err := os.ReadDir(filepath.Join(b.path, uploadsDirName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	uploads := []Upload{}
+	for _, entry := range entries {
+		upload, err := b.GetMultipartUpload(entry.Name())
+		if err != nil {
+			// Skip uploads being completed or aborted concurrently.
+			if errors.Is(err, ErrNoSuchUpload) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if strings.HasPrefix(upload.Key, prefix) {
+			uploads = append(uploads, *upload)
+		}
+	}
+
+	slices.SortFunc(uploads, func(a Upload, b Upload) int {
+		return strings.Compare(a.Key+"/"+a.Initiated.Format(time.RFC3339Nano), b.Key+"/"+b.Initiated.Format(time.RFC3339Nano))
+	})
+
+	return uploads, nil
+}
+
+// UploadPart stores a part of a multipart upload, replacing any previous part with the same number.
+func (b *Bucket) UploadPart(uploadID string, partNumber int, body io.Reader, size int64, contentMD5 []byte) (*Part, error) {
+	if partNumber < 1 || partNumber > maxPartNumber {
+		return nil, ErrInvalidArgument
+	}
+
+	_, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpPath, sum, written, err := b.writeTemp(body, size, contentMD5)
+	if err != nil {
+		return nil, err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { _ = os.Remove(tmpPath) })
+
+	part := &Part{
+		PartNumber:   partNumber,
+		Size:         written,
+		ETag:         hex.EncodeToString(sum),
+		LastModified: time.Now().UTC(),
+	}
+
+	partPath := filepath.Join(b.uploadPath(uploadID), "part."+strconv.Itoa(partNumber))
+
+	err = os.Rename(tmpPath, partPath+objectDataSuffix)
+	if err != nil {
+		// The upload may have been aborted in the meantime.
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNoSuchUpload
+		}
+
+		return nil, err
+	}
+
+	reverter.Success()
+
+	err = b.writeJSON(partPath+objectMetaSuffix, part)
+	if err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+// ListParts returns the parts uploaded so far for the multipart upload, sorted by part number.
+func (b *Bucket) ListParts(uploadID string) ([]Part, error) {
+	_, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(b.uploadPath(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []Part{}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "part.") || !strings.HasSuffix(entry.Name(), objectMetaSuffix) {
+			continue
+		}
+
+		var part Part
+
+		err := readJSON(filepath.Join(b.uploadPath(uploadID), entry.Name()), &part)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, part)
+	}
+
+	slices.SortFunc(parts, func(a Part, b Part) int { return a.PartNumber - b.PartNumber })
+
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the given parts into the final object and removes the upload.
+// The parts must be listed in ascending order and their ETags must match the uploaded parts.
+func (b *Bucket) CompleteMultipartUpload(uploadID string, completed []Part) (*Object, error) {
+	upload, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(completed) == 0 {
+		return nil, ErrMalformedXML
+	}
+
+	for i := 1; i < len(completed); i++ {
+		if completed[i].PartNumber <= completed[i-1].PartNumber {
+			return nil, ErrInvalidPartOrder
+		}
+	}
+
+	uploaded, err := b.ListParts(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	partsByNumber := make(map[int]Part, len(uploaded))
+	for _, part := range uploaded {
+		partsByNumber[part.PartNumber] = part
+	}
+
+	tmp, err := b.createTemp()
+	if err != nil {
+		return nil, err
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	reverter.Add(func() { _ = os.Remove(tmp.Name()) })
+
+	defer func() { _ = tmp.Close() }()
+
+	md5Hash := md5.New()
+	var size int64
+
+	for i, completedPart := range completed {
+		part, found := partsByNumber[completedPart.PartNumber]
+		if !found || strings.Trim(completedPart.ETag, `"`) != part.ETag {
+			return nil, ErrInvalidPart
+		}
+
+		if i < len(completed)-1 && part.Size < minPartSize {
+			return nil, ErrEntityTooSmall
+		}
+
+		err = appendFile(tmp, filepath.Join(b.uploadPath(uploadID), "part."+strconv.Itoa(part.PartNumber)+objectDataSuffix))
+		if err != nil {
+			return nil, err
+		}
+
+		sum, err := hex.DecodeString(part.ETag)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _ = md5Hash.Write(sum)
+		size += part.Size
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &Object{
+		Key:          upload.Key,
+		Size:         size,
+		ETag:         fmt.Sprintf("%s-%d", hex.EncodeToString(md5Hash.Sum(nil)), len(completed)),
+		LastModified: time.Now().UTC(),
+		Headers:      upload.Headers,
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	reverter.Success()
+
+	err = b.commitObject(tmp.Name(), obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.RemoveAll(b.uploadPath(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// appendFile appends the content of the file at the given path to f.
+func appendFile(f *os.File, path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = src.Close() }()
+
+	_, err = io.Copy(f, src)
+
+	return err
+}
+
+// AbortMultipartUpload removes the multipart upload along with any uploaded parts.
+func (b *Bucket) AbortMultipartUpload(uploadID string) error {
+	_, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(b.uploadPath(uploadID))
+}
@@ -0,0 +1,177 @@
+package s3
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketObjects(t *testing.T) {
+	b := NewBucket("foo", t.TempDir())
+
+	obj, err := b.PutObject("a/b", map[string]string{"Content-Type": "text/plain"}, strings.NewReader("hello"), 5, nil)
+	require.NoError(t, err)
+
+	sum := md5.Sum([]byte("hello"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), obj.ETag)
+
+	// Keys which are prefixes of other keys are allowed.
+	_, err = b.PutObject("a", nil, strings.NewReader("x"), -1, nil)
+	require.NoError(t, err)
+
+	got, f, err := b.GetObject("a/b")
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	_ = f.Close()
+
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "text/plain", got.Headers["Content-Type"])
+
+	// Size and digest mismatches are rejected without replacing the object.
+	_, err = b.PutObject("a/b", nil, strings.NewReader("hello"), 6, nil)
+	assert.ErrorIs(t, err, ErrIncompleteBody)
+
+	_, err = b.PutObject("a/b", nil, strings.NewReader("bye"), -1, sum[:])
+	assert.ErrorIs(t, err, ErrBadDigest)
+
+	got, err = b.HeadObject("a/b")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), got.Size)
+
+	require.NoError(t, b.DeleteObject("a/b"))
+	require.NoError(t, b.DeleteObject("a/b"))
+
+	_, err = b.HeadObject("a/b")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+}
+
+func TestBucketListObjects(t *testing.T) {
+	b := NewBucket("foo", t.TempDir())
+
+	for _, key := range []string{"a", "b/1", "b/2", "c/1", "c/2/x", "d"} {
+		_, err := b.PutObject(key, nil, strings.NewReader(key), -1, nil)
+		require.NoError(t, err)
+	}
+
+	keys := func(result *ListResult) []string {
+		var out []string
+		for _, obj := range result.Objects {
+			out = append(out, obj.Key)
+		}
+
+		return out
+	}
+
+	result, err := b.ListObjects("", "", "", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b/1", "b/2", "c/1", "c/2/x", "d"}, keys(result))
+	assert.False(t, result.IsTruncated)
+
+	result, err = b.ListObjects("", "/", "", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "d"}, keys(result))
+	assert.Equal(t, []string{"b/", "c/"}, result.CommonPrefixes)
+
+	result, err = b.ListObjects("c/", "/", "", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c/1"}, keys(result))
+	assert.Equal(t, []string{"c/2/"}, result.CommonPrefixes)
+
+	// Paginate through the rolled up listing two entries at a time.
+	var pages [][]string
+	marker := ""
+	for {
+		result, err = b.ListObjects("", "/", marker, 2)
+		require.NoError(t, err)
+
+		pages = append(pages, append(keys(result), result.CommonPrefixes...))
+		if !result.IsTruncated {
+			break
+		}
+
+		marker = result.NextMarker
+	}
+
+	assert.Equal(t, [][]string{{"a", "b/"}, {"d", "c/"}}, pages)
+}
+
+func TestBucketMultipartUpload(t *testing.T) {
+	b := NewBucket("foo", t.TempDir())
+
+	upload, err := b.CreateMultipartUpload("big", map[string]string{"Content-Type": "application/x-tar"})
+	require.NoError(t, err)
+
+	part1Data := strings.Repeat("a", minPartSize)
+	part1, err := b.UploadPart(upload.UploadID, 1, strings.NewReader(part1Data), -1, nil)
+	require.NoError(t, err)
+
+	part2, err := b.UploadPart(upload.UploadID, 2, strings.NewReader("tail"), -1, nil)
+	require.NoError(t, err)
+
+	parts, err := b.ListParts(upload.UploadID)
+	require.NoError(t, err)
+	assert.Len(t, parts, 2)
+
+	_, err = b.CompleteMultipartUpload(upload.UploadID, []Part{{PartNumber: 2, ETag: part2.ETag}, {PartNumber: 1, ETag: part1.ETag}})
+	assert.ErrorIs(t, err, ErrInvalidPartOrder)
+
+	_, err = b.CompleteMultipartUpload(upload.UploadID, []Part{{PartNumber: 1, ETag: "bad"}})
+	assert.ErrorIs(t, err, ErrInvalidPart)
+
+	obj, err := b.CompleteMultipartUpload(upload.UploadID, []Part{{PartNumber: 1, ETag: `"` + part1.ETag + `"`}, {PartNumber: 2, ETag: part2.ETag}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(minPartSize+4), obj.Size)
+	assert.True(t, strings.HasSuffix(obj.ETag, "-2"))
+	assert.Equal(t, "application/x-tar", obj.Headers["Content-Type"])
+
+	_, err = b.GetMultipartUpload(upload.UploadID)
+	assert.ErrorIs(t, err, ErrNoSuchUpload)
+
+	// Upload IDs are used as paths and must be validated.
+	_, err = b.GetMultipartUpload("../objects")
+	assert.ErrorIs(t, err, ErrNoSuchUpload)
+}
+
+func TestBucketServe(t *testing.T) {
+	b := NewBucket("foo", t.TempDir())
+
+	serve := func(method string, target string, body string, readOnly bool) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		b.Serve(w, httptest.NewRequest(method, target, strings.NewReader(body)), readOnly)
+
+		return w
+	}
+
+	w := serve(http.MethodPut, "/foo/dir/file.txt", "hello world", false)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(http.MethodPut, "/foo/dir/file.txt", "hello world", true)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serve(http.MethodGet, "/bar/dir/file.txt", "", false)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serve(http.MethodGet, "/foo/dir/file.txt", "", true)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello world", w.Body.String())
+
+	w = serve(http.MethodGet, "/foo?list-type=2&delimiter=/", "", true)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<CommonPrefixes><Prefix>dir/</Prefix></CommonPrefixes>")
+
+	w = serve(http.MethodGet, "/foo/missing", "", true)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "<Code>NoSuchKey</Code>")
+
+	w = serve(http.MethodGet, "/foo?policy", "", true)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
@@ -0,0 +1,227 @@
+package s3
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxChunkHeaderLength is the maximum length of a chunk header or trailer line in an aws-chunked payload.
+const maxChunkHeaderLength = 4096
+
+// emptySHA256 is the hex encoded SHA256 hash of an empty payload.
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// hashingReader verifies the SHA256 hash of the payload once it has been fully read.
+type hashingReader struct {
+	body     io.ReadCloser
+	hash     hash.Hash
+	expected []byte
+}
+
+func newHashingReader(body io.ReadCloser, expected []byte) *hashingReader {
+	return &hashingReader{body: body, hash: sha256.New(), expected: expected}
+}
+
+// Read implements io.Reader.
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.body.Read(p)
+	_, _ = h.hash.Write(p[:n])
+
+	if errors.Is(err, io.EOF) && subtle.ConstantTimeCompare(h.hash.Sum(nil), h.expected) != 1 {
+		return n, ErrContentSHA256Mismatch
+	}
+
+	return n, err
+}
+
+// Close implements io.Closer.
+func (h *hashingReader) Close() error {
+	return h.body.Close()
+}
+
+// chunkSigner verifies the signatures of the chunks of a streaming payload.
+// Each chunk signature is chained to the signature of the previous chunk, starting with the request signature.
+type chunkSigner struct {
+	signingKey    []byte
+	timestamp     string
+	scope         string
+	prevSignature string
+}
+
+// verify checks the signature of a chunk given the SHA256 hash of its data.
+func (s *chunkSigner) verify(chunkHash []byte, signature string) error {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256-PAYLOAD",
+		s.timestamp,
+		s.scope,
+		s.prevSignature,
+		emptySHA256,
+		hex.EncodeToString(chunkHash),
+	}, "\n")
+
+	expected := hex.EncodeToString(hmacSHA256(s.signingKey, stringToSign))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return ErrSignatureDoesNotMatch
+	}
+
+	s.prevSignature = signature
+
+	return nil
+}
+
+// chunkedReader decodes an aws-chunked payload.
+// When a signer is provided, the chunk signatures are verified, otherwise the chunks are expected to be unsigned
+// and may be followed by trailing headers, which are discarded.
+type chunkedReader struct {
+	body      io.ReadCloser
+	r         *bufio.Reader
+	signer    *chunkSigner
+	hash      hash.Hash
+	signature string
+	remaining int64
+	err       error
+}
+
+func newChunkedReader(body io.ReadCloser, signer *chunkSigner) *chunkedReader {
+	return &chunkedReader{
+		body:   body,
+		r:      bufio.NewReader(body),
+		signer: signer,
+		hash:   sha256.New(),
+	}
+}
+
+// Read implements io.Reader.
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	if c.remaining == 0 {
+		c.err = c.nextChunk()
+		if c.err != nil {
+			return 0, c.err
+		}
+	}
+
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	_, _ = c.hash.Write(p[:n])
+
+	if c.remaining == 0 {
+		err = c.endChunk()
+	} else if errors.Is(err, io.EOF) {
+		err = ErrIncompleteBody
+	}
+
+	if err != nil {
+		c.err = err
+	}
+
+	return n, err
+}
+
+// Close implements io.Closer.
+func (c *chunkedReader) Close() error {
+	return c.body.Close()
+}
+
+// readLine reads a CRLF terminated line.
+func (c *chunkedReader) readLine() (string, error) {
+	var line strings.Builder
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return "", ErrIncompleteBody
+		}
+
+		if b == '\n' {
+			return strings.TrimSuffix(line.String(), "\r"), nil
+		}
+
+		if line.Len() >= maxChunkHeaderLength {
+			return "", ErrInvalidArgument
+		}
+
+		line.WriteByte(b)
+	}
+}
+
+// nextChunk parses the header of the next chunk, returning io.EOF on the final chunk.
+func (c *chunkedReader) nextChunk() error {
+	header, err := c.readLine()
+	if err != nil {
+		return err
+	}
+
+	sizeField, extension, _ := strings.Cut(header, ";")
+
+	size, err := strconv.ParseInt(sizeField, 16, 64)
+	if err != nil || size < 0 {
+		return ErrInvalidArgument
+	}
+
+	if c.signer != nil {
+		var found bool
+
+		c.signature, found = strings.CutPrefix(extension, "chunk-signature=")
+		if !found {
+			return ErrSignatureDoesNotMatch
+		}
+	}
+
+	c.hash.Reset()
+	c.remaining = size
+
+	if size > 0 {
+		return nil
+	}
+
+	// The final chunk is empty and is followed by optional trailers and an empty line.
+	if c.signer != nil {
+		err = c.signer.verify(c.hash.Sum(nil), c.signature)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+
+		if line == "" {
+			return io.EOF
+		}
+	}
+}
+
+// endChunk consumes the CRLF terminating a chunk's data and verifies its signature.
+func (c *chunkedReader) endChunk() error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+
+	if line != "" {
+		return ErrInvalidArgument
+	}
+
+	if c.signer != nil {
+		return c.signer.verify(c.hash.Sum(nil), c.signature)
+	}
+
+	return nil
+}
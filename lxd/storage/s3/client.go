@@ -0,0 +1,278 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// clientPartSize is the size of the parts used by the client for multipart uploads, overridden in tests.
+var clientPartSize = 16 * 1024 * 1024
+
+// clientRegion is the region used to sign requests, S3 compatible servers not using regions accept it.
+const clientRegion = "us-east-1"
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// Client is a minimal S3 client able to store, retrieve and delete objects in a single bucket.
+// Requests are signed using AWS Signature Version 4 and use path-style addressing.
+type Client struct {
+	endpoint   *url.URL
+	bucket     string
+	prefix     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the bucket referenced by the given URL.
+// The URL is of the form "https://<host>[:<port>]/<bucket>[/<prefix>]", object keys used with the client are
+// relative to the prefix.
+func NewClient(bucketURL string, accessKey string, secretKey string, transport http.RoundTripper) (*Client, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing S3 URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("Unsupported S3 URL scheme %q", u.Scheme)
+	}
+
+	bucket, prefix := SplitPath(u.Path)
+	if bucket == "" {
+		return nil, errors.New("S3 URL is missing the bucket name")
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Client{
+		endpoint:   &url.URL{Scheme: u.Scheme, Host: u.Host},
+		bucket:     bucket,
+		prefix:     strings.Trim(prefix, "/"),
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+// objectKey returns the full object key in the bucket of the given key.
+func (c *Client) objectKey(key string) string {
+	if c.prefix == "" {
+		return key
+	}
+
+	return c.prefix + "/" + key
+}
+
+// URL returns the URL of the object with the given key.
+func (c *Client) URL(key string) string {
+	u := *c.endpoint
+	u.Path = "/" + path.Join(c.bucket, c.objectKey(key))
+
+	return u.String()
+}
+
+// do sends a signed request for the given object key and returns the response if the request succeeded.
+// The caller is responsible for closing the response body.
+func (c *Client) do(ctx context.Context, method string, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := *c.endpoint
+	u.Path = "/" + c.bucket
+	if key != "" {
+		u.Path += "/" + c.objectKey(key)
+	}
+
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query, false)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.sign(req, body)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed sending S3 request: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+
+		s3Err := &Error{StatusCode: resp.StatusCode}
+		_ = xml.NewDecoder(resp.Body).Decode(s3Err)
+		if s3Err.Code == "" {
+			s3Err.Code = http.StatusText(resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("S3 request failed (HTTP %d): %w", resp.StatusCode, s3Err)
+	}
+
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 authorization header to the request.
+func (c *Client) sign(req *http.Request, body []byte) {
+	payloadHash := sha256.Sum256(body)
+
+	a := &Auth{
+		AccessKey:     c.accessKey,
+		date:          timeNow().UTC(),
+		region:        clientRegion,
+		signedHeaders: []string{"host", strings.ToLower(headerContentSHA256), strings.ToLower(headerDate)},
+		payloadHash:   hex.EncodeToString(payloadHash[:]),
+	}
+
+	a.scopeDate = a.date.Format(dateFormat)
+
+	req.Host = req.URL.Host
+	req.Header.Set(headerContentSHA256, a.payloadHash)
+	req.Header.Set(headerDate, a.date.Format(timeFormat))
+
+	signingKey := deriveSigningKey(c.secretKey, a.scopeDate, a.region)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, a.stringToSign(a.canonicalRequest(req))))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s,SignedHeaders=%s,Signature=%s", signatureAlgorithm, a.AccessKey, a.scope(), strings.Join(a.signedHeaders, ";"), signature))
+}
+
+// PutObject uploads the data read from r as the object with the given key and returns the object size.
+// The data is streamed using a multipart upload once it exceeds the size of a single part, so its size
+// doesn't need to be known in advance.
+func (c *Client) PutObject(ctx context.Context, key string, r io.Reader) (int64, error) {
+	buf := make([]byte, clientPartSize)
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, err
+	}
+
+	// Small objects are uploaded with a single request.
+	if n < clientPartSize {
+		resp, err := c.do(ctx, http.MethodPut, key, nil, buf[:n])
+		if err != nil {
+			return 0, err
+		}
+
+		_ = resp.Body.Close()
+
+		return int64(n), nil
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": []string{""}}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("Failed creating multipart upload: %w", err)
+	}
+
+	upload := initiateMultipartUploadResult{}
+	err = xml.NewDecoder(resp.Body).Decode(&upload)
+	_ = resp.Body.Close()
+	if err != nil {
+		return 0, fmt.Errorf("Failed parsing multipart upload: %w", err)
+	}
+
+	size, err := c.uploadParts(ctx, key, upload.UploadID, buf[:n], r)
+	if err != nil {
+		// Abort the upload so that the uploaded parts don't linger in the bucket.
+		resp, abortErr := c.do(context.Background(), http.MethodDelete, key, url.Values{"uploadId": []string{upload.UploadID}}, nil)
+		if abortErr == nil {
+			_ = resp.Body.Close()
+		}
+
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// uploadParts uploads the first part and the remaining data of r as parts of the given multipart upload and
+// completes it.
+func (c *Client) uploadParts(ctx context.Context, key string, uploadID string, first []byte, r io.Reader) (int64, error) {
+	var size int64
+	var parts []completedPart
+
+	data := first
+	for partNumber := 1; len(data) > 0; partNumber++ {
+		query := url.Values{"partNumber": []string{strconv.Itoa(partNumber)}, "uploadId": []string{uploadID}}
+
+		resp, err := c.do(ctx, http.MethodPut, key, query, data)
+		if err != nil {
+			return 0, fmt.Errorf("Failed uploading part %d: %w", partNumber, err)
+		}
+
+		_ = resp.Body.Close()
+
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: resp.Header.Get("ETag")})
+		size += int64(len(data))
+
+		n, err := io.ReadFull(r, first[:cap(first)])
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, err
+		}
+
+		data = first[:n]
+	}
+
+	body, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": []string{uploadID}}, body)
+	if err != nil {
+		return 0, fmt.Errorf("Failed completing multipart upload: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	// The completion may fail after the response status was sent, in which case the body holds the error.
+	s3Err := &Error{}
+	err = xml.NewDecoder(resp.Body).Decode(s3Err)
+	if err == nil && s3Err.Code != "" {
+		return 0, fmt.Errorf("Failed completing multipart upload: %w", s3Err)
+	}
+
+	return size, nil
+}
+
+// GetObject returns a reader for the object with the given key and its size.
+// The caller is responsible for closing the reader.
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
+// DeleteObject deletes the object with the given key. Deleting a missing object isn't an error.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	b := NewBucket("foo", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, err := ParseAuth(r)
+		if err == nil && auth.AccessKey != exampleAccessKey {
+			err = ErrInvalidAccessKeyID
+		}
+
+		if err == nil {
+			err = auth.Verify(r, exampleSecretKey)
+		}
+
+		if err != nil {
+			AsError(err).Response(w)
+			return
+		}
+
+		b.Serve(w, r, false)
+	}))
+	defer server.Close()
+
+	clientPartSize = minPartSize
+	t.Cleanup(func() { clientPartSize = 16 * 1024 * 1024 })
+
+	c, err := NewClient(server.URL+"/foo/backups/", exampleAccessKey, exampleSecretKey, nil)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/foo/backups/a%20b", c.URL("a b"))
+
+	ctx := context.Background()
+
+	// Single request upload, using a key which needs to be encoded.
+	size, err := c.PutObject(ctx, "a b/c+d", bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	_, err = b.HeadObject("backups/a b/c+d")
+	require.NoError(t, err)
+
+	// Multipart upload.
+	data := bytes.Repeat([]byte("x"), 2*minPartSize+10)
+	size, err = c.PutObject(ctx, "big", bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+
+	r, size, err := c.GetObject(ctx, "big")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	_ = r.Close()
+	assert.Equal(t, data, got)
+
+	uploads, err := b.ListMultipartUploads("")
+	require.NoError(t, err)
+	assert.Empty(t, uploads)
+
+	require.NoError(t, c.DeleteObject(ctx, "big"))
+
+	_, _, err = c.GetObject(ctx, "big")
+	require.Error(t, err)
+	assert.Equal(t, ErrNoSuchKey.Code, AsError(err).Code)
+
+	// Wrong credentials.
+	c, err = NewClient(server.URL+"/foo", exampleAccessKey, "wrong", nil)
+	require.NoError(t, err)
+
+	_, err = c.PutObject(ctx, "a", bytes.NewReader(nil))
+	require.Error(t, err)
+	assert.Equal(t, ErrSignatureDoesNotMatch.Code, AsError(err).Code)
+
+	_, err = NewClient(server.URL, exampleAccessKey, exampleSecretKey, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"syscall"
+)
+
+// Error represents an S3 API error.
+type Error struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+	Resource   string   `xml:"Resource,omitempty"`
+	BucketName string   `xml:"BucketName,omitempty"`
+
+	StatusCode int `xml:"-"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Response writes the error to the given response writer in the S3 XML format.
+func (e *Error) Response(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(e.StatusCode)
+
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(e)
+}
+
+// newError returns a new S3 error with the given code, HTTP status code and message.
+func newError(code string, statusCode int, message string) *Error {
+	return &Error{Code: code, StatusCode: statusCode, Message: message}
+}
+
+// Common S3 errors.
+var (
+	ErrAccessDenied              = newError("AccessDenied", http.StatusForbidden, "Access Denied")
+	ErrAuthorizationHeader       = newError("AuthorizationHeaderMalformed", http.StatusBadRequest, "The authorization header is malformed")
+	ErrBadDigest                 = newError("BadDigest", http.StatusBadRequest, "The Content-MD5 you specified did not match what we received")
+	ErrContentSHA256Mismatch     = newError("XAmzContentSHA256Mismatch", http.StatusBadRequest, "The provided 'x-amz-content-sha256' header does not match what was computed")
+	ErrEntityTooSmall            = newError("EntityTooSmall", http.StatusBadRequest, "Your proposed upload is smaller than the minimum allowed object size")
+	ErrExpiredPresignRequest     = newError("AccessDenied", http.StatusForbidden, "Request has expired")
+	ErrIncompleteBody            = newError("IncompleteBody", http.StatusBadRequest, "You did not provide the number of bytes specified by the Content-Length HTTP header")
+	ErrInternalError             = newError("InternalError", http.StatusInternalServerError, "We encountered an internal error, please try again")
+	ErrInvalidAccessKeyID        = newError("InvalidAccessKeyId", http.StatusForbidden, "The access key ID you provided does not exist in our records")
+	ErrInvalidArgument           = newError("InvalidArgument", http.StatusBadRequest, "Invalid argument")
+	ErrInvalidPart               = newError("InvalidPart", http.StatusBadRequest, "One or more of the specified parts could not be found")
+	ErrInvalidPartOrder          = newError("InvalidPartOrder", http.StatusBadRequest, "The list of parts was not in ascending order")
+	ErrInvalidRange              = newError("InvalidRange", http.StatusRequestedRangeNotSatisfiable, "The requested range is not satisfiable")
+	ErrKeyTooLong                = newError("KeyTooLongError", http.StatusBadRequest, "Your key is too long")
+	ErrMalformedXML              = newError("MalformedXML", http.StatusBadRequest, "The XML you provided was not well-formed or did not validate against our published schema")
+	ErrMethodNotAllowed          = newError("MethodNotAllowed", http.StatusMethodNotAllowed, "The specified method is not allowed against this resource")
+	ErrMissingContentLength      = newError("MissingContentLength", http.StatusLengthRequired, "You must provide the Content-Length HTTP header")
+	ErrMissingSecurityHeader     = newError("MissingSecurityHeader", http.StatusBadRequest, "Your request is missing a required header")
+	ErrNoSuchBucket              = newError("NoSuchBucket", http.StatusNotFound, "The specified bucket does not exist")
+	ErrNoSuchKey                 = newError("NoSuchKey", http.StatusNotFound, "The specified key does not exist")
+	ErrNoSuchUpload              = newError("NoSuchUpload", http.StatusNotFound, "The specified multipart upload does not exist")
+	ErrNotImplemented            = newError("NotImplemented", http.StatusNotImplemented, "A header or query you provided implies functionality that is not implemented")
+	ErrQuotaExceeded             = newError("QuotaExceeded", http.StatusInsufficientStorage, "The bucket has reached its size limit")
+	ErrRequestTimeTooSkewed      = newError("RequestTimeTooSkewed", http.StatusForbidden, "The difference between the request time and the server's time is too large")
+	ErrSignatureDoesNotMatch     = newError("SignatureDoesNotMatch", http.StatusForbidden, "The request signature we calculated does not match the signature you provided")
+	ErrUnsupportedSignatureAlgo  = newError("InvalidRequest", http.StatusBadRequest, "Only AWS4-HMAC-SHA256 signatures are supported")
+	ErrUnsupportedContentEncoded = newError("NotImplemented", http.StatusNotImplemented, "The provided payload signing mode is not supported")
+)
+
+// AsError converts the given error into an S3 error.
+// Errors that aren't already S3 errors are reported as ErrQuotaExceeded when caused by the bucket running out
+// of space and as ErrInternalError otherwise.
+func AsError(err error) *Error {
+	var s3Err *Error
+	if errors.As(err, &s3Err) {
+		return s3Err
+	}
+
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EDQUOT) {
+		return ErrQuotaExceeded
+	}
+
+	return ErrInternalError
+}
@@ -0,0 +1,740 @@
+package s3
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+)
+
+// xmlns is the XML namespace of the S3 API.
+const xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// storedHeaders are the request headers stored alongside an object and returned when it is retrieved,
+// in addition to user metadata headers (X-Amz-Meta-*).
+var storedHeaders = []string{"Cache-Control", "Content-Disposition", "Content-Encoding", "Content-Language", "Content-Type", "Expires"}
+
+// unsupportedSubresources are the bucket and object subresources which aren't implemented.
+var unsupportedSubresources = []string{"accelerate", "acl", "analytics", "attributes", "cors", "encryption", "intelligent-tiering", "inventory", "legal-hold", "lifecycle", "logging", "metrics", "notification", "object-lock", "ownershipControls", "policy", "policyStatus", "publicAccessBlock", "replication", "requestPayment", "restore", "retention", "select", "tagging", "torrent", "versions", "website"}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name       `xml:"ListAllMyBucketsResult"`
+	Xmlns   string         `xml:"xmlns,attr"`
+	Owner   owner          `xml:"Owner"`
+	Buckets []bucketResult `xml:"Buckets>Bucket"`
+}
+
+type owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type bucketResult struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type objectResult struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefixResult struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name             `xml:"ListBucketResult"`
+	Xmlns                 string               `xml:"xmlns,attr"`
+	Name                  string               `xml:"Name"`
+	Prefix                string               `xml:"Prefix"`
+	Marker                *string              `xml:"Marker,omitempty"`
+	NextMarker            string               `xml:"NextMarker,omitempty"`
+	StartAfter            string               `xml:"StartAfter,omitempty"`
+	ContinuationToken     string               `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string               `xml:"NextContinuationToken,omitempty"`
+	KeyCount              *int                 `xml:"KeyCount,omitempty"`
+	MaxKeys               int                  `xml:"MaxKeys"`
+	Delimiter             string               `xml:"Delimiter,omitempty"`
+	EncodingType          string               `xml:"EncodingType,omitempty"`
+	IsTruncated           bool                 `xml:"IsTruncated"`
+	Contents              []objectResult       `xml:"Contents"`
+	CommonPrefixes        []commonPrefixResult `xml:"CommonPrefixes"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+type versioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+type copyObjectResult struct {
+	XMLName      xml.Name `xml:"CopyObjectResult"`
+	Xmlns        string   `xml:"xmlns,attr"`
+	ETag         string   `xml:"ETag"`
+	LastModified string   `xml:"LastModified"`
+}
+
+type deleteRequest struct {
+	Quiet   bool `xml:"Quiet"`
+	Objects []struct {
+		Key string `xml:"Key"`
+	} `xml:"Object"`
+}
+
+type deletedResult struct {
+	Key string `xml:"Key"`
+}
+
+type deleteErrorResult struct {
+	Key     string `xml:"Key"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name            `xml:"DeleteResult"`
+	Xmlns   string              `xml:"xmlns,attr"`
+	Deleted []deletedResult     `xml:"Deleted"`
+	Errors  []deleteErrorResult `xml:"Error"`
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completeMultipartUploadRequest struct {
+	Parts []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+type partResult struct {
+	PartNumber   int    `xml:"PartNumber"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+}
+
+type listPartsResult struct {
+	XMLName     xml.Name     `xml:"ListPartsResult"`
+	Xmlns       string       `xml:"xmlns,attr"`
+	Bucket      string       `xml:"Bucket"`
+	Key         string       `xml:"Key"`
+	UploadID    string       `xml:"UploadId"`
+	IsTruncated bool         `xml:"IsTruncated"`
+	Parts       []partResult `xml:"Part"`
+}
+
+type uploadResult struct {
+	Key       string `xml:"Key"`
+	UploadID  string `xml:"UploadId"`
+	Initiated string `xml:"Initiated"`
+}
+
+type listMultipartUploadsResult struct {
+	XMLName     xml.Name       `xml:"ListMultipartUploadsResult"`
+	Xmlns       string         `xml:"xmlns,attr"`
+	Bucket      string         `xml:"Bucket"`
+	Prefix      string         `xml:"Prefix"`
+	IsTruncated bool           `xml:"IsTruncated"`
+	Uploads     []uploadResult `xml:"Upload"`
+}
+
+// SplitPath splits the path of a path-style S3 request into the bucket name and object key.
+func SplitPath(path string) (bucketName string, key string) {
+	bucketName, key, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	return bucketName, key
+}
+
+// formatTime formats a time as expected in S3 XML responses.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// quoteETag returns the ETag in the quoted form used in S3 responses.
+func quoteETag(etag string) string {
+	return `"` + etag + `"`
+}
+
+// writeXML writes the XML representation of v as the response.
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the S3 error representation of err as the response.
+func (b *Bucket) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	s3Err := *AsError(err)
+	if s3Err.Code == ErrInternalError.Code {
+		logger.Warn("Failed handling storage bucket request", logger.Ctx{"bucket": b.Name, "method": r.Method, "path": r.URL.Path, "err": err})
+	}
+
+	s3Err.Resource = r.URL.Path
+	s3Err.BucketName = b.Name
+	s3Err.Response(w)
+}
+
+// Serve handles a path-style S3 API request against the bucket.
+// The request must already have been authenticated. If readOnly is set only requests that don't modify the
+// bucket are allowed.
+func (b *Bucket) Serve(w http.ResponseWriter, r *http.Request, readOnly bool) {
+	if readOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		b.writeError(w, r, ErrAccessDenied)
+		return
+	}
+
+	query := r.URL.Query()
+	for _, subresource := range unsupportedSubresources {
+		if query.Has(subresource) {
+			b.writeError(w, r, ErrNotImplemented)
+			return
+		}
+	}
+
+	bucketName, key := SplitPath(r.URL.Path)
+	if bucketName == "" {
+		if r.Method != http.MethodGet {
+			b.writeError(w, r, ErrMethodNotAllowed)
+			return
+		}
+
+		b.listBuckets(w)
+		return
+	}
+
+	if bucketName != b.Name {
+		b.writeError(w, r, ErrAccessDenied)
+		return
+	}
+
+	var err error
+	if key == "" {
+		err = b.serveBucket(w, r)
+	} else {
+		err = b.serveObject(w, r, key)
+	}
+
+	if err != nil {
+		b.writeError(w, r, err)
+	}
+}
+
+// listBuckets handles ListBuckets, only listing this bucket.
+func (b *Bucket) listBuckets(w http.ResponseWriter) {
+	writeXML(w, listAllMyBucketsResult{
+		Xmlns:   xmlns,
+		Buckets: []bucketResult{{Name: b.Name, CreationDate: formatTime(b.Created())}},
+	})
+}
+
+// serveBucket handles requests against the bucket itself.
+func (b *Bucket) serveBucket(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+		return nil
+	case http.MethodPut:
+		// The bucket already exists and is owned by the caller.
+		w.WriteHeader(http.StatusOK)
+		return nil
+	case http.MethodPost:
+		if query.Has("delete") {
+			return b.deleteObjects(w, r)
+		}
+	case http.MethodGet:
+		switch {
+		case query.Has("location"):
+			writeXML(w, locationConstraint{Xmlns: xmlns})
+			return nil
+		case query.Has("versioning"):
+			writeXML(w, versioningConfiguration{Xmlns: xmlns})
+			return nil
+		case query.Has("uploads"):
+			return b.listMultipartUploads(w, r)
+		default:
+			return b.listObjects(w, r)
+		}
+	}
+
+	return ErrMethodNotAllowed
+}
+
+// listObjects handles ListObjects and ListObjectsV2.
+func (b *Bucket) listObjects(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+
+	maxKeys := maxListKeys
+	if query.Has("max-keys") {
+		var err error
+
+		maxKeys, err = strconv.Atoi(query.Get("max-keys"))
+		if err != nil || maxKeys < 0 {
+			return ErrInvalidArgument
+		}
+
+		maxKeys = min(maxKeys, maxListKeys)
+	}
+
+	encodingType := query.Get("encoding-type")
+	if encodingType != "" && encodingType != "url" {
+		return ErrInvalidArgument
+	}
+
+	encode := func(s string) string {
+		if encodingType == "url" {
+			return url.QueryEscape(s)
+		}
+
+		return s
+	}
+
+	resp := listBucketResult{
+		Xmlns:        xmlns,
+		Name:         b.Name,
+		Prefix:       encode(query.Get("prefix")),
+		MaxKeys:      maxKeys,
+		Delimiter:    encode(query.Get("delimiter")),
+		EncodingType: encodingType,
+	}
+
+	listV2 := query.Get("list-type") == "2"
+
+	var marker string
+	if listV2 {
+		marker = query.Get("start-after")
+		resp.StartAfter = encode(marker)
+
+		token := query.Get("continuation-token")
+		if token != "" {
+			decoded, err := base64.StdEncoding.DecodeString(token)
+			if err != nil {
+				return ErrInvalidArgument
+			}
+
+			marker = string(decoded)
+			resp.ContinuationToken = token
+		}
+	} else {
+		marker = query.Get("marker")
+		resp.Marker = &marker
+	}
+
+	result, err := b.ListObjects(query.Get("prefix"), query.Get("delimiter"), marker, maxKeys)
+	if err != nil {
+		return err
+	}
+
+	resp.IsTruncated = result.IsTruncated
+
+	for _, obj := range result.Objects {
+		resp.Contents = append(resp.Contents, objectResult{
+			Key:          encode(obj.Key),
+			LastModified: formatTime(obj.LastModified),
+			ETag:         quoteETag(obj.ETag),
+			Size:         obj.Size,
+			StorageClass: "STANDARD",
+		})
+	}
+
+	for _, prefix := range result.CommonPrefixes {
+		resp.CommonPrefixes = append(resp.CommonPrefixes, commonPrefixResult{Prefix: encode(prefix)})
+	}
+
+	if listV2 {
+		keyCount := len(result.Objects) + len(result.CommonPrefixes)
+		resp.KeyCount = &keyCount
+
+		if result.NextMarker != "" {
+			resp.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(result.NextMarker))
+		}
+	} else {
+		resp.NextMarker = encode(result.NextMarker)
+	}
+
+	writeXML(w, resp)
+
+	return nil
+}
+
+// deleteObjects handles DeleteObjects.
+func (b *Bucket) deleteObjects(w http.ResponseWriter, r *http.Request) error {
+	var req deleteRequest
+
+	err := xml.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return ErrMalformedXML
+	}
+
+	// Drain the body so that its signature or hash is verified before acting on it.
+	_, err = io.Copy(io.Discard, r.Body)
+	if err != nil {
+		return err
+	}
+
+	if len(req.Objects) > maxListKeys {
+		return ErrMalformedXML
+	}
+
+	resp := deleteResult{Xmlns: xmlns}
+	for _, obj := range req.Objects {
+		err := b.DeleteObject(obj.Key)
+		if err != nil {
+			s3Err := AsError(err)
+			resp.Errors = append(resp.Errors, deleteErrorResult{Key: obj.Key, Code: s3Err.Code, Message: s3Err.Message})
+			continue
+		}
+
+		if !req.Quiet {
+			resp.Deleted = append(resp.Deleted, deletedResult{Key: obj.Key})
+		}
+	}
+
+	writeXML(w, resp)
+
+	return nil
+}
+
+// listMultipartUploads handles ListMultipartUploads.
+func (b *Bucket) listMultipartUploads(w http.ResponseWriter, r *http.Request) error {
+	prefix := r.URL.Query().Get("prefix")
+
+	uploads, err := b.ListMultipartUploads(prefix)
+	if err != nil {
+		return err
+	}
+
+	resp := listMultipartUploadsResult{Xmlns: xmlns, Bucket: b.Name, Prefix: prefix}
+	for _, upload := range uploads {
+		resp.Uploads = append(resp.Uploads, uploadResult{
+			Key:       upload.Key,
+			UploadID:  upload.UploadID,
+			Initiated: formatTime(upload.Initiated),
+		})
+	}
+
+	writeXML(w, resp)
+
+	return nil
+}
+
+// serveObject handles requests against an object.
+func (b *Bucket) serveObject(w http.ResponseWriter, r *http.Request, key string) error {
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if uploadID != "" {
+			return b.listParts(w, uploadID)
+		}
+
+		return b.getObject(w, r, key)
+	case http.MethodPut:
+		if uploadID != "" {
+			return b.uploadPart(w, r, uploadID)
+		}
+
+		if r.Header.Get("X-Amz-Copy-Source") != "" {
+			return b.copyObject(w, r, key)
+		}
+
+		return b.putObject(w, r, key)
+	case http.MethodPost:
+		if query.Has("uploads") {
+			return b.createMultipartUpload(w, r, key)
+		}
+
+		if uploadID != "" {
+			return b.completeMultipartUpload(w, r, key, uploadID)
+		}
+	case http.MethodDelete:
+		if uploadID != "" {
+			err := b.AbortMultipartUpload(uploadID)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := b.DeleteObject(key)
+			if err != nil {
+				return err
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	return ErrMethodNotAllowed
+}
+
+// requestHeaders returns the request headers to be stored alongside an object.
+func requestHeaders(r *http.Request) map[string]string {
+	headers := map[string]string{}
+	for name, values := range r.Header {
+		if strings.HasPrefix(name, "X-Amz-Meta-") {
+			headers[name] = strings.Join(values, ",")
+		}
+	}
+
+	for _, name := range storedHeaders {
+		value := r.Header.Get(name)
+		if value != "" {
+			headers[name] = value
+		}
+	}
+
+	// The aws-chunked content encoding only applies to the transfer of the payload.
+	encodings := []string{}
+	for encoding := range strings.SplitSeq(headers["Content-Encoding"], ",") {
+		encoding = strings.TrimSpace(encoding)
+		if encoding != "" && encoding != "aws-chunked" {
+			encodings = append(encodings, encoding)
+		}
+	}
+
+	if len(encodings) > 0 {
+		headers["Content-Encoding"] = strings.Join(encodings, ",")
+	} else {
+		delete(headers, "Content-Encoding")
+	}
+
+	return headers
+}
+
+// contentMD5 returns the decoded Content-MD5 request header, or nil if not set.
+func contentMD5(r *http.Request) ([]byte, error) {
+	value := r.Header.Get("Content-MD5")
+	if value == "" {
+		return nil, nil
+	}
+
+	sum, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidArgument
+	}
+
+	return sum, nil
+}
+
+// getObject handles GetObject and HeadObject, including range and conditional requests.
+func (b *Bucket) getObject(w http.ResponseWriter, r *http.Request, key string) error {
+	obj, f, err := b.GetObject(key)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	for name, value := range obj.Headers {
+		w.Header().Set(name, value)
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "binary/octet-stream")
+	}
+
+	w.Header().Set("ETag", quoteETag(obj.ETag))
+	http.ServeContent(w, r, "", obj.LastModified, f)
+
+	return nil
+}
+
+// putObject handles PutObject.
+func (b *Bucket) putObject(w http.ResponseWriter, r *http.Request, key string) error {
+	sum, err := contentMD5(r)
+	if err != nil {
+		return err
+	}
+
+	obj, err := b.PutObject(key, requestHeaders(r), r.Body, r.ContentLength, sum)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", quoteETag(obj.ETag))
+	w.WriteHeader(http.StatusOK)
+
+	return nil
+}
+
+// copyObject handles CopyObject within the bucket.
+func (b *Bucket) copyObject(w http.ResponseWriter, r *http.Request, key string) error {
+	source, err := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+	if err != nil {
+		return ErrInvalidArgument
+	}
+
+	// Version IDs aren't supported as the bucket isn't versioned.
+	source, _, _ = strings.Cut(source, "?")
+
+	sourceBucket, sourceKey := SplitPath("/" + strings.TrimPrefix(source, "/"))
+	if sourceBucket != b.Name {
+		return ErrAccessDenied
+	}
+
+	srcObj, f, err := b.GetObject(sourceKey)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	headers := srcObj.Headers
+	if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+		headers = requestHeaders(r)
+	}
+
+	obj, err := b.PutObject(key, headers, f, srcObj.Size, nil)
+	if err != nil {
+		return err
+	}
+
+	writeXML(w, copyObjectResult{Xmlns: xmlns, ETag: quoteETag(obj.ETag), LastModified: formatTime(obj.LastModified)})
+
+	return nil
+}
+
+// createMultipartUpload handles CreateMultipartUpload.
+func (b *Bucket) createMultipartUpload(w http.ResponseWriter, r *http.Request, key string) error {
+	upload, err := b.CreateMultipartUpload(key, requestHeaders(r))
+	if err != nil {
+		return err
+	}
+
+	writeXML(w, initiateMultipartUploadResult{Xmlns: xmlns, Bucket: b.Name, Key: key, UploadID: upload.UploadID})
+
+	return nil
+}
+
+// uploadPart handles UploadPart.
+func (b *Bucket) uploadPart(w http.ResponseWriter, r *http.Request, uploadID string) error {
+	if r.Header.Get("X-Amz-Copy-Source") != "" {
+		return ErrNotImplemented
+	}
+
+	partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil {
+		return ErrInvalidArgument
+	}
+
+	sum, err := contentMD5(r)
+	if err != nil {
+		return err
+	}
+
+	part, err := b.UploadPart(uploadID, partNumber, r.Body, r.ContentLength, sum)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", quoteETag(part.ETag))
+	w.WriteHeader(http.StatusOK)
+
+	return nil
+}
+
+// completeMultipartUpload handles CompleteMultipartUpload.
+func (b *Bucket) completeMultipartUpload(w http.ResponseWriter, r *http.Request, key string, uploadID string) error {
+	upload, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return err
+	}
+
+	if upload.Key != key {
+		return ErrNoSuchUpload
+	}
+
+	var req completeMultipartUploadRequest
+
+	err = xml.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return ErrMalformedXML
+	}
+
+	// Drain the body so that its signature or hash is verified before acting on it.
+	_, err = io.Copy(io.Discard, r.Body)
+	if err != nil {
+		return err
+	}
+
+	parts := make([]Part, 0, len(req.Parts))
+	for _, part := range req.Parts {
+		parts = append(parts, Part{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+
+	obj, err := b.CompleteMultipartUpload(uploadID, parts)
+	if err != nil {
+		return err
+	}
+
+	writeXML(w, completeMultipartUploadResult{
+		Xmlns:    xmlns,
+		Location: "/" + b.Name + "/" + key,
+		Bucket:   b.Name,
+		Key:      key,
+		ETag:     quoteETag(obj.ETag),
+	})
+
+	return nil
+}
+
+// listParts handles ListParts.
+func (b *Bucket) listParts(w http.ResponseWriter, uploadID string) error {
+	upload, err := b.GetMultipartUpload(uploadID)
+	if err != nil {
+		return err
+	}
+
+	parts, err := b.ListParts(uploadID)
+	if err != nil {
+		return err
+	}
+
+	resp := listPartsResult{Xmlns: xmlns, Bucket: b.Name, Key: upload.Key, UploadID: uploadID}
+	for _, part := range parts {
+		resp.Parts = append(resp.Parts, partResult{
+			PartNumber:   part.PartNumber,
+			LastModified: formatTime(part.LastModified),
+			ETag:         quoteETag(part.ETag),
+			Size:         part.Size,
+		})
+	}
+
+	writeXML(w, resp)
+
+	return nil
+}
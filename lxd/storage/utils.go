@@ -337,7 +337,7 @@ func VolumeDBSnapshotsGet(pool Pool, projectName string, volume string, volumeTy
 // BucketDBCreate creates a bucket in the database.
 // The supplied bucket's config may be modified with defaults for the storage pool being used.
 // Returns bucket DB record ID.
-func BucketDBCreate(ctx context.Context, pool Pool, projectName string, bucket *api.StorageBucketsPost) (int64, error) {
+func BucketDBCreate(ctx context.Context, pool Pool, projectName string, memberSpecific bool, bucket *api.StorageBucketsPost) (int64, error) {
 	p, ok := pool.(*lxdBackend)
 	if !ok {
 		return -1, errors.New("Pool is not a lxdBackend")
@@ -373,7 +373,7 @@ func BucketDBCreate(ctx context.Context, pool Pool, projectName string, bucket *
 
 	err = p.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		// Create the database entry for the storage bucket.
-		bucketID, err = tx.CreateStoragePoolBucket(ctx, p.ID(), projectName, *bucket)
+		bucketID, err = tx.CreateStoragePoolBucket(ctx, p.ID(), projectName, memberSpecific, *bucket)
 
 		return err
 	})
@@ -384,6 +384,29 @@ func BucketDBCreate(ctx context.Context, pool Pool, projectName string, bucket *
 	return bucketID, nil
 }
 
+// generateBucketCredentials fills in any missing access or secret key with a randomly generated one.
+func generateBucketCredentials(creds *drivers.S3Credentials) error {
+	if creds.AccessKey == "" {
+		randomStr, err := shared.RandomCryptoString()
+		if err != nil {
+			return fmt.Errorf("Failed generating bucket access key: %w", err)
+		}
+
+		creds.AccessKey = randomStr[:20]
+	}
+
+	if creds.SecretKey == "" {
+		randomStr, err := shared.RandomCryptoString()
+		if err != nil {
+			return fmt.Errorf("Failed generating bucket secret key: %w", err)
+		}
+
+		creds.SecretKey = randomStr[:40]
+	}
+
+	return nil
+}
+
 // BucketDBDelete deletes a bucket from the database.
 func BucketDBDelete(ctx context.Context, pool Pool, bucketID int64) error {
 	p, ok := pool.(*lxdBackend)
@@ -430,7 +453,7 @@ func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error
 		//  shortdesc: Size/quota of the storage volume
 		//  scope: global
 
-		// lxdmeta:generate(entities=storage-cephobject; group=bucket-conf; key=size)
+		// lxdmeta:generate(entities=storage-btrfs,storage-cephobject,storage-dir,storage-lvm,storage-zfs; group=bucket-conf; key=size)
 		//
 		// ---
 		//  type: string
@@ -508,6 +531,37 @@ func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error
 		rules["security.shared"] = validate.Optional(validate.IsBool)
 	}
 
+	// Replication is only available for custom volumes.
+	if vol != nil && vol.Type() == drivers.VolumeTypeCustom {
+		// lxdmeta:generate(entities=storage-btrfs,storage-cephfs,storage-ceph,storage-dir,storage-lvm,storage-zfs,storage-powerflex,storage-pure,storage-alletra; group=volume-conf; key=replication.target_pool)
+		// The volume and its snapshots are copied to a volume with the same name and project in this storage pool
+		// according to `replication.schedule`.
+		// Subsequent replications only transfer the changes since the previous one where the storage drivers allow it.
+		// ---
+		//  type: string
+		//  condition: custom volume
+		//  shortdesc: Storage pool to replicate the volume to
+		//  scope: global
+		rules["replication.target_pool"] = validate.IsAny
+		// lxdmeta:generate(entities=storage-btrfs,storage-cephfs,storage-ceph,storage-dir,storage-lvm,storage-zfs,storage-powerflex,storage-pure,storage-alletra; group=volume-conf; key=replication.schedule)
+		// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable replication (the default).
+		// ---
+		//  type: string
+		//  condition: custom volume
+		//  shortdesc: Schedule for replicating the volume to `replication.target_pool`
+		//  scope: global
+		rules["replication.schedule"] = validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"}))
+		// lxdmeta:generate(entities=storage-btrfs,storage-cephfs,storage-ceph,storage-dir,storage-lvm,storage-zfs,storage-powerflex,storage-pure,storage-alletra; group=volume-conf; key=volatile.replica_of)
+		// Set on the copies created by replication, in the `<pool>/<volume>` format.
+		// Replication only refreshes existing volumes that are a replica of the source volume.
+		// ---
+		//  type: string
+		//  condition: custom volume
+		//  shortdesc: Source storage pool and volume of a replica
+		//  scope: global
+		rules["volatile.replica_of"] = validate.IsAny
+	}
+
 	// Those keys are only valid for volumes.
 	if vol != nil {
 		// lxdmeta:generate(entities=storage-btrfs,storage-cephfs,storage-ceph,storage-dir,storage-lvm,storage-zfs,storage-powerflex,storage-pure,storage-alletra; group=volume-conf; key=volatile.uuid)
@@ -22,6 +22,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 
+	"github.com/canonical/lxd/client"
 	"github.com/canonical/lxd/lxd/archive"
 	"github.com/canonical/lxd/lxd/auth"
 	"github.com/canonical/lxd/lxd/backup"
@@ -2874,6 +2875,64 @@ func createStoragePoolVolumeFromBackup(s *state.State, r *http.Request, requestP
 		return response.InternalError(err)
 	}
 
+	// Incremental backups overwrite an existing volume so the caller must be able to edit it and the
+	// backup must be applied on the cluster member hosting it.
+	if bInfo.Parent != "" {
+		nodeInfo, err := getRemoteVolumeNodeInfo(r.Context(), s, bInfo.Pool, projectName, bInfo.Name, cluster.StoragePoolVolumeTypeCustom)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		location := ""
+		if nodeInfo != nil {
+			location = nodeInfo.Name
+		} else if s.ServerClustered {
+			location = s.ServerName
+		}
+
+		pool, err := storagePools.LoadByName(s, bInfo.Pool)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if pool.Driver().Info().Remote {
+			location = ""
+		}
+
+		err = s.Authorizer.CheckPermission(r.Context(), entity.StorageVolumeURL(requestProjectName, location, bInfo.Pool, cluster.StoragePoolVolumeTypeNameCustom, bInfo.Name), auth.EntitlementCanEdit)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if nodeInfo != nil {
+			client, err := lxdCluster.Connect(r.Context(), nodeInfo.Address, s.Endpoints.NetworkCert(), s.ServerCert(), false)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			_, err = backupFile.Seek(0, io.SeekStart)
+			if err != nil {
+				return response.InternalError(err)
+			}
+
+			logger.Debug("Forward incremental volume backup request", logger.Ctx{"local": s.ServerName, "target": nodeInfo.Name, "targetAddress": nodeInfo.Address})
+			op, err := client.UseProject(requestProjectName).UseTarget(nodeInfo.Name).CreateStoragePoolVolumeFromBackup(bInfo.Pool, lxd.StoragePoolVolumeBackupArgs{
+				BackupFile: backupFile,
+				Name:       bInfo.Name,
+			})
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			opAPI := op.Get()
+			return operations.ForwardedOperationResponse(&opAPI)
+		}
+
+		if !pool.Driver().Info().IncrementalBackups {
+			return response.BadRequest(fmt.Errorf("Incremental backups aren't supported by storage driver %q", pool.Driver().Info().Name))
+		}
+	}
+
 	// Copy reverter so far so we can use it inside run after this function has finished.
 	runRevert := revert.Clone()
 
@@ -2891,6 +2950,17 @@ func createStoragePoolVolumeFromBackup(s *state.State, r *http.Request, requestP
 			return fmt.Errorf("Optimized backup storage driver %q differs from the target storage pool driver %q", bInfo.Backend, pool.Driver().Info().Name)
 		}
 
+		// Incremental backups are applied on top of the existing volume they were taken from.
+		if bInfo.Parent != "" {
+			err = pool.RefreshCustomVolumeFromBackup(ctx, *bInfo, backupFile, op)
+			if err != nil {
+				return fmt.Errorf("Apply incremental backup to custom volume: %w", err)
+			}
+
+			runRevert.Success()
+			return nil
+		}
+
 		// Dump tarball to storage.
 		err = pool.CreateCustomVolumeFromBackup(ctx, *bInfo, backupFile, op)
 		if err != nil {
@@ -25,6 +25,8 @@ import (
 	"github.com/canonical/lxd/lxd/project/limits"
 	"github.com/canonical/lxd/lxd/request"
 	"github.com/canonical/lxd/lxd/response"
+	storagePools "github.com/canonical/lxd/lxd/storage"
+	storageDrivers "github.com/canonical/lxd/lxd/storage/drivers"
 	"github.com/canonical/lxd/lxd/util"
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/api"
@@ -211,6 +213,7 @@ func storagePoolVolumeTypeCustomBackupsGet(d *Daemon, r *http.Request) response.
 
 	for i, b := range volumeBackups {
 		backups[i] = backup.NewVolumeBackup(s, effectiveProjectName, details.pool.Name(), details.volumeName, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.VolumeOnly, b.OptimizedStorage)
+		backups[i].SetTarget(b.Target, b.TargetLocation)
 	}
 
 	resultString := []string{}
@@ -418,9 +421,48 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 		return response.BadRequest(err)
 	}
 
+	err = backup.ValidateTarget(s, req.Target)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	fullName := details.volumeName + shared.SnapshotDelimiter + backupName
 	volumeOnly := req.VolumeOnly
 
+	// Resolve the base of an incremental backup to one of the volume's snapshots.
+	parentSnapshot := ""
+	if req.IncrementalFrom != "" {
+		if !req.OptimizedStorage || volumeOnly {
+			return response.BadRequest(errors.New("Incremental backups must use optimized storage and include snapshots"))
+		}
+
+		if !details.pool.Driver().Info().IncrementalBackups {
+			return response.BadRequest(fmt.Errorf("Incremental backups aren't supported by storage driver %q", details.pool.Driver().Info().Name))
+		}
+
+		_, err = backup.ValidateBackupName(req.IncrementalFrom)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		snapshots, err := storagePools.VolumeDBSnapshotsGet(details.pool, effectiveProjectName, details.volumeName, storageDrivers.VolumeTypeCustom)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		snapNames := make([]string, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			_, snapName, _ := api.GetParentAndSnapshotName(snapshot.Name)
+			snapNames = append(snapNames, snapName)
+		}
+
+		backupPath := filepath.Join(s.BackupsStoragePath(effectiveProjectName), "custom", details.pool.Name(), project.StorageVolume(effectiveProjectName, details.volumeName+shared.SnapshotDelimiter+req.IncrementalFrom))
+		parentSnapshot, err = backupIncrementalParent(s, req.IncrementalFrom, snapNames, backupPath)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	backup := func(ctx context.Context, op *operations.Operation) error {
 		args := db.StoragePoolVolumeBackup{
 			Name:                 fullName,
@@ -430,9 +472,10 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 			VolumeOnly:           volumeOnly,
 			OptimizedStorage:     req.OptimizedStorage,
 			CompressionAlgorithm: req.CompressionAlgorithm,
+			Target:               req.Target,
 		}
 
-		err := volumeBackupCreate(s, args, effectiveProjectName, details.pool.Name(), details.volumeName, req.Version)
+		err := volumeBackupCreate(s, args, effectiveProjectName, details.pool.Name(), details.volumeName, parentSnapshot, req.Version)
 		if err != nil {
 			return fmt.Errorf("Create volume backup: %w", err)
 		}
@@ -845,16 +888,20 @@ func storagePoolVolumeTypeCustomBackupExportGet(d *Daemon, r *http.Request) resp
 	fullName := details.volumeName + shared.SnapshotDelimiter + backupName
 
 	// Ensure the volume exists
-	_, err = storagePoolVolumeBackupLoadByName(r.Context(), s, effectiveProjectName, details.pool.Name(), fullName)
+	volBackup, err := storagePoolVolumeBackupLoadByName(r.Context(), s, effectiveProjectName, details.pool.Name(), fullName)
 	if err != nil {
 		return response.SmartError(err)
 	}
 
+	s.Events.SendLifecycle(effectiveProjectName, lifecycle.StorageVolumeBackupRetrieved.Event(details.pool.Name(), details.volumeTypeName, fullName, effectiveProjectName, request.CreateRequestor(r.Context()), nil))
+
+	if volBackup.Target() != "" {
+		return backupTargetExportResponse(r.Context(), s, volBackup.Target(), volBackup.TargetLocation())
+	}
+
 	ent := response.FileResponseEntry{
 		Path: filepath.Join(s.BackupsStoragePath(effectiveProjectName), "custom", details.pool.Name(), project.StorageVolume(effectiveProjectName, fullName)),
 	}
 
-	s.Events.SendLifecycle(effectiveProjectName, lifecycle.StorageVolumeBackupRetrieved.Event(details.pool.Name(), details.volumeTypeName, fullName, effectiveProjectName, request.CreateRequestor(r.Context()), nil))
-
 	return response.FileResponse([]response.FileResponseEntry{ent}, nil)
 }
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/lxd/db"
+	"github.com/canonical/lxd/lxd/db/cluster"
+	"github.com/canonical/lxd/lxd/db/operationtype"
+	"github.com/canonical/lxd/lxd/operations"
+	"github.com/canonical/lxd/lxd/state"
+	storagePools "github.com/canonical/lxd/lxd/storage"
+	"github.com/canonical/lxd/lxd/task"
+	"github.com/canonical/lxd/lxd/util"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+)
+
+func autoReplicateCustomVolumesTask(stateFunc func() *state.State) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := stateFunc()
+
+		var volumes, remoteVolumes []db.StorageVolumeArgs
+		var memberCount int
+		var onlineMemberIDs []int64
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			allVolumes, err := tx.GetStoragePoolVolumesWithType(ctx, cluster.StoragePoolVolumeTypeCustom, true)
+			if err != nil {
+				return fmt.Errorf("Failed getting volumes for auto custom volume replication task: %w", err)
+			}
+
+			for _, v := range allVolumes {
+				schedule := v.Config["replication.schedule"]
+				if schedule == "" || v.Config["replication.target_pool"] == "" {
+					continue
+				}
+
+				// Check if replication is scheduled.
+				if !snapshotIsScheduledNow(schedule, v.ID) {
+					continue
+				}
+
+				if v.NodeID < 0 {
+					// Keep a separate list of remote volumes in order to select a member to
+					// perform the replication later.
+					remoteVolumes = append(remoteVolumes, v)
+				} else {
+					logger.Debug("Scheduling local custom volume replication", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName})
+					volumes = append(volumes, v) // Always include local volumes.
+				}
+			}
+
+			if len(remoteVolumes) > 0 {
+				// Get list of cluster members.
+				members, err := tx.GetNodes(ctx)
+				if err != nil {
+					return fmt.Errorf("Failed getting cluster members: %w", err)
+				}
+
+				memberCount = len(members)
+
+				// Filter to online members.
+				for _, member := range members {
+					if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+						continue
+					}
+
+					onlineMemberIDs = append(onlineMemberIDs, member.ID)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed getting custom volume info", logger.Ctx{"err": err})
+			return
+		}
+
+		if len(remoteVolumes) > 0 {
+			// Skip replicating remote custom volumes if there are no online members, as we can't be
+			// sure that the cluster isn't partitioned and we may end up replicating from multiple members.
+			if memberCount > 1 && len(onlineMemberIDs) <= 0 {
+				logger.Error("Skipping remote volumes for auto custom volume replication task due to no online members")
+			} else {
+				localMemberID := s.DB.Cluster.GetNodeID()
+
+				for _, v := range remoteVolumes {
+					// If there are multiple cluster members, a stable random member is chosen
+					// to perform the replication from.
+					if memberCount > 1 {
+						selectedNodeID, err := util.GetStableRandomInt64FromList(int64(v.ID), onlineMemberIDs)
+						if err != nil {
+							logger.Error("Failed scheduling remote auto custom volume replication task", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName, "err": err})
+							continue
+						}
+
+						// Don't replicate, if we're not the chosen one.
+						if localMemberID != selectedNodeID {
+							continue
+						}
+					}
+
+					logger.Debug("Scheduling remote custom volume replication", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName})
+					volumes = append(volumes, v)
+				}
+			}
+		}
+
+		if len(volumes) == 0 {
+			return
+		}
+
+		opRun := func(ctx context.Context, op *operations.Operation) error {
+			return autoReplicateCustomVolumes(ctx, s, volumes)
+		}
+
+		args := operations.OperationArgs{
+			Type:    operationtype.VolumeReplicateScheduled,
+			Class:   operations.OperationClassTask,
+			RunHook: opRun,
+		}
+
+		logger.Info("Replicating scheduled custom volumes")
+		op, err := operations.ScheduleServerOperation(s, args)
+		if err != nil {
+			logger.Error("Failed creating scheduled volume replication operation", logger.Ctx{"err": err})
+			return
+		}
+
+		err = op.Wait(ctx)
+		if err != nil {
+			logger.Error("Failed scheduled custom volume replication", logger.Ctx{"err": err})
+		} else {
+			logger.Info("Done replicating scheduled custom volumes")
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// autoReplicateCustomVolumes replicates the given custom volumes to their target pools.
+// A failure to replicate a volume doesn't prevent the other volumes from being replicated.
+func autoReplicateCustomVolumes(ctx context.Context, s *state.State, volumes []db.StorageVolumeArgs) error {
+	var failed []string
+
+	// Replicate the volumes sequentially.
+	for _, v := range volumes {
+		err := ctx.Err()
+		if err != nil {
+			return err // Stop if context is cancelled.
+		}
+
+		err = replicateCustomVolume(ctx, s, v)
+		if err != nil {
+			logger.Error("Failed replicating custom volume", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName, "targetPool": v.Config["replication.target_pool"], "err": err})
+			failed = append(failed, v.ProjectName+"/"+v.PoolName+"/"+v.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Failed replicating custom volumes: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// replicateCustomVolume copies a custom volume and its snapshots to a volume of the same name and project in the
+// volume's replication target pool. If the replica already exists, it is refreshed so that only the snapshots and
+// changes made since the last replication are transferred. Replicas are marked with "volatile.replica_of" and an
+// existing volume without that marker is never overwritten.
+func replicateCustomVolume(ctx context.Context, s *state.State, v db.StorageVolumeArgs) error {
+	targetPool, err := storagePools.LoadByName(s, v.Config["replication.target_pool"])
+	if err != nil {
+		return fmt.Errorf("Failed loading replication target pool: %w", err)
+	}
+
+	replicaOf := v.PoolName + "/" + v.Name
+
+	var replica *db.StorageVolume
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		replica, err = tx.GetStoragePoolVolume(ctx, targetPool.ID(), v.ProjectName, cluster.StoragePoolVolumeTypeCustom, v.Name, true)
+		return err
+	})
+	if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+		return fmt.Errorf("Failed checking for existing replica: %w", err)
+	}
+
+	if replica != nil && replica.Config["volatile.replica_of"] != replicaOf {
+		return fmt.Errorf("Volume %q already exists in storage pool %q and isn't a replica of %q", v.Name, targetPool.Name(), replicaOf)
+	}
+
+	// The replica must not be replicated itself nor take its own snapshots, as those would conflict with the
+	// snapshots copied from the source volume.
+	config := maps.Clone(v.Config)
+	for k := range config {
+		if strings.HasPrefix(k, "replication.") || k == "snapshots.schedule" || k == "volatile.uuid" {
+			delete(config, k)
+		}
+	}
+
+	config["volatile.replica_of"] = replicaOf
+
+	if replica != nil {
+		return targetPool.RefreshCustomVolume(ctx, v.ProjectName, v.ProjectName, v.Name, v.Description, config, v.PoolName, v.Name, true, nil)
+	}
+
+	return targetPool.CreateCustomVolumeFromCopy(ctx, v.ProjectName, v.ProjectName, v.Name, v.Description, config, v.PoolName, v.Name, true, nil)
+}
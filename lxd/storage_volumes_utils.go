@@ -222,6 +222,7 @@ func storagePoolVolumeBackupLoadByName(ctx context.Context, s *state.State, proj
 
 	volumeName := strings.Split(backupName, "/")[0]
 	backup := backup.NewVolumeBackup(s, projectName, poolName, volumeName, b.ID, b.Name, b.CreationDate, b.ExpiryDate, b.VolumeOnly, b.OptimizedStorage)
+	backup.SetTarget(b.Target, b.TargetLocation)
 
 	return backup, nil
 }
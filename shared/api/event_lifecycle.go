@@ -25,8 +25,16 @@ const (
 	EventLifecycleClusterLinkUpdated                = "cluster-link-updated"
 	EventLifecycleReplicatorCreated                 = "replicator-created"
 	EventLifecycleReplicatorDeleted                 = "replicator-deleted"
+	EventLifecycleReplicatorFailback                = "replicator-failback"
+	EventLifecycleReplicatorFailbackFailed          = "replicator-failback-failed"
+	EventLifecycleReplicatorFailover                = "replicator-failover"
+	EventLifecycleReplicatorFailoverFailed          = "replicator-failover-failed"
+	EventLifecycleReplicatorInstancesStarted        = "replicator-instances-started"
+	EventLifecycleReplicatorProjectDemoted          = "replicator-project-demoted"
+	EventLifecycleReplicatorProjectPromoted         = "replicator-project-promoted"
 	EventLifecycleReplicatorRenamed                 = "replicator-renamed"
 	EventLifecycleReplicatorRun                     = "replicator-run"
+	EventLifecycleReplicatorSynced                  = "replicator-synced"
 	EventLifecycleReplicatorUpdated                 = "replicator-updated"
 	EventLifecycleClusterTokenCreated               = "cluster-token-created"
 	EventLifecycleConfigUpdated                     = "config-updated"
@@ -70,6 +78,7 @@ const (
 	EventLifecycleInstanceShutdown                  = "instance-shutdown"
 	EventLifecycleInstanceSnapshotCreated           = "instance-snapshot-created"
 	EventLifecycleInstanceSnapshotDeleted           = "instance-snapshot-deleted"
+	EventLifecycleInstanceSnapshotFreezeFailed      = "instance-snapshot-freeze-failed"
 	EventLifecycleInstanceSnapshotRenamed           = "instance-snapshot-renamed"
 	EventLifecycleInstanceSnapshotUpdated           = "instance-snapshot-updated"
 	EventLifecycleInstanceStarted                   = "instance-started"
@@ -79,6 +88,10 @@ const (
 	EventLifecycleNetworkACLDeleted                 = "network-acl-deleted"
 	EventLifecycleNetworkACLRenamed                 = "network-acl-renamed"
 	EventLifecycleNetworkACLUpdated                 = "network-acl-updated"
+	EventLifecycleNetworkAddressSetCreated          = "network-address-set-created"
+	EventLifecycleNetworkAddressSetDeleted          = "network-address-set-deleted"
+	EventLifecycleNetworkAddressSetRenamed          = "network-address-set-renamed"
+	EventLifecycleNetworkAddressSetUpdated          = "network-address-set-updated"
 	EventLifecycleNetworkCreated                    = "network-created"
 	EventLifecycleNetworkDeleted                    = "network-deleted"
 	EventLifecycleNetworkForwardCreated             = "network-forward-created"
@@ -43,6 +43,18 @@ type InstanceBackupsPost struct {
 	//
 	// API extension: backup_metadata_version
 	Version uint32 `json:"version" yaml:"version"`
+
+	// Snapshot or backup to use as the base of an incremental backup
+	// Example: snap0
+	//
+	// API extension: backup_incremental
+	IncrementalFrom string `json:"incremental_from,omitempty" yaml:"incremental_from,omitempty"`
+
+	// Where to store the backup (empty for the server's backups storage)
+	// Example: s3
+	//
+	// API extension: backup_target_s3
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
 }
 
 // InstanceBackup represents a LXD instance backup.
@@ -76,6 +88,18 @@ type InstanceBackup struct {
 	// Whether to use a pool-optimized binary format (instead of plain tarball)
 	// Example: true
 	OptimizedStorage bool `json:"optimized_storage" yaml:"optimized_storage"`
+
+	// Where the backup is stored (empty for the server's backups storage)
+	// Example: s3
+	//
+	// API extension: backup_target_s3
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+
+	// Location of the backup in its target
+	// Example: default/instances/foo/backup0-20210323T203837Z
+	//
+	// API extension: backup_target_s3
+	TargetLocation string `json:"target_location,omitempty" yaml:"target_location,omitempty"`
 }
 
 // InstanceBackupPost represents the fields available for the renaming of a instance backup.
@@ -56,6 +56,34 @@ type InstanceState struct {
 
 	// CPU usage information
 	CPU InstanceStateCPU `json:"cpu" yaml:"cpu"`
+
+	// NUMA nodes of the virtual machine and their placement on the host
+	//
+	// API extension: instances_numa
+	NUMANodes []InstanceStateNUMANode `json:"numa_nodes,omitempty" yaml:"numa_nodes,omitempty"`
+}
+
+// InstanceStateNUMANode represents a NUMA node of a LXD virtual machine and its placement on the host.
+//
+// swagger:model
+//
+// API extension: instances_numa.
+type InstanceStateNUMANode struct {
+	// NUMA node ID in the guest
+	// Example: 0
+	ID uint64 `json:"id" yaml:"id"`
+
+	// Host NUMA nodes the memory of the node is bound to
+	// Example: [1]
+	HostNodes []uint64 `json:"host_nodes" yaml:"host_nodes"`
+
+	// vCPUs of the node
+	// Example: [0, 1, 2, 3]
+	CPUs []uint64 `json:"cpus" yaml:"cpus"`
+
+	// Memory of the node in bytes
+	// Example: 4294967296
+	Memory int64 `json:"memory" yaml:"memory"`
 }
 
 // InstanceStateDisk represents the disk information section of a LXD instance's state.
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // NetworksPost represents the fields of a new LXD network
 //
 // swagger:model
@@ -151,7 +155,7 @@ type NetworkLease struct {
 	// Example: 10.0.0.98
 	Address string `json:"address" yaml:"address"`
 
-	// The type of record (static or dynamic)
+	// The type of record (static, dynamic or reserved)
 	// Example: dynamic
 	Type string `json:"type" yaml:"type"`
 
@@ -168,6 +172,25 @@ type NetworkLease struct {
 	Project string `json:"project" yaml:"project"`
 }
 
+// NetworkLeasesPost represents the fields of a new DHCP lease reservation
+//
+// swagger:model
+//
+// API extension: network_leases_reservations.
+type NetworkLeasesPost struct {
+	// The hostname given to the client
+	// Example: printer
+	Hostname string `json:"hostname" yaml:"hostname"`
+
+	// The MAC address of the client
+	// Example: 00:16:3e:2c:89:d9
+	Hwaddr string `json:"hwaddr" yaml:"hwaddr"`
+
+	// The reserved IP address
+	// Example: 10.0.0.98
+	Address string `json:"address" yaml:"address"`
+}
+
 // NetworkState represents the network state
 //
 // swagger:model
@@ -213,6 +236,11 @@ type NetworkState struct {
 	//
 	// API extension: network_state_ovn
 	OVN *NetworkStateOVN `json:"ovn" yaml:"ovn"`
+
+	// Additional WireGuard network information
+	//
+	// API extension: network_wireguard
+	WireGuard *NetworkStateWireGuard `json:"wireguard" yaml:"wireguard"`
 }
 
 // NetworkStateAddress represents a network address
@@ -347,3 +375,56 @@ type NetworkStateOVN struct {
 	// OVN network chassis name
 	Chassis string `json:"chassis" yaml:"chassis"`
 }
+
+// NetworkStateWireGuard represents WireGuard specific state
+//
+// swagger:model
+//
+// API extension: network_wireguard.
+type NetworkStateWireGuard struct {
+	// Public key of the interface
+	// Example: xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// UDP port the interface listens on
+	// Example: 51820
+	ListenPort uint64 `json:"listen_port" yaml:"listen_port"`
+
+	// List of peers of the interface
+	Peers []NetworkStateWireGuardPeer `json:"peers" yaml:"peers"`
+}
+
+// NetworkStateWireGuardPeer represents the state of a WireGuard peer
+//
+// swagger:model
+//
+// API extension: network_wireguard.
+type NetworkStateWireGuardPeer struct {
+	// Name of the peer
+	// Example: site2
+	Name string `json:"name" yaml:"name"`
+
+	// Public key of the peer
+	// Example: HIgo9xNzJMWLKASShiTqIybxZ0U3wGLiUeJ1PKf8ykw=
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// Current endpoint of the peer
+	// Example: 198.51.100.10:51820
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	// List of addresses routed to the peer
+	// Example: ["10.99.0.2/32", "10.170.45.0/24"]
+	AllowedIPs []string `json:"allowed_ips" yaml:"allowed_ips"`
+
+	// Time of the latest handshake with the peer (zero if none)
+	// Example: 2025-06-24T16:02:43Z
+	LatestHandshake time.Time `json:"latest_handshake" yaml:"latest_handshake"`
+
+	// Number of bytes received from the peer
+	// Example: 250542118
+	BytesReceived uint64 `json:"bytes_received" yaml:"bytes_received"`
+
+	// Number of bytes sent to the peer
+	// Example: 17524040140
+	BytesSent uint64 `json:"bytes_sent" yaml:"bytes_sent"`
+}
@@ -0,0 +1,119 @@
+package api
+
+// NetworkAddressSetPost used for renaming an address set.
+//
+// swagger:model
+//
+// API extension: network_address_sets.
+type NetworkAddressSetPost struct {
+	// lxdmeta:generate(entities=network-address-set; group=address-set-properties; key=name)
+	// The name is used to reference the address set in network ACL rules, prefixed with `$`.
+	// ---
+	//  type: string
+	//  required: yes
+	//  shortdesc: Unique name of the network address set in the project
+
+	// The new name for the address set
+	// Example: office
+	Name string `json:"name" yaml:"name"`
+}
+
+// NetworkAddressSetPut used for updating an address set.
+//
+// swagger:model
+//
+// API extension: network_address_sets.
+type NetworkAddressSetPut struct {
+	// lxdmeta:generate(entities=network-address-set; group=address-set-properties; key=description)
+	//
+	// ---
+	//  type: string
+	//  required: no
+	//  shortdesc: Description of the network address set
+
+	// Description of the address set
+	// Example: Office and VPN ranges
+	Description string `json:"description" yaml:"description"`
+
+	// lxdmeta:generate(entities=network-address-set; group=address-set-properties; key=addresses)
+	// Each entry is an IPv4 or IPv6 address or a CIDR subnet.
+	// ---
+	//  type: string list
+	//  required: no
+	//  shortdesc: Addresses in the set
+
+	// List of addresses or subnets in the set
+	// Example: ["192.0.2.0/24", "2001:db8::/32"]
+	Addresses []string `json:"addresses" yaml:"addresses"`
+
+	// lxdmeta:generate(entities=network-address-set; group=address-set-properties; key=config)
+	// The only supported keys are `user.*` custom keys.
+	// ---
+	//  type: string set
+	//  required: no
+	//  shortdesc: User-provided free-form key/value pairs
+
+	// Address set configuration map (refer to doc/howto/network_address_sets.md)
+	// Example: {"user.mykey": "foo"}
+	Config map[string]string `json:"config" yaml:"config"`
+}
+
+// NetworkAddressSet used for displaying an address set.
+//
+// swagger:model
+//
+// API extension: network_address_sets.
+type NetworkAddressSet struct {
+	WithEntitlements `yaml:",inline"`
+
+	// The name of the address set
+	// Example: office
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the address set
+	// Example: Office and VPN ranges
+	Description string `json:"description" yaml:"description"`
+
+	// List of addresses or subnets in the set
+	// Example: ["192.0.2.0/24", "2001:db8::/32"]
+	Addresses []string `json:"addresses" yaml:"addresses"`
+
+	// Address set configuration map (refer to doc/howto/network_address_sets.md)
+	// Example: {"user.mykey": "foo"}
+	Config map[string]string `json:"config" yaml:"config"`
+
+	// List of URLs of objects using this address set
+	// Read only: true
+	// Example: ["/1.0/network-acls/web"]
+	UsedBy []string `json:"used_by" yaml:"used_by"`
+
+	// Project name
+	// Example: project1
+	Project string `json:"project" yaml:"project"`
+}
+
+// Writable converts a full NetworkAddressSet struct into a NetworkAddressSetPut struct (filters read-only fields).
+func (set *NetworkAddressSet) Writable() NetworkAddressSetPut {
+	return NetworkAddressSetPut{
+		Description: set.Description,
+		Addresses:   set.Addresses,
+		Config:      set.Config,
+	}
+}
+
+// SetWritable sets applicable values from NetworkAddressSetPut struct to NetworkAddressSet struct.
+func (set *NetworkAddressSet) SetWritable(put NetworkAddressSetPut) {
+	set.Description = put.Description
+	set.Addresses = put.Addresses
+	set.Config = put.Config
+}
+
+// NetworkAddressSetsPost used for creating an address set.
+//
+// swagger:model
+//
+// API extension: network_address_sets.
+type NetworkAddressSetsPost struct {
+	NetworkAddressSetPost `yaml:",inline"`
+	NetworkAddressSetPut  `yaml:",inline"`
+}
@@ -0,0 +1,63 @@
+package api
+
+// NetworkBGP represents the state of the BGP sessions of a network on a server
+//
+// swagger:model
+//
+// API extension: network_bgp_import.
+type NetworkBGP struct {
+	// BGP peers of the network
+	Peers []NetworkBGPPeer `json:"peers" yaml:"peers"`
+}
+
+// NetworkBGPPeer represents the state of the BGP session with a network peer
+//
+// swagger:model
+//
+// API extension: network_bgp_import.
+type NetworkBGPPeer struct {
+	// Name of the peer in the network configuration
+	// Example: router1
+	Name string `json:"name" yaml:"name"`
+
+	// Address of the peer
+	// Example: 192.0.2.1
+	Address string `json:"address" yaml:"address"`
+
+	// AS number of the peer
+	// Example: 65000
+	ASN uint32 `json:"asn" yaml:"asn"`
+
+	// State of the BGP session
+	// Example: established
+	State string `json:"state" yaml:"state"`
+
+	// State of the BFD session (empty when BFD isn't enabled)
+	// Example: up
+	BFDState string `json:"bfd_state" yaml:"bfd_state"`
+
+	// Prefixes received from the peer
+	ReceivedPrefixes []NetworkBGPPrefix `json:"received_prefixes" yaml:"received_prefixes"`
+
+	// Prefixes advertised to the peer
+	AdvertisedPrefixes []NetworkBGPPrefix `json:"advertised_prefixes" yaml:"advertised_prefixes"`
+}
+
+// NetworkBGPPrefix represents a prefix exchanged with a BGP peer
+//
+// swagger:model
+//
+// API extension: network_bgp_import.
+type NetworkBGPPrefix struct {
+	// Prefix
+	// Example: 198.51.100.0/24
+	Prefix string `json:"prefix" yaml:"prefix"`
+
+	// Next hop of the prefix
+	// Example: 192.0.2.1
+	Nexthop string `json:"nexthop" yaml:"nexthop"`
+
+	// Whether a host route is installed for the received prefix
+	// Example: true
+	Imported bool `json:"imported" yaml:"imported"`
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"net"
 	"strings"
+	"time"
 )
 
 // NetworkLoadBalancerBackend represents a target backend specification in a network load balancer
@@ -179,11 +180,11 @@ type NetworkLoadBalancerPut struct {
 	Description string `json:"description" yaml:"description"`
 
 	// lxdmeta:generate(entities=network-load-balancer; group=load-balancer-properties; key=config)
-	// The only supported keys are `user.*` custom keys.
+	// The supported keys are the health check options (see {ref}`network-load-balancers-health-checks`) and `user.*` custom keys.
 	// ---
 	//  type: string set
 	//  required: no
-	//  shortdesc: User-provided free-form key/value pairs
+	//  shortdesc: Configuration options as key/value pairs
 
 	// Load balancer configuration map (refer to doc/network-load-balancers.md)
 	// Example: {"user.mykey": "foo"}
@@ -281,3 +282,40 @@ func (lb *NetworkLoadBalancer) SetWritable(put NetworkLoadBalancerPut) {
 	lb.Backends = put.Backends
 	lb.Ports = put.Ports
 }
+
+// NetworkLoadBalancerState is used for showing current state of a load balancer
+//
+// swagger:model
+//
+// API extension: network_load_balancer_health_check.
+type NetworkLoadBalancerState struct {
+	// Health of the load balancer backends keyed on backend name
+	BackendHealth map[string]NetworkLoadBalancerStateBackendHealth `json:"backend_health" yaml:"backend_health"`
+}
+
+// NetworkLoadBalancerStateBackendHealth represents the health of a load balancer backend
+//
+// swagger:model
+//
+// API extension: network_load_balancer_health_check.
+type NetworkLoadBalancerStateBackendHealth struct {
+	// Address of the backend
+	// Example: 192.0.2.2
+	Address string `json:"address" yaml:"address"`
+
+	// Port checked on the backend
+	// Example: 80
+	Port uint64 `json:"port" yaml:"port"`
+
+	// Health of the backend (one of "unknown", "online" or "offline")
+	// Example: online
+	Status string `json:"status" yaml:"status"`
+
+	// When the backend was last checked
+	// Example: 2021-03-23T20:00:00-04:00
+	LastCheckedAt time.Time `json:"last_checked_at" yaml:"last_checked_at"`
+
+	// Error returned by the last check if it failed
+	// Example: dial tcp 192.0.2.2:80: connect: connection refused
+	Error string `json:"error" yaml:"error"`
+}
@@ -18,6 +18,29 @@ const (
 	PlacementRigorPermissive string = "permissive"
 )
 
+const (
+	// PlacementScopeMember applies the placement policy to individual cluster members.
+	PlacementScopeMember string = "member"
+
+	// PlacementScopeFailureDomain applies the placement policy to cluster failure domains.
+	// Cluster members that share a failure domain are treated as a single placement target.
+	//
+	// API extension: placement_group_scope.
+	PlacementScopeFailureDomain string = "failure-domain"
+)
+
+const (
+	// PlacementRebalanceManual only reports placement groups that do not comply with their policy.
+	//
+	// API extension: placement_group_rebalance.
+	PlacementRebalanceManual string = "manual"
+
+	// PlacementRebalanceAuto migrates instances to restore compliance of placement groups with their policy.
+	//
+	// API extension: placement_group_rebalance.
+	PlacementRebalanceAuto string = "auto"
+)
+
 // PlacementGroup represents a group of instances that should be scheduled.
 //
 // API extension: instance_placement_groups.
@@ -85,3 +108,40 @@ type PlacementGroupPost struct {
 	// Example: pg2
 	Name string `json:"name" yaml:"name"`
 }
+
+// PlacementGroupCompliance represents the compliance of the instances in a placement group with its policy.
+//
+// swagger:model
+//
+// API extension: placement_group_rebalance.
+type PlacementGroupCompliance struct {
+	// Whether the current placement of the instances complies with the placement group policy.
+	// Example: false
+	Compliant bool `json:"compliant" yaml:"compliant"`
+
+	// Reason why the placement group is not compliant.
+	// Example: Multiple instances are placed on the same cluster member
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Instance migrations that would restore compliance.
+	Migrations []PlacementGroupMigration `json:"migrations" yaml:"migrations"`
+}
+
+// PlacementGroupMigration represents an instance migration that helps restore compliance of a placement group.
+//
+// swagger:model
+//
+// API extension: placement_group_rebalance.
+type PlacementGroupMigration struct {
+	// Name of the instance.
+	// Example: c1
+	Instance string `json:"instance" yaml:"instance"`
+
+	// Cluster member the instance is currently located on.
+	// Example: member01
+	Source string `json:"source" yaml:"source"`
+
+	// Cluster member the instance would be migrated to.
+	// Example: member02
+	Target string `json:"target" yaml:"target"`
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"time"
+)
+
+// ReplicatorRun represents a completed run of a replicator.
+//
+// swagger:model
+//
+// API extension: replicator_runs.
+type ReplicatorRun struct {
+	// Timestamp when the run started.
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// Timestamp when the run finished.
+	// Example: 2021-03-23T17:40:12.753398689-04:00
+	FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+
+	// Status of the run (Completed or Failed).
+	// Example: Completed
+	Status string `json:"status" yaml:"status"`
+
+	// Result of the replication of each instance.
+	Instances []ReplicatorRunInstance `json:"instances" yaml:"instances"`
+}
+
+// ReplicatorRunInstance represents the result of the replication of an instance during a replicator run.
+//
+// swagger:model
+//
+// API extension: replicator_runs.
+type ReplicatorRunInstance struct {
+	// Name of the instance.
+	// Example: c1
+	Name string `json:"name" yaml:"name"`
+
+	// Status of the replication of the instance (Completed or Failed).
+	// Example: Failed
+	Status string `json:"status" yaml:"status"`
+
+	// Number of bytes transferred.
+	// Example: 1073741824
+	BytesTransferred int64 `json:"bytes_transferred" yaml:"bytes_transferred"`
+
+	// Duration of the replication of the instance in milliseconds.
+	// Example: 95000
+	Duration int64 `json:"duration" yaml:"duration"`
+
+	// Error message if the replication of the instance failed.
+	// Example: Failed connecting to target cluster
+	Error string `json:"error" yaml:"error"`
+}
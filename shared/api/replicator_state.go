@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 const (
 	// ReplicatorStatusPending represents a replicator that has never been run.
 	ReplicatorStatusPending = "Pending"
@@ -23,6 +27,34 @@ type ReplicatorState struct {
 	// Status of the replicator job.
 	// Example: Pending
 	Status string `json:"status" yaml:"status"`
+
+	// Replication state of each instance in the project.
+	//
+	// API extension: replicator_rpo.
+	Instances []ReplicatorInstanceState `json:"instances" yaml:"instances"`
+}
+
+// ReplicatorInstanceState represents the replication state of an instance.
+//
+// swagger:model
+//
+// API extension: replicator_rpo.
+type ReplicatorInstanceState struct {
+	// Name of the instance.
+	// Example: c1
+	Name string `json:"name" yaml:"name"`
+
+	// Status of the last replication of the instance (Pending, Running, Completed, or Failed).
+	// Example: Completed
+	Status string `json:"status" yaml:"status"`
+
+	// Point in time of the last successful replication of the instance.
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastSyncAt time.Time `json:"last_sync_at" yaml:"last_sync_at"`
+
+	// Replication lag of the instance in seconds (zero if the instance was never replicated).
+	// Example: 42
+	Lag int64 `json:"lag" yaml:"lag"`
 }
 
 // ReplicatorStatePut represents the fields available to change the state of a replicator.
@@ -31,7 +63,7 @@ type ReplicatorState struct {
 //
 // API extension: replicators.
 type ReplicatorStatePut struct {
-	// Action to perform on the replicator (start, restore).
+	// Action to perform on the replicator (start, restore, failover, failback).
 	// Example: start
 	Action string `json:"action" yaml:"action"`
 }
@@ -29,6 +29,18 @@ type StoragePoolVolumeBackup struct {
 	// Whether to use a pool-optimized binary format (instead of plain tarball)
 	// Example: true
 	OptimizedStorage bool `json:"optimized_storage" yaml:"optimized_storage"`
+
+	// Where the backup is stored (empty for the server's backups storage)
+	// Example: s3
+	//
+	// API extension: backup_target_s3
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+
+	// Location of the backup in its target
+	// Example: default/custom/default/foo/backup0-20210323T203837Z
+	//
+	// API extension: backup_target_s3
+	TargetLocation string `json:"target_location,omitempty" yaml:"target_location,omitempty"`
 }
 
 // StoragePoolVolumeBackupsPost represents the fields available for a new LXD volume backup
@@ -62,6 +74,18 @@ type StoragePoolVolumeBackupsPost struct {
 	//
 	// API extension: backup_metadata_version
 	Version uint32 `json:"version" yaml:"version"`
+
+	// Snapshot or backup to use as the base of an incremental backup
+	// Example: snap0
+	//
+	// API extension: backup_incremental
+	IncrementalFrom string `json:"incremental_from,omitempty" yaml:"incremental_from,omitempty"`
+
+	// Where to store the backup (empty for the server's backups storage)
+	// Example: s3
+	//
+	// API extension: backup_target_s3
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
 }
 
 // StoragePoolVolumeBackupPost represents the fields available for the renaming of a volume backup
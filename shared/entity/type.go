@@ -68,6 +68,9 @@ const (
 	// TypeNetworkACL represents network acl resources.
 	TypeNetworkACL Type = "network_acl"
 
+	// TypeNetworkAddressSet represents network address set resources.
+	TypeNetworkAddressSet Type = "network_address_set"
+
 	// TypeClusterMember represents node resources.
 	TypeClusterMember Type = "cluster_member"
 
@@ -167,6 +170,7 @@ var entityTypes = map[Type]typeInfo{
 	TypeInstanceSnapshot:      instanceSnapshot{},
 	TypeNetwork:               network{},
 	TypeNetworkACL:            networkACL{},
+	TypeNetworkAddressSet:     networkAddressSet{},
 	TypeClusterMember:         clusterMember{},
 	TypeStoragePool:           storagePool{},
 	TypeStorageVolume:         storageVolume{},
@@ -370,6 +374,22 @@ func (networkACL) pathArgNames() []string {
 	return []string{"name"}
 }
 
+type networkAddressSet struct {
+	typeInfoCommon
+}
+
+func (networkAddressSet) requiresProject() bool {
+	return true
+}
+
+func (networkAddressSet) path() []string {
+	return []string{"network-address-sets", pathPlaceholder}
+}
+
+func (networkAddressSet) pathArgNames() []string {
+	return []string{"name"}
+}
+
 type clusterMember struct {
 	typeInfoCommon
 }
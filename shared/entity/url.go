@@ -285,6 +285,11 @@ func NetworkACLURL(projectName string, networkACLName string) *api.URL {
 	return TypeNetworkACL.urlMust(projectName, "", networkACLName)
 }
 
+// NetworkAddressSetURL returns an *api.URL to a network address set.
+func NetworkAddressSetURL(projectName string, networkAddressSetName string) *api.URL {
+	return TypeNetworkAddressSet.urlMust(projectName, "", networkAddressSetName)
+}
+
 // NetworkZoneURL returns an *api.URL to a network zone.
 func NetworkZoneURL(projectName string, networkZoneName string) *api.URL {
 	return TypeNetworkZone.urlMust(projectName, "", networkZoneName)
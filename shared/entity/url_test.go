@@ -164,6 +164,15 @@ func TestEntityPermissionURL_RoundTrip(t *testing.T) {
 				"name": "1.2.3.4",
 			},
 		},
+		{
+			Name:        "Network address set",
+			URL:         "/1.0/network-address-sets/office?project=foo",
+			WantType:    TypeNetworkAddressSet,
+			WantProject: "foo",
+			WantArgs: map[string]string{
+				"name": "office",
+			},
+		},
 		{
 			Name:        "Network zone",
 			URL:         "/1.0/network-zones/1.2.3.4",
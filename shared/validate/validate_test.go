@@ -412,6 +412,28 @@ func Test_IsSize(t *testing.T) {
 	}
 }
 
+func Test_IsBitSize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"100Mbit", true},
+		{"1Gbit", true},
+		{"1000", true},
+		{"10kbit", true},
+		{"-1Mbit", false},
+		{"abc", false},
+		{"1MB", false},
+	}
+
+	for _, test := range tests {
+		err := validate.IsBitSize(test.value)
+		if (err == nil) != test.expected {
+			t.Errorf("IsBitSize(%q) = %v, want %v", test.value, err == nil, test.expected)
+		}
+	}
+}
+
 func Test_IsDeviceID(t *testing.T) {
 	tests := []struct {
 		value    string
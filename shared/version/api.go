@@ -488,6 +488,31 @@ var APIExtensions = []string{
 	"image_extended_metadata",
 	"cluster_links",
 	"replicators",
+	"placement_group_scope",
+	"placement_group_rebalance",
+	"replicator_rpo",
+	"replicator_failover",
+	"replicator_runs",
+	"storage_buckets_local",
+	"storage_bucket_lifecycle",
+	"storage_volume_replication",
+	"backup_incremental",
+	"backup_target_s3",
+	"network_load_balancer_bridge",
+	"network_load_balancer_health_check",
+	"network_address_sets",
+	"network_acl_state",
+	"network_wireguard",
+	"network_bgp_import",
+	"network_bridge_evpn",
+	"network_zones_dnssec",
+	"network_leases_reservations",
+	"network_nic_qos",
+	"memory_hotplug",
+	"instances_numa",
+	"instance_snapshots_overlay",
+	"instance_snapshots_consistency",
+	"instance_console_vnc",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
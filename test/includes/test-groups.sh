@@ -51,7 +51,9 @@ readonly test_group_cluster=(
     "clustering_link_info"
     "clustering_replicator_basic"
     "clustering_replicator_scheduled"
+    "clustering_replicator_rpo"
     "clustering_replicator_dr"
+    "clustering_replicator_failover"
     "clustering_replicator_snapshot"
 )
 
@@ -124,7 +126,10 @@ readonly test_group_network=(
     "dns"
     "network"
     "network_acl"
+    "network_address_set"
+    "network_wireguard"
     "network_forward"
+    "network_load_balancer"
     "network_zone"
     "network_ovn"
 )
@@ -194,6 +199,7 @@ readonly test_group_snap=(
     "snap_basic_usage_vm"
     "snap_lxd_user"
     "snap_vm_empty"
+    "snap_vm_snapshot_overlay"
 )
 
 readonly test_group_standalone_storage=(
@@ -208,6 +214,8 @@ readonly test_group_standalone_storage=(
     "backup_volume_expiry"
     "backup_export_import_recover"
     "backup_inconsistent_config"
+    "backup_incremental"
+    "backup_target_s3"
     "container_copy_incremental"
     "container_copy_start"
     "container_devices_disk"
@@ -242,6 +250,7 @@ readonly test_group_standalone_storage=(
     "snapshot_restore"
     "snapshot_expiry"
     "snapshot_schedule"
+    "snapshot_consistency"
     "snapshot_volume_db_recovery"
     "snapshot_fail"
     "snapshot_multi_volume"
@@ -250,6 +259,7 @@ readonly test_group_standalone_storage=(
     "storage"
     "storage_volume_snapshots"
     "storage_local_volume_handling"
+    "storage_volume_replication"
     "storage_profiles"
     "storage_volume_attach"
     "storage_volume_attach_vm"
@@ -260,6 +270,7 @@ readonly test_group_standalone_storage=(
     "storage_driver_zfs"
     "storage_driver_pure"
     "storage_buckets"
+    "storage_buckets_local"
     "storage_volume_import"
     "storage_volume_initial_config"
 )
@@ -313,7 +313,7 @@ fine_grained: true"
   echo "${list_output}" | grep -Fq 'server,/1.0,"admin:(admins),can_create_cluster_links,can_create_groups,can_create_identities,can_create_identity_provider_groups,can_create_projects,can_create_storage_pools,can_delete_cluster_links,can_delete_groups,can_delete_identities,can_delete_identity_provider_groups,can_delete_projects,can_delete_storage_pools,can_edit,can_edit_cluster_links,can_edit_groups,can_edit_identities,can_edit_identity_provider_groups,can_edit_projects,can_edit_storage_pools,can_override_cluster_target_restriction,can_view_cluster_links,can_view_events,can_view_groups,can_view_identities,can_view_identity_provider_groups,can_view_metrics,can_view_operations,can_view_permissions,can_view_projects,can_view_resources,can_view_unmanaged_networks,can_view_warnings,permission_manager,project_manager,storage_pool_manager,viewer"'
 
   list_output="$(lxc auth permission list entity_type=project --format csv --max-entitlements 0)"
-  echo "${list_output}" | grep -Fq 'project,/1.0/projects/default,"can_create_image_aliases,can_create_images,can_create_instances,can_create_network_acls,can_create_network_zones,can_create_networks,can_create_placement_groups,can_create_profiles,can_create_replicators,can_create_storage_buckets,can_create_storage_volumes,can_delete,can_delete_image_aliases,can_delete_images,can_delete_instances,can_delete_network_acls,can_delete_network_zones,can_delete_networks,can_delete_placement_groups,can_delete_profiles,can_delete_replicators,can_delete_storage_buckets,can_delete_storage_volumes,can_edit,can_edit_image_aliases,can_edit_images,can_edit_instances,can_edit_network_acls,can_edit_network_zones,can_edit_networks,can_edit_placement_groups,can_edit_profiles,can_edit_replicators,can_edit_storage_buckets,can_edit_storage_volumes,can_operate_instances,can_view,can_view_events,can_view_image_aliases,can_view_images,can_view_instances,can_view_metrics,can_view_network_acls,can_view_network_zones,can_view_networks,can_view_operations,can_view_placement_groups,can_view_profiles,can_view_replicators,can_view_storage_buckets,can_view_storage_volumes,image_alias_manager,image_manager,instance_manager,network_acl_manager,network_manager,network_zone_manager,operator,placement_group_manager,profile_manager,replicator_manager,storage_bucket_manager,storage_volume_manager,viewer"'
+  echo "${list_output}" | grep -Fq 'project,/1.0/projects/default,"can_create_image_aliases,can_create_images,can_create_instances,can_create_network_acls,can_create_network_address_sets,can_create_network_zones,can_create_networks,can_create_placement_groups,can_create_profiles,can_create_replicators,can_create_storage_buckets,can_create_storage_volumes,can_delete,can_delete_image_aliases,can_delete_images,can_delete_instances,can_delete_network_acls,can_delete_network_address_sets,can_delete_network_zones,can_delete_networks,can_delete_placement_groups,can_delete_profiles,can_delete_replicators,can_delete_storage_buckets,can_delete_storage_volumes,can_edit,can_edit_image_aliases,can_edit_images,can_edit_instances,can_edit_network_acls,can_edit_network_address_sets,can_edit_network_zones,can_edit_networks,can_edit_placement_groups,can_edit_profiles,can_edit_replicators,can_edit_storage_buckets,can_edit_storage_volumes,can_operate_instances,can_view,can_view_events,can_view_image_aliases,can_view_images,can_view_instances,can_view_metrics,can_view_network_acls,can_view_network_address_sets,can_view_network_zones,can_view_networks,can_view_operations,can_view_placement_groups,can_view_profiles,can_view_replicators,can_view_storage_buckets,can_view_storage_volumes,image_alias_manager,image_manager,instance_manager,network_acl_manager,network_address_set_manager,network_manager,network_zone_manager,operator,placement_group_manager,profile_manager,replicator_manager,storage_bucket_manager,storage_volume_manager,viewer"'
 
   # Test max entitlements flag doesn't apply to entitlements that are assigned.
   lxc auth group permission add test-group server viewer
@@ -1459,3 +1459,164 @@ EOF
   lxc delete -f c1
   rm -r "${tmpDir}"
 }
+
+test_backup_incremental() {
+  lxd_backend=$(storage_backend "$LXD_DIR")
+
+  if [ "$lxd_backend" != "btrfs" ] && [ "$lxd_backend" != "zfs" ]; then
+    echo "==> SKIP: Storage driver does not support incremental backups"
+    return
+  fi
+
+  ensure_import_testimage
+
+  local tmpDir
+  tmpDir="$(mktemp -d -p "${TEST_DIR}" incremental-XXX)"
+
+  # Create the full backup of an instance.
+  lxc launch testimage c1 -d "${SMALL_ROOT_DISK}"
+  lxc exec c1 -- sh -c "echo foo > /root/foo"
+  lxc snapshot c1 snap0
+  lxc export c1 "${tmpDir}/c1-full.tar.gz" --optimized-storage
+
+  # Incremental backups require optimized storage and snapshots.
+  ! lxc export c1 "${tmpDir}/c1-invalid.tar.gz" --incremental-from snap0 || false
+  ! lxc export c1 "${tmpDir}/c1-invalid.tar.gz" --optimized-storage --instance-only --incremental-from snap0 || false
+  ! lxc export c1 "${tmpDir}/c1-invalid.tar.gz" --optimized-storage --incremental-from missing || false
+
+  # Create an incremental backup on top of snap0.
+  lxc exec c1 -- sh -c "echo bar > /root/bar"
+  lxc snapshot c1 snap1
+  lxc exec c1 -- sh -c "echo baz > /root/baz"
+  lxc export c1 "${tmpDir}/c1-incr1.tar.gz" --optimized-storage --incremental-from snap0
+  tar -xzf "${tmpDir}/c1-incr1.tar.gz" -C "${tmpDir}" backup/index.yaml
+  [ "$(yq '.parent' < "${tmpDir}/backup/index.yaml")" = "snap0" ]
+  [ "$(yq '.snapshots | join(",")' < "${tmpDir}/backup/index.yaml")" = "snap1" ]
+
+  # Create an incremental backup based on a backup stored on the server.
+  lxc query -X POST /1.0/instances/c1/backups -d '{"name": "b0", "optimized_storage": true, "incremental_from": "snap0"}'
+  lxc snapshot c1 snap2
+  lxc query -X POST /1.0/instances/c1/backups -d '{"name": "b1", "optimized_storage": true, "incremental_from": "b0"}'
+  lxc query /1.0/instances/c1/backups/b1/export > "${tmpDir}/c1-incr2.tar.gz"
+  tar -xzf "${tmpDir}/c1-incr2.tar.gz" -C "${tmpDir}" backup/index.yaml
+  [ "$(yq '.parent' < "${tmpDir}/backup/index.yaml")" = "snap1" ]
+  [ "$(yq '.snapshots | join(",")' < "${tmpDir}/backup/index.yaml")" = "snap2" ]
+
+  lxc delete -f c1
+
+  # Restore the chain.
+  lxc import "${tmpDir}/c1-full.tar.gz" --incremental "${tmpDir}/c1-incr1.tar.gz"
+  [ "$(lxc query /1.0/instances/c1/snapshots | jq -r 'map(split("/") | last) | join(",")')" = "snap0,snap1" ]
+
+  # Applying an incremental backup requires its parent to be the latest snapshot.
+  ! lxc import "${tmpDir}/c1-incr1.tar.gz" || false
+
+  # Applying an incremental backup requires the instance to be stopped.
+  lxc start c1
+  ! lxc import "${tmpDir}/c1-incr2.tar.gz" || false
+  lxc stop -f c1
+
+  lxc import "${tmpDir}/c1-incr2.tar.gz"
+  [ "$(lxc query /1.0/instances/c1/snapshots | jq -r 'map(split("/") | last) | join(",")')" = "snap0,snap1,snap2" ]
+
+  lxc start c1
+  [ "$(lxc exec c1 -- cat /root/foo /root/bar /root/baz)" = "foo
+bar
+baz" ]
+
+  lxc delete -f c1
+
+  # Custom volumes.
+  local pool
+  pool="lxdtest-$(basename "${LXD_DIR}")"
+  lxc storage volume create "${pool}" vol1
+  lxc storage volume snapshot "${pool}" vol1 snap0
+  lxc storage volume export "${pool}" vol1 "${tmpDir}/vol1-full.tar.gz" --optimized-storage
+  lxc storage volume snapshot "${pool}" vol1 snap1
+  lxc storage volume export "${pool}" vol1 "${tmpDir}/vol1-incr1.tar.gz" --optimized-storage --incremental-from snap0
+  lxc storage volume delete "${pool}" vol1
+
+  lxc storage volume import "${pool}" "${tmpDir}/vol1-full.tar.gz"
+  lxc storage volume import "${pool}" "${tmpDir}/vol1-incr1.tar.gz"
+  [ "$(lxc query "/1.0/storage-pools/${pool}/volumes/custom/vol1/snapshots" | jq -r 'map(split("/") | last) | join(",")')" = "snap0,snap1" ]
+  lxc storage volume delete "${pool}" vol1
+
+  rm -rf "${tmpDir}"
+}
+
+test_backup_target_s3() {
+  local lxd_backend
+
+  lxd_backend=$(storage_backend "$LXD_DIR")
+
+  if [ "$lxd_backend" = "ceph" ]; then
+    echo "==> SKIP: S3 backup target tests require a local storage bucket"
+    return
+  fi
+
+  ensure_import_testimage
+
+  local pool bucketName s3Endpoint initCreds accessKey secretKey location
+  pool="lxdtest-$(basename "${LXD_DIR}")"
+  bucketName="lxd$$.backups"
+  s3Endpoint="127.0.0.1:$(local_tcp_port)"
+
+  # Use a bucket of the local pool as the backup target.
+  lxc config set core.storage_buckets_address "${s3Endpoint}"
+  initCreds=$(lxc storage bucket create "${pool}" "${bucketName}")
+  accessKey=$(echo "${initCreds}" | awk '{ if ($2 == "access" && $3 == "key:") {print $4}}')
+  secretKey=$(echo "${initCreds}" | awk '{ if ($2 == "secret" && $3 == "key:") {print $4}}')
+
+  lxc launch testimage c1 -d "${SMALL_ROOT_DISK}"
+  lxc exec c1 -- sh -c "echo foo > /root/foo"
+
+  # The target must be configured.
+  ! lxc export c1 --backup-target s3 || false
+  ! lxc query -X POST /1.0/instances/c1/backups -d '{"name": "b0", "target": "foo"}' || false
+
+  lxc config set backups.target.s3.url="https://${s3Endpoint}/${bucketName}/backups" backups.target.s3.access_key="${accessKey}" backups.target.s3.secret_key="${secretKey}"
+
+  # Store a backup in the bucket and check it is recorded with its location.
+  lxc query -X POST /1.0/instances/c1/backups -d '{"name": "b0", "target": "s3"}'
+  [ "$(lxc query /1.0/instances/c1/backups/b0 | jq -r '.target')" = "s3" ]
+  location="$(lxc query /1.0/instances/c1/backups/b0 | jq -r '.target_location')"
+  [ -n "${location}" ]
+
+  # The backup can still be downloaded through LXD.
+  lxc query /1.0/instances/c1/backups/b0/export > "${TEST_DIR}/c1-s3.tar.gz"
+  tar -tzf "${TEST_DIR}/c1-s3.tar.gz" backup/index.yaml
+  rm "${TEST_DIR}/c1-s3.tar.gz"
+
+  # Backups can only be imported into the project they were taken from.
+  lxc project create foo -c features.images=false -c features.profiles=false
+  ! lxc import "${location}" --backup-target s3 c2 --project foo || false
+  lxc project delete foo
+  ! lxc import "default/instances/../../foo/instances/c1/b0" --backup-target s3 c2 || false
+  ! lxc import "/${location}" --backup-target s3 c2 || false
+
+  # Restore the instance straight from the bucket.
+  lxc delete -f c1
+  lxc import "${location}" --backup-target s3 c2
+  lxc start c2
+  [ "$(lxc exec c2 -- cat /root/foo)" = "foo" ]
+
+  # Deleting the backup removes the object from the bucket.
+  lxc launch testimage c1 -d "${SMALL_ROOT_DISK}"
+  lxc export c1 --backup-target s3 | grep -F "stored at: default/instances/c1/"
+  location="$(lxc query /1.0/instances/c1/backups | jq -r '.[0]' | xargs lxc query | jq -r '.target_location')"
+  lxc query -X DELETE "$(lxc query /1.0/instances/c1/backups | jq -r '.[0]')"
+  ! lxc import "${location}" --backup-target s3 c3 || false
+  lxc delete -f c1 c2
+
+  # Custom volume backups.
+  lxc storage volume create "${pool}" vol1
+  lxc storage volume export "${pool}" vol1 --backup-target s3 | grep -F "stored at: default/custom/${pool}/vol1/"
+  [ "$(lxc query "/1.0/storage-pools/${pool}/volumes/custom/vol1/backups?recursion=1" | jq -r '.[0].target')" = "s3" ]
+  lxc storage volume delete "${pool}" vol1
+
+  lxc config unset backups.target.s3.url
+  lxc config unset backups.target.s3.access_key
+  lxc config unset backups.target.s3.secret_key
+  lxc storage bucket delete "${pool}" "${bucketName}"
+  lxc config unset core.storage_buckets_address
+}
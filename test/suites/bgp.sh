@@ -49,6 +49,48 @@ test_bgp() {
     exit 1
   fi
 
+  sub_test "Configure route import and BFD for a bridge network peer"
+  local brName="lxdt$$"
+  lxc network create "${brName}" ipv4.address=192.0.2.254/24 ipv6.address=none
+  ! lxc network set "${brName}" bgp.peers.router1.address=192.0.2.2 bgp.peers.router1.asn=65001 bgp.peers.router1.import_prefixes=foo || false
+  ! lxc network set "${brName}" bgp.peers.router1.address=192.0.2.2 bgp.peers.router1.asn=65001 bgp.peers.router1.import_prefixes=198.51.100.1/24 || false
+  ! lxc network set "${brName}" bgp.peers.router1.address=192.0.2.2 bgp.peers.router1.asn=65001 bgp.peers.router1.bfd=foo || false
+  lxc network set "${brName}" bgp.peers.router1.address=192.0.2.2 bgp.peers.router1.asn=65001 bgp.peers.router1.import_prefixes=198.51.100.0/24,2001:db8::/32 bgp.peers.router1.bfd=true
+
+  # The BFD listener is started along with the session.
+  ss -ulnH | grep -F ":3784 "
+
+  sub_test "Check the BGP state of the network"
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].name')" = "router1" ]
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].asn')" = "65001" ]
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].state')" != "established" ]
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].bfd_state')" = "down" ]
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].received_prefixes | length')" = "0" ]
+  lxc network list-bgp-peers "${brName}" --format csv | grep -xE "router1,192\.0\.2\.2,65001,[A-Z]+,DOWN,0,0,0"
+
+  # Disabling BFD stops the session and the listener.
+  lxc network unset "${brName}" bgp.peers.router1.bfd
+  [ "$(lxc query "/1.0/networks/${brName}/bgp" | jq -r '.peers[0].bfd_state')" = "" ]
+  ! ss -ulnH | grep -F ":3784 " || false
+
+  lxc network delete "${brName}"
+
+  sub_test "Create an EVPN bridge network"
+  brName="ev$$"
+  ! lxc network create "${brName}" bridge.mode=evpn bridge.mtu=1500 evpn.local_address=127.0.0.1 || false
+  ! lxc network create "${brName}" bridge.mode=standard evpn.vni=100 || false
+  lxc network create "${brName}" ipv4.address=192.0.2.254/24 ipv6.address=none bridge.mode=evpn evpn.vni=100 evpn.local_address=127.0.0.1 evpn.peers=127.0.0.2
+  [ "$(ip -d -j link show "${brName}-evpn" | jq -r '.[0].linkinfo.info_data.id')" = "100" ]
+  [ "$(ip -d -j link show "${brName}-evpn" | jq -r '.[0].linkinfo.info_data.learning')" = "false" ]
+  [ "$(cat "/sys/class/net/${brName}/mtu")" = "1450" ]
+
+  # Changing the VNI recreates the overlay.
+  lxc network set "${brName}" evpn.vni=200
+  [ "$(ip -d -j link show "${brName}-evpn" | jq -r '.[0].linkinfo.info_data.id')" = "200" ]
+
+  lxc network delete "${brName}"
+  ! ip link show "${brName}-evpn" || false
+
   sub_test "Unconfigure BGP listener and verify it is no longer listening"
   lxc config set core.bgp_address="" core.bgp_routerid="" core.bgp_asn=""
 
@@ -5826,6 +5826,127 @@ test_clustering_replicator_scheduled() {
   kill_lxd "${LXD_ONE_DIR}"
 }
 
+test_clustering_replicator_rpo() {
+  # Create two standalone clustered LXD daemons to simulate two separate clusters.
+  LXD_ONE_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
+  spawn_lxd "${LXD_ONE_DIR}" false
+
+  LXD_TWO_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
+  spawn_lxd "${LXD_TWO_DIR}" false
+
+  # Enable clustering on both.
+  LXD_DIR="${LXD_ONE_DIR}" lxc cluster enable node1
+  LXD_DIR="${LXD_TWO_DIR}" lxc cluster enable node2
+
+  # Create projects on both clusters.
+  LXD_DIR="${LXD_ONE_DIR}" lxc project create replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc project create replicator-project
+
+  # Setup auth groups and cluster links so replica.mode validation can read the
+  # target project through the cluster-link identity, just like the basic test.
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group create replicator-group
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group permission add replicator-group project replicator-project operator
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group permission add replicator-group project replicator-project can_edit
+  LXD_ONE_TRUST_TOKEN="$(LXD_DIR="${LXD_ONE_DIR}" lxc cluster link create lxd_two --quiet --auth-group replicator-group)"
+
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group create replicator-group
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group permission add replicator-group project replicator-project operator
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group permission add replicator-group project replicator-project can_edit
+  LXD_DIR="${LXD_TWO_DIR}" lxc cluster link create lxd_one --token "${LXD_ONE_TRUST_TOKEN}" --auth-group replicator-group
+
+  # Configure replica project settings.
+  LXD_DIR="${LXD_TWO_DIR}" lxc project set replicator-project replica.cluster=lxd_one replica.mode=standby
+  LXD_DIR="${LXD_ONE_DIR}" lxc project set replicator-project replica.cluster=lxd_two replica.mode=leader
+
+  # Setup storage on both clusters.
+  LXD_DIR="${LXD_ONE_DIR}" lxc storage create pool1 dir --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc storage create pool1 dir --project replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc profile device add default root disk path="/" pool="pool1" --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc profile device add default root disk path="/" pool="pool1" --project replicator-project
+
+  # Create the replicator and an instance to replicate.
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator create my-replicator cluster=lxd_two --project replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc init --empty c1 --project replicator-project -d "${SMALL_ROOT_DISK}"
+
+  # The recovery point objective can't be combined with a schedule.
+  ! LXD_DIR="${LXD_ONE_DIR}" lxc replicator set my-replicator replication.rpo=1h schedule="* * * * *" --project replicator-project || false
+  ! LXD_DIR="${LXD_ONE_DIR}" lxc replicator set my-replicator replication.rpo=0s --project replicator-project || false
+
+  sub_test "Wait for the continuous replicator run"
+
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator set my-replicator replication.rpo=1h --project replicator-project
+
+  # The continuous replication task checks for replicators to run every 10 seconds.
+  local i
+  for i in $(seq 30); do
+    if LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/replicators/my-replicator/state?project=replicator-project | jq --exit-status '.instances[] | select(.name == "c1") | .status == "Completed"'; then
+      break
+    fi
+
+    sleep 1
+  done
+
+  LXD_DIR="${LXD_TWO_DIR}" lxc list c1 --project replicator-project -f csv -c ns | grep -xF 'c1,STOPPED'
+  LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/replicators/my-replicator/state?project=replicator-project | jq --exit-status '.instances[] | select(.name == "c1") | .status == "Completed" and .lag >= 0 and .lag < 3600'
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator info my-replicator --project replicator-project | grep -F 'RPO: 1h'
+
+  # Runs keep being started after the previous one completes.
+  local runs_before
+  runs_before="$(LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/replicators/my-replicator/runs?project=replicator-project | jq 'length')"
+  for i in $(seq 30); do
+    if LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/replicators/my-replicator/runs?project=replicator-project | jq --exit-status --argjson before "${runs_before}" 'length > $before'; then
+      break
+    fi
+
+    sleep 1
+  done
+
+  LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/replicators/my-replicator/runs?project=replicator-project | jq --exit-status --argjson before "${runs_before}" 'length > $before'
+
+  # No warning is raised while the replication lag is within the recovery point objective.
+  LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/warnings?recursion=1 | jq --exit-status '[.[] | select(.type == "Replicator recovery point objective exceeded")] | length == 0'
+
+  sub_test "Verify a warning is raised when the replication lag exceeds the recovery point objective"
+
+  # Runs fail while the target cluster is down, so the replication lag grows past the recovery point objective.
+  shutdown_lxd "${LXD_TWO_DIR}"
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator set my-replicator replication.rpo=2s --project replicator-project
+
+  for i in $(seq 30); do
+    if LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/warnings?recursion=1 | jq --exit-status '[.[] | select(.type == "Replicator recovery point objective exceeded" and .status == "new")] | length == 1'; then
+      break
+    fi
+
+    sleep 1
+  done
+
+  LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/warnings?recursion=1 | jq --exit-status '[.[] | select(.type == "Replicator recovery point objective exceeded" and .status == "new" and .project == "replicator-project" and (.last_message | contains("c1")))] | length == 1'
+
+  sub_test "Verify the warning is resolved once the replication lag is back within the recovery point objective"
+
+  respawn_lxd "${LXD_TWO_DIR}" true
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator set my-replicator replication.rpo=1h --project replicator-project
+
+  for i in $(seq 30); do
+    if LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/warnings?recursion=1 | jq --exit-status '[.[] | select(.type == "Replicator recovery point objective exceeded" and .status == "resolved")] | length == 1'; then
+      break
+    fi
+
+    sleep 1
+  done
+
+  LXD_DIR="${LXD_ONE_DIR}" lxc query /1.0/warnings?recursion=1 | jq --exit-status '[.[] | select(.type == "Replicator recovery point objective exceeded" and .status == "resolved")] | length == 1'
+
+  # Stop the continuous replication before cleanup.
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator unset my-replicator replication.rpo --project replicator-project
+
+  # Cleanup
+  LXD_DIR="${LXD_TWO_DIR}" lxc profile device remove default root --project replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc profile device remove default root --project replicator-project
+  kill_lxd "${LXD_TWO_DIR}"
+  kill_lxd "${LXD_ONE_DIR}"
+}
+
 test_clustering_replicator_dr() {
   # Create two standalone clustered LXD daemons to simulate two separate clusters.
   LXD_ONE_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
@@ -5981,6 +6102,98 @@ test_clustering_replicator_dr() {
   kill_lxd "${LXD_ONE_DIR}"
 }
 
+test_clustering_replicator_failover() {
+  # Create two standalone clustered LXD daemons to simulate two separate clusters.
+  LXD_ONE_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
+  spawn_lxd "${LXD_ONE_DIR}" false
+
+  LXD_TWO_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
+  spawn_lxd "${LXD_TWO_DIR}" false
+
+  # Enable clustering on both.
+  LXD_DIR="${LXD_ONE_DIR}" lxc cluster enable node1
+  LXD_DIR="${LXD_TWO_DIR}" lxc cluster enable node2
+
+  # Create projects on both clusters.
+  LXD_DIR="${LXD_ONE_DIR}" lxc project create replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc project create replicator-project
+
+  # Setup auth groups and cluster links so replica.mode validation can read the
+  # target project through the cluster-link identity, just like the basic test.
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group create replicator-group
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group permission add replicator-group project replicator-project operator
+  LXD_DIR="${LXD_ONE_DIR}" lxc auth group permission add replicator-group project replicator-project can_edit
+  LXD_ONE_TRUST_TOKEN="$(LXD_DIR="${LXD_ONE_DIR}" lxc cluster link create lxd_two --quiet --auth-group replicator-group)"
+
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group create replicator-group
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group permission add replicator-group project replicator-project operator
+  LXD_DIR="${LXD_TWO_DIR}" lxc auth group permission add replicator-group project replicator-project can_edit
+  LXD_DIR="${LXD_TWO_DIR}" lxc cluster link create lxd_one --token "${LXD_ONE_TRUST_TOKEN}" --auth-group replicator-group
+
+  # Configure replica project settings.
+  LXD_DIR="${LXD_TWO_DIR}" lxc project set replicator-project replica.cluster=lxd_one replica.mode=standby
+  LXD_DIR="${LXD_ONE_DIR}" lxc project set replicator-project replica.cluster=lxd_two replica.mode=leader
+
+  # Setup storage on both clusters.
+  LXD_DIR="${LXD_ONE_DIR}" lxc storage create pool1 dir --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc storage create pool1 dir --project replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc profile device add default root disk path="/" pool="pool1" --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc profile device add default root disk path="/" pool="pool1" --project replicator-project
+
+  # Create a replicator on each side, the one on LXD_TWO is used to fail over and the one on LXD_ONE to fail back.
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator create my-replicator cluster=lxd_two --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc replicator create my-replicator cluster=lxd_one --project replicator-project
+
+  # c2 is empty and can't be started, so it is excluded from the instances started on promotion.
+  LXD_DIR="${LXD_ONE_DIR}" ensure_import_testimage replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc launch testimage c1 --project replicator-project -d "${SMALL_ROOT_DISK}"
+  LXD_DIR="${LXD_ONE_DIR}" lxc init --empty c2 --project replicator-project -d "${SMALL_ROOT_DISK}" -c boot.autostart=false
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator run my-replicator --project replicator-project
+  LXD_DIR="${LXD_TWO_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,STOPPED'
+  LXD_DIR="${LXD_TWO_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c2,STOPPED'
+
+  sub_test "Verify a failed failover restarts the instances stopped on the leader"
+
+  # A running local instance makes the final sync fail after the instances on LXD_ONE have been stopped.
+  LXD_DIR="${LXD_TWO_DIR}" lxc start c1 --project replicator-project
+  ! LXD_DIR="${LXD_TWO_DIR}" lxc replicator failover my-replicator --project replicator-project || false
+  LXD_DIR="${LXD_ONE_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,RUNNING'
+  LXD_DIR="${LXD_ONE_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c2,STOPPED'
+  [ "$(LXD_DIR="${LXD_ONE_DIR}" lxc project get replicator-project replica.mode)" = "leader" ]
+  [ "$(LXD_DIR="${LXD_TWO_DIR}" lxc project get replicator-project replica.mode)" = "standby" ]
+  LXD_DIR="${LXD_TWO_DIR}" lxc stop c1 --force --project replicator-project
+
+  sub_test "Fail over to LXD_TWO"
+
+  LXD_DIR="${LXD_TWO_DIR}" lxc replicator failover my-replicator --project replicator-project
+  [ "$(LXD_DIR="${LXD_ONE_DIR}" lxc project get replicator-project replica.mode)" = "standby" ]
+  [ "$(LXD_DIR="${LXD_TWO_DIR}" lxc project get replicator-project replica.mode)" = "leader" ]
+  LXD_DIR="${LXD_ONE_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,STOPPED'
+  LXD_DIR="${LXD_TWO_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,RUNNING'
+  LXD_DIR="${LXD_TWO_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c2,STOPPED'
+
+  # Failing over again is rejected as the local project is no longer a standby.
+  [ "$(CLIENT_DEBUG="" SHELL_TRACING="" LXD_DIR="${LXD_TWO_DIR}" lxc replicator failover my-replicator --project replicator-project 2>&1)" = 'Error: Project must have "replica.mode" set to standby to fail over' ]
+
+  sub_test "Fail back to LXD_ONE"
+
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator failback my-replicator --project replicator-project
+  [ "$(LXD_DIR="${LXD_ONE_DIR}" lxc project get replicator-project replica.mode)" = "leader" ]
+  [ "$(LXD_DIR="${LXD_TWO_DIR}" lxc project get replicator-project replica.mode)" = "standby" ]
+  LXD_DIR="${LXD_ONE_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,RUNNING'
+  LXD_DIR="${LXD_ONE_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c2,STOPPED'
+  LXD_DIR="${LXD_TWO_DIR}" lxc list --project replicator-project -f csv -c ns | grep -xF 'c1,STOPPED'
+
+  # Replication resumes in the original direction.
+  LXD_DIR="${LXD_ONE_DIR}" lxc replicator run my-replicator --project replicator-project
+
+  # Cleanup
+  LXD_DIR="${LXD_TWO_DIR}" lxc profile device remove default root --project replicator-project
+  LXD_DIR="${LXD_ONE_DIR}" lxc profile device remove default root --project replicator-project
+  kill_lxd "${LXD_TWO_DIR}"
+  kill_lxd "${LXD_ONE_DIR}"
+}
+
 test_clustering_replicator_snapshot() {
   # Create two standalone clustered LXD daemons to simulate two separate clusters.
   LXD_ONE_DIR=$(mktemp -d -p "${TEST_DIR}" XXX)
@@ -3,8 +3,11 @@ test_console() {
 
   lxc launch testimage cons1
 
-  # The VGA console is only available for VMs
+  # The VGA and VNC consoles and screenshots are only available for VMs
   ! lxc console --type vga cons1 || false
+  ! lxc console --type vnc cons1 || false
+  ! lxc console cons1 --screenshot "${TEST_DIR}/cons1.png" || false
+  ! lxc query "/1.0/instances/cons1/console?type=invalid" || false
 
   # Simulate console interactions with 'expect' and use 'tr' and 'grep' to
   # filter out leaked (control) chars. To debug, use 'expect -d'.
@@ -77,6 +80,31 @@ test_console_vm() {
   wait "${CONSOLE_PID}" || true
   ! [ -e "${SPICE_UNIX_SOCKET}" ] || false
 
+  # The VNC console is only available when enabled
+  ! lxc console --type vnc v1 || false
+  lxc stop --force v1
+  lxc config set v1 security.vnc=true
+  lxc start v1
+
+  echo "===> Check VNC console address"
+  lxc console --type vnc v1 > "${OUTPUT}" &
+  CONSOLE_PID=$!
+  sleep 0.1
+  grep -F "raw VNC socket" < "${OUTPUT}"
+
+  VNC_UNIX_SOCKET="$(tail -n1 < "${OUTPUT}" | tr -d ' ')"
+  [ -S "${VNC_UNIX_SOCKET}" ]
+
+  echo "===> Test VNC socket connectivity"
+  nc -zvU "${VNC_UNIX_SOCKET}"
+  wait "${CONSOLE_PID}" || true
+  ! [ -e "${VNC_UNIX_SOCKET}" ] || false
+
+  echo "===> Check VGA screenshot"
+  lxc console v1 --screenshot "${TEST_DIR}/v1.png"
+  [ "$(head -c 4 "${TEST_DIR}/v1.png" | tail -c 3)" = "PNG" ]
+  rm "${TEST_DIR}/v1.png"
+
   # Cleanup
   lxc delete --force v1
   rm "${OUTPUT}"
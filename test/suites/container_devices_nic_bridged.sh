@@ -368,6 +368,51 @@ test_container_devices_nic_bridged() {
     nft -nn list chain netdev lxd "egress.netprio.${ctName}.${vethHostName}" | grep -F "meta priority set 0:6"
   fi
 
+  # Check burst sizes and DSCP marking are applied on update.
+  lxc config device set "${ctName}" eth0 limits.ingress.burst=64KiB limits.egress.burst=128KiB
+  if ! tc class show dev "${vethHostName}" | grep -F "burst 64Kb" ; then
+    echo "limits.ingress.burst invalid"
+    false
+  fi
+  if ! tc filter show dev "${vethHostName}" egress | grep -F "burst 128Kb" ; then
+    echo "limits.egress.burst invalid"
+    false
+  fi
+
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! lxc config device set "${ctName}" eth0 limits.dscp 46 || false
+  else
+    lxc config device set "${ctName}" eth0 limits.dscp 46
+    nft -nn list chain netdev lxd "ingress.dscp.${ctName}.${vethHostName}" | grep -F "ip dscp set 0x2e"
+
+    # Check the network default is used when the NIC doesn't set it.
+    lxc config device unset "${ctName}" eth0 limits.dscp
+    ! nft -nn list chain netdev lxd "ingress.dscp.${ctName}.${vethHostName}" || false
+    lxc network set "${brName}" qos.dscp 10
+    lxc config device set "${ctName}" eth0 limits.ingress.burst 32KiB
+    nft -nn list chain netdev lxd "ingress.dscp.${ctName}.${vethHostName}" | grep -F "ip dscp set 0x0a"
+    lxc network unset "${brName}" qos.dscp
+  fi
+
+  lxc config device unset "${ctName}" eth0 limits.ingress.burst
+  lxc config device unset "${ctName}" eth0 limits.egress.burst
+
+  # Check the minimum bandwidth is guaranteed within the ingress limit.
+  lxc config device set "${ctName}" eth0 limits.ingress.min=1Mbit
+  tc class show dev "${vethHostName}" | grep -F "class htb 1:1 root" | grep -F "rate 3Mbit"
+  tc class show dev "${vethHostName}" | grep -F "class htb 1:10 parent 1:1" | grep -F "rate 1Mbit ceil 3Mbit"
+  ! lxc config device set "${ctName}" eth0 limits.ingress.min=4Mbit || false
+  lxc config device unset "${ctName}" eth0 limits.ingress.min
+  ! tc class show dev "${vethHostName}" | grep -F "class htb 1:1 root" || false
+  tc class show dev "${vethHostName}" | grep -F "class htb 1:10 root" | grep -F "rate 3Mbit"
+
+  # Check the network default is used when the NIC doesn't set it.
+  lxc network set "${brName}" qos.ingress.min 2Mbit
+  lxc config device set "${ctName}" eth0 limits.ingress.burst 32KiB
+  tc class show dev "${vethHostName}" | grep -F "class htb 1:10 parent 1:1" | grep -F "rate 2Mbit ceil 3Mbit"
+  lxc network unset "${brName}" qos.ingress.min
+  lxc config device unset "${ctName}" eth0 limits.ingress.burst
+
   # Check custom MTU is applied update.
   if [ "$(lxc exec "${ctName}" -- cat /sys/class/net/eth0/mtu)" != "1402" ]; then
     echo "mtu invalid"
@@ -137,6 +137,33 @@ test_network() {
   grep -F ",${v4_addr_foo},STATIC" <<< "${list_leases}"
   grep -F ",${v6_addr_foo},STATIC" <<< "${list_leases}"
 
+  # Check DHCP reservations.
+  v4_reserved="$(lxc network get lxdt$$ ipv4.address | cut -d/ -f1)50"
+  v6_reserved="$(lxc network get lxdt$$ ipv6.address | cut -d/ -f1)50"
+  lxc network lease reserve lxdt$$ 00:16:3E:00:00:50 "${v4_reserved}" --hostname printer
+  lxc network lease reserve lxdt$$ 00:16:3e:00:00:50 "${v6_reserved}"
+  grep -F "00:16:3e:00:00:50,${v4_reserved},[${v6_reserved}],printer" "${LXD_DIR}/networks/lxdt$$/dnsmasq.hosts/_reservation.00-16-3e-00-00-50"
+  list_leases="$(lxc network lease list -f csv lxdt$$)"
+  grep -xF "printer,00:16:3e:00:00:50,${v4_reserved},RESERVED" <<< "${list_leases}"
+  ! lxc network lease reserve lxdt$$ 00:16:3e:00:00:51 "${v4_reserved}" || false
+  ! lxc network lease reserve lxdt$$ 00:16:3e:00:00:51 "${v4_addr}" || false
+  ! lxc network lease reserve lxdt$$ 00:16:3e:00:00:51 "$(lxc network get lxdt$$ ipv4.address | cut -d/ -f1)" || false
+  ! lxc network lease reserve lxdt$$ 00:16:3e:00:00:51 192.0.2.50 || false
+  lxc network lease release lxdt$$ "${v4_reserved}"
+  ! grep -F "${v4_reserved}" "${LXD_DIR}/networks/lxdt$$/dnsmasq.hosts/_reservation.00-16-3e-00-00-50" || false
+  lxc network lease release lxdt$$ "${v6_reserved}"
+  [ ! -e "${LXD_DIR}/networks/lxdt$$/dnsmasq.hosts/_reservation.00-16-3e-00-00-50" ]
+  ! lxc network lease release lxdt$$ "${v4_reserved}" || false
+
+  # Check DHCP options.
+  lxc network lease option lxdt$$ 42 192.0.2.10
+  [ "$(lxc network get lxdt$$ ipv4.dhcp.options.42)" = "192.0.2.10" ]
+  pgrep -af dnsmasq | grep -F -- "--dhcp-option-force=42,192.0.2.10"
+  lxc network lease option lxdt$$ -f csv | grep -xF "42,192.0.2.10"
+  ! lxc network set lxdt$$ ipv4.dhcp.options.255 foo || false
+  lxc network lease option lxdt$$ 42 ""
+  ! pgrep -af dnsmasq | grep -F -- "--dhcp-option-force=42," || false
+
   # Request DHCPv6 lease (if udhcpc6 is in busybox image).
   if lxc exec nettest -- busybox --list | grep -wF udhcpc6 ; then
     lxc exec nettest -- udhcpc6 -f -i eth0 -n -q -t5 2>&1 | grep -F 'IPv6 obtained'
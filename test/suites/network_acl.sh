@@ -122,7 +122,18 @@ EOF
   if [ "$firewallDriver" = "xtables" ]; then
     iptables -w -S | grep -xF -- "-A lxd_acl_${netName} -s 192.168.1.2/32 -d ${daddr} -o ${netName} -p tcp -m multiport --dports 22,2222:2223 -j ACCEPT"
   else
-    nft -nn list chain inet lxd "acl.${netName}" | grep -F "oifname \"${netName}\" ip saddr 192.168.1.2 ip daddr ${daddr} tcp dport { 22, 2222-2223 } accept"
+    nft -nn list chain inet lxd "acl.${netName}" | grep -F "oifname \"${netName}\" ip saddr 192.168.1.2 ip daddr ${daddr} tcp dport { 22, 2222-2223 } counter packets 0 bytes 0 accept comment \"lxd_acl"
+  fi
+
+  echo "Verify the ACL rule counters"
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! lxc network acl info testacl || false # Not supported with xtables.
+  else
+    acl_state_output="$(lxc query /1.0/network-acls/testacl/state)"
+    jq --exit-status '.ingress | length == 2' <<< "${acl_state_output}"
+    jq --exit-status '.egress == []' <<< "${acl_state_output}"
+    jq --exit-status '.ingress[1].packets == 0' <<< "${acl_state_output}"
+    [ "$(lxc network acl info testacl | grep -cF 'Packets: 0')" = 2 ]
   fi
 
   echo "Stop applying ACL to test network"
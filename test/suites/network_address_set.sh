@@ -0,0 +1,94 @@
+test_network_address_set() {
+  firewallDriver=$(lxc info | awk -F ":" '/firewall:/{gsub(/ /, "", $0); print $2}')
+  netName=lxdt$$
+
+  lxc network create "${netName}" ipv4.address=192.0.2.1/24 ipv6.address=fd42:4242:4242:1010::1/64
+
+  # Check basic address set creation, listing, deletion and project namespacing support.
+  ! lxc network address-set create 192.168.1.1 || false # Don't allow non-hostname compatible names.
+  ! lxc network address-set create testset foo || false # Don't allow invalid addresses.
+  ! lxc network address-set create testset 192.0.2.10 192.0.2.10 || false # Don't allow duplicate addresses.
+  lxc network address-set create testset 192.0.2.10 198.51.100.0/24 2001:db8::10 user.mykey=foo
+  lxc project create testproj -c features.networks=true
+  lxc network address-set create testset --project testproj
+  [ "$(lxc network address-set ls -f csv | grep -cwF 'testset')" = 1 ]
+  [ "$(lxc network address-set ls -f csv --project testproj | grep -cwF 'testset')" = 1 ]
+  [ "$(lxc network address-set get testset user.mykey)" = "foo" ]
+  lxc network address-set delete testset --project testproj
+  lxc project delete testproj
+
+  # Address addition and removal.
+  ! lxc network address-set add testset 192.0.2.10 || false # Address already in set.
+  lxc network address-set add testset 192.0.2.11
+  lxc network address-set remove testset 192.0.2.11
+  ! lxc network address-set remove testset 192.0.2.11 || false # Address not in set.
+  set_show_output="$(lxc query /1.0/network-address-sets/testset)"
+  jq --exit-status '.addresses == ["192.0.2.10", "198.51.100.0/24", "2001:db8::10"]' <<< "${set_show_output}"
+  jq --exit-status '.used_by | length == 0' <<< "${set_show_output}"
+
+  # Address set PATCH merges config and keeps addresses.
+  lxc query --wait -X PATCH -d '{"config": {"user.myotherkey": "bah"}}' /1.0/network-address-sets/testset
+  set_show_output="$(lxc query /1.0/network-address-sets/testset)"
+  jq --exit-status '.config["user.mykey"] == "foo"' <<< "${set_show_output}"
+  jq --exit-status '.config["user.myotherkey"] == "bah"' <<< "${set_show_output}"
+  jq --exit-status '.addresses | length == 3' <<< "${set_show_output}"
+
+  # Reference the address set from an ACL rule.
+  lxc network acl create testacl
+  ! lxc network acl rule add testacl ingress action=allow source="\$missing" || false # Unknown address set.
+  lxc network acl rule add testacl ingress action=allow source="\$testset" protocol=tcp destination_port=22
+  lxc network acl rule add testacl egress action=allow destination="\$testset"
+  [ "$(lxc network address-set show testset | grep -cF '/1.0/network-acls/testacl')" = 1 ]
+  ! lxc network address-set delete testset || false # Can't delete an address set in use.
+  ! lxc network address-set rename testset testset2 || false # Can't rename an address set in use.
+
+  echo "Apply ACL to network"
+  lxc network set "${netName}" security.acls=testacl
+
+  echo "Verify corresponding firewall rules"
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -S "lxd_acl_${netName}" | grep -F -- "-s 192.0.2.10/32"
+    iptables -w -S "lxd_acl_${netName}" | grep -F -- "-s 198.51.100.0/24"
+  else
+    setID="$(nft -nn list sets inet | grep -oE "addrset[0-9]+_ip4\.${netName}" | head -n1)"
+    [ -n "${setID}" ]
+    nft -nn list set inet lxd "${setID}" | grep -F "192.0.2.10"
+    nft -nn list set inet lxd "${setID}" | grep -F "198.51.100.0/24"
+    nft -nn list chain inet lxd "acl.${netName}" | grep -F "ip saddr @${setID}"
+  fi
+
+  echo "Update address set in place and verify firewall"
+  lxc network address-set add testset 203.0.113.5
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -S "lxd_acl_${netName}" | grep -F -- "-s 203.0.113.5/32"
+  else
+    nft -nn list set inet lxd "${setID}" | grep -F "203.0.113.5"
+  fi
+
+  lxc network address-set remove testset 203.0.113.5
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! iptables -w -S "lxd_acl_${netName}" | grep -F -- "203.0.113.5" || false
+  else
+    ! nft -nn list set inet lxd "${setID}" | grep -F "203.0.113.5" || false
+  fi
+
+  echo "Stop applying ACL to test network"
+  lxc network unset "${netName}" security.acls
+  if [ "$firewallDriver" = "nftables" ]; then
+    ! nft -nn list set inet lxd "${setID}" || false
+  fi
+
+  # Address set is released once no ACL references it.
+  lxc network acl delete testacl
+  [ "$(lxc query /1.0/network-address-sets/testset | jq -r '.used_by | length')" = 0 ]
+
+  # Address set rename.
+  ! lxc network address-set rename testset 192.168.1.1 || false # Don't allow non-hostname compatible names.
+  lxc network address-set rename testset testset2
+  lxc network address-set show testset2
+
+  lxc network address-set delete testset2
+  [ "$(lxc network address-set ls -f csv || echo fail)" = "" ]
+
+  lxc network delete "${netName}"
+}
@@ -0,0 +1,132 @@
+test_network_load_balancer() {
+  firewallDriver=$(lxc info | awk -F ":" '/firewall:/{gsub(/ /, "", $0); print $2}')
+  netName=lxdt$$
+
+  lxc network create "${netName}" \
+        ipv4.address=192.0.2.1/24 \
+        ipv6.address=fd42:4242:4242:1010::1/64
+
+  # Check automatic listen address allocation isn't supported on bridges.
+  ! lxc network load-balancer create "${netName}" --allocate=ipv4 || false
+
+  # Check creating empty load balancer doesn't create any firewall rules.
+  lxc network load-balancer create "${netName}" 198.51.100.1
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! iptables -w -t nat -S | grep -F "generated for LXD network-load-balancer ${netName}" || false
+  else
+    ! nft -nn list chain inet lxd "lbprert.${netName}" || false
+  fi
+
+  # Check the listen address can't be shared with a forward.
+  ! lxc network forward create "${netName}" 198.51.100.1 || false
+  ! lxc network load-balancer create "${netName}" 198.51.100.1 || false
+
+  # Check load balancer is exported via BGP prefixes.
+  lxc query /internal/testing/bgp | grep -F "198.51.100.1/32"
+
+  # Check backends must be in the network's subnet.
+  ! lxc network load-balancer backend add "${netName}" 198.51.100.1 b0 198.51.100.2 || false
+
+  # Check a port spread across two backends creates valid firewall rules.
+  lxc network load-balancer backend add "${netName}" 198.51.100.1 b1 192.0.2.2 8080
+  lxc network load-balancer backend add "${netName}" 198.51.100.1 b2 192.0.2.3
+  ! lxc network load-balancer port add "${netName}" 198.51.100.1 tcp 80 missing || false
+  lxc network load-balancer port add "${netName}" 198.51.100.1 tcp 80 b1,b2
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -t nat -S | grep -F -- "-A PREROUTING -d 198.51.100.1/32 -p tcp -m tcp --dport 80 -m statistic --mode nth --every 2 --packet 0 -m comment --comment \"generated for LXD network-load-balancer ${netName}\" -j DNAT --to-destination 192.0.2.2:8080"
+    iptables -w -t nat -S | grep -F -- "-A PREROUTING -d 198.51.100.1/32 -p tcp -m tcp --dport 80 -m comment --comment \"generated for LXD network-load-balancer ${netName}\" -j DNAT --to-destination 192.0.2.3:80"
+    iptables -w -t nat -S | grep -F -- "-A POSTROUTING -s 192.0.2.3/32 -d 192.0.2.3/32 -p tcp -m tcp --dport 80 -m comment --comment \"generated for LXD network-load-balancer ${netName}\" -j MASQUERADE"
+    [ "$(iptables -w -t nat -S | grep -cF "generated for LXD network-load-balancer ${netName}")" -eq 6 ]
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip daddr 198.51.100.1 tcp dport 80 dnat ip to numgen inc mod 2 map { 0 : 192.0.2.2 . 8080, 1 : 192.0.2.3 . 80 }"
+    nft -nn list chain inet lxd "lbout.${netName}" | grep -F "ip daddr 198.51.100.1 tcp dport 80 dnat ip to numgen inc mod 2 map { 0 : 192.0.2.2 . 8080, 1 : 192.0.2.3 . 80 }"
+    nft -nn list chain inet lxd "lbpstrt.${netName}" | grep -F "ip saddr 192.0.2.3 ip daddr 192.0.2.3 tcp dport 80 masquerade"
+  fi
+
+  # Check removing a backend from the port updates the firewall rules.
+  lxc network load-balancer port remove "${netName}" 198.51.100.1 tcp 80
+  lxc network load-balancer port add "${netName}" 198.51.100.1 udp 53 b2
+  if [ "$firewallDriver" = "xtables" ]; then
+    [ "$(iptables -w -t nat -S | grep -cF "generated for LXD network-load-balancer ${netName}")" -eq 3 ]
+    iptables -w -t nat -S | grep -F -- "-A PREROUTING -d 198.51.100.1/32 -p udp -m udp --dport 53 -m comment --comment \"generated for LXD network-load-balancer ${netName}\" -j DNAT --to-destination 192.0.2.3:53"
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip daddr 198.51.100.1 udp dport 53 dnat ip to numgen inc mod 1 map { 0 : 192.0.2.3 . 53 }"
+    ! nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "tcp dport 80" || false
+  fi
+
+  # Check deleting the load balancer clears its firewall rules and BGP prefix.
+  lxc network load-balancer delete "${netName}" 198.51.100.1
+  ! lxc query /internal/testing/bgp | grep -F "198.51.100.1/32" || false
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! iptables -w -t nat -S | grep -F "generated for LXD network-load-balancer ${netName}" || false
+  else
+    ! nft -nn list chain inet lxd "lbprert.${netName}" || false
+    ! nft -nn list chain inet lxd "lbout.${netName}" || false
+    ! nft -nn list chain inet lxd "lbpstrt.${netName}" || false
+  fi
+
+  # Check backends failing their health checks are removed from the load balancer.
+  # The healthy backend is the DNS server listening on the bridge address.
+  lxc network load-balancer create "${netName}" 198.51.100.2
+  lxc network load-balancer backend add "${netName}" 198.51.100.2 up 192.0.2.1 53
+  lxc network load-balancer backend add "${netName}" 198.51.100.2 down 192.0.2.3 53
+  lxc network load-balancer port add "${netName}" 198.51.100.2 tcp 53 up,down
+  lxc network load-balancer backend add "${netName}" 198.51.100.2 down2 192.0.2.4 53
+  lxc network load-balancer port add "${netName}" 198.51.100.2 tcp 5353 down2
+  ! lxc network load-balancer set "${netName}" 198.51.100.2 healthcheck=icmp || false
+  ! lxc network load-balancer set "${netName}" 198.51.100.2 healthcheck.interval=1 || false
+  lxc network load-balancer set "${netName}" 198.51.100.2 healthcheck=tcp healthcheck.interval=5 healthcheck.timeout=1 healthcheck.failure_threshold=1
+  for _ in $(seq 30); do
+    if [ "$firewallDriver" = "xtables" ]; then
+      ! iptables -w -t nat -S | grep -F -- "--to-destination 192.0.2.3:53" && break
+    else
+      nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip daddr 198.51.100.2 tcp dport 53 dnat ip to numgen inc mod 1 map { 0 : 192.0.2.1 . 53 }" && break
+    fi
+
+    sleep 1
+  done
+
+  lxc query "/1.0/networks/${netName}/load-balancers/198.51.100.2/state" | jq -e '.backend_health.up.status == "online" and .backend_health.down.status == "offline"'
+  lxc network load-balancer info "${netName}" 198.51.100.2 | grep -xF "    Status: offline"
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -t nat -S | grep -F -- "-A PREROUTING -d 198.51.100.2/32 -p tcp -m tcp --dport 53 -m comment --comment \"generated for LXD network-load-balancer ${netName}\" -j DNAT --to-destination 192.0.2.1:53"
+    ! iptables -w -t nat -S | grep -F -- "--to-destination 192.0.2.3:53" || false
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip daddr 198.51.100.2 tcp dport 53 dnat ip to numgen inc mod 1 map { 0 : 192.0.2.1 . 53 }"
+  fi
+
+  # Check a port specification whose backends are all offline keeps its backends.
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -t nat -S | grep -F -- "--to-destination 192.0.2.4:53"
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "192.0.2.4"
+  fi
+
+  # Check disabling the health checks restores all the backends.
+  lxc network load-balancer unset "${netName}" 198.51.100.2 healthcheck
+  if [ "$firewallDriver" = "xtables" ]; then
+    iptables -w -t nat -S | grep -F -- "--to-destination 192.0.2.3:53"
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip daddr 198.51.100.2 tcp dport 53 dnat ip to numgen inc mod 2 map { 0 : 192.0.2.1 . 53, 1 : 192.0.2.3 . 53 }"
+  fi
+
+  lxc network load-balancer delete "${netName}" 198.51.100.2
+
+  # Check IPv6 load balancers and that deleting the network clears the load balancer firewall rules.
+  lxc network load-balancer create "${netName}" 2001:db8::1
+  lxc network load-balancer backend add "${netName}" 2001:db8::1 b1 fd42:4242:4242:1010::2 8080
+  lxc network load-balancer port add "${netName}" 2001:db8::1 tcp 80 b1
+  if [ "$firewallDriver" = "xtables" ]; then
+    ip6tables -w -t nat -S | grep -F -- "-j DNAT --to-destination [fd42:4242:4242:1010::2]:8080"
+  else
+    nft -nn list chain inet lxd "lbprert.${netName}" | grep -F "ip6 daddr 2001:db8::1 tcp dport 80 dnat ip6 to numgen inc mod 1 map { 0 : fd42:4242:4242:1010::2 . 8080 }"
+  fi
+
+  lxc network delete "${netName}"
+
+  if [ "$firewallDriver" = "xtables" ]; then
+    ! ip6tables -w -t nat -S | grep -F "generated for LXD network-load-balancer ${netName}" || false
+  else
+    ! nft -nn list chain inet lxd "lbprert.${netName}" || false
+  fi
+}
@@ -0,0 +1,72 @@
+test_network_wireguard() {
+  if ! command -v wg >/dev/null || ! ip link add lxdtwgcheck type wireguard 2>/dev/null; then
+    echo "==> SKIP: WireGuard tests (missing wg tool or kernel support)"
+    return
+  fi
+
+  ip link delete lxdtwgcheck
+
+  brName="lxdt$$"
+  wg1="lxdtwga$$"
+  wg2="lxdtwgb$$"
+
+  lxc network create "${brName}" ipv4.address=192.0.2.1/24 ipv6.address=none
+
+  # Check configuration validation.
+  ! lxc network create "${wg1}" --type=wireguard wireguard.listen_port=foo || false
+  ! lxc network create "${wg1}" --type=wireguard peers.site2.public_key=foo peers.site2.allowed_ips=10.99.0.2/32 || false # Invalid public key.
+  ! lxc network create "${wg1}" --type=wireguard peers.site2.allowed_ips=10.99.0.2/32 || false # Missing public key.
+
+  lxc network create "${wg1}" --type=wireguard ipv4.address=10.99.0.1/24 wireguard.listen_port=51821 wireguard.networks="${brName}"
+  lxc network create "${wg2}" --type=wireguard ipv4.address=10.98.0.1/24 wireguard.listen_port=51822
+
+  # The public key is derived from the private key kept on the server.
+  [ "$(stat -c %a "${LXD_DIR}/networks/${wg1}/wireguard.key")" = "600" ]
+  wg1Key="$(lxc query "/1.0/networks/${wg1}/state" | jq -r '.wireguard.public_key')"
+  [ "$(wg pubkey < "${LXD_DIR}/networks/${wg1}/wireguard.key")" = "${wg1Key}" ]
+  [ "$(wg show "${wg1}" listen-port)" = "51821" ]
+  lxc network info "${wg1}" | grep -xF "  Public key: ${wg1Key}"
+  ! lxc network show "${wg1}" | grep -F "$(cat "${LXD_DIR}/networks/${wg1}/wireguard.key")" || false
+
+  # Exchange the peer configuration between the two networks.
+  ! lxc network wireguard export "${brName}" || false # Not a WireGuard network.
+  lxc network wireguard import "${wg2}" site1 "$(lxc network wireguard export "${wg1}" --endpoint 127.0.0.1)"
+  lxc network wireguard import "${wg1}" site2 "$(lxc network wireguard export "${wg2}" --endpoint 127.0.0.1:51822)"
+  [ "$(lxc network get "${wg2}" peers.site1.public_key)" = "${wg1Key}" ]
+  [ "$(lxc network get "${wg2}" peers.site1.endpoint)" = "127.0.0.1:51821" ]
+  [ "$(lxc network get "${wg2}" peers.site1.allowed_ips)" = "10.99.0.1/32,192.0.2.0/24" ]
+  [ "$(lxc network get "${wg1}" peers.site2.allowed_ips)" = "10.98.0.1/32" ]
+
+  # Peers and routes are applied to the interfaces.
+  wg show "${wg2}" allowed-ips | grep -F "${wg1Key}" | grep -F "192.0.2.0/24"
+  ip -4 route show dev "${wg2}" proto static | grep -F "192.0.2.0/24"
+  ip -4 route show dev "${wg2}" proto static | grep -F "10.99.0.1"
+  [ "$(lxc query "/1.0/networks/${wg2}/state" | jq -r '.wireguard.peers[0].name')" = "site1" ]
+  [ "$(lxc query "/1.0/networks/${wg2}/state" | jq -r '.wireguard.peers[0].endpoint')" = "127.0.0.1:51821" ]
+
+  # Peers can't share a public key or overlapping allowed IPs.
+  ! lxc network set "${wg2}" peers.other.public_key="${wg1Key}" peers.other.allowed_ips=203.0.113.0/24 || false
+  ! lxc network set "${wg2}" peers.other.public_key="$(wg genkey | wg pubkey)" peers.other.allowed_ips=192.0.2.128/25 || false
+
+  # Removing a peer removes it from the interface along with its routes.
+  ! lxc network unset "${wg2}" peers.site1.public_key || false # Public key required.
+  lxc network edit "${wg2}" <<EOF
+config:
+  ipv4.address: 10.98.0.1/24
+  wireguard.listen_port: "51822"
+EOF
+  [ "$(wg show "${wg2}" peers)" = "" ]
+  ! ip -4 route show dev "${wg2}" proto static | grep -F "192.0.2.0/24" || false
+
+  # The key is kept across renames and restarts.
+  lxc network rename "${wg1}" "${wg1}x"
+  [ "$(lxc query "/1.0/networks/${wg1}x/state" | jq -r '.wireguard.public_key')" = "${wg1Key}" ]
+  ! ip link show "${wg1}" || false
+  lxc network rename "${wg1}x" "${wg1}"
+
+  lxc network delete "${wg1}"
+  lxc network delete "${wg2}"
+  [ ! -d "${LXD_DIR}/networks/${wg1}" ]
+  ! ip link show "${wg1}" || false
+  lxc network delete "${brName}"
+}
@@ -157,6 +157,68 @@ test_network_zone() {
 
   lxc network zone record delete lxd.example.net patchtest
 
+  # Test dynamic updates.
+  ! lxc network zone set lxd.example.net peers.upd.update=foo || false
+  lxc network zone set lxd.example.net peers.upd.key=dGVzdGtleQ== peers.upd.update=true
+  nsupdate -y "hmac-sha256:lxd.example.net_upd.:dGVzdGtleQ==" << EOF
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+prereq nxdomain dyn.lxd.example.net
+update add dyn.lxd.example.net 600 A 192.0.2.100
+send
+EOF
+  lxc network zone record show lxd.example.net dyn | grep -F 192.0.2.100
+  dig "@${DNS_ADDR}" -p "${DNS_PORT}" axfr lxd.example.net | grep "dyn.lxd.example.net.\s\+600\s\+IN\s\+A\s\+192.0.2.100"
+
+  # Check that the prerequisites are enforced.
+  ! nsupdate -y "hmac-sha256:lxd.example.net_upd.:dGVzdGtleQ==" << EOF || false
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+prereq nxdomain dyn.lxd.example.net
+update add dyn.lxd.example.net 600 A 192.0.2.101
+send
+EOF
+
+  # Check that unsigned updates and updates to the zone apex are refused.
+  ! nsupdate << EOF || false
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update add dyn.lxd.example.net 600 A 192.0.2.102
+send
+EOF
+  ! nsupdate -y "hmac-sha256:lxd.example.net_upd.:dGVzdGtleQ==" << EOF || false
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update add lxd.example.net 600 A 192.0.2.102
+send
+EOF
+
+  nsupdate -y "hmac-sha256:lxd.example.net_upd.:dGVzdGtleQ==" << EOF
+server ${DNS_ADDR} ${DNS_PORT}
+zone lxd.example.net
+update delete dyn.lxd.example.net A
+send
+EOF
+  ! lxc network zone record show lxd.example.net dyn || false
+  lxc network zone unset lxd.example.net peers.upd.key
+  lxc network zone unset lxd.example.net peers.upd.update
+
+  # Test DNSSEC signing.
+  lxc network zone set lxd.example.net dnssec=true
+  lxc network zone get lxd.example.net volatile.dnssec.ds | grep -w DS
+  [ "$(lxc query /1.0/network-zones/lxd.example.net | jq -r '.config["volatile.dnssec.key"]')" = "null" ]
+  ! lxc network zone set lxd.example.net volatile.dnssec.key=foo || false
+  ! lxc network zone set lxd.example.net volatile.dnssec.ds=foo || false
+  dig "@${DNS_ADDR}" -p "${DNS_PORT}" axfr lxd.example.net | grep -w DNSKEY
+  dig "@${DNS_ADDR}" -p "${DNS_PORT}" axfr lxd.example.net | grep "c1.lxd.example.net.\s\+300\s\+IN\s\+RRSIG\s\+A\s\+"
+
+  # Check that the key is kept when the configuration is replaced.
+  ds="$(lxc network zone get lxd.example.net volatile.dnssec.ds)"
+  lxc query --wait -X PUT /1.0/network-zones/lxd.example.net -d '{"config": {"dnssec": "true", "peers.test.address": "192.0.2.1"}}'
+  [ "$(lxc network zone get lxd.example.net volatile.dnssec.ds)" = "${ds}" ]
+  lxc network zone unset lxd.example.net dnssec
+  ! dig "@${DNS_ADDR}" -p "${DNS_PORT}" axfr lxd.example.net | grep -w RRSIG || false
+
   # Check that the listener survives a restart of LXD
   shutdown_lxd "${LXD_DIR}"
   respawn_lxd "${LXD_DIR}" true
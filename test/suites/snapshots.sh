@@ -472,6 +472,37 @@ test_snapshot_schedule() {
   lxc delete -f c1
 }
 
+test_snapshot_consistency() {
+  ensure_import_testimage
+
+  lxc launch testimage c1 -d "${SMALL_ROOT_DISK}" -c snapshots.consistency=application
+  lxc exec c1 -- mkdir -p /etc/lxd-agent/hooks.d
+  lxc exec c1 -- sh -c 'printf "#!/bin/sh\necho \"\$1\" >> /root/hooks.log\n" > /etc/lxd-agent/hooks.d/10-log'
+  lxc exec c1 -- chmod +x /etc/lxd-agent/hooks.d/10-log
+
+  # Check the hooks are run before and after the snapshot.
+  lxc snapshot c1
+  [ "$(lxc exec c1 -- cat /root/hooks.log | tr '\n' ' ')" = "freeze thaw " ]
+
+  # Check the snapshot was taken after the freeze hooks.
+  lxc restore c1 snap0
+  [ "$(lxc exec c1 -- cat /root/hooks.log)" = "freeze" ]
+
+  # Check a failing hook doesn't prevent the snapshot.
+  lxc exec c1 -- sh -c 'printf "#!/bin/sh\nexit 1\n" > /etc/lxd-agent/hooks.d/20-fail'
+  lxc exec c1 -- chmod +x /etc/lxd-agent/hooks.d/20-fail
+  lxc snapshot c1
+  [ "$(lxc list --columns S --format csv c1)" = "2" ]
+
+  # Check the hooks aren't run for crash-consistent snapshots.
+  lxc exec c1 -- rm /root/hooks.log
+  lxc config set c1 snapshots.consistency=crash
+  lxc snapshot c1
+  ! lxc exec c1 -- test -e /root/hooks.log || false
+
+  lxc delete -f c1
+}
+
 test_snapshot_volume_db_recovery() {
   ensure_import_testimage
 
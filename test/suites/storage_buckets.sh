@@ -151,6 +151,28 @@ EOF
   s3cmdrun "${initAccessKey}" "${initSecretKey}" del "s3://${bucketPrefix}.foo2/${lxdTestFile}"
   lxc storage bucket delete "${poolName}" "${bucketPrefix}.foo2"
 
+  # Test bucket lifecycle, versioning and policy.
+  ! lxc storage bucket create "${poolName}" "${bucketPrefix}.foo3" lifecycle.expiration=0 || false
+  initCreds=$(lxc storage bucket create "${poolName}" "${bucketPrefix}.foo3" lifecycle.expiration=30 versioning=true)
+  initAccessKey=$(echo "${initCreds}" | awk '{ if ($2 == "access" && $3 == "key:") {print $4}}')
+  initSecretKey=$(echo "${initCreds}" | awk '{ if ($2 == "secret" && $3 == "key:") {print $4}}')
+  s3cmdrun "${initAccessKey}" "${initSecretKey}" getlifecycle "s3://${bucketPrefix}.foo3" | grep -F "<Days>30</Days>"
+  lxc storage bucket set "${poolName}" "${bucketPrefix}.foo3" lifecycle.abort_incomplete_uploads=2
+  s3cmdrun "${initAccessKey}" "${initSecretKey}" getlifecycle "s3://${bucketPrefix}.foo3" | grep -F "<DaysAfterInitiation>2</DaysAfterInitiation>"
+  lxc storage bucket unset "${poolName}" "${bucketPrefix}.foo3" lifecycle.expiration
+  lxc storage bucket unset "${poolName}" "${bucketPrefix}.foo3" lifecycle.abort_incomplete_uploads
+  ! s3cmdrun "${initAccessKey}" "${initSecretKey}" getlifecycle "s3://${bucketPrefix}.foo3" || false
+  lxc storage bucket set "${poolName}" "${bucketPrefix}.foo3" versioning=false
+
+  s3cmdrun "${initAccessKey}" "${initSecretKey}" put "${lxdTestFile}" "s3://${bucketPrefix}.foo3"
+  [ "$(curl -s -o /dev/null -w "%{http_code}" "${s3Endpoint}/${bucketPrefix}.foo3/${lxdTestFile}")" = "403" ]
+  lxc storage bucket set "${poolName}" "${bucketPrefix}.foo3" policy.anonymous_read=true
+  [ "$(curl -s -o /dev/null -w "%{http_code}" "${s3Endpoint}/${bucketPrefix}.foo3/${lxdTestFile}")" = "200" ]
+  lxc storage bucket set "${poolName}" "${bucketPrefix}.foo3" policy.anonymous_read=false
+  [ "$(curl -s -o /dev/null -w "%{http_code}" "${s3Endpoint}/${bucketPrefix}.foo3/${lxdTestFile}")" = "403" ]
+  s3cmdrun "${initAccessKey}" "${initSecretKey}" del "s3://${bucketPrefix}.foo3/${lxdTestFile}"
+  lxc storage bucket delete "${poolName}" "${bucketPrefix}.foo3"
+
   # Cleanup test file used earlier.
   rm "${lxdTestFile}"
 
@@ -175,3 +197,97 @@ EOF
 
   delete_object_storage_pool "${poolName}"
 }
+
+test_storage_buckets_local() {
+  local lxd_backend
+
+  lxd_backend=$(storage_backend "$LXD_DIR")
+
+  if [ "$lxd_backend" = "ceph" ]; then
+    export TEST_UNMET_REQUIREMENT="Local storage bucket tests require a local storage backend"
+    return
+  fi
+
+  local poolName bucketName s3Endpoint
+  poolName="$(lxc profile device get default root pool)"
+  bucketName="lxd$$.local"
+  s3Endpoint="127.0.0.1:$(local_tcp_port)"
+
+  s3cmdlocal() {
+    local accessKey secretKey
+    accessKey="${1}"
+    secretKey="${2}"
+    shift 2
+
+    timeout -k 5 10 s3cmd \
+      --access_key="${accessKey}" \
+      --secret_key="${secretKey}" \
+      --host="${s3Endpoint}" \
+      --host-bucket="${s3Endpoint}" \
+      --stop-on-error \
+      --max-retries=0 \
+      --no-check-certificate \
+      --multipart-chunk-size-mb=5 \
+      "$@"
+  }
+
+  # Buckets can be created without the listener but the URL is only reported once it is configured.
+  lxc storage bucket create "${poolName}" "${bucketName}" size=64MiB
+  [ "$(lxc storage bucket show "${poolName}" "${bucketName}" | awk '{if ($1 == "s3_url:") {print $2}}')" = '""' ]
+  lxc storage bucket delete "${poolName}" "${bucketName}"
+
+  lxc config set core.storage_buckets_address "${s3Endpoint}"
+
+  initCreds=$(lxc storage bucket create "${poolName}" "${bucketName}" size=64MiB)
+  adAccessKey=$(echo "${initCreds}" | awk '{ if ($2 == "access" && $3 == "key:") {print $4}}')
+  adSecretKey=$(echo "${initCreds}" | awk '{ if ($2 == "secret" && $3 == "key:") {print $4}}')
+  [ "$(lxc storage bucket show "${poolName}" "${bucketName}" | awk '{if ($1 == "s3_url:") {print $2}}')" = "https://${s3Endpoint}/${bucketName}" ]
+
+  creds=$(lxc storage bucket key create "${poolName}" "${bucketName}" ro-key --role=read-only)
+  roAccessKey=$(echo "${creds}" | awk '{ if ($1 == "Access" && $2 == "key:") {print $3}}')
+  roSecretKey=$(echo "${creds}" | awk '{ if ($1 == "Secret" && $2 == "key:") {print $3}}')
+
+  # Access keys must be unique on each member.
+  ! lxc storage bucket key create "${poolName}" "${bucketName}" dup-key --access-key="${roAccessKey}" || false
+
+  # Each key only sees its own bucket.
+  s3cmdlocal "${adAccessKey}" "${adSecretKey}" ls | grep -F "${bucketName}"
+  s3cmdlocal "${roAccessKey}" "${roSecretKey}" ls | grep -F "${bucketName}"
+  ! s3cmdlocal "${adAccessKey}" "wrong" ls || false
+
+  # Single part and multipart uploads.
+  lxdTestFile="bucketfile_$$.txt"
+  truncate -s 1M "${lxdTestFile}"
+  lxdTestBigFile="bucketbigfile_$$.bin"
+  dd if=/dev/urandom of="${lxdTestBigFile}" bs=1M count=12 status=none
+
+  s3cmdlocal "${adAccessKey}" "${adSecretKey}" put "${lxdTestFile}" "s3://${bucketName}/dir/${lxdTestFile}"
+  s3cmdlocal "${adAccessKey}" "${adSecretKey}" put "${lxdTestBigFile}" "s3://${bucketName}/${lxdTestBigFile}"
+  ! s3cmdlocal "${roAccessKey}" "${roSecretKey}" put "${lxdTestFile}" "s3://${bucketName}/${lxdTestFile}" || false
+
+  s3cmdlocal "${roAccessKey}" "${roSecretKey}" ls "s3://${bucketName}" | grep -F "DIR"
+  s3cmdlocal "${roAccessKey}" "${roSecretKey}" ls "s3://${bucketName}/dir/" | grep -F "${lxdTestFile}"
+
+  s3cmdlocal "${roAccessKey}" "${roSecretKey}" get "s3://${bucketName}/${lxdTestBigFile}" "${lxdTestBigFile}.get"
+  cmp "${lxdTestBigFile}" "${lxdTestBigFile}.get"
+  rm "${lxdTestBigFile}.get"
+
+  # Quotas are enforced through the size of the bucket volume (not available on dir without project quotas).
+  if [ "${lxd_backend}" != "dir" ]; then
+    lxc storage bucket set "${poolName}" "${bucketName}" size=8MiB
+    truncate -s 16M "${lxdTestFile}"
+    ! s3cmdlocal "${adAccessKey}" "${adSecretKey}" put "${lxdTestFile}" "s3://${bucketName}/${lxdTestFile}" || false
+  fi
+
+  ! s3cmdlocal "${roAccessKey}" "${roSecretKey}" del "s3://${bucketName}/${lxdTestBigFile}" || false
+  s3cmdlocal "${adAccessKey}" "${adSecretKey}" del "s3://${bucketName}/${lxdTestBigFile}"
+  ! s3cmdlocal "${adAccessKey}" "${adSecretKey}" ls "s3://${bucketName}" | grep -F "${lxdTestBigFile}" || false
+
+  # Deleted keys are rejected.
+  lxc storage bucket key delete "${poolName}" "${bucketName}" ro-key
+  ! s3cmdlocal "${roAccessKey}" "${roSecretKey}" ls || false
+
+  rm "${lxdTestFile}" "${lxdTestBigFile}"
+  lxc storage bucket delete "${poolName}" "${bucketName}"
+  lxc config unset core.storage_buckets_address
+}
@@ -15,6 +15,7 @@ test_storage_driver_zfs() {
   do_zfs_delegate
   do_zfs_rebase
   do_recursive_copy_snapshot_cleanup
+  do_zfs_bucket_datasets
   do_zfs_bucket_dataset_cleanup
 }
 
@@ -465,6 +466,33 @@ do_recursive_copy_snapshot_cleanup() {
   [ "$(zfs list -rt snapshot "${storage_pool}/deleted/containers" 2>&1)" = "no datasets available" ]
 }
 
+do_zfs_bucket_datasets() {
+  local storage_pool
+  storage_pool="lxdtest-$(basename "${LXD_DIR}")"
+
+  sub_test "Verify storage buckets are backed by datasets"
+
+  lxc storage create "${storage_pool}-buckets" zfs
+
+  local zfs_pool
+  zfs_pool="$(lxc storage get "${storage_pool}-buckets" zfs.pool_name)"
+
+  zfs list -H -o name "${zfs_pool}/buckets"
+  zfs list -H -o name "${zfs_pool}/deleted/buckets"
+
+  # The bucket size is applied as the dataset quota.
+  lxc storage bucket create "${storage_pool}-buckets" foo size=64MiB
+  [ "$(zfs get -H -o value quota "${zfs_pool}/buckets/default_foo")" = "64M" ]
+
+  lxc storage bucket set "${storage_pool}-buckets" foo size=128MiB
+  [ "$(zfs get -H -o value quota "${zfs_pool}/buckets/default_foo")" = "128M" ]
+
+  lxc storage bucket delete "${storage_pool}-buckets" foo
+  ! zfs list -H -o name "${zfs_pool}/buckets/default_foo" || false
+
+  lxc storage delete "${storage_pool}-buckets"
+}
+
 do_zfs_bucket_dataset_cleanup() {
   local storage_pool
   storage_pool="lxdtest-$(basename "${LXD_DIR}")"
@@ -476,9 +504,9 @@ do_zfs_bucket_dataset_cleanup() {
   local zfs_pool
   zfs_pool="$(lxc storage get "${storage_pool}-buckets-patch-test" zfs.pool_name)"
 
-  # Inject the orphaned datasets again to simulate an existing pool that predates the patch that removes them on startup.
-  zfs create "${zfs_pool}/buckets"
-  zfs create "${zfs_pool}/deleted/buckets"
+  # Inject leftover datasets to simulate an existing pool with buckets created by an older version.
+  zfs create "${zfs_pool}/buckets/legacy"
+  zfs create "${zfs_pool}/deleted/buckets/legacy"
 
   # Restart LXD to trigger the storage_zfs_remove_local_bucket_datasets patch.
   # The patch is idempotent but won't re-run on subsequent starts; the datasets
@@ -488,16 +516,14 @@ do_zfs_bucket_dataset_cleanup() {
   shutdown_lxd "${LXD_DIR}"
   respawn_lxd "${LXD_DIR}" true
 
-  # Both datasets must be gone after LXD applies the startup patch.
-  if zfs list -H -o name "${zfs_pool}/buckets" > /dev/null 2>&1; then
-    echo "ERROR: ${zfs_pool}/buckets still exists after startup patch, aborting" >&2
-    exit 1
-  fi
+  # The leftovers must be gone while the parent datasets are recreated for new buckets.
+  ! zfs list -H -o name "${zfs_pool}/buckets/legacy" || false
+  ! zfs list -H -o name "${zfs_pool}/deleted/buckets/legacy" || false
+  zfs list -H -o name "${zfs_pool}/buckets"
+  zfs list -H -o name "${zfs_pool}/deleted/buckets"
 
-  if zfs list -H -o name "${zfs_pool}/deleted/buckets" > /dev/null 2>&1; then
-    echo "ERROR: ${zfs_pool}/deleted/buckets still exists after startup patch, aborting" >&2
-    exit 1
-  fi
+  lxc storage bucket create "${storage_pool}-buckets-patch-test" foo
+  lxc storage bucket delete "${storage_pool}-buckets-patch-test" foo
 
   lxc storage delete "${storage_pool}-buckets-patch-test"
 }
@@ -0,0 +1,62 @@
+test_storage_volume_replication() {
+  local pool target_pool i
+  pool="$(lxc profile device get default root pool)"
+  target_pool="lxdtest-$(basename "${LXD_DIR}")-replica"
+
+  lxc storage create "${target_pool}" dir
+  lxc storage volume create "${pool}" vol1
+
+  # Check validation.
+  ! lxc storage volume set "${pool}" vol1 replication.schedule="* * * * *" || false
+  ! lxc storage volume set "${pool}" vol1 replication.target_pool="${pool}" || false
+  ! lxc storage volume set "${pool}" vol1 replication.target_pool=missing || false
+  ! lxc storage volume set "${pool}" vol1 replication.target_pool="${target_pool}" replication.schedule=invalid || false
+  ! lxc storage volume set "${target_pool}" vol1 replication.target_pool="${pool}" || false
+
+  # An unrelated volume with the same name in the target pool must not be overwritten.
+  lxc storage volume create "${target_pool}" vol2 user.foo=mine
+  lxc storage volume create "${pool}" vol2
+  lxc storage volume snapshot "${pool}" vol2 snap0
+  lxc storage volume set "${pool}" vol2 replication.target_pool="${target_pool}" replication.schedule="* * * * *"
+
+  # Replicate the volume and its snapshots every minute.
+  lxc storage volume snapshot "${pool}" vol1 snap0
+  lxc storage volume set "${pool}" vol1 replication.target_pool="${target_pool}" replication.schedule="* * * * *" user.foo=bar
+
+  for i in $(seq 90); do
+    lxc storage volume show "${target_pool}" vol1/snap0 >/dev/null 2>&1 && break
+    sleep 1
+  done
+
+  echo "Replica found after ${i}s"
+  [ "$(lxc storage volume get "${target_pool}" vol1 user.foo)" = "bar" ]
+  [ "$(lxc storage volume get "${target_pool}" vol1 replication.target_pool)" = "" ]
+  [ "$(lxc storage volume get "${target_pool}" vol1 volatile.replica_of)" = "${pool}/vol1" ]
+
+  # Check that the new snapshots are replicated on the next run.
+  lxc storage volume snapshot "${pool}" vol1 snap1
+
+  for i in $(seq 90); do
+    lxc storage volume show "${target_pool}" vol1/snap1 >/dev/null 2>&1 && break
+    sleep 1
+  done
+
+  echo "Replicated snapshot found after ${i}s"
+  lxc storage volume show "${target_pool}" vol1/snap0
+
+  # The unrelated volume was left untouched.
+  [ "$(lxc storage volume get "${target_pool}" vol2 user.foo)" = "mine" ]
+  [ "$(lxc storage volume get "${target_pool}" vol2 volatile.replica_of)" = "" ]
+  ! lxc storage volume show "${target_pool}" vol2/snap0 || false
+
+  # Cleanup.
+  lxc storage volume unset "${pool}" vol1 replication.schedule
+  lxc storage volume unset "${pool}" vol1 replication.target_pool
+  lxc storage volume unset "${pool}" vol2 replication.schedule
+  lxc storage volume unset "${pool}" vol2 replication.target_pool
+  lxc storage volume delete "${pool}" vol1
+  lxc storage volume delete "${pool}" vol2
+  lxc storage volume delete "${target_pool}" vol1
+  lxc storage volume delete "${target_pool}" vol2
+  lxc storage delete "${target_pool}"
+}
@@ -202,3 +202,37 @@ test_snap_vm_empty() {
   # useful to test snap provided BIOS boot
   _boot_mode
 }
+
+test_snap_vm_snapshot_overlay() {
+  ensure_import_ubuntu_vm_image
+
+  lxc launch ubuntu-vm v1 --vm -c limits.memory=384MiB -c snapshots.overlay=true -d root,size.state=384MiB -d "${SMALL_VM_ROOT_DISK}"
+  waitInstanceReady v1
+
+  echo "==> Snapshot the running VM through an overlay with the guest filesystems frozen"
+  lxc exec v1 -- sh -c "echo before > /root/overlay-test"
+  lxc snapshot v1 snap0
+  [ "$(lxc list -f csv -c s v1)" = "RUNNING" ]
+  [ ! -e "${LXD_DIR}/virtual-machines/v1/snapshot_overlay.qcow2" ]
+
+  echo "==> Writes made after the snapshot are merged back into the root disk"
+  lxc exec v1 -- sh -c "echo after > /root/overlay-test && sync"
+
+  echo "==> A second overlay snapshot doesn't clash with a leftover overlay block device"
+  lxc snapshot v1 snap1
+  [ ! -e "${LXD_DIR}/virtual-machines/v1/snapshot_overlay.qcow2" ]
+  [ "$(lxc list -f csv -c S v1)" = "2" ]
+
+  echo "==> The root disk kept the writes once the overlay is gone"
+  lxc restart -f v1
+  waitInstanceReady v1
+  [ "$(lxc exec v1 -- cat /root/overlay-test)" = "after" ]
+
+  echo "==> The snapshot contains the writes made before it was taken"
+  lxc restore v1 snap0
+  waitInstanceReady v1
+  [ "$(lxc exec v1 -- cat /root/overlay-test)" = "before" ]
+
+  # Cleanup
+  lxc delete -f v1
+}